@@ -177,6 +177,12 @@ func main() {
 				Usage:   "Address of ipam grpc service",
 				Sources: cli.EnvVars("NEXAPI_IPAM_URL"),
 			},
+			&cli.StringFlag{
+				Name:    "ipam-backend",
+				Value:   "remote",
+				Usage:   "IPAM backend: 'remote' uses the ipam grpc service, 'embedded' stores the allocations in the apiserver database",
+				Sources: cli.EnvVars("NEXAPI_IPAM_BACKEND"),
+			},
 			&cli.BoolFlag{
 				Name:    "trace-insecure",
 				Value:   false,
@@ -273,6 +279,12 @@ func main() {
 				Required: false,
 				Sources:  cli.EnvVars("NEXAPI_SMTP_FROM"),
 			},
+			&cli.DurationFlag{
+				Name:    "ephemeral-device-grace-period",
+				Value:   5 * time.Minute,
+				Usage:   "How long ephemeral devices can be offline before they are deleted",
+				Sources: cli.EnvVars("NEXAPI_EPHEMERAL_DEVICE_GRACE_PERIOD"),
+			},
 			&cli.StringFlag{
 				Name:     "ca-cert",
 				Usage:    "Certificate authority cert",
@@ -302,7 +314,7 @@ func main() {
 				wg := &sync.WaitGroup{}
 				signalBus.Start(ctx, wg)
 
-				ipam := ipam.NewIPAM(logger.Sugar(), command.String("ipam-address"))
+				ipam := newIPAM(command, logger, db)
 
 				fflags := fflags.NewFFlags(logger.Sugar())
 
@@ -405,6 +417,9 @@ func main() {
 					log.Fatal(fmt.Errorf("invalid tls-key: %w", err))
 				}
 
+				api.StartWebhookDispatcher(ctx, wg)
+				api.StartEphemeralDeviceReaper(ctx, wg, command.Duration("ephemeral-device-grace-period"))
+
 				router, err := routers.NewAPIRouter(ctx, routers.APIRouterOptions{
 					Logger:          logger.Sugar(),
 					Api:             api,
@@ -521,11 +536,11 @@ func main() {
 		Commands: []*cli.Command{
 			{
 				Name:  "rebuild",
-				Usage: "Rebuild the IPAM service using the allocated ips and cidrs in nexodus database",
+				Usage: "Rebuild the IPAM backend using the allocated ips and cidrs in nexodus database",
 				Action: func(ctx context.Context, command *cli.Command) error {
 
 					withLoggerAndDB(ctx, command, func(logger *zap.Logger, db *gorm.DB, dsn string) {
-						ipam := ipam.NewIPAM(logger.Sugar(), command.String("ipam-address"))
+						ipam := newIPAM(command, logger, db)
 						if err := cmd.Rebuild(ctx, logger, db, ipam); err != nil {
 							log.Fatal(err)
 						}
@@ -620,6 +635,18 @@ func getLogger(command *cli.Command) *zap.Logger {
 	}
 	return logger
 }
+func newIPAM(command *cli.Command, logger *zap.Logger, db *gorm.DB) ipam.IPAM {
+	switch command.String("ipam-backend") {
+	case "remote":
+		return ipam.NewRemoteIPAM(logger.Sugar(), command.String("ipam-address"))
+	case "embedded":
+		return ipam.NewEmbeddedIPAM(logger.Sugar(), db)
+	default:
+		log.Fatalf("invalid --ipam-backend: %s", command.String("ipam-backend"))
+		return nil
+	}
+}
+
 func withLoggerAndDB(ctx context.Context, command *cli.Command, f func(logger *zap.Logger, db *gorm.DB, dsn string)) {
 	logger := getLogger(command)
 	cleanup := initTracer(logger.Sugar(), command.Bool("trace-insecure"), command.String("trace-endpoint"))
@@ -0,0 +1,857 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/nexodus-io/nexodus/internal/client"
+	"github.com/urfave/cli/v3"
+)
+
+func createApplyCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "apply",
+		Usage: "Create or update the resources described by a manifest",
+		Flags: manifestFlags(),
+		Action: func(ctx context.Context, command *cli.Command) error {
+			manifest, err := readManifest(command.String("filename"))
+			if err != nil {
+				return err
+			}
+			return applyManifest(ctx, command, manifest, command.Bool("prune"))
+		},
+	}
+}
+
+func createDiffCommand() *cli.Command {
+	flags := append(manifestFlags(), &cli.BoolFlag{
+		Name:  "exit-code",
+		Usage: "exit with status 1 when the manifest differs from the current state",
+	})
+	return &cli.Command{
+		Name:  "diff",
+		Usage: "Show the changes apply would make for a manifest",
+		Flags: flags,
+		Action: func(ctx context.Context, command *cli.Command) error {
+			manifest, err := readManifest(command.String("filename"))
+			if err != nil {
+				return err
+			}
+			return diffManifest(ctx, command, manifest, command.Bool("prune"), command.Bool("exit-code"))
+		},
+	}
+}
+
+func createExportCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "export",
+		Usage: "Export the resources of your organizations as a manifest",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:  "organization",
+				Usage: "the name of an organization to export, can be repeated. All organizations are exported when not set",
+			},
+		},
+		Action: func(ctx context.Context, command *cli.Command) error {
+			return exportManifest(ctx, command, command.StringSlice("organization"))
+		},
+	}
+}
+
+func manifestFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:     "filename",
+			Aliases:  []string{"f"},
+			Usage:    "the YAML or JSON manifest, use - to read it from stdin",
+			Required: true,
+		},
+		&cli.BoolFlag{
+			Name:  "prune",
+			Usage: "delete the resources of the manifest's organizations that are not in the manifest",
+		},
+	}
+}
+
+func applyManifest(ctx context.Context, command *cli.Command, manifest Manifest, prune bool) error {
+	c := createClient(ctx, command)
+
+	// plan first so that nothing is changed when the manifest can not be applied
+	plan := newManifestReconciler(ctx, c, true, prune)
+	plan.reconcile(ctx, manifest)
+	if len(plan.conflicts) > 0 {
+		return fmt.Errorf("the manifest can not be applied:\n  %s", strings.Join(plan.conflicts, "\n  "))
+	}
+	if len(plan.changes) == 0 {
+		fmt.Println("no changes")
+		return nil
+	}
+
+	r := newManifestReconciler(ctx, c, false, prune)
+	r.reconcile(ctx, manifest)
+	for _, change := range r.changes {
+		fmt.Printf("%s %s %sd\n", change.kind, change.path, change.action)
+		if change.bearerToken != "" {
+			fmt.Printf("  bearer token: %s\n", change.bearerToken)
+		}
+	}
+	return nil
+}
+
+func diffManifest(ctx context.Context, command *cli.Command, manifest Manifest, prune bool, exitCode bool) error {
+	c := createClient(ctx, command)
+	r := newManifestReconciler(ctx, c, true, prune)
+	r.reconcile(ctx, manifest)
+
+	for _, change := range r.changes {
+		symbol := "~"
+		switch change.action {
+		case manifestCreate:
+			symbol = "+"
+		case manifestDelete:
+			symbol = "-"
+		}
+		fmt.Printf("%s %s %s\n", symbol, change.kind, change.path)
+		for _, field := range change.fields {
+			fmt.Printf("    %s\n", field)
+		}
+	}
+	for _, conflict := range r.conflicts {
+		fmt.Printf("! %s\n", conflict)
+	}
+	if len(r.changes) == 0 && len(r.conflicts) == 0 {
+		fmt.Println("no changes")
+		return nil
+	}
+	if exitCode {
+		os.Exit(1)
+	}
+	return nil
+}
+
+func exportManifest(ctx context.Context, command *cli.Command, organizations []string) error {
+	manifest := exportedManifest(ctx, createClient(ctx, command), organizations)
+	switch command.String("output") {
+	case encodeJsonPretty, encodeJsonRaw:
+		show(command, nil, manifest)
+	default:
+		bytes, err := yaml.Marshal(manifest)
+		if err != nil {
+			return fmt.Errorf("failed to encode the manifest: %w", err)
+		}
+		fmt.Print(string(bytes))
+	}
+	return nil
+}
+
+// exportedManifest describes the current resources of the named organizations, or of all the
+// organizations of the user when none are named.
+func exportedManifest(ctx context.Context, c *client.APIClient, organizations []string) Manifest {
+	orgs := apiResponse(c.OrganizationsApi.ListOrganizations(ctx).Execute())
+	vpcs := apiResponse(c.VPCApi.ListVPCs(ctx).Execute())
+	regKeys := apiResponse(c.RegKeyApi.ListRegKeys(ctx).Execute())
+	serviceNetworks := apiResponse(c.ServiceNetworkApi.ListServiceNetworks(ctx).Execute())
+	invitations := apiResponse(c.InvitationApi.ListInvitations(ctx).Execute())
+
+	manifest := Manifest{Organizations: []ManifestOrganization{}}
+	for _, org := range orgs {
+		if len(organizations) > 0 && !contains(organizations, org.GetName()) {
+			continue
+		}
+		morg := ManifestOrganization{
+			Name:        org.GetName(),
+			Description: org.GetDescription(),
+		}
+
+		for _, vpc := range vpcs {
+			if vpc.GetOrganizationId() != org.GetId() {
+				continue
+			}
+			mvpc := ManifestVPC{
+				Description:           vpc.GetDescription(),
+				PrivateCidr:           vpc.GetPrivateCidr(),
+				Ipv4Cidr:              vpc.GetIpv4Cidr(),
+				Ipv6Cidr:              vpc.GetIpv6Cidr(),
+				RequireDeviceApproval: vpc.GetRequireDeviceApproval(),
+				PresharedKeys:         vpc.GetPresharedKeys(),
+			}
+			groups := apiResponse(c.VPCApi.ListSecurityGroupsInVPC(ctx, vpc.GetId()).Execute())
+			groupDescriptions := map[string]string{}
+			for _, sg := range groups {
+				groupDescriptions[sg.GetId()] = sg.GetDescription()
+				mvpc.SecurityGroups = append(mvpc.SecurityGroups, ManifestSecurityGroup{
+					Description:   sg.GetDescription(),
+					InboundRules:  sg.InboundRules,
+					OutboundRules: sg.OutboundRules,
+				})
+			}
+			for _, key := range regKeys {
+				if key.GetVpcId() == vpc.GetId() {
+					mkey := exportRegKey(key)
+					mkey.SecurityGroup = groupDescriptions[key.GetSecurityGroupId()]
+					mvpc.RegKeys = append(mvpc.RegKeys, mkey)
+				}
+			}
+			morg.VPCs = append(morg.VPCs, mvpc)
+		}
+
+		for _, sn := range serviceNetworks {
+			if sn.GetOrganizationId() != org.GetId() {
+				continue
+			}
+			msn := ManifestServiceNetwork{Description: sn.GetDescription()}
+			for _, key := range regKeys {
+				if key.GetServiceNetworkId() == sn.GetId() {
+					msn.RegKeys = append(msn.RegKeys, exportRegKey(key))
+				}
+			}
+			morg.ServiceNetworks = append(morg.ServiceNetworks, msn)
+		}
+
+		for _, invitation := range invitations {
+			if invitation.GetOrganizationId() != org.GetId() {
+				continue
+			}
+			morg.Invitations = append(morg.Invitations, ManifestInvitation{
+				Email:  invitation.GetEmail(),
+				UserID: invitation.GetUserId(),
+				Roles:  invitation.Roles,
+			})
+		}
+		manifest.Organizations = append(manifest.Organizations, morg)
+	}
+	return manifest
+}
+
+func exportRegKey(key client.ModelsRegKey) ManifestRegKey {
+	return ManifestRegKey{
+		Description:        key.GetDescription(),
+		ExpiresAt:          key.GetExpiresAt(),
+		SingleUse:          key.GetDeviceId() != "",
+		Ephemeral:          key.GetEphemeral(),
+		Settings:           key.Settings,
+		MaxUses:            key.GetMaxUses(),
+		AllowedSourceCidrs: key.AllowedSourceCidrs,
+		DeviceLabels:       key.DeviceLabels,
+		DeviceMetadata:     key.DeviceMetadata,
+		HostnameTemplate:   key.GetHostnameTemplate(),
+	}
+}
+
+// defaultSecurityGroupDescription is the description of the security group created with every vpc
+const defaultSecurityGroupDescription = "default vpc security group"
+
+const (
+	manifestCreate = "create"
+	manifestUpdate = "update"
+	manifestDelete = "delete"
+)
+
+// manifestChange is a change made, or that would be made, to a resource to match a manifest.
+type manifestChange struct {
+	action string
+	kind   string
+	path   string
+	// fields describes the updated fields
+	fields []string
+	// bearerToken is the token of a created reg key
+	bearerToken string
+}
+
+// manifestReconciler makes the resources visible to the current user match a manifest.
+// When dryRun is set it only records the changes it would make, the ids of resources it
+// would create are left empty.
+type manifestReconciler struct {
+	c      *client.APIClient
+	dryRun bool
+	prune  bool
+
+	organizations   []client.ModelsOrganization
+	vpcs            []client.ModelsVPC
+	regKeys         []client.ModelsRegKey
+	serviceNetworks []client.ModelsServiceNetwork
+	invitations     []client.ModelsInvitation
+
+	changes   []manifestChange
+	conflicts []string
+}
+
+func newManifestReconciler(ctx context.Context, c *client.APIClient, dryRun bool, prune bool) *manifestReconciler {
+	return &manifestReconciler{
+		c:               c,
+		dryRun:          dryRun,
+		prune:           prune,
+		organizations:   apiResponse(c.OrganizationsApi.ListOrganizations(ctx).Execute()),
+		vpcs:            apiResponse(c.VPCApi.ListVPCs(ctx).Execute()),
+		regKeys:         apiResponse(c.RegKeyApi.ListRegKeys(ctx).Execute()),
+		serviceNetworks: apiResponse(c.ServiceNetworkApi.ListServiceNetworks(ctx).Execute()),
+		invitations:     apiResponse(c.InvitationApi.ListInvitations(ctx).Execute()),
+	}
+}
+
+func (r *manifestReconciler) record(action, kind, path string, fields ...string) {
+	r.changes = append(r.changes, manifestChange{action: action, kind: kind, path: path, fields: fields})
+}
+
+func (r *manifestReconciler) conflict(format string, a ...any) {
+	r.conflicts = append(r.conflicts, fmt.Sprintf(format, a...))
+}
+
+func (r *manifestReconciler) reconcile(ctx context.Context, manifest Manifest) {
+	for _, org := range manifest.Organizations {
+		r.reconcileOrganization(ctx, org)
+	}
+}
+
+func (r *manifestReconciler) reconcileOrganization(ctx context.Context, morg ManifestOrganization) {
+	path := morg.Name
+	var orgId string
+	for _, org := range r.organizations {
+		if org.GetName() == morg.Name {
+			orgId = org.GetId()
+			if morg.Description != "" && org.GetDescription() != morg.Description {
+				r.conflict("organization %s: the description can not be changed from %q", path, org.GetDescription())
+			}
+			break
+		}
+	}
+	if orgId == "" {
+		r.record(manifestCreate, "organization", path)
+		if !r.dryRun {
+			org := apiResponse(r.c.OrganizationsApi.CreateOrganization(ctx).Organization(client.ModelsAddOrganization{
+				Name:        client.PtrString(morg.Name),
+				Description: client.PtrOptionalString(morg.Description),
+			}).Execute())
+			orgId = org.GetId()
+		}
+	}
+
+	// service networks go first, so that pruned vpcs don't hold on to them
+	r.reconcileServiceNetworks(ctx, path, orgId, morg.ServiceNetworks)
+	r.reconcileVPCs(ctx, path, orgId, morg.VPCs)
+	r.reconcileInvitations(ctx, path, orgId, morg.Invitations)
+}
+
+func (r *manifestReconciler) reconcileVPCs(ctx context.Context, orgPath string, orgId string, mvpcs []ManifestVPC) {
+	existing := []client.ModelsVPC{}
+	for _, vpc := range r.vpcs {
+		if orgId != "" && vpc.GetOrganizationId() == orgId {
+			existing = append(existing, vpc)
+		}
+	}
+
+	for _, mvpc := range mvpcs {
+		path := orgPath + "/" + mvpc.Description
+		matches := []client.ModelsVPC{}
+		for _, vpc := range existing {
+			if vpc.GetDescription() == mvpc.Description {
+				matches = append(matches, vpc)
+			}
+		}
+		if len(matches) > 1 {
+			r.conflict("vpc %s: matches %d vpcs", path, len(matches))
+			continue
+		}
+
+		var vpcId string
+		if len(matches) == 1 {
+			vpc := matches[0]
+			vpcId = vpc.GetId()
+			if vpc.GetPrivateCidr() != mvpc.PrivateCidr {
+				r.conflict("vpc %s: private_cidr can not be changed from %v", path, vpc.GetPrivateCidr())
+			}
+			if mvpc.Ipv4Cidr != "" && vpc.GetIpv4Cidr() != mvpc.Ipv4Cidr {
+				r.conflict("vpc %s: ipv4_cidr can not be changed from %s", path, vpc.GetIpv4Cidr())
+			}
+			if mvpc.Ipv6Cidr != "" && vpc.GetIpv6Cidr() != mvpc.Ipv6Cidr {
+				r.conflict("vpc %s: ipv6_cidr can not be changed from %s", path, vpc.GetIpv6Cidr())
+			}
+			if fields, update := vpcUpdate(vpc, mvpc); len(fields) > 0 {
+				r.record(manifestUpdate, "vpc", path, fields...)
+				if !r.dryRun {
+					apiResponse(r.c.VPCApi.UpdateVPC(ctx, vpcId).Update(update).Execute())
+				}
+			}
+		} else {
+			r.record(manifestCreate, "vpc", path)
+			if !r.dryRun {
+				vpc := apiResponse(r.c.VPCApi.CreateVPC(ctx).VPC(client.ModelsAddVPC{
+					OrganizationId:        client.PtrString(orgId),
+					Description:           client.PtrString(mvpc.Description),
+					PrivateCidr:           client.PtrBool(mvpc.PrivateCidr),
+					Ipv4Cidr:              client.PtrOptionalString(mvpc.Ipv4Cidr),
+					Ipv6Cidr:              client.PtrOptionalString(mvpc.Ipv6Cidr),
+					RequireDeviceApproval: client.PtrBool(mvpc.RequireDeviceApproval),
+					PresharedKeys:         client.PtrBool(mvpc.PresharedKeys),
+				}).Execute())
+				vpcId = vpc.GetId()
+			}
+		}
+
+		groups := r.reconcileSecurityGroups(ctx, path, vpcId, mvpc.SecurityGroups)
+		r.reconcileRegKeys(ctx, path, vpcId, "", groups, mvpc.RegKeys)
+		if r.prune && vpcId != "" {
+			for _, sg := range r.listSecurityGroups(ctx, vpcId) {
+				// the default security group can not be deleted
+				if sg.GetId() == vpcId || containsSecurityGroup(mvpc.SecurityGroups, sg.GetDescription()) {
+					continue
+				}
+				r.record(manifestDelete, "security group", path+"/"+sg.GetDescription())
+				if !r.dryRun {
+					apiResponse(r.c.SecurityGroupApi.DeleteSecurityGroup(ctx, sg.GetId()).Execute())
+				}
+			}
+		}
+	}
+
+	if r.prune {
+		for _, vpc := range existing {
+			// the default vpc of an organization can not be deleted
+			if vpc.GetId() == orgId || containsVPC(mvpcs, vpc.GetDescription()) {
+				continue
+			}
+			r.record(manifestDelete, "vpc", orgPath+"/"+vpc.GetDescription())
+			if !r.dryRun {
+				apiResponse(r.c.VPCApi.DeleteVPC(ctx, vpc.GetId()).Execute())
+			}
+		}
+	}
+}
+
+// vpcUpdate returns the fields of a vpc that differ from the manifest and the update that changes them.
+func vpcUpdate(vpc client.ModelsVPC, mvpc ManifestVPC) ([]string, client.ModelsUpdateVPC) {
+	var fields []string
+	update := client.ModelsUpdateVPC{}
+	if vpc.GetRequireDeviceApproval() != mvpc.RequireDeviceApproval {
+		fields = append(fields, fieldChange("require_device_approval", fmt.Sprint(vpc.GetRequireDeviceApproval()), fmt.Sprint(mvpc.RequireDeviceApproval)))
+		update.RequireDeviceApproval = client.PtrBool(mvpc.RequireDeviceApproval)
+	}
+	if vpc.GetPresharedKeys() != mvpc.PresharedKeys {
+		fields = append(fields, fieldChange("preshared_keys", fmt.Sprint(vpc.GetPresharedKeys()), fmt.Sprint(mvpc.PresharedKeys)))
+		update.PresharedKeys = client.PtrBool(mvpc.PresharedKeys)
+	}
+	return fields, update
+}
+
+// listSecurityGroups lists the current security groups of a vpc, none when the vpc does not exist yet.
+func (r *manifestReconciler) listSecurityGroups(ctx context.Context, vpcId string) []client.ModelsSecurityGroup {
+	if vpcId == "" {
+		return nil
+	}
+	return apiResponse(r.c.VPCApi.ListSecurityGroupsInVPC(ctx, vpcId).Execute())
+}
+
+// reconcileSecurityGroups returns the ids of the security groups of the vpc by description.
+func (r *manifestReconciler) reconcileSecurityGroups(ctx context.Context, vpcPath string, vpcId string, msgs []ManifestSecurityGroup) map[string]string {
+	existing := r.listSecurityGroups(ctx, vpcId)
+	ids := map[string]string{}
+	for _, sg := range existing {
+		if !r.prune || sg.GetId() == vpcId || containsSecurityGroup(msgs, sg.GetDescription()) {
+			ids[sg.GetDescription()] = sg.GetId()
+		}
+	}
+
+	for _, msg := range msgs {
+		path := vpcPath + "/" + msg.Description
+		matches := []client.ModelsSecurityGroup{}
+		for _, sg := range existing {
+			if sg.GetDescription() == msg.Description {
+				matches = append(matches, sg)
+			}
+		}
+		if len(matches) > 1 {
+			r.conflict("security group %s: matches %d security groups", path, len(matches))
+			continue
+		}
+		if err := checkICMPRules(msg.InboundRules, msg.OutboundRules); err != nil {
+			r.conflict("security group %s: %v", path, err)
+			continue
+		}
+
+		if len(matches) == 0 && vpcId == "" && msg.Description == defaultSecurityGroupDescription {
+			// the vpc will be created along with its empty default security group
+			matches = append(matches, client.ModelsSecurityGroup{Description: client.PtrString(msg.Description)})
+		}
+		if len(matches) == 0 {
+			r.record(manifestCreate, "security group", path)
+			ids[msg.Description] = ""
+			if !r.dryRun {
+				sg := apiResponse(r.c.SecurityGroupApi.CreateSecurityGroup(ctx).SecurityGroup(client.ModelsAddSecurityGroup{
+					VpcId:         client.PtrString(vpcId),
+					Description:   client.PtrString(msg.Description),
+					InboundRules:  msg.InboundRules,
+					OutboundRules: msg.OutboundRules,
+				}).Execute())
+				ids[msg.Description] = sg.GetId()
+			}
+			continue
+		}
+
+		sg := matches[0]
+		var fields []string
+		update := client.ModelsUpdateSecurityGroup{}
+		if !securityRulesEqual(sg.InboundRules, msg.InboundRules) {
+			fields = append(fields, fieldChange("inbound_rules", toJson(sg.InboundRules), toJson(msg.InboundRules)))
+			update.InboundRules = normalizeSecurityRules(msg.InboundRules)
+		}
+		if !securityRulesEqual(sg.OutboundRules, msg.OutboundRules) {
+			fields = append(fields, fieldChange("outbound_rules", toJson(sg.OutboundRules), toJson(msg.OutboundRules)))
+			update.OutboundRules = normalizeSecurityRules(msg.OutboundRules)
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		r.record(manifestUpdate, "security group", path, fields...)
+		if !r.dryRun {
+			apiResponse(r.c.SecurityGroupApi.UpdateSecurityGroup(ctx, sg.GetId()).Update(update).Execute())
+		}
+	}
+	return ids
+}
+
+// reconcileRegKeys reconciles the reg keys of either a vpc or a service network.
+func (r *manifestReconciler) reconcileRegKeys(ctx context.Context, parentPath string, vpcId string, serviceNetworkId string, groups map[string]string, mkeys []ManifestRegKey) {
+	existing := []client.ModelsRegKey{}
+	for _, key := range r.regKeys {
+		if (vpcId != "" && key.GetVpcId() == vpcId) || (serviceNetworkId != "" && key.GetServiceNetworkId() == serviceNetworkId) {
+			existing = append(existing, key)
+		}
+	}
+
+	for _, mkey := range mkeys {
+		path := parentPath + "/" + mkey.Description
+		matches := []client.ModelsRegKey{}
+		for _, key := range existing {
+			if key.GetDescription() == mkey.Description {
+				matches = append(matches, key)
+			}
+		}
+		if len(matches) > 1 {
+			r.conflict("reg key %s: matches %d reg keys", path, len(matches))
+			continue
+		}
+
+		var securityGroupId string
+		if mkey.SecurityGroup != "" {
+			id, found := groups[mkey.SecurityGroup]
+			if !found {
+				r.conflict("reg key %s: security group %q not found", path, mkey.SecurityGroup)
+				continue
+			}
+			securityGroupId = id
+		}
+
+		if len(matches) == 0 {
+			change := manifestChange{action: manifestCreate, kind: "reg key", path: path}
+			if !r.dryRun {
+				key := apiResponse(r.c.RegKeyApi.CreateRegKey(ctx).RegKey(client.ModelsAddRegKey{
+					VpcId:              client.PtrOptionalString(vpcId),
+					ServiceNetworkId:   client.PtrOptionalString(serviceNetworkId),
+					Description:        client.PtrString(mkey.Description),
+					SecurityGroupId:    client.PtrOptionalString(securityGroupId),
+					ExpiresAt:          client.PtrOptionalString(mkey.ExpiresAt),
+					SingleUse:          client.PtrBool(mkey.SingleUse),
+					Ephemeral:          client.PtrBool(mkey.Ephemeral),
+					Settings:           mkey.Settings,
+					MaxUses:            client.PtrInt32(mkey.MaxUses),
+					AllowedSourceCidrs: mkey.AllowedSourceCidrs,
+					DeviceLabels:       mkey.DeviceLabels,
+					DeviceMetadata:     mkey.DeviceMetadata,
+					HostnameTemplate:   client.PtrOptionalString(mkey.HostnameTemplate),
+				}).Execute())
+				change.bearerToken = key.GetBearerToken()
+			}
+			r.changes = append(r.changes, change)
+			continue
+		}
+
+		key := matches[0]
+		if singleUse := key.GetDeviceId() != ""; singleUse != mkey.SingleUse {
+			r.conflict("reg key %s: single_use can not be changed from %v", path, singleUse)
+			continue
+		}
+		if key.GetEphemeral() != mkey.Ephemeral {
+			r.conflict("reg key %s: ephemeral can not be changed from %v", path, key.GetEphemeral())
+			continue
+		}
+		fields, update := regKeyUpdate(key, mkey, securityGroupId)
+		if len(fields) == 0 {
+			continue
+		}
+		r.record(manifestUpdate, "reg key", path, fields...)
+		if !r.dryRun {
+			apiResponse(r.c.RegKeyApi.UpdateRegKey(ctx, key.GetId()).Update(update).Execute())
+		}
+	}
+
+	if r.prune {
+		for _, key := range existing {
+			if containsRegKey(mkeys, key.GetDescription()) {
+				continue
+			}
+			r.record(manifestDelete, "reg key", parentPath+"/"+key.GetDescription())
+			if !r.dryRun {
+				apiResponse(r.c.RegKeyApi.DeleteRegKey(ctx, key.GetId()).Execute())
+			}
+		}
+	}
+}
+
+// regKeyUpdate returns the fields of a reg key that differ from the manifest and the update that
+// changes them. Only the fields that are set in the manifest are compared.
+func regKeyUpdate(key client.ModelsRegKey, mkey ManifestRegKey, securityGroupId string) ([]string, client.ModelsUpdateRegKey) {
+	var fields []string
+	update := client.ModelsUpdateRegKey{}
+	if mkey.SecurityGroup != "" && key.GetSecurityGroupId() != securityGroupId {
+		fields = append(fields, fieldChange("security_group", key.GetSecurityGroupId(), mkey.SecurityGroup))
+		update.SecurityGroupId = client.PtrString(securityGroupId)
+	}
+	if mkey.ExpiresAt != "" && !sameTime(key.GetExpiresAt(), mkey.ExpiresAt) {
+		fields = append(fields, fieldChange("expires_at", key.GetExpiresAt(), mkey.ExpiresAt))
+		update.ExpiresAt = client.PtrString(mkey.ExpiresAt)
+	}
+	if len(mkey.Settings) > 0 && toJson(key.Settings) != toJson(mkey.Settings) {
+		fields = append(fields, fieldChange("settings", toJson(key.Settings), toJson(mkey.Settings)))
+		update.Settings = mkey.Settings
+	}
+	if mkey.MaxUses != 0 && key.GetMaxUses() != mkey.MaxUses {
+		fields = append(fields, fieldChange("max_uses", fmt.Sprint(key.GetMaxUses()), fmt.Sprint(mkey.MaxUses)))
+		update.MaxUses = client.PtrInt32(mkey.MaxUses)
+	}
+	if len(mkey.AllowedSourceCidrs) > 0 && !stringSetsEqual(key.AllowedSourceCidrs, mkey.AllowedSourceCidrs) {
+		fields = append(fields, fieldChange("allowed_source_cidrs", toJson(key.AllowedSourceCidrs), toJson(mkey.AllowedSourceCidrs)))
+		update.AllowedSourceCidrs = mkey.AllowedSourceCidrs
+	}
+	if len(mkey.DeviceLabels) > 0 && toJson(key.DeviceLabels) != toJson(mkey.DeviceLabels) {
+		fields = append(fields, fieldChange("device_labels", toJson(key.DeviceLabels), toJson(mkey.DeviceLabels)))
+		update.DeviceLabels = mkey.DeviceLabels
+	}
+	if len(mkey.DeviceMetadata) > 0 && toJson(key.DeviceMetadata) != toJson(mkey.DeviceMetadata) {
+		fields = append(fields, fieldChange("device_metadata", toJson(key.DeviceMetadata), toJson(mkey.DeviceMetadata)))
+		update.DeviceMetadata = mkey.DeviceMetadata
+	}
+	if mkey.HostnameTemplate != "" && key.GetHostnameTemplate() != mkey.HostnameTemplate {
+		fields = append(fields, fieldChange("hostname_template", key.GetHostnameTemplate(), mkey.HostnameTemplate))
+		update.HostnameTemplate = client.PtrString(mkey.HostnameTemplate)
+	}
+	return fields, update
+}
+
+func (r *manifestReconciler) reconcileServiceNetworks(ctx context.Context, orgPath string, orgId string, msns []ManifestServiceNetwork) {
+	existing := []client.ModelsServiceNetwork{}
+	for _, sn := range r.serviceNetworks {
+		if orgId != "" && sn.GetOrganizationId() == orgId {
+			existing = append(existing, sn)
+		}
+	}
+
+	for _, msn := range msns {
+		path := orgPath + "/" + msn.Description
+		matches := []client.ModelsServiceNetwork{}
+		for _, sn := range existing {
+			if sn.GetDescription() == msn.Description {
+				matches = append(matches, sn)
+			}
+		}
+		if len(matches) > 1 {
+			r.conflict("service network %s: matches %d service networks", path, len(matches))
+			continue
+		}
+
+		var serviceNetworkId string
+		if len(matches) == 1 {
+			serviceNetworkId = matches[0].GetId()
+		} else {
+			r.record(manifestCreate, "service network", path)
+			if !r.dryRun {
+				sn := apiResponse(r.c.ServiceNetworkApi.CreateServiceNetwork(ctx).ServiceNetwork(client.ModelsAddServiceNetwork{
+					OrganizationId: client.PtrString(orgId),
+					Description:    client.PtrString(msn.Description),
+				}).Execute())
+				serviceNetworkId = sn.GetId()
+			}
+		}
+		r.reconcileRegKeys(ctx, path, "", serviceNetworkId, nil, msn.RegKeys)
+	}
+
+	if r.prune {
+		for _, sn := range existing {
+			if containsServiceNetwork(msns, sn.GetDescription()) {
+				continue
+			}
+			r.record(manifestDelete, "service network", orgPath+"/"+sn.GetDescription())
+			if !r.dryRun {
+				apiResponse(r.c.ServiceNetworkApi.DeleteServiceNetwork(ctx, sn.GetId()).Execute())
+			}
+		}
+	}
+}
+
+func (r *manifestReconciler) reconcileInvitations(ctx context.Context, orgPath string, orgId string, minvitations []ManifestInvitation) {
+	existing := []client.ModelsInvitation{}
+	for _, invitation := range r.invitations {
+		if orgId != "" && invitation.GetOrganizationId() == orgId {
+			existing = append(existing, invitation)
+		}
+	}
+
+	for _, minvitation := range minvitations {
+		path := orgPath + "/" + minvitation.key()
+		var match *client.ModelsInvitation
+		for i, invitation := range existing {
+			if (minvitation.Email != "" && invitation.GetEmail() == minvitation.Email) ||
+				(minvitation.UserID != "" && invitation.GetUserId() == minvitation.UserID) {
+				match = &existing[i]
+				break
+			}
+		}
+		if match != nil {
+			if !stringSetsEqual(match.Roles, minvitation.Roles) {
+				r.conflict("invitation %s: the roles can not be changed from %s", path, strings.Join(match.Roles, ","))
+			}
+			continue
+		}
+		r.record(manifestCreate, "invitation", path)
+		if !r.dryRun {
+			apiResponse(r.c.InvitationApi.CreateInvitation(ctx).Invitation(client.ModelsAddInvitation{
+				OrganizationId: client.PtrString(orgId),
+				Email:          client.PtrOptionalString(minvitation.Email),
+				UserId:         client.PtrOptionalString(minvitation.UserID),
+				Roles:          minvitation.Roles,
+			}).Execute())
+		}
+	}
+
+	if r.prune {
+		for _, invitation := range existing {
+			key := ManifestInvitation{Email: invitation.GetEmail(), UserID: invitation.GetUserId()}.key()
+			if containsInvitation(minvitations, invitation) {
+				continue
+			}
+			r.record(manifestDelete, "invitation", orgPath+"/"+key)
+			if !r.dryRun {
+				apiResponse(r.c.InvitationApi.DeleteInvitation(ctx, invitation.GetId()).Execute())
+			}
+		}
+	}
+}
+
+// normalizeSecurityRules sets the optional fields of security rules to their defaults so that
+// the rules of a manifest can be compared to the ones returned by the api.
+func normalizeSecurityRules(rules []client.ModelsSecurityRule) []client.ModelsSecurityRule {
+	result := make([]client.ModelsSecurityRule, 0, len(rules))
+	for _, rule := range rules {
+		ipRanges := rule.IpRanges
+		if ipRanges == nil {
+			ipRanges = []string{}
+		}
+		result = append(result, client.ModelsSecurityRule{
+			IpProtocol:   client.PtrString(rule.GetIpProtocol()),
+			FromPort:     client.PtrInt32(rule.GetFromPort()),
+			ToPort:       client.PtrInt32(rule.GetToPort()),
+			IpRanges:     ipRanges,
+			PeerSelector: client.PtrString(rule.GetPeerSelector()),
+		})
+	}
+	return result
+}
+
+func securityRulesEqual(a, b []client.ModelsSecurityRule) bool {
+	return reflect.DeepEqual(normalizeSecurityRules(a), normalizeSecurityRules(b))
+}
+
+func sameTime(a, b string) bool {
+	at, err := time.Parse(time.RFC3339, a)
+	if err != nil {
+		return false
+	}
+	bt, err := time.Parse(time.RFC3339, b)
+	if err != nil {
+		return false
+	}
+	return at.Equal(bt)
+}
+
+func stringSetsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, value := range a {
+		if !contains(b, value) {
+			return false
+		}
+	}
+	return true
+}
+
+func fieldChange(field, from, to string) string {
+	return fmt.Sprintf("%s: %s -> %s", field, from, to)
+}
+
+func toJson(value any) string {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(bytes)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsVPC(vpcs []ManifestVPC, description string) bool {
+	for _, vpc := range vpcs {
+		if vpc.Description == description {
+			return true
+		}
+	}
+	return false
+}
+
+func containsSecurityGroup(groups []ManifestSecurityGroup, description string) bool {
+	for _, sg := range groups {
+		if sg.Description == description {
+			return true
+		}
+	}
+	return false
+}
+
+func containsRegKey(keys []ManifestRegKey, description string) bool {
+	for _, key := range keys {
+		if key.Description == description {
+			return true
+		}
+	}
+	return false
+}
+
+func containsServiceNetwork(networks []ManifestServiceNetwork, description string) bool {
+	for _, sn := range networks {
+		if sn.Description == description {
+			return true
+		}
+	}
+	return false
+}
+
+func containsInvitation(invitations []ManifestInvitation, invitation client.ModelsInvitation) bool {
+	for _, i := range invitations {
+		if (i.Email != "" && i.Email == invitation.GetEmail()) || (i.UserID != "" && i.UserID == invitation.GetUserId()) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,485 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/google/uuid"
+	"github.com/nexodus-io/nexodus/internal/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAPI is an in-memory stand-in for the apiserver endpoints used by the manifest commands.
+// Like the apiserver it creates the default vpc of new organizations, with the id of the
+// organization, and the default security group of new vpcs, with the id of the vpc.
+type fakeAPI struct {
+	mu        sync.Mutex
+	resources map[string][]map[string]any
+	// mutations are the POST, PATCH and DELETE requests that were served, as "METHOD kind/description"
+	mutations []string
+}
+
+var fakeAPIKinds = []string{"organizations", "vpcs", "security-groups", "reg-keys", "service-networks", "invitations"}
+
+func newFakeAPI(t *testing.T) (*fakeAPI, *client.APIClient) {
+	f := &fakeAPI{resources: map[string][]map[string]any{}}
+	mux := http.NewServeMux()
+	for _, kind := range fakeAPIKinds {
+		mux.HandleFunc("GET /api/"+kind, func(w http.ResponseWriter, r *http.Request) {
+			f.reply(w, f.list(kind, nil))
+		})
+		mux.HandleFunc("POST /api/"+kind, func(w http.ResponseWriter, r *http.Request) {
+			var obj map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&obj); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			f.reply(w, f.create(kind, obj, true))
+		})
+		mux.HandleFunc("PATCH /api/"+kind+"/{id}", func(w http.ResponseWriter, r *http.Request) {
+			var update map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			f.reply(w, f.update(kind, r.PathValue("id"), update))
+		})
+		mux.HandleFunc("DELETE /api/"+kind+"/{id}", func(w http.ResponseWriter, r *http.Request) {
+			f.reply(w, f.delete(kind, r.PathValue("id")))
+		})
+	}
+	mux.HandleFunc("GET /api/vpcs/{id}/security-groups", func(w http.ResponseWriter, r *http.Request) {
+		f.reply(w, f.list("security-groups", map[string]any{"vpc_id": r.PathValue("id")}))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	cfg := client.NewConfiguration()
+	cfg.Servers = client.ServerConfigurations{{URL: server.URL}}
+	return f, client.NewAPIClient(cfg)
+}
+
+func (f *fakeAPI) reply(w http.ResponseWriter, value any) {
+	if value == nil {
+		http.Error(w, `{"error":"not found"}`, http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func (f *fakeAPI) list(kind string, where map[string]any) []map[string]any {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	result := []map[string]any{}
+	for _, obj := range f.resources[kind] {
+		if matchesFields(obj, where) {
+			result = append(result, obj)
+		}
+	}
+	return result
+}
+
+// create stores a resource, record is false for the resources that tests set up directly.
+func (f *fakeAPI) create(kind string, obj map[string]any, record bool) map[string]any {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.createLocked(kind, obj, record)
+}
+
+func (f *fakeAPI) createLocked(kind string, obj map[string]any, record bool) map[string]any {
+	if _, found := obj["id"]; !found {
+		obj["id"] = uuid.NewString()
+	}
+	if record {
+		f.mutations = append(f.mutations, "POST "+kind+"/"+describe(obj))
+	}
+	f.resources[kind] = append(f.resources[kind], obj)
+
+	switch kind {
+	case "organizations":
+		f.createLocked("vpcs", map[string]any{"id": obj["id"], "organization_id": obj["id"], "description": "default vpc"}, false)
+	case "vpcs":
+		f.createLocked("security-groups", map[string]any{"id": obj["id"], "vpc_id": obj["id"], "description": defaultSecurityGroupDescription}, false)
+	case "reg-keys":
+		if obj["single_use"] == true {
+			obj["device_id"] = uuid.NewString()
+		}
+		delete(obj, "single_use")
+		obj["bearer_token"] = "RK:" + obj["id"].(string)
+	}
+	return obj
+}
+
+func (f *fakeAPI) update(kind string, id string, update map[string]any) map[string]any {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, obj := range f.resources[kind] {
+		if obj["id"] == id {
+			f.mutations = append(f.mutations, "PATCH "+kind+"/"+describe(obj))
+			for field, value := range update {
+				obj[field] = value
+			}
+			return obj
+		}
+	}
+	return nil
+}
+
+func (f *fakeAPI) delete(kind string, id string) map[string]any {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i, obj := range f.resources[kind] {
+		if obj["id"] == id {
+			f.mutations = append(f.mutations, "DELETE "+kind+"/"+describe(obj))
+			f.resources[kind] = append(f.resources[kind][:i], f.resources[kind][i+1:]...)
+			return obj
+		}
+	}
+	return nil
+}
+
+func (f *fakeAPI) recordedMutations() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	result := append([]string{}, f.mutations...)
+	sort.Strings(result)
+	return result
+}
+
+func describe(obj map[string]any) string {
+	for _, field := range []string{"name", "description", "email", "user_id"} {
+		if value, ok := obj[field].(string); ok && value != "" {
+			return value
+		}
+	}
+	return obj["id"].(string)
+}
+
+func matchesFields(obj map[string]any, where map[string]any) bool {
+	for field, value := range where {
+		if obj[field] != value {
+			return false
+		}
+	}
+	return true
+}
+
+func parseManifest(t *testing.T, content string) Manifest {
+	manifest, err := readManifest(writeManifest(t, content))
+	require.NoError(t, err)
+	return manifest
+}
+
+func reconcileManifest(c *client.APIClient, manifest Manifest, dryRun bool, prune bool) *manifestReconciler {
+	ctx := context.Background()
+	r := newManifestReconciler(ctx, c, dryRun, prune)
+	r.reconcile(ctx, manifest)
+	return r
+}
+
+func changePaths(changes []manifestChange) []string {
+	result := []string{}
+	for _, change := range changes {
+		result = append(result, change.action+" "+change.kind+" "+change.path)
+	}
+	sort.Strings(result)
+	return result
+}
+
+const applyTestManifest = `
+organizations:
+- name: acme
+  vpcs:
+  - description: prod
+    require_device_approval: true
+    security_groups:
+    - description: web
+      inbound_rules:
+      - ip_protocol: tcp
+        from_port: 443
+        to_port: 443
+        ip_ranges: ["10.0.0.0/8"]
+    reg_keys:
+    - description: fleet
+      security_group: web
+      max_uses: 10
+    - description: laptop
+      single_use: true
+  service_networks:
+  - description: services
+    reg_keys:
+    - description: connector
+  invitations:
+  - email: bob@example.com
+    roles: [member]
+`
+
+func TestApplyManifest(t *testing.T) {
+	api, c := newFakeAPI(t)
+	manifest := parseManifest(t, applyTestManifest)
+
+	plan := reconcileManifest(c, manifest, true, false)
+	require.Empty(t, plan.conflicts)
+	require.Empty(t, api.recordedMutations(), "a dry run must not change anything")
+
+	r := reconcileManifest(c, manifest, false, false)
+	require.Empty(t, r.conflicts)
+	assert.Equal(t, changePaths(plan.changes), changePaths(r.changes))
+	assert.Equal(t, []string{
+		"create invitation acme/bob@example.com",
+		"create organization acme",
+		"create reg key acme/prod/fleet",
+		"create reg key acme/prod/laptop",
+		"create reg key acme/services/connector",
+		"create security group acme/prod/web",
+		"create service network acme/services",
+		"create vpc acme/prod",
+	}, changePaths(r.changes))
+
+	web := api.list("security-groups", map[string]any{"description": "web"})
+	require.Len(t, web, 1)
+	fleet := api.list("reg-keys", map[string]any{"description": "fleet"})
+	require.Len(t, fleet, 1)
+	assert.Equal(t, web[0]["id"], fleet[0]["security_group_id"])
+	for _, change := range r.changes {
+		if change.kind == "reg key" {
+			assert.NotEmpty(t, change.bearerToken, change.path)
+		}
+	}
+
+	// the created resources are matched by description
+	again := reconcileManifest(c, manifest, true, false)
+	assert.Empty(t, again.conflicts)
+	assert.Empty(t, again.changes)
+}
+
+func TestApplyManifestUpdatesResourcesMatchedByDescription(t *testing.T) {
+	api, c := newFakeAPI(t)
+	org := api.create("organizations", map[string]any{"name": "acme"}, false)
+	prod := api.create("vpcs", map[string]any{"organization_id": org["id"], "description": "prod"}, false)
+	api.create("security-groups", map[string]any{"vpc_id": prod["id"], "description": "web"}, false)
+	api.create("reg-keys", map[string]any{"vpc_id": prod["id"], "description": "fleet", "max_uses": 5}, false)
+
+	r := reconcileManifest(c, parseManifest(t, applyTestManifest), false, false)
+	require.Empty(t, r.conflicts)
+	assert.Equal(t, []string{
+		"create invitation acme/bob@example.com",
+		"create reg key acme/prod/laptop",
+		"create reg key acme/services/connector",
+		"create service network acme/services",
+		"update reg key acme/prod/fleet",
+		"update security group acme/prod/web",
+		"update vpc acme/prod",
+	}, changePaths(r.changes))
+	assert.Len(t, api.list("vpcs", map[string]any{"description": "prod"}), 1)
+	assert.Equal(t, true, api.list("vpcs", map[string]any{"description": "prod"})[0]["require_device_approval"])
+	assert.Len(t, api.list("reg-keys", map[string]any{"description": "fleet"}), 1)
+}
+
+func TestApplyManifestPruneDeletesOnlyUnlistedResourcesOfTheNamedOrganizations(t *testing.T) {
+	api, c := newFakeAPI(t)
+	for _, name := range []string{"acme", "other"} {
+		org := api.create("organizations", map[string]any{"name": name}, false)
+		prod := api.create("vpcs", map[string]any{"organization_id": org["id"], "description": "prod"}, false)
+		stale := api.create("vpcs", map[string]any{"organization_id": org["id"], "description": "stale"}, false)
+		api.create("security-groups", map[string]any{"vpc_id": prod["id"], "description": "web"}, false)
+		api.create("security-groups", map[string]any{"vpc_id": prod["id"], "description": "old"}, false)
+		api.create("reg-keys", map[string]any{"vpc_id": prod["id"], "description": "fleet"}, false)
+		api.create("reg-keys", map[string]any{"vpc_id": prod["id"], "description": "old"}, false)
+		api.create("reg-keys", map[string]any{"vpc_id": stale["id"], "description": "stale"}, false)
+		services := api.create("service-networks", map[string]any{"organization_id": org["id"], "description": "services"}, false)
+		api.create("reg-keys", map[string]any{"service_network_id": services["id"], "description": "old"}, false)
+		api.create("service-networks", map[string]any{"organization_id": org["id"], "description": "stale"}, false)
+		api.create("invitations", map[string]any{"organization_id": org["id"], "email": "bob@example.com", "roles": []string{"member"}}, false)
+		api.create("invitations", map[string]any{"organization_id": org["id"], "email": "eve@example.com", "roles": []string{"member"}}, false)
+	}
+
+	manifest := parseManifest(t, `
+organizations:
+- name: acme
+  vpcs:
+  - description: prod
+    security_groups:
+    - description: web
+    reg_keys:
+    - description: fleet
+  service_networks:
+  - description: services
+  invitations:
+  - email: bob@example.com
+    roles: [member]
+`)
+
+	plan := reconcileManifest(c, manifest, true, true)
+	require.Empty(t, plan.conflicts)
+	require.Empty(t, api.recordedMutations(), "a dry run must not delete anything")
+
+	r := reconcileManifest(c, manifest, false, true)
+	require.Empty(t, r.conflicts)
+	assert.Equal(t, changePaths(plan.changes), changePaths(r.changes))
+
+	assert.Equal(t, []string{
+		"DELETE invitations/eve@example.com",
+		"DELETE reg-keys/old",
+		"DELETE reg-keys/old",
+		"DELETE security-groups/old",
+		"DELETE service-networks/stale",
+		"DELETE vpcs/stale",
+	}, api.recordedMutations())
+
+	// the deletes were all made in acme, the resources of other are untouched
+	for name, vpcs := range map[string]int{"acme": 2, "other": 3} {
+		org := api.list("organizations", map[string]any{"name": name})[0]
+		assert.Len(t, api.list("vpcs", map[string]any{"organization_id": org["id"]}), vpcs, name)
+		assert.Len(t, api.list("service-networks", map[string]any{"organization_id": org["id"]}), vpcs-1, name)
+		assert.Len(t, api.list("invitations", map[string]any{"organization_id": org["id"]}), vpcs-1, name)
+	}
+	assert.Len(t, api.list("security-groups", map[string]any{"description": "old"}), 1)
+	assert.Len(t, api.list("reg-keys", map[string]any{"description": "old"}), 2)
+}
+
+func TestApplyManifestPruneKeepsDefaultResources(t *testing.T) {
+	api, c := newFakeAPI(t)
+	org := api.create("organizations", map[string]any{"name": "acme"}, false)
+
+	r := reconcileManifest(c, parseManifest(t, `
+organizations:
+- name: acme
+  vpcs:
+  - description: prod
+`), false, true)
+	require.Empty(t, r.conflicts)
+	assert.Equal(t, []string{"create vpc acme/prod"}, changePaths(r.changes))
+	assert.Len(t, api.list("vpcs", map[string]any{"id": org["id"]}), 1)
+	assert.Len(t, api.list("security-groups", map[string]any{"description": defaultSecurityGroupDescription}), 2)
+}
+
+func TestApplyManifestConflicts(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(api *fakeAPI, orgId string, vpcId string)
+		manifest string
+		conflict string
+	}{
+		{
+			name: "duplicate vpc descriptions",
+			setup: func(api *fakeAPI, orgId string, vpcId string) {
+				api.create("vpcs", map[string]any{"organization_id": orgId, "description": "prod"}, false)
+			},
+			manifest: `
+organizations:
+- name: acme
+  vpcs:
+  - description: prod
+    reg_keys:
+    - description: fleet
+`,
+			conflict: "vpc acme/prod: matches 2 vpcs",
+		},
+		{
+			name: "private cidr change",
+			manifest: `
+organizations:
+- name: acme
+  vpcs:
+  - description: prod
+    private_cidr: true
+`,
+			conflict: "vpc acme/prod: private_cidr can not be changed from false",
+		},
+		{
+			name: "single use change",
+			setup: func(api *fakeAPI, orgId string, vpcId string) {
+				api.create("reg-keys", map[string]any{"vpc_id": vpcId, "description": "laptop", "single_use": true}, false)
+			},
+			manifest: `
+organizations:
+- name: acme
+  vpcs:
+  - description: prod
+    reg_keys:
+    - description: laptop
+`,
+			conflict: "reg key acme/prod/laptop: single_use can not be changed from true",
+		},
+		{
+			name: "unknown security group",
+			manifest: `
+organizations:
+- name: acme
+  vpcs:
+  - description: prod
+    reg_keys:
+    - description: fleet
+      security_group: web
+`,
+			conflict: `reg key acme/prod/fleet: security group "web" not found`,
+		},
+		{
+			name: "invitation roles change",
+			setup: func(api *fakeAPI, orgId string, vpcId string) {
+				api.create("invitations", map[string]any{"organization_id": orgId, "email": "bob@example.com", "roles": []string{"member"}}, false)
+			},
+			manifest: `
+organizations:
+- name: acme
+  invitations:
+  - email: bob@example.com
+    roles: [owner]
+`,
+			conflict: "invitation acme/bob@example.com: the roles can not be changed from member",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api, c := newFakeAPI(t)
+			org := api.create("organizations", map[string]any{"name": "acme"}, false)
+			vpc := api.create("vpcs", map[string]any{"organization_id": org["id"], "description": "prod"}, false)
+			if tt.setup != nil {
+				tt.setup(api, org["id"].(string), vpc["id"].(string))
+			}
+
+			r := reconcileManifest(c, parseManifest(t, tt.manifest), true, true)
+			assert.Equal(t, []string{tt.conflict}, r.conflicts)
+			assert.Empty(t, api.recordedMutations())
+		})
+	}
+}
+
+func TestExportManifestRoundTrip(t *testing.T) {
+	api, c := newFakeAPI(t)
+	require.Empty(t, reconcileManifest(c, parseManifest(t, applyTestManifest), false, false).conflicts)
+	other := api.create("organizations", map[string]any{"name": "other"}, false)
+	api.create("vpcs", map[string]any{"organization_id": other["id"], "description": "prod"}, false)
+
+	exported := exportedManifest(context.Background(), c, []string{"acme"})
+	require.Len(t, exported.Organizations, 1)
+	org := exported.Organizations[0]
+	assert.Equal(t, "acme", org.Name)
+	var descriptions []string
+	for _, vpc := range org.VPCs {
+		descriptions = append(descriptions, vpc.Description)
+	}
+	assert.ElementsMatch(t, []string{"default vpc", "prod"}, descriptions)
+
+	data, err := yaml.Marshal(exported)
+	require.NoError(t, err)
+	manifest := parseManifest(t, string(data))
+	assert.Equal(t, exported, manifest)
+
+	// applying an export, even with prune, changes nothing
+	r := reconcileManifest(c, manifest, true, true)
+	assert.Empty(t, r.conflicts)
+	assert.Empty(t, r.changes, strings.Join(changePaths(r.changes), "\n"))
+
+	assert.Len(t, exportedManifest(context.Background(), c, nil).Organizations, 2)
+}
@@ -2,7 +2,10 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"github.com/nexodus-io/nexodus/internal/client"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -60,6 +63,57 @@ func createDeviceCommand() *cli.Command {
 					return deleteDevice(ctx, command, devID)
 				},
 			},
+			{
+				Name:  "approve",
+				Usage: "Approve a device that is pending approval to join its vpc",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "device-id",
+						Required: true,
+					},
+				},
+				Action: func(ctx context.Context, command *cli.Command) error {
+					devID, err := getUUID(command, "device-id")
+					if err != nil {
+						return err
+					}
+					return approveDevice(ctx, command, devID)
+				},
+			},
+			{
+				Name:  "reject",
+				Usage: "Reject and delete a device that is pending approval to join its vpc",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "device-id",
+						Required: true,
+					},
+				},
+				Action: func(ctx context.Context, command *cli.Command) error {
+					devID, err := getUUID(command, "device-id")
+					if err != nil {
+						return err
+					}
+					return rejectDevice(ctx, command, devID)
+				},
+			},
+			{
+				Name:  "denied-flows",
+				Usage: "List the flows denied by the security group of a device during the last week",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "device-id",
+						Required: true,
+					},
+				},
+				Action: func(ctx context.Context, command *cli.Command) error {
+					devID, err := getUUID(command, "device-id")
+					if err != nil {
+						return err
+					}
+					return listDeniedFlows(ctx, command, devID)
+				},
+			},
 			{
 				Name:  "update",
 				Usage: "Update a device",
@@ -76,6 +130,11 @@ func createDeviceCommand() *cli.Command {
 						Name:     "hostname",
 						Required: false,
 					},
+					&cli.StringSliceFlag{
+						Name:     "label",
+						Usage:    "key=value label of the device, can be repeated. Replaces all the existing labels",
+						Required: false,
+					},
 				},
 				Action: func(ctx context.Context, command *cli.Command) error {
 
@@ -96,6 +155,13 @@ func createDeviceCommand() *cli.Command {
 						}
 						update.SecurityGroupId = client.PtrString(value)
 					}
+					if command.IsSet("label") {
+						labels, err := parseDeviceLabels(command.StringSlice("label"))
+						if err != nil {
+							return err
+						}
+						update.Labels = labels
+					}
 					return updateDevice(ctx, command, devID, update)
 				},
 			},
@@ -129,6 +195,7 @@ func deviceTableFields(command *cli.Command) []TableField {
 
 	fields = append(fields, TableField{Header: "VPC ID", Field: "VpcId"})
 	fields = append(fields, TableField{Header: "RELAY", Field: "Relay"})
+	fields = append(fields, TableField{Header: "PENDING", Field: "Pending"})
 	if full {
 		fields = append(fields, TableField{Header: "PUBLIC KEY", Field: "PublicKey"})
 		fields = append(fields, TableField{Header: "LOCAL IP", Formatter: func(item interface{}) string {
@@ -144,6 +211,10 @@ func deviceTableFields(command *cli.Command) []TableField {
 			dev := item.(client.ModelsDevice)
 			return strings.Join(dev.AllowedIps, ", ")
 		}})
+		fields = append(fields, TableField{Header: "PRIMARY CIDR", Formatter: func(item interface{}) string {
+			dev := item.(client.ModelsDevice)
+			return strings.Join(dev.PrimaryCidrs, ", ")
+		}})
 		fields = append(fields, TableField{Header: "REFLEXIVE IPv4", Formatter: func(item interface{}) string {
 			dev := item.(client.ModelsDevice)
 			var reflexiveIp4 []string
@@ -167,6 +238,15 @@ func deviceTableFields(command *cli.Command) []TableField {
 		fields = append(fields, TableField{Header: "SYMMETRIC NAT", Field: "SymmetricNat"})
 		fields = append(fields, TableField{Header: "OS", Field: "Os"})
 		fields = append(fields, TableField{Header: "SECURITY GROUP ID", Field: "SecurityGroupId"})
+		fields = append(fields, TableField{Header: "LABELS", Formatter: func(item interface{}) string {
+			dev := item.(client.ModelsDevice)
+			labels := []string{}
+			for key, value := range dev.Labels {
+				labels = append(labels, fmt.Sprintf("%s=%s", key, value))
+			}
+			sort.Strings(labels)
+			return strings.Join(labels, ",")
+		}})
 		fields = append(fields, TableField{Header: "ONLINE", Field: "Online"})
 		fields = append(fields, TableField{Header: "ONLINE SINCE", Formatter: func(item interface{}) string {
 			d := item.(client.ModelsDevice)
@@ -212,6 +292,59 @@ func deleteDevice(ctx context.Context, command *cli.Command, devID string) error
 	return nil
 }
 
+func approveDevice(ctx context.Context, command *cli.Command, devID string) error {
+	c := createClient(ctx, command)
+	res := apiResponse(c.DevicesApi.
+		ApproveDevice(ctx, devID).
+		Execute())
+	show(command, deviceTableFields(command), res)
+	showSuccessfully(command, "approved")
+	return nil
+}
+
+func rejectDevice(ctx context.Context, command *cli.Command, devID string) error {
+	c := createClient(ctx, command)
+	res := apiResponse(c.DevicesApi.
+		RejectDevice(ctx, devID).
+		Execute())
+	show(command, deviceTableFields(command), res)
+	showSuccessfully(command, "rejected")
+	return nil
+}
+
+func deviceDeniedFlowTableFields() []TableField {
+	var fields []TableField
+	fields = append(fields, TableField{Header: "TIME", Formatter: func(item interface{}) string {
+		flow := item.(client.ModelsDeviceDeniedFlow)
+		t, err := time.Parse(time.RFC3339, flow.GetTime())
+		if err != nil {
+			return flow.GetTime()
+		}
+		return t.Local().Format(time.DateTime)
+	}})
+	fields = append(fields, TableField{Header: "DIRECTION", Field: "Direction"})
+	fields = append(fields, TableField{Header: "PROTOCOL", Field: "Protocol"})
+	fields = append(fields, TableField{Header: "SOURCE", Field: "Source"})
+	fields = append(fields, TableField{Header: "DESTINATION", Field: "Destination"})
+	fields = append(fields, TableField{Header: "PORT", Formatter: func(item interface{}) string {
+		flow := item.(client.ModelsDeviceDeniedFlow)
+		if port := flow.GetPort(); port != 0 {
+			return strconv.Itoa(int(port))
+		}
+		return "-"
+	}})
+	return fields
+}
+
+func listDeniedFlows(ctx context.Context, command *cli.Command, devID string) error {
+	c := createClient(ctx, command)
+	res := apiResponse(c.DevicesApi.
+		ListDeniedFlows(ctx, devID).
+		Execute())
+	show(command, deviceDeniedFlowTableFields(), res)
+	return nil
+}
+
 func updateDevice(ctx context.Context, command *cli.Command, devID string, update client.ModelsUpdateDevice) error {
 	c := createClient(ctx, command)
 	res := apiResponse(c.DevicesApi.
@@ -222,3 +355,16 @@ func updateDevice(ctx context.Context, command *cli.Command, devID string, updat
 	showSuccessfully(command, "updated")
 	return nil
 }
+
+// parseDeviceLabels converts key=value flag values into a labels map
+func parseDeviceLabels(values []string) (map[string]string, error) {
+	labels := map[string]string{}
+	for _, value := range values {
+		key, val, found := strings.Cut(value, "=")
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid label %q: expected key=value", value)
+		}
+		labels[key] = val
+	}
+	return labels, nil
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"github.com/nexodus-io/nexodus/internal/client"
+	"github.com/urfave/cli/v3"
+)
+
+var vpcDNSRecordSubcommands []*cli.Command
+
+func init() {
+	vpcDNSRecordSubcommands = []*cli.Command{
+		{
+			Name:  "list",
+			Usage: "List the custom DNS records of a vpc",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "vpc-id",
+					Required: true,
+				},
+			},
+			Action: func(ctx context.Context, command *cli.Command) error {
+				vpcID, err := getUUID(command, "vpc-id")
+				if err != nil {
+					return err
+				}
+				return listDNSRecords(ctx, command, vpcID)
+			},
+		},
+		{
+			Name:  "create",
+			Usage: "Create a custom DNS record in a vpc",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "vpc-id",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     "name",
+					Usage:    "name relative to the vpc zone, for example db.prod",
+					Required: true,
+				},
+				&cli.StringSliceFlag{
+					Name:     "address",
+					Usage:    "IPv4 or IPv6 address the name resolves to, can be repeated",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     "description",
+					Required: false,
+				},
+			},
+			Action: func(ctx context.Context, command *cli.Command) error {
+				vpcID, err := getUUID(command, "vpc-id")
+				if err != nil {
+					return err
+				}
+				return createDNSRecord(ctx, command, vpcID, client.ModelsAddDNSRecord{
+					Name:        client.PtrString(command.String("name")),
+					Addresses:   command.StringSlice("address"),
+					Description: client.PtrOptionalString(command.String("description")),
+				})
+			},
+		},
+		{
+			Name:  "update",
+			Usage: "Update a custom DNS record",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "vpc-id",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     "record-id",
+					Required: true,
+				},
+				&cli.StringSliceFlag{
+					Name:     "address",
+					Usage:    "IPv4 or IPv6 address the name resolves to, can be repeated",
+					Required: false,
+				},
+				&cli.StringFlag{
+					Name:     "description",
+					Required: false,
+				},
+			},
+			Action: func(ctx context.Context, command *cli.Command) error {
+				vpcID, err := getUUID(command, "vpc-id")
+				if err != nil {
+					return err
+				}
+				recordID, err := getUUID(command, "record-id")
+				if err != nil {
+					return err
+				}
+				update := client.ModelsUpdateDNSRecord{
+					Addresses: command.StringSlice("address"),
+				}
+				if command.IsSet("description") {
+					update.Description = client.PtrString(command.String("description"))
+				}
+				return updateDNSRecord(ctx, command, vpcID, recordID, update)
+			},
+		},
+		{
+			Name:  "delete",
+			Usage: "Delete a custom DNS record",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "vpc-id",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     "record-id",
+					Required: true,
+				},
+			},
+			Action: func(ctx context.Context, command *cli.Command) error {
+				vpcID, err := getUUID(command, "vpc-id")
+				if err != nil {
+					return err
+				}
+				recordID, err := getUUID(command, "record-id")
+				if err != nil {
+					return err
+				}
+				return deleteDNSRecord(ctx, command, vpcID, recordID)
+			},
+		},
+	}
+}
+
+func dnsRecordTableFields() []TableField {
+	var fields []TableField
+	fields = append(fields, TableField{Header: "RECORD ID", Field: "Id"})
+	fields = append(fields, TableField{Header: "NAME", Field: "Name"})
+	fields = append(fields, TableField{Header: "ADDRESSES", Field: "Addresses"})
+	fields = append(fields, TableField{Header: "DESCRIPTION", Field: "Description"})
+	return fields
+}
+
+func listDNSRecords(ctx context.Context, command *cli.Command, vpcID string) error {
+	c := createClient(ctx, command)
+	res := apiResponse(c.VPCApi.
+		ListDNSRecordsInVPC(ctx, vpcID).
+		Execute())
+	show(command, dnsRecordTableFields(), res)
+	return nil
+}
+
+func createDNSRecord(ctx context.Context, command *cli.Command, vpcID string, resource client.ModelsAddDNSRecord) error {
+	c := createClient(ctx, command)
+	res := apiResponse(c.VPCApi.
+		CreateDNSRecordInVPC(ctx, vpcID).
+		DNSRecord(resource).
+		Execute())
+	show(command, dnsRecordTableFields(), res)
+	return nil
+}
+
+func updateDNSRecord(ctx context.Context, command *cli.Command, vpcID string, recordID string, update client.ModelsUpdateDNSRecord) error {
+	c := createClient(ctx, command)
+	res := apiResponse(c.VPCApi.
+		UpdateDNSRecordInVPC(ctx, vpcID, recordID).
+		Update(update).
+		Execute())
+	show(command, dnsRecordTableFields(), res)
+	showSuccessfully(command, "updated")
+	return nil
+}
+
+func deleteDNSRecord(ctx context.Context, command *cli.Command, vpcID string, recordID string) error {
+	c := createClient(ctx, command)
+	res := apiResponse(c.VPCApi.
+		DeleteDNSRecordInVPC(ctx, vpcID, recordID).
+		Execute())
+	show(command, dnsRecordTableFields(), res)
+	showSuccessfully(command, "deleted")
+	return nil
+}
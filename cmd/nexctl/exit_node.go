@@ -12,6 +12,10 @@ type exitNodeOrigin struct {
 	Endpoint            string
 	AllowedIPs          []string
 	PersistentKeepAlive string
+	DeviceId            string
+	Hostname            string
+	Active              bool
+	Healthy             bool
 }
 
 func enableExitNodeClient(ctx context.Context, command *cli.Command) error {
@@ -19,7 +23,7 @@ func enableExitNodeClient(ctx context.Context, command *cli.Command) error {
 		return err
 	}
 
-	result, err := callNexd("EnableExitNodeClient", "")
+	result, err := callNexd("EnableExitNodeClient", command.String("via"))
 	if err != nil {
 		return fmt.Errorf("Failed to enable exit node client: %w\n", err)
 	}
@@ -54,8 +58,12 @@ func disableExitNodeClient(ctx context.Context, command *cli.Command) error {
 
 func exitNodeTableFields(command *cli.Command) []TableField {
 	var fields []TableField
+	fields = append(fields, TableField{Header: "HOSTNAME", Field: "Hostname"})
+	fields = append(fields, TableField{Header: "DEVICE ID", Field: "DeviceId"})
 	fields = append(fields, TableField{Header: "ENDPOINT ADDRESS", Field: "Endpoint"})
 	fields = append(fields, TableField{Header: "PUBLIC KEY", Field: "PublicKey"})
+	fields = append(fields, TableField{Header: "HEALTHY", Field: "Healthy"})
+	fields = append(fields, TableField{Header: "ACTIVE", Field: "Active"})
 	return fields
 }
 func listExitNodes(ctx context.Context, command *cli.Command, encodeOut string) error {
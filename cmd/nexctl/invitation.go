@@ -38,6 +38,7 @@ func createInvitationCommand() *cli.Command {
 					},
 					&cli.StringSliceFlag{
 						Name:        "role",
+						Usage:       "role to grant: owner, member, read-only, device-admin, security-group-editor, invite-only or a custom role of the organization",
 						Required:    false,
 						DefaultText: "member",
 						Value:       []string{"member"},
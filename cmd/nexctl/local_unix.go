@@ -44,6 +44,22 @@ func init() {
 				Usage:  "Display the nexd status",
 				Action: cmdLocalStatus,
 			},
+			{
+				Name:  "rotate-key",
+				Usage: "Rotate the wireguard key of the device, peers switch to the new key without the device registering again",
+				Action: func(ctx context.Context, command *cli.Command) error {
+					if err := checkVersion(); err != nil {
+						return err
+					}
+					result, err := callNexd("RotateKey", "")
+					if err != nil {
+						fmt.Printf("%s\n", err)
+						return err
+					}
+					fmt.Printf("%s\n", result)
+					return nil
+				},
+			},
 			{
 				Name:  "get",
 				Usage: "Get a value from the local nexd instance",
@@ -166,12 +182,12 @@ func init() {
 						Flags: []cli.Flag{
 							&cli.StringSliceFlag{
 								Name:     "ingress",
-								Usage:    "Forward connections from the Nexodus network made to [port] on this proxy instance to port [destination_port] at [destination_ip] via a locally accessible network using a `value` in the form: protocol:port:destination_ip:destination_port. All fields are required.",
+								Usage:    "Forward connections from the Nexodus network made to [port] on this proxy instance to port [destination_port] at [destination] via a locally accessible network using a `value` in the form: protocol:port:destination:destination_port, where [destination] is an IP address or a hostname that is resolved for every connection. All fields are required.",
 								Required: false,
 							},
 							&cli.StringSliceFlag{
 								Name:     "egress",
-								Usage:    "Forward connections from a locally accessible network made to [port] on this proxy instance to port [destination_port] at [destination_ip] via the Nexodus network using a `value` in the form: protocol:port:destination_ip:destination_port. All fields are required.",
+								Usage:    "Forward connections from a locally accessible network made to [port] on this proxy instance to port [destination_port] at [destination] via the Nexodus network using a `value` in the form: protocol:port:destination:destination_port, where [destination] is an IP address or a hostname that is resolved for every connection. All fields are required.",
 								Required: false,
 							},
 						},
@@ -185,12 +201,12 @@ func init() {
 						Flags: []cli.Flag{
 							&cli.StringSliceFlag{
 								Name:     "ingress",
-								Usage:    "Forward connections from the Nexodus network made to [port] on this proxy instance to port [destination_port] at [destination_ip] via a locally accessible network using a `value` in the form: protocol:port:destination_ip:destination_port. All fields are required.",
+								Usage:    "Forward connections from the Nexodus network made to [port] on this proxy instance to port [destination_port] at [destination] via a locally accessible network using a `value` in the form: protocol:port:destination:destination_port, where [destination] is an IP address or a hostname that is resolved for every connection. All fields are required.",
 								Required: false,
 							},
 							&cli.StringSliceFlag{
 								Name:     "egress",
-								Usage:    "Forward connections from a locally accessible network made to [port] on this proxy instance to port [destination_port] at [destination_ip] via the Nexodus network using a `value` in the form: protocol:port:destination_ip:destination_port. All fields are required.",
+								Usage:    "Forward connections from a locally accessible network made to [port] on this proxy instance to port [destination_port] at [destination] via the Nexodus network using a `value` in the form: protocol:port:destination:destination_port, where [destination] is an IP address or a hostname that is resolved for every connection. All fields are required.",
 								Required: false,
 							},
 						},
@@ -235,6 +251,34 @@ func init() {
 					},
 				},
 			},
+			{
+				Name:  "security-group",
+				Usage: "Commands for interacting with the nexd security group enforcement",
+				Commands: []*cli.Command{
+					{
+						Name:  "stats",
+						Usage: "list the packets and bytes matched by each rule of the security group of this device, including the default drop",
+						Action: func(ctx context.Context, command *cli.Command) error {
+							return listSecurityGroupStats(ctx, command)
+						},
+					},
+					{
+						Name:  "denied",
+						Usage: "list the flows denied by the security group of this device, nexd must be started with --security-group-log-denied",
+						Flags: []cli.Flag{
+							&cli.BoolFlag{
+								Name:    "follow",
+								Aliases: []string{"f"},
+								Usage:   "keep listing the flows as they are denied",
+								Value:   false,
+							},
+						},
+						Action: func(ctx context.Context, command *cli.Command) error {
+							return listSecurityGroupDenied(ctx, command)
+						},
+					},
+				},
+			},
 			{
 				Name:  "exit-node",
 				Usage: "Commands for interacting nexd exit node configuration",
@@ -250,6 +294,13 @@ func init() {
 					{
 						Name:  "enable",
 						Usage: "Enable the device to use an exit node in the current organization. Warning: this will funnel all traffic through the exit node if one exists and will likely cause your device to be unreachable outside of the nexodus peer network.",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:     "via",
+								Usage:    "the hostname or device id of the exit node to use, or auto to use the healthy exit node with the lowest latency and fail over to another one when it becomes unhealthy. The choice is kept across nexd restarts",
+								Required: false,
+							},
+						},
 						Action: func(ctx context.Context, command *cli.Command) error {
 							return enableExitNodeClient(ctx, command)
 						},
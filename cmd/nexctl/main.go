@@ -108,6 +108,11 @@ func main() {
 			createServiceNetworkCommand(),
 			createSiteCommand(),
 			createInvitationCommand(),
+			createTokenCommand(),
+			createWebhookCommand(),
+			createApplyCommand(),
+			createDiffCommand(),
+			createExportCommand(),
 		},
 	}
 
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/nexodus-io/nexodus/internal/client"
+	"github.com/nexodus-io/nexodus/internal/util"
+)
+
+// Manifest is the declarative description of the resources of one or more organizations
+// used by the apply, diff and export commands. Resources are matched against the API by
+// name for organizations, by email or user id for invitations, and by description for
+// everything else.
+type Manifest struct {
+	Organizations []ManifestOrganization `json:"organizations"`
+}
+
+type ManifestOrganization struct {
+	Name            string                   `json:"name"`
+	Description     string                   `json:"description,omitempty"`
+	VPCs            []ManifestVPC            `json:"vpcs,omitempty"`
+	ServiceNetworks []ManifestServiceNetwork `json:"service_networks,omitempty"`
+	Invitations     []ManifestInvitation     `json:"invitations,omitempty"`
+}
+
+type ManifestVPC struct {
+	Description           string                  `json:"description"`
+	PrivateCidr           bool                    `json:"private_cidr,omitempty"`
+	Ipv4Cidr              string                  `json:"ipv4_cidr,omitempty"`
+	Ipv6Cidr              string                  `json:"ipv6_cidr,omitempty"`
+	RequireDeviceApproval bool                    `json:"require_device_approval,omitempty"`
+	PresharedKeys         bool                    `json:"preshared_keys,omitempty"`
+	SecurityGroups        []ManifestSecurityGroup `json:"security_groups,omitempty"`
+	RegKeys               []ManifestRegKey        `json:"reg_keys,omitempty"`
+}
+
+type ManifestSecurityGroup struct {
+	Description   string                      `json:"description"`
+	InboundRules  []client.ModelsSecurityRule `json:"inbound_rules,omitempty"`
+	OutboundRules []client.ModelsSecurityRule `json:"outbound_rules,omitempty"`
+}
+
+type ManifestRegKey struct {
+	Description string `json:"description"`
+	// SecurityGroup is the description of a security group of the same VPC
+	SecurityGroup string                 `json:"security_group,omitempty"`
+	ExpiresAt     string                 `json:"expires_at,omitempty"`
+	SingleUse     bool                   `json:"single_use,omitempty"`
+	Ephemeral     bool                   `json:"ephemeral,omitempty"`
+	Settings      map[string]interface{} `json:"settings,omitempty"`
+	// MaxUses limits the number of devices the key can register, the limit is left as is when it is 0
+	MaxUses            int32    `json:"max_uses,omitempty"`
+	AllowedSourceCidrs []string `json:"allowed_source_cidrs,omitempty"`
+	// DeviceLabels, DeviceMetadata and HostnameTemplate are applied to the devices registered with the key
+	DeviceLabels     map[string]string      `json:"device_labels,omitempty"`
+	DeviceMetadata   map[string]interface{} `json:"device_metadata,omitempty"`
+	HostnameTemplate string                 `json:"hostname_template,omitempty"`
+}
+
+type ManifestServiceNetwork struct {
+	Description string           `json:"description"`
+	RegKeys     []ManifestRegKey `json:"reg_keys,omitempty"`
+}
+
+type ManifestInvitation struct {
+	Email  string   `json:"email,omitempty"`
+	UserID string   `json:"user_id,omitempty"`
+	Roles  []string `json:"roles,omitempty"`
+}
+
+// readManifest reads a YAML or JSON manifest from a file, or from stdin when the file name is "-".
+func readManifest(filename string) (Manifest, error) {
+	var data []byte
+	var err error
+	if filename == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(filename)
+	}
+	if err != nil {
+		return Manifest{}, fmt.Errorf("failed to read the manifest: %w", err)
+	}
+
+	// YAML is a superset of JSON, so both formats are accepted.
+	data, err = yaml.YAMLToJSON(data)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("failed to parse the manifest %s: %w", filename, err)
+	}
+	manifest := Manifest{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&manifest); err != nil {
+		return Manifest{}, fmt.Errorf("failed to parse the manifest %s: %w", filename, err)
+	}
+	if err := manifest.validate(); err != nil {
+		return Manifest{}, fmt.Errorf("invalid manifest %s: %w", filename, err)
+	}
+	return manifest, nil
+}
+
+// validate checks that every resource has the key it is matched by and that keys are unique.
+func (m Manifest) validate() error {
+	orgs := map[string]bool{}
+	for _, org := range m.Organizations {
+		if org.Name == "" {
+			return fmt.Errorf("organization: name is required")
+		}
+		if orgs[org.Name] {
+			return fmt.Errorf("organization %s: defined more than once", org.Name)
+		}
+		orgs[org.Name] = true
+
+		vpcs := map[string]bool{}
+		for _, vpc := range org.VPCs {
+			path := org.Name + "/" + vpc.Description
+			if vpc.Description == "" {
+				return fmt.Errorf("organization %s: vpc description is required", org.Name)
+			}
+			if vpcs[vpc.Description] {
+				return fmt.Errorf("vpc %s: defined more than once", path)
+			}
+			vpcs[vpc.Description] = true
+
+			groups := map[string]bool{}
+			for _, sg := range vpc.SecurityGroups {
+				if sg.Description == "" {
+					return fmt.Errorf("vpc %s: security group description is required", path)
+				}
+				if groups[sg.Description] {
+					return fmt.Errorf("security group %s/%s: defined more than once", path, sg.Description)
+				}
+				groups[sg.Description] = true
+			}
+			if err := validateManifestRegKeys(path, vpc.RegKeys); err != nil {
+				return err
+			}
+		}
+
+		networks := map[string]bool{}
+		for _, sn := range org.ServiceNetworks {
+			path := org.Name + "/" + sn.Description
+			if sn.Description == "" {
+				return fmt.Errorf("organization %s: service network description is required", org.Name)
+			}
+			if networks[sn.Description] {
+				return fmt.Errorf("service network %s: defined more than once", path)
+			}
+			networks[sn.Description] = true
+			for _, key := range sn.RegKeys {
+				if key.SecurityGroup != "" {
+					return fmt.Errorf("reg key %s/%s: service network reg keys can not have a security group", path, key.Description)
+				}
+			}
+			if err := validateManifestRegKeys(path, sn.RegKeys); err != nil {
+				return err
+			}
+		}
+
+		invitations := map[string]bool{}
+		for _, invitation := range org.Invitations {
+			if (invitation.Email == "") == (invitation.UserID == "") {
+				return fmt.Errorf("organization %s: invitations require either an email or a user_id", org.Name)
+			}
+			key := invitation.key()
+			if invitations[key] {
+				return fmt.Errorf("invitation %s/%s: defined more than once", org.Name, key)
+			}
+			invitations[key] = true
+		}
+	}
+	return nil
+}
+
+func validateManifestRegKeys(path string, keys []ManifestRegKey) error {
+	seen := map[string]bool{}
+	for _, key := range keys {
+		if key.Description == "" {
+			return fmt.Errorf("%s: reg key description is required", path)
+		}
+		if seen[key.Description] {
+			return fmt.Errorf("reg key %s/%s: defined more than once", path, key.Description)
+		}
+		seen[key.Description] = true
+		if key.ExpiresAt != "" {
+			if _, err := time.Parse(time.RFC3339, key.ExpiresAt); err != nil {
+				return fmt.Errorf("reg key %s/%s: invalid expires_at: %w", path, key.Description, err)
+			}
+		}
+		if key.MaxUses < 0 {
+			return fmt.Errorf("reg key %s/%s: max_uses must not be negative", path, key.Description)
+		}
+		for _, cidr := range key.AllowedSourceCidrs {
+			if !util.IsValidPrefix(cidr) {
+				return fmt.Errorf("reg key %s/%s: invalid allowed_source_cidrs: %s", path, key.Description, cidr)
+			}
+		}
+		if err := util.ValidateLabels(key.DeviceLabels); err != nil {
+			return fmt.Errorf("reg key %s/%s: device_labels: %w", path, key.Description, err)
+		}
+	}
+	return nil
+}
+
+// key returns the value an invitation is matched by
+func (i ManifestInvitation) key() string {
+	if i.Email != "" {
+		return i.Email
+	}
+	return i.UserID
+}
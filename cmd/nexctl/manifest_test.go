@@ -0,0 +1,152 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nexodus-io/nexodus/internal/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeManifest(t *testing.T, content string) string {
+	filename := filepath.Join(t.TempDir(), "manifest.yaml")
+	require.NoError(t, os.WriteFile(filename, []byte(content), 0600))
+	return filename
+}
+
+func TestReadManifest(t *testing.T) {
+	manifest, err := readManifest(writeManifest(t, `
+organizations:
+- name: acme
+  vpcs:
+  - description: prod
+    require_device_approval: true
+    preshared_keys: true
+    reg_keys:
+    - description: fleet
+      max_uses: 50
+      allowed_source_cidrs: ["192.0.2.0/24"]
+      device_labels:
+        site: lab
+      device_metadata:
+        rack: {row: 12}
+      hostname_template: edge-{{.Index}}
+`))
+	require.NoError(t, err)
+	require.Len(t, manifest.Organizations, 1)
+	require.Len(t, manifest.Organizations[0].VPCs, 1)
+	vpc := manifest.Organizations[0].VPCs[0]
+	assert.Equal(t, "prod", vpc.Description)
+	assert.True(t, vpc.RequireDeviceApproval)
+	assert.True(t, vpc.PresharedKeys)
+	require.Len(t, vpc.RegKeys, 1)
+	assert.Equal(t, int32(50), vpc.RegKeys[0].MaxUses)
+	assert.Equal(t, []string{"192.0.2.0/24"}, vpc.RegKeys[0].AllowedSourceCidrs)
+	assert.Equal(t, map[string]string{"site": "lab"}, vpc.RegKeys[0].DeviceLabels)
+	assert.Equal(t, map[string]interface{}{"rack": map[string]interface{}{"row": float64(12)}}, vpc.RegKeys[0].DeviceMetadata)
+	assert.Equal(t, "edge-{{.Index}}", vpc.RegKeys[0].HostnameTemplate)
+}
+
+func TestReadInvalidManifest(t *testing.T) {
+	for _, content := range []string{
+		"organizations:\n- description: no name\n",
+		"organizations:\n- name: acme\n- name: acme\n",
+		"organizations:\n- name: acme\n  vpcs:\n  - description: prod\n    unknown: true\n",
+		"organizations:\n- name: acme\n  vpcs:\n  - description: prod\n    reg_keys:\n    - description: fleet\n      max_uses: -1\n",
+		"organizations:\n- name: acme\n  vpcs:\n  - description: prod\n    reg_keys:\n    - description: fleet\n      allowed_source_cidrs: [192.0.2.1]\n",
+		"organizations:\n- name: acme\n  vpcs:\n  - description: prod\n    reg_keys:\n    - description: fleet\n      device_labels: {\"s,ite\": lab}\n",
+	} {
+		_, err := readManifest(writeManifest(t, content))
+		assert.Error(t, err, content)
+	}
+}
+
+func TestVPCUpdate(t *testing.T) {
+	vpc := client.ModelsVPC{
+		Description:           client.PtrString("prod"),
+		RequireDeviceApproval: client.PtrBool(false),
+		PresharedKeys:         client.PtrBool(true),
+	}
+
+	fields, _ := vpcUpdate(vpc, ManifestVPC{Description: "prod", PresharedKeys: true})
+	assert.Empty(t, fields)
+
+	fields, update := vpcUpdate(vpc, ManifestVPC{Description: "prod", RequireDeviceApproval: true})
+	assert.Equal(t, []string{
+		"require_device_approval: false -> true",
+		"preshared_keys: true -> false",
+	}, fields)
+	assert.Equal(t, client.ModelsUpdateVPC{
+		RequireDeviceApproval: client.PtrBool(true),
+		PresharedKeys:         client.PtrBool(false),
+	}, update)
+}
+
+func TestRegKeyUpdate(t *testing.T) {
+	key := client.ModelsRegKey{
+		Description:        client.PtrString("fleet"),
+		MaxUses:            client.PtrInt32(10),
+		AllowedSourceCidrs: []string{"192.0.2.0/24", "198.51.100.0/24"},
+	}
+
+	// fields that are not set in the manifest are left as is
+	fields, _ := regKeyUpdate(key, ManifestRegKey{Description: "fleet"}, "")
+	assert.Empty(t, fields)
+
+	fields, _ = regKeyUpdate(key, ManifestRegKey{
+		Description:        "fleet",
+		MaxUses:            10,
+		AllowedSourceCidrs: []string{"198.51.100.0/24", "192.0.2.0/24"},
+	}, "")
+	assert.Empty(t, fields)
+
+	fields, update := regKeyUpdate(key, ManifestRegKey{
+		Description:        "fleet",
+		MaxUses:            50,
+		AllowedSourceCidrs: []string{"192.0.2.0/24"},
+	}, "")
+	assert.Equal(t, []string{
+		"max_uses: 10 -> 50",
+		`allowed_source_cidrs: ["192.0.2.0/24","198.51.100.0/24"] -> ["192.0.2.0/24"]`,
+	}, fields)
+	assert.Equal(t, client.ModelsUpdateRegKey{
+		MaxUses:            client.PtrInt32(50),
+		AllowedSourceCidrs: []string{"192.0.2.0/24"},
+	}, update)
+}
+
+func TestRegKeyDeviceDefaultsUpdate(t *testing.T) {
+	key := client.ModelsRegKey{
+		Description:      client.PtrString("fleet"),
+		DeviceLabels:     map[string]string{"site": "lab"},
+		DeviceMetadata:   map[string]interface{}{"rack": "r1"},
+		HostnameTemplate: client.PtrString("edge-{{.Index}}"),
+	}
+
+	fields, _ := regKeyUpdate(key, ManifestRegKey{
+		Description:      "fleet",
+		DeviceLabels:     map[string]string{"site": "lab"},
+		DeviceMetadata:   map[string]interface{}{"rack": "r1"},
+		HostnameTemplate: "edge-{{.Index}}",
+	}, "")
+	assert.Empty(t, fields)
+
+	fields, update := regKeyUpdate(key, ManifestRegKey{
+		Description:      "fleet",
+		DeviceLabels:     map[string]string{"site": "dc"},
+		DeviceMetadata:   map[string]interface{}{"rack": "r2"},
+		HostnameTemplate: "dc-{{.Index}}",
+	}, "")
+	assert.Equal(t, []string{
+		`device_labels: {"site":"lab"} -> {"site":"dc"}`,
+		`device_metadata: {"rack":"r1"} -> {"rack":"r2"}`,
+		"hostname_template: edge-{{.Index}} -> dc-{{.Index}}",
+	}, fields)
+	assert.Equal(t, client.ModelsUpdateRegKey{
+		DeviceLabels:     map[string]string{"site": "dc"},
+		DeviceMetadata:   map[string]interface{}{"rack": "r2"},
+		HostnameTemplate: client.PtrString("dc-{{.Index}}"),
+	}, update)
+}
@@ -2,6 +2,9 @@ package main
 
 import (
 	"context"
+	"sort"
+	"strings"
+
 	"github.com/nexodus-io/nexodus/internal/client"
 	"github.com/urfave/cli/v3"
 )
@@ -85,6 +88,24 @@ func createOrganizationCommand() *cli.Command {
 					return createOrganization(ctx, command, name, description)
 				},
 			},
+			{
+				Name:  "audit",
+				Usage: "List the changes made to the resources of an organization",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "organization-id",
+						Required: true,
+					},
+				},
+				Action: func(ctx context.Context, command *cli.Command) error {
+					organizationID, err := getUUID(command, "organization-id")
+					if err != nil {
+						return err
+					}
+
+					return listOrganizationAuditEvents(ctx, command, organizationID)
+				},
+			},
 			{
 				Name:  "delete",
 				Usage: "Delete a organization",
@@ -202,3 +223,39 @@ func deleteOrganizationUser(ctx context.Context, command *cli.Command, orgId str
 	showSuccessfully(command, "deleted")
 	return nil
 }
+
+func auditEventTableFields() []TableField {
+	var fields []TableField
+	fields = append(fields, TableField{Header: "TIME", Field: "CreatedAt"})
+	fields = append(fields, TableField{Header: "ACTION", Field: "Action"})
+	fields = append(fields, TableField{Header: "RESOURCE KIND", Field: "ResourceKind"})
+	fields = append(fields, TableField{Header: "RESOURCE ID", Field: "ResourceId"})
+	fields = append(fields, TableField{Header: "ACTOR USER ID", Field: "ActorUserId"})
+	fields = append(fields, TableField{Header: "ACTOR DEVICE ID", Field: "ActorDeviceId"})
+	fields = append(fields, TableField{Header: "SOURCE IP", Field: "SourceIp"})
+	fields = append(fields, TableField{Header: "CHANGED FIELDS", Formatter: func(item interface{}) string {
+		event := item.(client.ModelsAuditEvent)
+		changed := map[string]struct{}{}
+		for key := range event.Before {
+			changed[key] = struct{}{}
+		}
+		for key := range event.After {
+			changed[key] = struct{}{}
+		}
+		keys := make([]string, 0, len(changed))
+		for key := range changed {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return strings.Join(keys, ",")
+	}})
+	return fields
+}
+func listOrganizationAuditEvents(ctx context.Context, command *cli.Command, orgId string) error {
+	c := createClient(ctx, command)
+	res := apiResponse(c.OrganizationsApi.
+		ListAuditEventsInOrganization(ctx, orgId).
+		Execute())
+	show(command, auditEventTableFields(), res)
+	return nil
+}
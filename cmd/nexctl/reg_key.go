@@ -48,6 +48,36 @@ func createRegKeyCommand() *cli.Command {
 						Name:     "single-use",
 						Required: false,
 					},
+					&cli.BoolFlag{
+						Name:     "ephemeral",
+						Usage:    "devices registered with the key are deleted after they go offline",
+						Required: false,
+					},
+					&cli.IntFlag{
+						Name:     "max-uses",
+						Usage:    "maximum number of devices the key can register",
+						Required: false,
+					},
+					&cli.StringSliceFlag{
+						Name:     "allowed-source-cidr",
+						Usage:    "network the key can be used from, can be repeated",
+						Required: false,
+					},
+					&cli.StringSliceFlag{
+						Name:     "device-label",
+						Usage:    "key=value label of the registered devices, can be repeated",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "device-metadata",
+						Usage:    "json object with the metadata of the registered devices",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "hostname-template",
+						Usage:    "go template that names the registered devices, e.g. 'edge-{{.Index}}'",
+						Required: false,
+					},
 					&cli.DurationFlag{
 						Name:     "expiration",
 						Required: false,
@@ -68,13 +98,28 @@ func createRegKeyCommand() *cli.Command {
 						settings = nil
 					}
 
+					labels, err := parseDeviceLabels(command.StringSlice("device-label"))
+					if err != nil {
+						return err
+					}
+					metadata, err := getRegKeyDeviceMetadata(command)
+					if err != nil {
+						return err
+					}
+
 					return createRegKey(ctx, command, client.ModelsAddRegKey{
-						VpcId:           client.PtrOptionalString(command.String("vpc-id")),
-						Description:     client.PtrOptionalString(command.String("description")),
-						ExpiresAt:       client.PtrOptionalString(getExpiration(command, "expiration")),
-						SingleUse:       client.PtrBool(command.Bool("single-use")),
-						SecurityGroupId: client.PtrOptionalString(command.String("security-group-id")),
-						Settings:        settings,
+						VpcId:              client.PtrOptionalString(command.String("vpc-id")),
+						Description:        client.PtrOptionalString(command.String("description")),
+						ExpiresAt:          client.PtrOptionalString(getExpiration(command, "expiration")),
+						SingleUse:          client.PtrBool(command.Bool("single-use")),
+						Ephemeral:          client.PtrBool(command.Bool("ephemeral")),
+						MaxUses:            client.PtrInt32(int32(command.Int("max-uses"))),
+						AllowedSourceCidrs: command.StringSlice("allowed-source-cidr"),
+						SecurityGroupId:    client.PtrOptionalString(command.String("security-group-id")),
+						Settings:           settings,
+						DeviceLabels:       labels,
+						DeviceMetadata:     metadata,
+						HostnameTemplate:   client.PtrOptionalString(command.String("hostname-template")),
 					})
 				},
 			},
@@ -94,6 +139,31 @@ func createRegKeyCommand() *cli.Command {
 						Name:     "description",
 						Required: false,
 					},
+					&cli.IntFlag{
+						Name:     "max-uses",
+						Usage:    "maximum number of devices the key can register, 0 removes the limit",
+						Required: false,
+					},
+					&cli.StringSliceFlag{
+						Name:     "allowed-source-cidr",
+						Usage:    "network the key can be used from, can be repeated",
+						Required: false,
+					},
+					&cli.StringSliceFlag{
+						Name:     "device-label",
+						Usage:    "key=value label of the registered devices, can be repeated",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "device-metadata",
+						Usage:    "json object with the metadata of the registered devices",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "hostname-template",
+						Usage:    "go template that names the registered devices, e.g. 'edge-{{.Index}}'",
+						Required: false,
+					},
 					&cli.DurationFlag{
 						Name:     "expiration",
 						Required: false,
@@ -114,12 +184,53 @@ func createRegKeyCommand() *cli.Command {
 						settings = nil
 					}
 
-					return updateRegKey(ctx, command, command.String("reg-key-id"), client.ModelsUpdateRegKey{
+					update := client.ModelsUpdateRegKey{
 						Description:     client.PtrOptionalString(command.String("description")),
 						ExpiresAt:       client.PtrOptionalString(getExpiration(command, "expiration")),
 						SecurityGroupId: client.PtrOptionalString(command.String("security-group-id")),
 						Settings:        settings,
-					})
+					}
+					if command.IsSet("max-uses") {
+						update.MaxUses = client.PtrInt32(int32(command.Int("max-uses")))
+					}
+					if command.IsSet("allowed-source-cidr") {
+						update.AllowedSourceCidrs = command.StringSlice("allowed-source-cidr")
+					}
+					if command.IsSet("device-label") {
+						labels, err := parseDeviceLabels(command.StringSlice("device-label"))
+						if err != nil {
+							return err
+						}
+						update.DeviceLabels = labels
+					}
+					if command.IsSet("device-metadata") {
+						metadata, err := getRegKeyDeviceMetadata(command)
+						if err != nil {
+							return err
+						}
+						update.DeviceMetadata = metadata
+					}
+					if command.IsSet("hostname-template") {
+						update.HostnameTemplate = client.PtrString(command.String("hostname-template"))
+					}
+					return updateRegKey(ctx, command, command.String("reg-key-id"), update)
+				},
+			},
+			{
+				Name:  "devices",
+				Usage: "List the devices registered with a registration key",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "reg-key-id",
+						Required: true,
+					},
+				},
+				Action: func(ctx context.Context, command *cli.Command) error {
+					id, err := getUUID(command, "reg-key-id")
+					if err != nil {
+						return err
+					}
+					return listRegKeyDevices(ctx, command, id)
 				},
 			},
 			{
@@ -151,6 +262,13 @@ func regTokenTableFields(command *cli.Command) []TableField {
 		record := item.(client.ModelsRegKey)
 		return fmt.Sprintf("--reg-key %s#%s", command.String("service-url"), record.GetBearerToken())
 	}})
+	fields = append(fields, TableField{Header: "REMAINING USES", Formatter: func(item interface{}) string {
+		key := item.(client.ModelsRegKey)
+		if !key.HasRemainingUses() {
+			return "unlimited"
+		}
+		return fmt.Sprintf("%d", key.GetRemainingUses())
+	}})
 	if command.Bool("full") {
 		fields = append(fields, TableField{Header: "VPC ID", Field: "VpcId"})
 		fields = append(fields, TableField{Header: "SECURITY GROUP ID", Field: "SecurityGroupId"})
@@ -162,6 +280,12 @@ func regTokenTableFields(command *cli.Command) []TableField {
 				return "true"
 			}
 		}})
+		fields = append(fields, TableField{Header: "EPHEMERAL", Field: "Ephemeral"})
+		fields = append(fields, TableField{Header: "USES", Field: "Uses"})
+		fields = append(fields, TableField{Header: "ALLOWED SOURCE CIDRS", Field: "AllowedSourceCidrs"})
+		fields = append(fields, TableField{Header: "HOSTNAME TEMPLATE", Field: "HostnameTemplate"})
+		fields = append(fields, TableField{Header: "DEVICE LABELS", Field: "DeviceLabels"})
+		fields = append(fields, TableField{Header: "DEVICE METADATA", Field: "DeviceMetadata"})
 		fields = append(fields, TableField{Header: "EXPIRES AT", Field: "ExpiresAt"})
 		// fields = append(fields, TableField{Header: "BEARER TOKEN", Field: "BearerToken"})
 		fields = append(fields, TableField{Header: "SETTINGS", Field: "Settings"})
@@ -169,6 +293,17 @@ func regTokenTableFields(command *cli.Command) []TableField {
 	return fields
 }
 
+func getRegKeyDeviceMetadata(command *cli.Command) (map[string]interface{}, error) {
+	if command.String("device-metadata") == "" {
+		return nil, nil
+	}
+	metadata := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(command.String("device-metadata")), &metadata); err != nil {
+		return nil, fmt.Errorf("invalid --device-metadata flag value: %w", err)
+	}
+	return metadata, nil
+}
+
 func listRegKeys(ctx context.Context, command *cli.Command) error {
 	c := createClient(ctx, command)
 	rows := apiResponse(c.RegKeyApi.
@@ -202,6 +337,15 @@ func updateRegKey(ctx context.Context, command *cli.Command, id string, update c
 	return nil
 }
 
+func listRegKeyDevices(ctx context.Context, command *cli.Command, id string) error {
+	c := createClient(ctx, command)
+	res := apiResponse(c.RegKeyApi.
+		ListDevicesForRegKey(ctx, id).
+		Execute())
+	show(command, deviceTableFields(command), res)
+	return nil
+}
+
 func deleteRegKey(ctx context.Context, command *cli.Command, id string) error {
 	c := createClient(ctx, command)
 	res := apiResponse(c.RegKeyApi.
@@ -4,6 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/nexodus-io/nexodus/internal/client"
 	"github.com/urfave/cli/v3"
 )
@@ -244,3 +248,142 @@ func checkICMPRule(rule client.ModelsSecurityRule) error {
 	}
 	return nil
 }
+
+type securityRuleStats struct {
+	Direction    string
+	Rule         string
+	IpProtocol   string
+	Ports        string
+	IpRanges     []string
+	PeerSelector string
+	Packets      uint64
+	Bytes        uint64
+}
+
+func securityGroupStatsTableFields() []TableField {
+	var fields []TableField
+	fields = append(fields, TableField{Header: "DIRECTION", Field: "Direction"})
+	fields = append(fields, TableField{Header: "RULE", Field: "Rule"})
+	fields = append(fields, TableField{Header: "PROTOCOL", Field: "IpProtocol"})
+	fields = append(fields, TableField{Header: "PORTS", Field: "Ports"})
+	fields = append(fields, TableField{Header: "IP RANGES", Formatter: func(item interface{}) string {
+		return strings.Join(item.(securityRuleStats).IpRanges, ", ")
+	}})
+	fields = append(fields, TableField{Header: "PEER SELECTOR", Field: "PeerSelector"})
+	fields = append(fields, TableField{Header: "PACKETS", Field: "Packets"})
+	fields = append(fields, TableField{Header: "BYTES", Field: "Bytes"})
+	return fields
+}
+
+// listSecurityGroupStats lists the counters of the security group rules enforced by nexd
+func listSecurityGroupStats(ctx context.Context, command *cli.Command) error {
+	var stats []securityRuleStats
+	if err := checkVersion(); err != nil {
+		return err
+	}
+
+	result, err := callNexd("SecurityGroupStats", "")
+	if err != nil {
+		return fmt.Errorf("Failed to get the security group stats: %w\n", err)
+	}
+
+	err = json.Unmarshal([]byte(result), &stats)
+	if err != nil {
+		return fmt.Errorf("Failed to marshall the security group stats: %w\n", err)
+	}
+
+	show(command, securityGroupStatsTableFields(), stats)
+	return nil
+}
+
+type deniedFlow struct {
+	Seq         uint64
+	Time        time.Time
+	Direction   string
+	Protocol    string
+	Source      string
+	Destination string
+	Port        uint16
+}
+
+func deniedFlowTableFields() []TableField {
+	var fields []TableField
+	fields = append(fields, TableField{Header: "TIME", Formatter: func(item interface{}) string {
+		return item.(deniedFlow).Time.Local().Format(time.DateTime)
+	}})
+	fields = append(fields, TableField{Header: "DIRECTION", Field: "Direction"})
+	fields = append(fields, TableField{Header: "PROTOCOL", Field: "Protocol"})
+	fields = append(fields, TableField{Header: "SOURCE", Field: "Source"})
+	fields = append(fields, TableField{Header: "DESTINATION", Field: "Destination"})
+	fields = append(fields, TableField{Header: "PORT", Formatter: deniedFlowPort})
+	return fields
+}
+
+func deniedFlowPort(item interface{}) string {
+	if port := item.(deniedFlow).Port; port != 0 {
+		return strconv.Itoa(int(port))
+	}
+	return "-"
+}
+
+func getSecurityGroupDenied(after uint64) ([]deniedFlow, error) {
+	var flows []deniedFlow
+	result, err := callNexd("SecurityGroupDenied", strconv.FormatUint(after, 10))
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get the denied flows: %w\n", err)
+	}
+
+	err = json.Unmarshal([]byte(result), &flows)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to marshall the denied flows: %w\n", err)
+	}
+	return flows, nil
+}
+
+// listSecurityGroupDenied lists the flows denied by the security group enforced by nexd, with --follow it
+// polls nexd for the flows denied since the last one listed until it is interrupted
+func listSecurityGroupDenied(ctx context.Context, command *cli.Command) error {
+	if err := checkVersion(); err != nil {
+		return err
+	}
+
+	flows, err := getSecurityGroupDenied(0)
+	if err != nil {
+		return err
+	}
+	if !command.Bool("follow") {
+		show(command, deniedFlowTableFields(), flows)
+		return nil
+	}
+
+	// the flows are printed as they arrive, a line per flow
+	output := command.String("output")
+	if output == encodeColumn {
+		fmt.Printf("%-19s  %-9s  %-8s  %-39s  %-39s  %s\n", "TIME", "DIRECTION", "PROTOCOL", "SOURCE", "DESTINATION", "PORT")
+	}
+	var last uint64
+	for {
+		for _, flow := range flows {
+			if output == encodeColumn || output == encodeNoHeader {
+				fmt.Printf("%-19s  %-9s  %-8s  %-39s  %-39s  %s\n", flow.Time.Local().Format(time.DateTime),
+					flow.Direction, flow.Protocol, flow.Source, flow.Destination, deniedFlowPort(flow))
+			} else {
+				bytes, err := json.Marshal(flow)
+				if err != nil {
+					return fmt.Errorf("failed to encode the denied flow: %w", err)
+				}
+				fmt.Println(string(bytes))
+			}
+			last = flow.Seq
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(time.Second):
+		}
+		if flows, err = getSecurityGroupDenied(last); err != nil {
+			return err
+		}
+	}
+}
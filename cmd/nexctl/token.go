@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/nexodus-io/nexodus/internal/client"
+	"github.com/urfave/cli/v3"
+)
+
+func createTokenCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "token",
+		Usage: "Commands relating to API tokens",
+		Commands: []*cli.Command{
+			{
+				Name:  "list",
+				Usage: "List your API tokens",
+				Action: func(ctx context.Context, command *cli.Command) error {
+					return listAPITokens(ctx, command)
+				},
+			},
+			{
+				Name:  "create",
+				Usage: "Create an API token",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "description",
+						Required: false,
+					},
+					&cli.StringSliceFlag{
+						Name:  "scope",
+						Usage: "an API scope granted to the token, can be repeated",
+						Value: []string{"read:organizations", "write:organizations", "read:devices", "write:devices"},
+					},
+					&cli.DurationFlag{
+						Name:     "expiration",
+						Usage:    "how long the token is valid for, the token does not expire if not set",
+						Required: false,
+					},
+				},
+				Action: func(ctx context.Context, command *cli.Command) error {
+					token := client.ModelsAddAPIToken{
+						Description: client.PtrOptionalString(command.String("description")),
+						Scopes:      command.StringSlice("scope"),
+					}
+					if expiration := command.Duration("expiration"); expiration != 0 {
+						token.ExpiresAt = client.PtrString(time.Now().Add(expiration).Format(time.RFC3339))
+					}
+					return createAPIToken(ctx, command, token)
+				},
+			},
+			{
+				Name:  "revoke",
+				Usage: "Revoke an API token",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "token-id",
+						Required: true,
+					},
+				},
+				Action: func(ctx context.Context, command *cli.Command) error {
+					id, err := getUUID(command, "token-id")
+					if err != nil {
+						return err
+					}
+					return revokeAPIToken(ctx, command, id)
+				},
+			},
+		},
+	}
+}
+
+func apiTokenTableFields(command *cli.Command) []TableField {
+	var fields []TableField
+	fields = append(fields, TableField{Header: "TOKEN ID", Field: "Id"})
+	fields = append(fields, TableField{Header: "DESCRIPTION", Field: "Description"})
+	fields = append(fields, TableField{Header: "SCOPES", Formatter: func(item interface{}) string {
+		return strings.Join(item.(client.ModelsAPIToken).Scopes, ",")
+	}})
+	fields = append(fields, TableField{Header: "EXPIRES AT", Field: "ExpiresAt"})
+	fields = append(fields, TableField{Header: "LAST USED AT", Field: "LastUsedAt"})
+	return fields
+}
+
+func listAPITokens(ctx context.Context, command *cli.Command) error {
+	c := createClient(ctx, command)
+	rows := apiResponse(c.UsersApi.
+		ListAPITokens(ctx, "me").
+		Execute())
+	show(command, apiTokenTableFields(command), rows)
+	return nil
+}
+
+func createAPIToken(ctx context.Context, command *cli.Command, token client.ModelsAddAPIToken) error {
+	c := createClient(ctx, command)
+	res := apiResponse(c.UsersApi.
+		CreateAPIToken(ctx, "me").
+		Token(token).
+		Execute())
+	fields := apiTokenTableFields(command)
+	// the bearer token is only returned when the token is created
+	fields = append(fields, TableField{Header: "BEARER TOKEN", Field: "BearerToken"})
+	show(command, fields, res)
+	return nil
+}
+
+func revokeAPIToken(ctx context.Context, command *cli.Command, id string) error {
+	c := createClient(ctx, command)
+	res := apiResponse(c.UsersApi.
+		DeleteAPIToken(ctx, "me", id).
+		Execute())
+	show(command, apiTokenTableFields(command), res)
+	showSuccessfully(command, "revoked")
+	return nil
+}
@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"strings"
+
 	"github.com/google/uuid"
 	"github.com/nexodus-io/nexodus/internal/client"
 	"github.com/urfave/cli/v3"
@@ -39,14 +41,26 @@ func createVpcCommand() *cli.Command {
 						Name:     "ipv6-cidr",
 						Required: false,
 					},
+					&cli.BoolFlag{
+						Name:     "require-device-approval",
+						Usage:    "new devices must be approved before they join the vpc",
+						Required: false,
+					},
+					&cli.BoolFlag{
+						Name:     "preshared-keys",
+						Usage:    "add a wireguard pre-shared key to the peering of every pair of devices in the vpc",
+						Required: false,
+					},
 				},
 				Action: func(ctx context.Context, command *cli.Command) error {
 					return createVPC(ctx, command, client.ModelsAddVPC{
-						Ipv4Cidr:       client.PtrOptionalString(command.String("ipv4-cidr")),
-						Ipv6Cidr:       client.PtrOptionalString(command.String("ipv6-cidr")),
-						Description:    client.PtrOptionalString(command.String("description")),
-						OrganizationId: client.PtrOptionalString(command.String("organization-id")),
-						PrivateCidr:    client.PtrBool(!(command.String("ipv4-cidr") == "" && command.String("ipv6-cidr") == "")),
+						Ipv4Cidr:              client.PtrOptionalString(command.String("ipv4-cidr")),
+						Ipv6Cidr:              client.PtrOptionalString(command.String("ipv6-cidr")),
+						Description:           client.PtrOptionalString(command.String("description")),
+						OrganizationId:        client.PtrOptionalString(command.String("organization-id")),
+						PrivateCidr:           client.PtrBool(!(command.String("ipv4-cidr") == "" && command.String("ipv6-cidr") == "")),
+						RequireDeviceApproval: client.PtrBool(command.Bool("require-device-approval")),
+						PresharedKeys:         client.PtrBool(command.Bool("preshared-keys")),
 					})
 				},
 			},
@@ -62,6 +76,16 @@ func createVpcCommand() *cli.Command {
 						Name:     "description",
 						Required: false,
 					},
+					&cli.BoolFlag{
+						Name:     "require-device-approval",
+						Usage:    "new devices must be approved before they join the vpc",
+						Required: false,
+					},
+					&cli.BoolFlag{
+						Name:     "preshared-keys",
+						Usage:    "add a wireguard pre-shared key to the peering of every pair of devices in the vpc",
+						Required: false,
+					},
 				},
 				Action: func(ctx context.Context, command *cli.Command) error {
 					id, err := getUUID(command, "vpc-id")
@@ -72,6 +96,12 @@ func createVpcCommand() *cli.Command {
 					update := client.ModelsUpdateVPC{
 						Description: client.PtrString(command.String("description")),
 					}
+					if command.IsSet("require-device-approval") {
+						update.RequireDeviceApproval = client.PtrBool(command.Bool("require-device-approval"))
+					}
+					if command.IsSet("preshared-keys") {
+						update.PresharedKeys = client.PtrBool(command.Bool("preshared-keys"))
+					}
 					return updateVPC(ctx, command, id, update)
 				},
 			},
@@ -92,11 +122,33 @@ func createVpcCommand() *cli.Command {
 					return deleteVPC(ctx, command, vpcID)
 				},
 			},
+			{
+				Name:  "routes",
+				Usage: "List the cidrs advertised in a vpc along with their primary and standby routers",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "vpc-id",
+						Required: true,
+					},
+				},
+				Action: func(ctx context.Context, command *cli.Command) error {
+					vpcID, err := getUUID(command, "vpc-id")
+					if err != nil {
+						return err
+					}
+					return listVPCRoutes(ctx, command, vpcID)
+				},
+			},
 			{
 				Name:     "metadata",
 				Usage:    "Commands relating to device metadata across the vpc",
 				Commands: vpcMetadataSubcommands,
 			},
+			{
+				Name:     "dns-record",
+				Usage:    "Commands relating to the custom DNS records of a vpc",
+				Commands: vpcDNSRecordSubcommands,
+			},
 		},
 	}
 }
@@ -125,6 +177,8 @@ func vpcTableFields() []TableField {
 	fields = append(fields, TableField{Header: "IPV4 CIDR", Field: "Ipv4Cidr"})
 	fields = append(fields, TableField{Header: "IPV6 CIDR", Field: "Ipv6Cidr"})
 	fields = append(fields, TableField{Header: "DESCRIPTION", Field: "Description"})
+	fields = append(fields, TableField{Header: "REQUIRE DEVICE APPROVAL", Field: "RequireDeviceApproval"})
+	fields = append(fields, TableField{Header: "PRESHARED KEYS", Field: "PresharedKeys"})
 	return fields
 }
 func listVPCs(ctx context.Context, command *cli.Command) error {
@@ -158,3 +212,23 @@ func deleteVPC(ctx context.Context, command *cli.Command, id string) error {
 	showSuccessfully(command, "deleted")
 	return nil
 }
+
+func vpcRouteTableFields() []TableField {
+	var fields []TableField
+	fields = append(fields, TableField{Header: "CIDR", Field: "Cidr"})
+	fields = append(fields, TableField{Header: "PRIMARY DEVICE ID", Field: "PrimaryDeviceId"})
+	fields = append(fields, TableField{Header: "STANDBY DEVICE IDS", Formatter: func(item interface{}) string {
+		route := item.(client.ModelsAdvertisedRoute)
+		return strings.Join(route.StandbyDeviceIds, ", ")
+	}})
+	return fields
+}
+
+func listVPCRoutes(ctx context.Context, command *cli.Command, id string) error {
+	c := createClient(ctx, command)
+	res := apiResponse(c.VPCApi.
+		ListRoutesInVPC(ctx, id).
+		Execute())
+	show(command, vpcRouteTableFields(), res)
+	return nil
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/nexodus-io/nexodus/internal/client"
+	"github.com/urfave/cli/v3"
+)
+
+func createWebhookCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "webhook",
+		Usage: "Commands relating to webhooks",
+		Commands: []*cli.Command{
+			{
+				Name:  "list",
+				Usage: "List webhooks",
+				Action: func(ctx context.Context, command *cli.Command) error {
+					return listWebhooks(ctx, command)
+				},
+			},
+			{
+				Name:  "create",
+				Usage: "Create a webhook",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "organization-id",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "url",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "description",
+						Required: false,
+					},
+					&cli.StringSliceFlag{
+						Name:  "event-kind",
+						Usage: "a kind of event to deliver: device, security-group, vpc or dns-record, can be repeated. All kinds are delivered when not set",
+					},
+					&cli.StringFlag{
+						Name:  "secret",
+						Usage: "the key the payloads are signed with, a random secret is generated when not set",
+					},
+				},
+				Action: func(ctx context.Context, command *cli.Command) error {
+					return createWebhook(ctx, command, client.ModelsAddWebhook{
+						OrganizationId: client.PtrOptionalString(command.String("organization-id")),
+						Url:            client.PtrString(command.String("url")),
+						Description:    client.PtrOptionalString(command.String("description")),
+						EventKinds:     command.StringSlice("event-kind"),
+						Secret:         client.PtrOptionalString(command.String("secret")),
+					})
+				},
+			},
+			{
+				Name:  "update",
+				Usage: "Update a webhook",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "webhook-id",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "url",
+						Required: false,
+					},
+					&cli.StringFlag{
+						Name:     "description",
+						Required: false,
+					},
+					&cli.StringSliceFlag{
+						Name:  "event-kind",
+						Usage: "a kind of event to deliver: device, security-group, vpc or dns-record, can be repeated",
+					},
+					&cli.StringFlag{
+						Name:     "secret",
+						Required: false,
+					},
+				},
+				Action: func(ctx context.Context, command *cli.Command) error {
+					id, err := getUUID(command, "webhook-id")
+					if err != nil {
+						return err
+					}
+					return updateWebhook(ctx, command, id, client.ModelsUpdateWebhook{
+						Url:         client.PtrOptionalString(command.String("url")),
+						Description: client.PtrOptionalString(command.String("description")),
+						EventKinds:  command.StringSlice("event-kind"),
+						Secret:      client.PtrOptionalString(command.String("secret")),
+					})
+				},
+			},
+			{
+				Name:  "delete",
+				Usage: "Delete a webhook",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "webhook-id",
+						Required: true,
+					},
+				},
+				Action: func(ctx context.Context, command *cli.Command) error {
+					id, err := getUUID(command, "webhook-id")
+					if err != nil {
+						return err
+					}
+					return deleteWebhook(ctx, command, id)
+				},
+			},
+			{
+				Name:  "test",
+				Usage: "Deliver a test event to a webhook",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "webhook-id",
+						Required: true,
+					},
+				},
+				Action: func(ctx context.Context, command *cli.Command) error {
+					id, err := getUUID(command, "webhook-id")
+					if err != nil {
+						return err
+					}
+					return testWebhook(ctx, command, id)
+				},
+			},
+			{
+				Name:  "deliveries",
+				Usage: "List the deliveries made to a webhook",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "webhook-id",
+						Required: true,
+					},
+				},
+				Action: func(ctx context.Context, command *cli.Command) error {
+					id, err := getUUID(command, "webhook-id")
+					if err != nil {
+						return err
+					}
+					return listWebhookDeliveries(ctx, command, id)
+				},
+			},
+		},
+	}
+}
+
+func webhookTableFields() []TableField {
+	var fields []TableField
+	fields = append(fields, TableField{Header: "WEBHOOK ID", Field: "Id"})
+	fields = append(fields, TableField{Header: "ORGANIZATION ID", Field: "OrganizationId"})
+	fields = append(fields, TableField{Header: "URL", Field: "Url"})
+	fields = append(fields, TableField{Header: "DESCRIPTION", Field: "Description"})
+	fields = append(fields, TableField{Header: "EVENT KINDS", Formatter: func(item interface{}) string {
+		kinds := item.(client.ModelsWebhook).EventKinds
+		if len(kinds) == 0 {
+			return "all"
+		}
+		return strings.Join(kinds, ",")
+	}})
+	return fields
+}
+
+func webhookDeliveryTableFields() []TableField {
+	var fields []TableField
+	fields = append(fields, TableField{Header: "DELIVERY ID", Field: "Id"})
+	fields = append(fields, TableField{Header: "CREATED AT", Field: "CreatedAt"})
+	fields = append(fields, TableField{Header: "EVENT", Formatter: func(item interface{}) string {
+		delivery := item.(client.ModelsWebhookDelivery)
+		return delivery.GetEventKind() + "/" + delivery.GetEventType()
+	}})
+	fields = append(fields, TableField{Header: "STATUS", Field: "Status"})
+	fields = append(fields, TableField{Header: "ATTEMPTS", Field: "Attempts"})
+	fields = append(fields, TableField{Header: "RESPONSE CODE", Field: "ResponseCode"})
+	fields = append(fields, TableField{Header: "ERROR", Field: "Error"})
+	return fields
+}
+
+func listWebhooks(ctx context.Context, command *cli.Command) error {
+	c := createClient(ctx, command)
+	rows := apiResponse(c.WebhooksApi.
+		ListWebhooks(ctx).
+		Execute())
+	show(command, webhookTableFields(), rows)
+	return nil
+}
+
+func createWebhook(ctx context.Context, command *cli.Command, webhook client.ModelsAddWebhook) error {
+	c := createClient(ctx, command)
+	if webhook.GetOrganizationId() == "" {
+		webhook.OrganizationId = client.PtrString(getDefaultOrgId(ctx, c))
+	}
+	res := apiResponse(c.WebhooksApi.
+		CreateWebhook(ctx).
+		Webhook(webhook).
+		Execute())
+	fields := webhookTableFields()
+	// the secret is only returned when the webhook is created
+	fields = append(fields, TableField{Header: "SECRET", Field: "Secret"})
+	show(command, fields, res)
+	return nil
+}
+
+func updateWebhook(ctx context.Context, command *cli.Command, id string, update client.ModelsUpdateWebhook) error {
+	c := createClient(ctx, command)
+	res := apiResponse(c.WebhooksApi.
+		UpdateWebhook(ctx, id).
+		Update(update).
+		Execute())
+	show(command, webhookTableFields(), res)
+	showSuccessfully(command, "updated")
+	return nil
+}
+
+func deleteWebhook(ctx context.Context, command *cli.Command, id string) error {
+	c := createClient(ctx, command)
+	res := apiResponse(c.WebhooksApi.
+		DeleteWebhook(ctx, id).
+		Execute())
+	show(command, webhookTableFields(), res)
+	showSuccessfully(command, "deleted")
+	return nil
+}
+
+func testWebhook(ctx context.Context, command *cli.Command, id string) error {
+	c := createClient(ctx, command)
+	res := apiResponse(c.WebhooksApi.
+		TestWebhook(ctx, id).
+		Execute())
+	show(command, webhookDeliveryTableFields(), res)
+	return nil
+}
+
+func listWebhookDeliveries(ctx context.Context, command *cli.Command, id string) error {
+	c := createClient(ctx, command)
+	rows := apiResponse(c.WebhooksApi.
+		ListWebhookDeliveries(ctx, id).
+		Execute())
+	show(command, webhookDeliveryTableFields(), rows)
+	return nil
+}
@@ -189,31 +189,36 @@ func nexdRun(ctx context.Context, command *cli.Command, logger *zap.Logger, logL
 	defer util.IgnoreError(stateStore.Close)
 
 	options := nexodus.Options{
-		Logger:                  logger.Sugar(),
-		LogLevel:                logLevel,
-		ApiURL:                  apiURL,
-		RegKey:                  regKey,
-		Username:                command.String("username"),
-		Password:                command.String("password"),
-		ListenPort:              int(command.Int("listen-port")),
-		RequestedIP:             command.String("request-ip"),
-		UserProvidedLocalIP:     command.String("local-endpoint-ip"),
-		AdvertiseCidrs:          advertiseCidr,
-		Relay:                   relayNode,
-		RelayDerp:               relayDerpNode,
-		RelayOnly:               command.Bool("relay-only"),
-		NetworkRouter:           command.Bool("network-router"),
-		NetworkRouterDisableNAT: command.Bool("disable-nat"),
-		ExitNodeClientEnabled:   command.Bool("exit-node-client"),
-		ExitNodeOriginEnabled:   command.Bool("exit-node"),
-		InsecureSkipTlsVerify:   command.Bool("insecure-skip-tls-verify"),
-		Version:                 Version,
-		UserspaceMode:           userspaceMode,
-		StateStore:              stateStore,
-		StateDir:                stateDir,
-		Context:                 ctx,
-		VpcId:                   parseUUIDFlag(command, "vpc-id"),
-		SecurityGroupId:         parseUUIDFlag(command, "security-group-id"),
+		Logger:                    logger.Sugar(),
+		LogLevel:                  logLevel,
+		ApiURL:                    apiURL,
+		RegKey:                    regKey,
+		Username:                  command.String("username"),
+		Password:                  command.String("password"),
+		ListenPort:                int(command.Int("listen-port")),
+		RequestedIP:               command.String("request-ip"),
+		UserProvidedLocalIP:       command.String("local-endpoint-ip"),
+		AdvertiseCidrs:            advertiseCidr,
+		Relay:                     relayNode,
+		RelayDerp:                 relayDerpNode,
+		RelayOnly:                 command.Bool("relay-only"),
+		NetworkRouter:             command.Bool("network-router"),
+		NetworkRouterDisableNAT:   command.Bool("disable-nat"),
+		ExitNodeClientEnabled:     command.Bool("exit-node-client"),
+		ExitNodeOriginEnabled:     command.Bool("exit-node"),
+		MeshDNS:                   command.Bool("mesh-dns"),
+		InsecureSkipTlsVerify:     command.Bool("insecure-skip-tls-verify"),
+		Version:                   Version,
+		UserspaceMode:             userspaceMode,
+		StateStore:                stateStore,
+		StateDir:                  stateDir,
+		Context:                   ctx,
+		VpcId:                     parseUUIDFlag(command, "vpc-id"),
+		SecurityGroupId:           parseUUIDFlag(command, "security-group-id"),
+		MetricsAddress:            command.String("metrics-address"),
+		KeyRotationInterval:       command.Duration("key-rotation-interval"),
+		SecurityGroupLogDenied:    command.Bool("security-group-log-denied"),
+		SecurityGroupReportDenied: command.Bool("security-group-report-denied"),
 	}
 
 	if relayDerpNode {
@@ -322,12 +327,12 @@ func main() {
 				Flags: []cli.Flag{
 					&cli.StringSliceFlag{
 						Name:     "ingress",
-						Usage:    "Forward connections from the Nexodus network made to [port] on this proxy instance to port [destination_port] at [destination_ip] via a locally accessible network using a `value` in the form: protocol:port:destination_ip:destination_port. All fields are required.",
+						Usage:    "Forward connections from the Nexodus network made to [port] on this proxy instance to port [destination_port] at [destination] via a locally accessible network using a `value` in the form: protocol:port:destination:destination_port, where [destination] is an IP address or a hostname that is resolved for every connection. All fields are required.",
 						Required: false,
 					},
 					&cli.StringSliceFlag{
 						Name:     "egress",
-						Usage:    "Forward connections from a locally accessible network made to [port] on this proxy instance to port [destination_port] at [destination_ip] via the Nexodus network using a `value` in the form: protocol:port:destination_ip:destination_port. All fields are required.",
+						Usage:    "Forward connections from a locally accessible network made to [port] on this proxy instance to port [destination_port] at [destination] via the Nexodus network using a `value` in the form: protocol:port:destination:destination_port, where [destination] is an IP address or a hostname that is resolved for every connection. All fields are required.",
 						Required: false,
 					},
 				},
@@ -341,17 +346,23 @@ func main() {
 							return fmt.Errorf("exit-node support is currently only supported for Linux operating systems")
 						}
 						advertiseCidrs := command.StringSlice("advertise-cidr")
-						// Check if "0.0.0.0/0" already exists in advertise-cidr
-						found := false
-						for _, prefix := range advertiseCidrs {
-							if prefix == "0.0.0.0/0" {
-								found = true
-								break
+						// Check if the IPv4 and IPv6 default routes already exist in advertise-cidr
+						updated := false
+						for _, defaultRoute := range []string{"0.0.0.0/0", "::/0"} {
+							found := false
+							for _, prefix := range advertiseCidrs {
+								if prefix == defaultRoute {
+									found = true
+									break
+								}
+							}
+							// If not found, add it to advertise-cidr
+							if !found {
+								advertiseCidrs = append(advertiseCidrs, defaultRoute)
+								updated = true
 							}
 						}
-						// If not found, add it to advertise-cidr
-						if !found {
-							advertiseCidrs = append(advertiseCidrs, "0.0.0.0/0")
+						if updated {
 							err := command.Set("advertise-cidr", strings.Join(advertiseCidrs, ","))
 							if err != nil {
 								return fmt.Errorf("failed to set advertise-cidr: %w", err)
@@ -631,6 +642,51 @@ func main() {
 				Category:   agentOptions,
 				Persistent: true,
 			},
+			&cli.BoolFlag{
+				Name:       "mesh-dns",
+				Usage:      "Run a DNS resolver on the tunnel address that answers <hostname>.<vpc-id>.nexodus.internal names for the devices in the VPC and configure the host to use it for that zone",
+				Value:      false,
+				Sources:    cli.EnvVars("NEXD_MESH_DNS"),
+				Required:   false,
+				Category:   agentOptions,
+				Persistent: true,
+			},
+			&cli.StringFlag{
+				Name:       "metrics-address",
+				Value:      "",
+				Usage:      "Serve Prometheus metrics on /metrics of this `address`, for example 127.0.0.1:9100 (optional)",
+				Sources:    cli.EnvVars("NEXD_METRICS_ADDRESS"),
+				Required:   false,
+				Category:   agentOptions,
+				Persistent: true,
+			},
+			&cli.DurationFlag{
+				Name:       "key-rotation-interval",
+				Value:      0,
+				Usage:      "Rotate the wireguard key of the device once it is older than this `duration`, for example 720h, 0 disables the rotation",
+				Sources:    cli.EnvVars("NEXD_KEY_ROTATION_INTERVAL"),
+				Required:   false,
+				Category:   agentOptions,
+				Persistent: true,
+			},
+			&cli.BoolFlag{
+				Name:       "security-group-log-denied",
+				Usage:      "Log a rate-limited sample of the flows denied by the security group, they are listed with nexctl nexd security-group denied (Linux only)",
+				Value:      false,
+				Sources:    cli.EnvVars("NEXD_SECURITY_GROUP_LOG_DENIED"),
+				Required:   false,
+				Category:   agentOptions,
+				Persistent: true,
+			},
+			&cli.BoolFlag{
+				Name:       "security-group-report-denied",
+				Usage:      "Report the flows denied by the security group to the api server, implies --security-group-log-denied (Linux only)",
+				Value:      false,
+				Sources:    cli.EnvVars("NEXD_SECURITY_GROUP_REPORT_DENIED"),
+				Required:   false,
+				Category:   agentOptions,
+				Persistent: true,
+			},
 			&cli.StringFlag{
 				Name:       "username",
 				Value:      "",
@@ -22,60 +22,64 @@ import (
 // VPCApiService VPCApi service
 type VPCApiService service
 
-type ApiCreateVPCRequest struct {
+type ApiCreateDNSRecordInVPCRequest struct {
 	ctx        context.Context
 	ApiService *VPCApiService
-	vPC        *ModelsAddVPC
+	id         string
+	dNSRecord  *ModelsAddDNSRecord
 }
 
-// Add VPC
-func (r ApiCreateVPCRequest) VPC(vPC ModelsAddVPC) ApiCreateVPCRequest {
-	r.vPC = &vPC
+// Add DNS Record
+func (r ApiCreateDNSRecordInVPCRequest) DNSRecord(dNSRecord ModelsAddDNSRecord) ApiCreateDNSRecordInVPCRequest {
+	r.dNSRecord = &dNSRecord
 	return r
 }
 
-func (r ApiCreateVPCRequest) Execute() (*ModelsVPC, *http.Response, error) {
-	return r.ApiService.CreateVPCExecute(r)
+func (r ApiCreateDNSRecordInVPCRequest) Execute() (*ModelsDNSRecord, *http.Response, error) {
+	return r.ApiService.CreateDNSRecordInVPCExecute(r)
 }
 
 /*
-CreateVPC Create an VPC
+CreateDNSRecordInVPC Add DNS Record
 
-Creates a named vpc with the given CIDR
+Adds a new custom DNS record to a VPC
 
 	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
-	@return ApiCreateVPCRequest
+	@param id VPC ID
+	@return ApiCreateDNSRecordInVPCRequest
 */
-func (a *VPCApiService) CreateVPC(ctx context.Context) ApiCreateVPCRequest {
-	return ApiCreateVPCRequest{
+func (a *VPCApiService) CreateDNSRecordInVPC(ctx context.Context, id string) ApiCreateDNSRecordInVPCRequest {
+	return ApiCreateDNSRecordInVPCRequest{
 		ApiService: a,
 		ctx:        ctx,
+		id:         id,
 	}
 }
 
 // Execute executes the request
 //
-//	@return ModelsVPC
-func (a *VPCApiService) CreateVPCExecute(r ApiCreateVPCRequest) (*ModelsVPC, *http.Response, error) {
+//	@return ModelsDNSRecord
+func (a *VPCApiService) CreateDNSRecordInVPCExecute(r ApiCreateDNSRecordInVPCRequest) (*ModelsDNSRecord, *http.Response, error) {
 	var (
 		localVarHTTPMethod  = http.MethodPost
 		localVarPostBody    interface{}
 		formFiles           []formFile
-		localVarReturnValue *ModelsVPC
+		localVarReturnValue *ModelsDNSRecord
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "VPCApiService.CreateVPC")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "VPCApiService.CreateDNSRecordInVPC")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/api/vpcs"
+	localVarPath := localBasePath + "/api/vpcs/{id}/dns-records"
+	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterValueToString(r.id, "id")), -1)
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
-	if r.vPC == nil {
-		return localVarReturnValue, nil, reportError("vPC is required and must be specified")
+	if r.dNSRecord == nil {
+		return localVarReturnValue, nil, reportError("dNSRecord is required and must be specified")
 	}
 
 	// to determine the Content-Type header
@@ -96,7 +100,7 @@ func (a *VPCApiService) CreateVPCExecute(r ApiCreateVPCRequest) (*ModelsVPC, *ht
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
 	// body params
-	localVarPostBody = r.vPC
+	localVarPostBody = r.dNSRecord
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
 	if err != nil {
 		return localVarReturnValue, nil, err
@@ -141,7 +145,7 @@ func (a *VPCApiService) CreateVPCExecute(r ApiCreateVPCRequest) (*ModelsVPC, *ht
 			newErr.model = v
 			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
-		if localVarHTTPResponse.StatusCode == 405 {
+		if localVarHTTPResponse.StatusCode == 404 {
 			var v ModelsBaseError
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
@@ -163,6 +167,17 @@ func (a *VPCApiService) CreateVPCExecute(r ApiCreateVPCRequest) (*ModelsVPC, *ht
 			newErr.model = v
 			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
+		if localVarHTTPResponse.StatusCode == 422 {
+			var v ModelsValidationError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
 		if localVarHTTPResponse.StatusCode == 429 {
 			var v ModelsBaseError
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
@@ -199,58 +214,64 @@ func (a *VPCApiService) CreateVPCExecute(r ApiCreateVPCRequest) (*ModelsVPC, *ht
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type ApiDeleteVPCRequest struct {
+type ApiCreateVPCRequest struct {
 	ctx        context.Context
 	ApiService *VPCApiService
-	id         string
+	vPC        *ModelsAddVPC
 }
 
-func (r ApiDeleteVPCRequest) Execute() (*ModelsVPC, *http.Response, error) {
-	return r.ApiService.DeleteVPCExecute(r)
+// Add VPC
+func (r ApiCreateVPCRequest) VPC(vPC ModelsAddVPC) ApiCreateVPCRequest {
+	r.vPC = &vPC
+	return r
+}
+
+func (r ApiCreateVPCRequest) Execute() (*ModelsVPC, *http.Response, error) {
+	return r.ApiService.CreateVPCExecute(r)
 }
 
 /*
-DeleteVPC Delete VPC
+CreateVPC Create an VPC
 
-Deletes an existing vpc and associated IPAM prefix
+Creates a named vpc with the given CIDR
 
 	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
-	@param id VPC ID
-	@return ApiDeleteVPCRequest
+	@return ApiCreateVPCRequest
 */
-func (a *VPCApiService) DeleteVPC(ctx context.Context, id string) ApiDeleteVPCRequest {
-	return ApiDeleteVPCRequest{
+func (a *VPCApiService) CreateVPC(ctx context.Context) ApiCreateVPCRequest {
+	return ApiCreateVPCRequest{
 		ApiService: a,
 		ctx:        ctx,
-		id:         id,
 	}
 }
 
 // Execute executes the request
 //
 //	@return ModelsVPC
-func (a *VPCApiService) DeleteVPCExecute(r ApiDeleteVPCRequest) (*ModelsVPC, *http.Response, error) {
+func (a *VPCApiService) CreateVPCExecute(r ApiCreateVPCRequest) (*ModelsVPC, *http.Response, error) {
 	var (
-		localVarHTTPMethod  = http.MethodDelete
+		localVarHTTPMethod  = http.MethodPost
 		localVarPostBody    interface{}
 		formFiles           []formFile
 		localVarReturnValue *ModelsVPC
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "VPCApiService.DeleteVPC")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "VPCApiService.CreateVPC")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/api/vpcs/{id}"
-	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterValueToString(r.id, "id")), -1)
+	localVarPath := localBasePath + "/api/vpcs"
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
+	if r.vPC == nil {
+		return localVarReturnValue, nil, reportError("vPC is required and must be specified")
+	}
 
 	// to determine the Content-Type header
-	localVarHTTPContentTypes := []string{}
+	localVarHTTPContentTypes := []string{"application/json"}
 
 	// set Content-Type header
 	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
@@ -266,6 +287,8 @@ func (a *VPCApiService) DeleteVPCExecute(r ApiDeleteVPCRequest) (*ModelsVPC, *ht
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
+	// body params
+	localVarPostBody = r.vPC
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
 	if err != nil {
 		return localVarReturnValue, nil, err
@@ -299,6 +322,17 @@ func (a *VPCApiService) DeleteVPCExecute(r ApiDeleteVPCRequest) (*ModelsVPC, *ht
 			newErr.model = v
 			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
+		if localVarHTTPResponse.StatusCode == 401 {
+			var v ModelsBaseError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
 		if localVarHTTPResponse.StatusCode == 405 {
 			var v ModelsBaseError
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
@@ -310,6 +344,17 @@ func (a *VPCApiService) DeleteVPCExecute(r ApiDeleteVPCRequest) (*ModelsVPC, *ht
 			newErr.model = v
 			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
+		if localVarHTTPResponse.StatusCode == 409 {
+			var v ModelsConflictsError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
 		if localVarHTTPResponse.StatusCode == 429 {
 			var v ModelsBaseError
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
@@ -346,51 +391,55 @@ func (a *VPCApiService) DeleteVPCExecute(r ApiDeleteVPCRequest) (*ModelsVPC, *ht
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type ApiGetVPCRequest struct {
+type ApiDeleteDNSRecordInVPCRequest struct {
 	ctx        context.Context
 	ApiService *VPCApiService
 	id         string
+	recordId   string
 }
 
-func (r ApiGetVPCRequest) Execute() (*ModelsVPC, *http.Response, error) {
-	return r.ApiService.GetVPCExecute(r)
+func (r ApiDeleteDNSRecordInVPCRequest) Execute() (*ModelsDNSRecord, *http.Response, error) {
+	return r.ApiService.DeleteDNSRecordInVPCExecute(r)
 }
 
 /*
-GetVPC Get VPCs
+DeleteDNSRecordInVPC Delete DNS Record
 
-Gets a VPC by VPC ID
+Deletes a DNS record from a VPC
 
 	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
 	@param id VPC ID
-	@return ApiGetVPCRequest
+	@param recordId DNS Record ID
+	@return ApiDeleteDNSRecordInVPCRequest
 */
-func (a *VPCApiService) GetVPC(ctx context.Context, id string) ApiGetVPCRequest {
-	return ApiGetVPCRequest{
+func (a *VPCApiService) DeleteDNSRecordInVPC(ctx context.Context, id string, recordId string) ApiDeleteDNSRecordInVPCRequest {
+	return ApiDeleteDNSRecordInVPCRequest{
 		ApiService: a,
 		ctx:        ctx,
 		id:         id,
+		recordId:   recordId,
 	}
 }
 
 // Execute executes the request
 //
-//	@return ModelsVPC
-func (a *VPCApiService) GetVPCExecute(r ApiGetVPCRequest) (*ModelsVPC, *http.Response, error) {
+//	@return ModelsDNSRecord
+func (a *VPCApiService) DeleteDNSRecordInVPCExecute(r ApiDeleteDNSRecordInVPCRequest) (*ModelsDNSRecord, *http.Response, error) {
 	var (
-		localVarHTTPMethod  = http.MethodGet
+		localVarHTTPMethod  = http.MethodDelete
 		localVarPostBody    interface{}
 		formFiles           []formFile
-		localVarReturnValue *ModelsVPC
+		localVarReturnValue *ModelsDNSRecord
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "VPCApiService.GetVPC")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "VPCApiService.DeleteDNSRecordInVPC")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/api/vpcs/{id}"
+	localVarPath := localBasePath + "/api/vpcs/{id}/dns-records/{record_id}"
 	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterValueToString(r.id, "id")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"record_id"+"}", url.PathEscape(parameterValueToString(r.recordId, "recordId")), -1)
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
@@ -446,17 +495,6 @@ func (a *VPCApiService) GetVPCExecute(r ApiGetVPCRequest) (*ModelsVPC, *http.Res
 			newErr.model = v
 			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
-		if localVarHTTPResponse.StatusCode == 401 {
-			var v ModelsBaseError
-			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-			if err != nil {
-				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
-			}
-			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
-			newErr.model = v
-			return localVarReturnValue, localVarHTTPResponse, newErr
-		}
 		if localVarHTTPResponse.StatusCode == 404 {
 			var v ModelsBaseError
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
@@ -504,34 +542,27 @@ func (a *VPCApiService) GetVPCExecute(r ApiGetVPCRequest) (*ModelsVPC, *http.Res
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type ApiListDevicesInVPCRequest struct {
+type ApiDeleteVPCRequest struct {
 	ctx        context.Context
 	ApiService *VPCApiService
 	id         string
-	gtRevision *int32
 }
 
-// greater than revision
-func (r ApiListDevicesInVPCRequest) GtRevision(gtRevision int32) ApiListDevicesInVPCRequest {
-	r.gtRevision = &gtRevision
-	return r
-}
-
-func (r ApiListDevicesInVPCRequest) Execute() ([]ModelsDevice, *http.Response, error) {
-	return r.ApiService.ListDevicesInVPCExecute(r)
+func (r ApiDeleteVPCRequest) Execute() (*ModelsVPC, *http.Response, error) {
+	return r.ApiService.DeleteVPCExecute(r)
 }
 
 /*
-ListDevicesInVPC List Devices
+DeleteVPC Delete VPC
 
-Lists all devices for this VPC
+Deletes an existing vpc and associated IPAM prefix
 
 	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
 	@param id VPC ID
-	@return ApiListDevicesInVPCRequest
+	@return ApiDeleteVPCRequest
 */
-func (a *VPCApiService) ListDevicesInVPC(ctx context.Context, id string) ApiListDevicesInVPCRequest {
-	return ApiListDevicesInVPCRequest{
+func (a *VPCApiService) DeleteVPC(ctx context.Context, id string) ApiDeleteVPCRequest {
+	return ApiDeleteVPCRequest{
 		ApiService: a,
 		ctx:        ctx,
 		id:         id,
@@ -540,30 +571,27 @@ func (a *VPCApiService) ListDevicesInVPC(ctx context.Context, id string) ApiList
 
 // Execute executes the request
 //
-//	@return []ModelsDevice
-func (a *VPCApiService) ListDevicesInVPCExecute(r ApiListDevicesInVPCRequest) ([]ModelsDevice, *http.Response, error) {
+//	@return ModelsVPC
+func (a *VPCApiService) DeleteVPCExecute(r ApiDeleteVPCRequest) (*ModelsVPC, *http.Response, error) {
 	var (
-		localVarHTTPMethod  = http.MethodGet
+		localVarHTTPMethod  = http.MethodDelete
 		localVarPostBody    interface{}
 		formFiles           []formFile
-		localVarReturnValue []ModelsDevice
+		localVarReturnValue *ModelsVPC
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "VPCApiService.ListDevicesInVPC")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "VPCApiService.DeleteVPC")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/api/vpcs/{id}/devices"
+	localVarPath := localBasePath + "/api/vpcs/{id}"
 	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterValueToString(r.id, "id")), -1)
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
 
-	if r.gtRevision != nil {
-		parameterAddToHeaderOrQuery(localVarQueryParams, "gt_revision", r.gtRevision, "")
-	}
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -614,7 +642,7 @@ func (a *VPCApiService) ListDevicesInVPCExecute(r ApiListDevicesInVPCRequest) ([
 			newErr.model = v
 			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
-		if localVarHTTPResponse.StatusCode == 401 {
+		if localVarHTTPResponse.StatusCode == 405 {
 			var v ModelsBaseError
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
@@ -661,86 +689,60 @@ func (a *VPCApiService) ListDevicesInVPCExecute(r ApiListDevicesInVPCRequest) ([
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type ApiListMetadataInVPCRequest struct {
+type ApiGetDNSRecordInVPCRequest struct {
 	ctx        context.Context
 	ApiService *VPCApiService
 	id         string
-	gtRevision *int32
-	prefix     *[]string
-	key        *string
-}
-
-// greater than revision
-func (r ApiListMetadataInVPCRequest) GtRevision(gtRevision int32) ApiListMetadataInVPCRequest {
-	r.gtRevision = &gtRevision
-	return r
-}
-
-// used to filter down to the specified key prefixes
-func (r ApiListMetadataInVPCRequest) Prefix(prefix []string) ApiListMetadataInVPCRequest {
-	r.prefix = &prefix
-	return r
-}
-
-// used to filter down to the specified key
-func (r ApiListMetadataInVPCRequest) Key(key string) ApiListMetadataInVPCRequest {
-	r.key = &key
-	return r
+	recordId   string
 }
 
-func (r ApiListMetadataInVPCRequest) Execute() ([]ModelsDeviceMetadata, *http.Response, error) {
-	return r.ApiService.ListMetadataInVPCExecute(r)
+func (r ApiGetDNSRecordInVPCRequest) Execute() (*ModelsDNSRecord, *http.Response, error) {
+	return r.ApiService.GetDNSRecordInVPCExecute(r)
 }
 
 /*
-ListMetadataInVPC List Device Metadata
+GetDNSRecordInVPC Get DNS Record
 
-Lists metadata for a device
+Gets a DNS record in a VPC by ID
 
 	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
 	@param id VPC ID
-	@return ApiListMetadataInVPCRequest
+	@param recordId DNS Record ID
+	@return ApiGetDNSRecordInVPCRequest
 */
-func (a *VPCApiService) ListMetadataInVPC(ctx context.Context, id string) ApiListMetadataInVPCRequest {
-	return ApiListMetadataInVPCRequest{
+func (a *VPCApiService) GetDNSRecordInVPC(ctx context.Context, id string, recordId string) ApiGetDNSRecordInVPCRequest {
+	return ApiGetDNSRecordInVPCRequest{
 		ApiService: a,
 		ctx:        ctx,
 		id:         id,
+		recordId:   recordId,
 	}
 }
 
 // Execute executes the request
 //
-//	@return []ModelsDeviceMetadata
-func (a *VPCApiService) ListMetadataInVPCExecute(r ApiListMetadataInVPCRequest) ([]ModelsDeviceMetadata, *http.Response, error) {
+//	@return ModelsDNSRecord
+func (a *VPCApiService) GetDNSRecordInVPCExecute(r ApiGetDNSRecordInVPCRequest) (*ModelsDNSRecord, *http.Response, error) {
 	var (
 		localVarHTTPMethod  = http.MethodGet
 		localVarPostBody    interface{}
 		formFiles           []formFile
-		localVarReturnValue []ModelsDeviceMetadata
+		localVarReturnValue *ModelsDNSRecord
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "VPCApiService.ListMetadataInVPC")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "VPCApiService.GetDNSRecordInVPC")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/api/vpcs/{id}/metadata"
+	localVarPath := localBasePath + "/api/vpcs/{id}/dns-records/{record_id}"
 	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterValueToString(r.id, "id")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"record_id"+"}", url.PathEscape(parameterValueToString(r.recordId, "recordId")), -1)
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
 
-	if r.gtRevision != nil {
-		parameterAddToHeaderOrQuery(localVarQueryParams, "gt_revision", r.gtRevision, "")
-	}
-	if r.prefix != nil {
-		parameterAddToHeaderOrQuery(localVarQueryParams, "prefix", r.prefix, "csv")
-	}
-	if r.key != nil {
-		parameterAddToHeaderOrQuery(localVarQueryParams, "key", r.key, "")
-	}
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -780,6 +782,50 @@ func (a *VPCApiService) ListMetadataInVPCExecute(r ApiListMetadataInVPCRequest)
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v ModelsBaseError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 401 {
+			var v ModelsBaseError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 404 {
+			var v ModelsBaseError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 429 {
+			var v ModelsBaseError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
 		if localVarHTTPResponse.StatusCode == 500 {
 			var v ModelsInternalServerError
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
@@ -805,14 +851,788 @@ func (a *VPCApiService) ListMetadataInVPCExecute(r ApiListMetadataInVPCRequest)
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type ApiListSecurityGroupsInVPCRequest struct {
+type ApiGetVPCRequest struct {
 	ctx        context.Context
 	ApiService *VPCApiService
 	id         string
-	gtRevision *int32
 }
 
-// greater than revision
+func (r ApiGetVPCRequest) Execute() (*ModelsVPC, *http.Response, error) {
+	return r.ApiService.GetVPCExecute(r)
+}
+
+/*
+GetVPC Get VPCs
+
+Gets a VPC by VPC ID
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@param id VPC ID
+	@return ApiGetVPCRequest
+*/
+func (a *VPCApiService) GetVPC(ctx context.Context, id string) ApiGetVPCRequest {
+	return ApiGetVPCRequest{
+		ApiService: a,
+		ctx:        ctx,
+		id:         id,
+	}
+}
+
+// Execute executes the request
+//
+//	@return ModelsVPC
+func (a *VPCApiService) GetVPCExecute(r ApiGetVPCRequest) (*ModelsVPC, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodGet
+		localVarPostBody    interface{}
+		formFiles           []formFile
+		localVarReturnValue *ModelsVPC
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "VPCApiService.GetVPC")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/api/vpcs/{id}"
+	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterValueToString(r.id, "id")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v ModelsBaseError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 401 {
+			var v ModelsBaseError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 404 {
+			var v ModelsBaseError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 429 {
+			var v ModelsBaseError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 500 {
+			var v ModelsInternalServerError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type ApiListDNSRecordsInVPCRequest struct {
+	ctx        context.Context
+	ApiService *VPCApiService
+	id         string
+	gtRevision *int32
+}
+
+// greater than revision
+func (r ApiListDNSRecordsInVPCRequest) GtRevision(gtRevision int32) ApiListDNSRecordsInVPCRequest {
+	r.gtRevision = &gtRevision
+	return r
+}
+
+func (r ApiListDNSRecordsInVPCRequest) Execute() ([]ModelsDNSRecord, *http.Response, error) {
+	return r.ApiService.ListDNSRecordsInVPCExecute(r)
+}
+
+/*
+ListDNSRecordsInVPC List DNS Records in a VPC
+
+Lists all the custom DNS records in a VPC
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@param id VPC ID
+	@return ApiListDNSRecordsInVPCRequest
+*/
+func (a *VPCApiService) ListDNSRecordsInVPC(ctx context.Context, id string) ApiListDNSRecordsInVPCRequest {
+	return ApiListDNSRecordsInVPCRequest{
+		ApiService: a,
+		ctx:        ctx,
+		id:         id,
+	}
+}
+
+// Execute executes the request
+//
+//	@return []ModelsDNSRecord
+func (a *VPCApiService) ListDNSRecordsInVPCExecute(r ApiListDNSRecordsInVPCRequest) ([]ModelsDNSRecord, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodGet
+		localVarPostBody    interface{}
+		formFiles           []formFile
+		localVarReturnValue []ModelsDNSRecord
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "VPCApiService.ListDNSRecordsInVPC")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/api/vpcs/{id}/dns-records"
+	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterValueToString(r.id, "id")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	if r.gtRevision != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "gt_revision", r.gtRevision, "")
+	}
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 401 {
+			var v ModelsBaseError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 404 {
+			var v ModelsBaseError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 429 {
+			var v ModelsBaseError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 500 {
+			var v ModelsInternalServerError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type ApiListDevicesInVPCRequest struct {
+	ctx        context.Context
+	ApiService *VPCApiService
+	id         string
+	gtRevision *int32
+}
+
+// greater than revision
+func (r ApiListDevicesInVPCRequest) GtRevision(gtRevision int32) ApiListDevicesInVPCRequest {
+	r.gtRevision = &gtRevision
+	return r
+}
+
+func (r ApiListDevicesInVPCRequest) Execute() ([]ModelsDevice, *http.Response, error) {
+	return r.ApiService.ListDevicesInVPCExecute(r)
+}
+
+/*
+ListDevicesInVPC List Devices
+
+Lists all devices for this VPC
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@param id VPC ID
+	@return ApiListDevicesInVPCRequest
+*/
+func (a *VPCApiService) ListDevicesInVPC(ctx context.Context, id string) ApiListDevicesInVPCRequest {
+	return ApiListDevicesInVPCRequest{
+		ApiService: a,
+		ctx:        ctx,
+		id:         id,
+	}
+}
+
+// Execute executes the request
+//
+//	@return []ModelsDevice
+func (a *VPCApiService) ListDevicesInVPCExecute(r ApiListDevicesInVPCRequest) ([]ModelsDevice, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodGet
+		localVarPostBody    interface{}
+		formFiles           []formFile
+		localVarReturnValue []ModelsDevice
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "VPCApiService.ListDevicesInVPC")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/api/vpcs/{id}/devices"
+	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterValueToString(r.id, "id")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	if r.gtRevision != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "gt_revision", r.gtRevision, "")
+	}
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v ModelsBaseError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 401 {
+			var v ModelsBaseError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 429 {
+			var v ModelsBaseError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 500 {
+			var v ModelsInternalServerError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type ApiListMetadataInVPCRequest struct {
+	ctx        context.Context
+	ApiService *VPCApiService
+	id         string
+	gtRevision *int32
+	prefix     *[]string
+	key        *string
+}
+
+// greater than revision
+func (r ApiListMetadataInVPCRequest) GtRevision(gtRevision int32) ApiListMetadataInVPCRequest {
+	r.gtRevision = &gtRevision
+	return r
+}
+
+// used to filter down to the specified key prefixes
+func (r ApiListMetadataInVPCRequest) Prefix(prefix []string) ApiListMetadataInVPCRequest {
+	r.prefix = &prefix
+	return r
+}
+
+// used to filter down to the specified key
+func (r ApiListMetadataInVPCRequest) Key(key string) ApiListMetadataInVPCRequest {
+	r.key = &key
+	return r
+}
+
+func (r ApiListMetadataInVPCRequest) Execute() ([]ModelsDeviceMetadata, *http.Response, error) {
+	return r.ApiService.ListMetadataInVPCExecute(r)
+}
+
+/*
+ListMetadataInVPC List Device Metadata
+
+Lists metadata for a device
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@param id VPC ID
+	@return ApiListMetadataInVPCRequest
+*/
+func (a *VPCApiService) ListMetadataInVPC(ctx context.Context, id string) ApiListMetadataInVPCRequest {
+	return ApiListMetadataInVPCRequest{
+		ApiService: a,
+		ctx:        ctx,
+		id:         id,
+	}
+}
+
+// Execute executes the request
+//
+//	@return []ModelsDeviceMetadata
+func (a *VPCApiService) ListMetadataInVPCExecute(r ApiListMetadataInVPCRequest) ([]ModelsDeviceMetadata, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodGet
+		localVarPostBody    interface{}
+		formFiles           []formFile
+		localVarReturnValue []ModelsDeviceMetadata
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "VPCApiService.ListMetadataInVPC")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/api/vpcs/{id}/metadata"
+	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterValueToString(r.id, "id")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	if r.gtRevision != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "gt_revision", r.gtRevision, "")
+	}
+	if r.prefix != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "prefix", r.prefix, "csv")
+	}
+	if r.key != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "key", r.key, "")
+	}
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 500 {
+			var v ModelsInternalServerError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type ApiListRoutesInVPCRequest struct {
+	ctx        context.Context
+	ApiService *VPCApiService
+	id         string
+}
+
+func (r ApiListRoutesInVPCRequest) Execute() ([]ModelsAdvertisedRoute, *http.Response, error) {
+	return r.ApiService.ListRoutesInVPCExecute(r)
+}
+
+/*
+ListRoutesInVPC List Routes in a VPC
+
+Lists the cidrs advertised by the devices of a VPC, along with the device that is the primary router of each cidr and the standby devices that take over when it goes offline
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@param id VPC ID
+	@return ApiListRoutesInVPCRequest
+*/
+func (a *VPCApiService) ListRoutesInVPC(ctx context.Context, id string) ApiListRoutesInVPCRequest {
+	return ApiListRoutesInVPCRequest{
+		ApiService: a,
+		ctx:        ctx,
+		id:         id,
+	}
+}
+
+// Execute executes the request
+//
+//	@return []ModelsAdvertisedRoute
+func (a *VPCApiService) ListRoutesInVPCExecute(r ApiListRoutesInVPCRequest) ([]ModelsAdvertisedRoute, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodGet
+		localVarPostBody    interface{}
+		formFiles           []formFile
+		localVarReturnValue []ModelsAdvertisedRoute
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "VPCApiService.ListRoutesInVPC")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/api/vpcs/{id}/routes"
+	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterValueToString(r.id, "id")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v ModelsBaseError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 401 {
+			var v ModelsBaseError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 404 {
+			var v ModelsBaseError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 429 {
+			var v ModelsBaseError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 500 {
+			var v ModelsInternalServerError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type ApiListSecurityGroupsInVPCRequest struct {
+	ctx        context.Context
+	ApiService *VPCApiService
+	id         string
+	gtRevision *int32
+}
+
+// greater than revision
 func (r ApiListSecurityGroupsInVPCRequest) GtRevision(gtRevision int32) ApiListSecurityGroupsInVPCRequest {
 	r.gtRevision = &gtRevision
 	return r
@@ -1083,6 +1903,191 @@ func (a *VPCApiService) ListVPCsExecute(r ApiListVPCsRequest) ([]ModelsVPC, *htt
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
+type ApiUpdateDNSRecordInVPCRequest struct {
+	ctx        context.Context
+	ApiService *VPCApiService
+	id         string
+	recordId   string
+	update     *ModelsUpdateDNSRecord
+}
+
+// DNS Record Update
+func (r ApiUpdateDNSRecordInVPCRequest) Update(update ModelsUpdateDNSRecord) ApiUpdateDNSRecordInVPCRequest {
+	r.update = &update
+	return r
+}
+
+func (r ApiUpdateDNSRecordInVPCRequest) Execute() (*ModelsDNSRecord, *http.Response, error) {
+	return r.ApiService.UpdateDNSRecordInVPCExecute(r)
+}
+
+/*
+UpdateDNSRecordInVPC Update DNS Record
+
+Updates a DNS record in a VPC by ID
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@param id VPC ID
+	@param recordId DNS Record ID
+	@return ApiUpdateDNSRecordInVPCRequest
+*/
+func (a *VPCApiService) UpdateDNSRecordInVPC(ctx context.Context, id string, recordId string) ApiUpdateDNSRecordInVPCRequest {
+	return ApiUpdateDNSRecordInVPCRequest{
+		ApiService: a,
+		ctx:        ctx,
+		id:         id,
+		recordId:   recordId,
+	}
+}
+
+// Execute executes the request
+//
+//	@return ModelsDNSRecord
+func (a *VPCApiService) UpdateDNSRecordInVPCExecute(r ApiUpdateDNSRecordInVPCRequest) (*ModelsDNSRecord, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodPatch
+		localVarPostBody    interface{}
+		formFiles           []formFile
+		localVarReturnValue *ModelsDNSRecord
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "VPCApiService.UpdateDNSRecordInVPC")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/api/vpcs/{id}/dns-records/{record_id}"
+	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterValueToString(r.id, "id")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"record_id"+"}", url.PathEscape(parameterValueToString(r.recordId, "recordId")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+	if r.update == nil {
+		return localVarReturnValue, nil, reportError("update is required and must be specified")
+	}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{"application/json"}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	// body params
+	localVarPostBody = r.update
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v ModelsBaseError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 401 {
+			var v ModelsBaseError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 404 {
+			var v ModelsBaseError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 422 {
+			var v ModelsValidationError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 429 {
+			var v ModelsBaseError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 500 {
+			var v ModelsInternalServerError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
 type ApiUpdateVPCRequest struct {
 	ctx        context.Context
 	ApiService *VPCApiService
@@ -75,6 +75,8 @@ type APIClient struct {
 	UsersApi *UsersApiService
 
 	VPCApi *VPCApiService
+
+	WebhooksApi *WebhooksApiService
 }
 
 type service struct {
@@ -106,6 +108,7 @@ func NewAPIClient(cfg *Configuration) *APIClient {
 	c.SitesApi = (*SitesApiService)(&c.common)
 	c.UsersApi = (*UsersApiService)(&c.common)
 	c.VPCApi = (*VPCApiService)(&c.common)
+	c.WebhooksApi = (*WebhooksApiService)(&c.common)
 
 	return c
 }
@@ -0,0 +1,40 @@
+package client
+
+import (
+	"github.com/nexodus-io/nexodus/internal/util"
+)
+
+// Informer creates a *ListInformer which provides a simpler
+// API to list dns records but which is implemented with the Watch api.  The *ListInformer
+// maintains a local dns record cache which gets updated with the Watch events.
+func (r ApiListDNSRecordsInVPCRequest) Informer() *ListInformer[ModelsDNSRecord] {
+	informer := NewInformer[ModelsDNSRecord](&DNSRecordAdaptor{}, r.gtRevision, ApiWatchRequest{
+		ctx:        r.ctx,
+		ApiService: r.ApiService.client.EventsApi,
+	}, map[string]interface{}{
+		"vpc-id": r.id,
+	})
+	return informer
+}
+
+type DNSRecordAdaptor struct{}
+
+func (d DNSRecordAdaptor) Revision(item ModelsDNSRecord) int32 {
+	return item.GetRevision()
+}
+
+func (d DNSRecordAdaptor) Key(item ModelsDNSRecord) string {
+	return item.GetId()
+}
+
+func (d DNSRecordAdaptor) Kind() string {
+	return "dns-record"
+}
+
+func (d DNSRecordAdaptor) Item(value map[string]interface{}) (ModelsDNSRecord, error) {
+	item := ModelsDNSRecord{}
+	err := util.JsonUnmarshal(value, &item)
+	return item, err
+}
+
+var _ InformerAdaptor[ModelsDNSRecord] = &DNSRecordAdaptor{}
@@ -19,16 +19,17 @@ var _ MappedNullable = &ModelsAddDevice{}
 
 // ModelsAddDevice struct for ModelsAddDevice
 type ModelsAddDevice struct {
-	AdvertiseCidrs  []string         `json:"advertise_cidrs,omitempty"`
-	Endpoints       []ModelsEndpoint `json:"endpoints,omitempty"`
-	Hostname        *string          `json:"hostname,omitempty"`
-	Ipv4TunnelIps   []ModelsTunnelIP `json:"ipv4_tunnel_ips,omitempty"`
-	Os              *string          `json:"os,omitempty"`
-	PublicKey       *string          `json:"public_key,omitempty"`
-	Relay           *bool            `json:"relay,omitempty"`
-	SecurityGroupId *string          `json:"security_group_id,omitempty"`
-	SymmetricNat    *bool            `json:"symmetric_nat,omitempty"`
-	VpcId           *string          `json:"vpc_id,omitempty"`
+	AdvertiseCidrs  []string          `json:"advertise_cidrs,omitempty"`
+	Endpoints       []ModelsEndpoint  `json:"endpoints,omitempty"`
+	Hostname        *string           `json:"hostname,omitempty"`
+	Ipv4TunnelIps   []ModelsTunnelIP  `json:"ipv4_tunnel_ips,omitempty"`
+	Labels          map[string]string `json:"labels,omitempty"`
+	Os              *string           `json:"os,omitempty"`
+	PublicKey       *string           `json:"public_key,omitempty"`
+	Relay           *bool             `json:"relay,omitempty"`
+	SecurityGroupId *string           `json:"security_group_id,omitempty"`
+	SymmetricNat    *bool             `json:"symmetric_nat,omitempty"`
+	VpcId           *string           `json:"vpc_id,omitempty"`
 }
 
 // NewModelsAddDevice instantiates a new ModelsAddDevice object
@@ -176,6 +177,38 @@ func (o *ModelsAddDevice) SetIpv4TunnelIps(v []ModelsTunnelIP) {
 	o.Ipv4TunnelIps = v
 }
 
+// GetLabels returns the Labels field value if set, zero value otherwise.
+func (o *ModelsAddDevice) GetLabels() map[string]string {
+	if o == nil || IsNil(o.Labels) {
+		var ret map[string]string
+		return ret
+	}
+	return o.Labels
+}
+
+// GetLabelsOk returns a tuple with the Labels field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsAddDevice) GetLabelsOk() (*map[string]string, bool) {
+	if o == nil || IsNil(o.Labels) {
+		return nil, false
+	}
+	return &o.Labels, true
+}
+
+// HasLabels returns a boolean if a field has been set.
+func (o *ModelsAddDevice) HasLabels() bool {
+	if o != nil && !IsNil(o.Labels) {
+		return true
+	}
+
+	return false
+}
+
+// SetLabels gets a reference to the given map[string]string and assigns it to the Labels field.
+func (o *ModelsAddDevice) SetLabels(v map[string]string) {
+	o.Labels = v
+}
+
 // GetOs returns the Os field value if set, zero value otherwise.
 func (o *ModelsAddDevice) GetOs() string {
 	if o == nil || IsNil(o.Os) {
@@ -390,6 +423,9 @@ func (o ModelsAddDevice) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.Ipv4TunnelIps) {
 		toSerialize["ipv4_tunnel_ips"] = o.Ipv4TunnelIps
 	}
+	if !IsNil(o.Labels) {
+		toSerialize["labels"] = o.Labels
+	}
 	if !IsNil(o.Os) {
 		toSerialize["os"] = o.Os
 	}
@@ -19,10 +19,22 @@ var _ MappedNullable = &ModelsAddRegKey{}
 
 // ModelsAddRegKey struct for ModelsAddRegKey
 type ModelsAddRegKey struct {
+	// AllowedSourceCidrs is optional, if set the registration key can only be used from these networks.
+	AllowedSourceCidrs []string `json:"allowed_source_cidrs,omitempty"`
 	// Description of the registration key.
 	Description *string `json:"description,omitempty"`
+	// DeviceLabels are added to the labels of the devices registered with the registration key.
+	DeviceLabels map[string]string `json:"device_labels,omitempty"`
+	// DeviceMetadata is copied to the metadata of the devices registered with the registration key.
+	DeviceMetadata map[string]interface{} `json:"device_metadata,omitempty"`
+	// Ephemeral devices are deleted after they have been offline for the ephemeral device grace period.
+	Ephemeral *bool `json:"ephemeral,omitempty"`
 	// ExpiresAt is optional, if set the registration key is only valid until the ExpiresAt time.
 	ExpiresAt *string `json:"expires_at,omitempty"`
+	// HostnameTemplate is optional, if set it is used to name the devices registered with the registration key, it is a Go template with the .Hostname and .Index fields.
+	HostnameTemplate *string `json:"hostname_template,omitempty"`
+	// MaxUses is optional, if set the registration key can only register that many devices.
+	MaxUses *int32 `json:"max_uses,omitempty"`
 	// SecurityGroupId is the ID of the security group to assign to the device.
 	SecurityGroupId *string `json:"security_group_id,omitempty"`
 	// ServiceNetworkID is the ID of the Service Network the device can join.
@@ -52,6 +64,38 @@ func NewModelsAddRegKeyWithDefaults() *ModelsAddRegKey {
 	return &this
 }
 
+// GetAllowedSourceCidrs returns the AllowedSourceCidrs field value if set, zero value otherwise.
+func (o *ModelsAddRegKey) GetAllowedSourceCidrs() []string {
+	if o == nil || IsNil(o.AllowedSourceCidrs) {
+		var ret []string
+		return ret
+	}
+	return o.AllowedSourceCidrs
+}
+
+// GetAllowedSourceCidrsOk returns a tuple with the AllowedSourceCidrs field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsAddRegKey) GetAllowedSourceCidrsOk() ([]string, bool) {
+	if o == nil || IsNil(o.AllowedSourceCidrs) {
+		return nil, false
+	}
+	return o.AllowedSourceCidrs, true
+}
+
+// HasAllowedSourceCidrs returns a boolean if a field has been set.
+func (o *ModelsAddRegKey) HasAllowedSourceCidrs() bool {
+	if o != nil && !IsNil(o.AllowedSourceCidrs) {
+		return true
+	}
+
+	return false
+}
+
+// SetAllowedSourceCidrs gets a reference to the given []string and assigns it to the AllowedSourceCidrs field.
+func (o *ModelsAddRegKey) SetAllowedSourceCidrs(v []string) {
+	o.AllowedSourceCidrs = v
+}
+
 // GetDescription returns the Description field value if set, zero value otherwise.
 func (o *ModelsAddRegKey) GetDescription() string {
 	if o == nil || IsNil(o.Description) {
@@ -84,6 +128,102 @@ func (o *ModelsAddRegKey) SetDescription(v string) {
 	o.Description = &v
 }
 
+// GetDeviceLabels returns the DeviceLabels field value if set, zero value otherwise.
+func (o *ModelsAddRegKey) GetDeviceLabels() map[string]string {
+	if o == nil || IsNil(o.DeviceLabels) {
+		var ret map[string]string
+		return ret
+	}
+	return o.DeviceLabels
+}
+
+// GetDeviceLabelsOk returns a tuple with the DeviceLabels field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsAddRegKey) GetDeviceLabelsOk() (*map[string]string, bool) {
+	if o == nil || IsNil(o.DeviceLabels) {
+		return nil, false
+	}
+	return &o.DeviceLabels, true
+}
+
+// HasDeviceLabels returns a boolean if a field has been set.
+func (o *ModelsAddRegKey) HasDeviceLabels() bool {
+	if o != nil && !IsNil(o.DeviceLabels) {
+		return true
+	}
+
+	return false
+}
+
+// SetDeviceLabels gets a reference to the given map[string]string and assigns it to the DeviceLabels field.
+func (o *ModelsAddRegKey) SetDeviceLabels(v map[string]string) {
+	o.DeviceLabels = v
+}
+
+// GetDeviceMetadata returns the DeviceMetadata field value if set, zero value otherwise.
+func (o *ModelsAddRegKey) GetDeviceMetadata() map[string]interface{} {
+	if o == nil || IsNil(o.DeviceMetadata) {
+		var ret map[string]interface{}
+		return ret
+	}
+	return o.DeviceMetadata
+}
+
+// GetDeviceMetadataOk returns a tuple with the DeviceMetadata field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsAddRegKey) GetDeviceMetadataOk() (map[string]interface{}, bool) {
+	if o == nil || IsNil(o.DeviceMetadata) {
+		return map[string]interface{}{}, false
+	}
+	return o.DeviceMetadata, true
+}
+
+// HasDeviceMetadata returns a boolean if a field has been set.
+func (o *ModelsAddRegKey) HasDeviceMetadata() bool {
+	if o != nil && !IsNil(o.DeviceMetadata) {
+		return true
+	}
+
+	return false
+}
+
+// SetDeviceMetadata gets a reference to the given map[string]interface{} and assigns it to the DeviceMetadata field.
+func (o *ModelsAddRegKey) SetDeviceMetadata(v map[string]interface{}) {
+	o.DeviceMetadata = v
+}
+
+// GetEphemeral returns the Ephemeral field value if set, zero value otherwise.
+func (o *ModelsAddRegKey) GetEphemeral() bool {
+	if o == nil || IsNil(o.Ephemeral) {
+		var ret bool
+		return ret
+	}
+	return *o.Ephemeral
+}
+
+// GetEphemeralOk returns a tuple with the Ephemeral field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsAddRegKey) GetEphemeralOk() (*bool, bool) {
+	if o == nil || IsNil(o.Ephemeral) {
+		return nil, false
+	}
+	return o.Ephemeral, true
+}
+
+// HasEphemeral returns a boolean if a field has been set.
+func (o *ModelsAddRegKey) HasEphemeral() bool {
+	if o != nil && !IsNil(o.Ephemeral) {
+		return true
+	}
+
+	return false
+}
+
+// SetEphemeral gets a reference to the given bool and assigns it to the Ephemeral field.
+func (o *ModelsAddRegKey) SetEphemeral(v bool) {
+	o.Ephemeral = &v
+}
+
 // GetExpiresAt returns the ExpiresAt field value if set, zero value otherwise.
 func (o *ModelsAddRegKey) GetExpiresAt() string {
 	if o == nil || IsNil(o.ExpiresAt) {
@@ -116,6 +256,70 @@ func (o *ModelsAddRegKey) SetExpiresAt(v string) {
 	o.ExpiresAt = &v
 }
 
+// GetHostnameTemplate returns the HostnameTemplate field value if set, zero value otherwise.
+func (o *ModelsAddRegKey) GetHostnameTemplate() string {
+	if o == nil || IsNil(o.HostnameTemplate) {
+		var ret string
+		return ret
+	}
+	return *o.HostnameTemplate
+}
+
+// GetHostnameTemplateOk returns a tuple with the HostnameTemplate field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsAddRegKey) GetHostnameTemplateOk() (*string, bool) {
+	if o == nil || IsNil(o.HostnameTemplate) {
+		return nil, false
+	}
+	return o.HostnameTemplate, true
+}
+
+// HasHostnameTemplate returns a boolean if a field has been set.
+func (o *ModelsAddRegKey) HasHostnameTemplate() bool {
+	if o != nil && !IsNil(o.HostnameTemplate) {
+		return true
+	}
+
+	return false
+}
+
+// SetHostnameTemplate gets a reference to the given string and assigns it to the HostnameTemplate field.
+func (o *ModelsAddRegKey) SetHostnameTemplate(v string) {
+	o.HostnameTemplate = &v
+}
+
+// GetMaxUses returns the MaxUses field value if set, zero value otherwise.
+func (o *ModelsAddRegKey) GetMaxUses() int32 {
+	if o == nil || IsNil(o.MaxUses) {
+		var ret int32
+		return ret
+	}
+	return *o.MaxUses
+}
+
+// GetMaxUsesOk returns a tuple with the MaxUses field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsAddRegKey) GetMaxUsesOk() (*int32, bool) {
+	if o == nil || IsNil(o.MaxUses) {
+		return nil, false
+	}
+	return o.MaxUses, true
+}
+
+// HasMaxUses returns a boolean if a field has been set.
+func (o *ModelsAddRegKey) HasMaxUses() bool {
+	if o != nil && !IsNil(o.MaxUses) {
+		return true
+	}
+
+	return false
+}
+
+// SetMaxUses gets a reference to the given int32 and assigns it to the MaxUses field.
+func (o *ModelsAddRegKey) SetMaxUses(v int32) {
+	o.MaxUses = &v
+}
+
 // GetSecurityGroupId returns the SecurityGroupId field value if set, zero value otherwise.
 func (o *ModelsAddRegKey) GetSecurityGroupId() string {
 	if o == nil || IsNil(o.SecurityGroupId) {
@@ -286,12 +490,30 @@ func (o ModelsAddRegKey) MarshalJSON() ([]byte, error) {
 
 func (o ModelsAddRegKey) ToMap() (map[string]interface{}, error) {
 	toSerialize := map[string]interface{}{}
+	if !IsNil(o.AllowedSourceCidrs) {
+		toSerialize["allowed_source_cidrs"] = o.AllowedSourceCidrs
+	}
 	if !IsNil(o.Description) {
 		toSerialize["description"] = o.Description
 	}
+	if !IsNil(o.DeviceLabels) {
+		toSerialize["device_labels"] = o.DeviceLabels
+	}
+	if !IsNil(o.DeviceMetadata) {
+		toSerialize["device_metadata"] = o.DeviceMetadata
+	}
+	if !IsNil(o.Ephemeral) {
+		toSerialize["ephemeral"] = o.Ephemeral
+	}
 	if !IsNil(o.ExpiresAt) {
 		toSerialize["expires_at"] = o.ExpiresAt
 	}
+	if !IsNil(o.HostnameTemplate) {
+		toSerialize["hostname_template"] = o.HostnameTemplate
+	}
+	if !IsNil(o.MaxUses) {
+		toSerialize["max_uses"] = o.MaxUses
+	}
 	if !IsNil(o.SecurityGroupId) {
 		toSerialize["security_group_id"] = o.SecurityGroupId
 	}
@@ -23,7 +23,10 @@ type ModelsAddVPC struct {
 	Ipv4Cidr       *string `json:"ipv4_cidr,omitempty"`
 	Ipv6Cidr       *string `json:"ipv6_cidr,omitempty"`
 	OrganizationId *string `json:"organization_id,omitempty"`
-	PrivateCidr    *bool   `json:"private_cidr,omitempty"`
+	// PresharedKeys adds a wireguard pre-shared key to the peering of every pair of devices in the VPC.
+	PresharedKeys         *bool `json:"preshared_keys,omitempty"`
+	PrivateCidr           *bool `json:"private_cidr,omitempty"`
+	RequireDeviceApproval *bool `json:"require_device_approval,omitempty"`
 }
 
 // NewModelsAddVPC instantiates a new ModelsAddVPC object
@@ -171,6 +174,38 @@ func (o *ModelsAddVPC) SetOrganizationId(v string) {
 	o.OrganizationId = &v
 }
 
+// GetPresharedKeys returns the PresharedKeys field value if set, zero value otherwise.
+func (o *ModelsAddVPC) GetPresharedKeys() bool {
+	if o == nil || IsNil(o.PresharedKeys) {
+		var ret bool
+		return ret
+	}
+	return *o.PresharedKeys
+}
+
+// GetPresharedKeysOk returns a tuple with the PresharedKeys field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsAddVPC) GetPresharedKeysOk() (*bool, bool) {
+	if o == nil || IsNil(o.PresharedKeys) {
+		return nil, false
+	}
+	return o.PresharedKeys, true
+}
+
+// HasPresharedKeys returns a boolean if a field has been set.
+func (o *ModelsAddVPC) HasPresharedKeys() bool {
+	if o != nil && !IsNil(o.PresharedKeys) {
+		return true
+	}
+
+	return false
+}
+
+// SetPresharedKeys gets a reference to the given bool and assigns it to the PresharedKeys field.
+func (o *ModelsAddVPC) SetPresharedKeys(v bool) {
+	o.PresharedKeys = &v
+}
+
 // GetPrivateCidr returns the PrivateCidr field value if set, zero value otherwise.
 func (o *ModelsAddVPC) GetPrivateCidr() bool {
 	if o == nil || IsNil(o.PrivateCidr) {
@@ -203,6 +238,38 @@ func (o *ModelsAddVPC) SetPrivateCidr(v bool) {
 	o.PrivateCidr = &v
 }
 
+// GetRequireDeviceApproval returns the RequireDeviceApproval field value if set, zero value otherwise.
+func (o *ModelsAddVPC) GetRequireDeviceApproval() bool {
+	if o == nil || IsNil(o.RequireDeviceApproval) {
+		var ret bool
+		return ret
+	}
+	return *o.RequireDeviceApproval
+}
+
+// GetRequireDeviceApprovalOk returns a tuple with the RequireDeviceApproval field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsAddVPC) GetRequireDeviceApprovalOk() (*bool, bool) {
+	if o == nil || IsNil(o.RequireDeviceApproval) {
+		return nil, false
+	}
+	return o.RequireDeviceApproval, true
+}
+
+// HasRequireDeviceApproval returns a boolean if a field has been set.
+func (o *ModelsAddVPC) HasRequireDeviceApproval() bool {
+	if o != nil && !IsNil(o.RequireDeviceApproval) {
+		return true
+	}
+
+	return false
+}
+
+// SetRequireDeviceApproval gets a reference to the given bool and assigns it to the RequireDeviceApproval field.
+func (o *ModelsAddVPC) SetRequireDeviceApproval(v bool) {
+	o.RequireDeviceApproval = &v
+}
+
 func (o ModelsAddVPC) MarshalJSON() ([]byte, error) {
 	toSerialize, err := o.ToMap()
 	if err != nil {
@@ -225,9 +292,15 @@ func (o ModelsAddVPC) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.OrganizationId) {
 		toSerialize["organization_id"] = o.OrganizationId
 	}
+	if !IsNil(o.PresharedKeys) {
+		toSerialize["preshared_keys"] = o.PresharedKeys
+	}
 	if !IsNil(o.PrivateCidr) {
 		toSerialize["private_cidr"] = o.PrivateCidr
 	}
+	if !IsNil(o.RequireDeviceApproval) {
+		toSerialize["require_device_approval"] = o.RequireDeviceApproval
+	}
 	return toSerialize, nil
 }
 
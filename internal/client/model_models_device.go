@@ -19,25 +19,29 @@ var _ MappedNullable = &ModelsDevice{}
 
 // ModelsDevice struct for ModelsDevice
 type ModelsDevice struct {
-	AdvertiseCidrs []string `json:"advertise_cidrs,omitempty"`
-	AllowedIps     []string `json:"allowed_ips,omitempty"`
-	// the token nexd should use to reconcile device state.
-	BearerToken     *string          `json:"bearer_token,omitempty"`
-	Endpoints       []ModelsEndpoint `json:"endpoints,omitempty"`
-	Hostname        *string          `json:"hostname,omitempty"`
-	Id              *string          `json:"id,omitempty"`
-	Ipv4TunnelIps   []ModelsTunnelIP `json:"ipv4_tunnel_ips,omitempty"`
-	Ipv6TunnelIps   []ModelsTunnelIP `json:"ipv6_tunnel_ips,omitempty"`
-	Online          *bool            `json:"online,omitempty"`
-	OnlineAt        *string          `json:"online_at,omitempty"`
-	Os              *string          `json:"os,omitempty"`
-	OwnerId         *string          `json:"owner_id,omitempty"`
-	PublicKey       *string          `json:"public_key,omitempty"`
-	Relay           *bool            `json:"relay,omitempty"`
-	Revision        *int32           `json:"revision,omitempty"`
-	SecurityGroupId *string          `json:"security_group_id,omitempty"`
-	SymmetricNat    *bool            `json:"symmetric_nat,omitempty"`
-	VpcId           *string          `json:"vpc_id,omitempty"`
+	AdvertiseCidrs []string          `json:"advertise_cidrs,omitempty"`
+	AllowedIps     []string          `json:"allowed_ips,omitempty"`
+	BearerToken    *string           `json:"bearer_token,omitempty"`
+	Endpoints      []ModelsEndpoint  `json:"endpoints,omitempty"`
+	Ephemeral      *bool             `json:"ephemeral,omitempty"`
+	Hostname       *string           `json:"hostname,omitempty"`
+	Id             *string           `json:"id,omitempty"`
+	Ipv4TunnelIps  []ModelsTunnelIP  `json:"ipv4_tunnel_ips,omitempty"`
+	Ipv6TunnelIps  []ModelsTunnelIP  `json:"ipv6_tunnel_ips,omitempty"`
+	Labels         map[string]string `json:"labels,omitempty"`
+	Online         *bool             `json:"online,omitempty"`
+	OnlineAt       *string           `json:"online_at,omitempty"`
+	Os             *string           `json:"os,omitempty"`
+	OwnerId        *string           `json:"owner_id,omitempty"`
+	Pending        *bool             `json:"pending,omitempty"`
+	// PrimaryCidrs are the advertised cidrs the device is the primary router for in its VPC.
+	PrimaryCidrs    []string `json:"primary_cidrs,omitempty"`
+	PublicKey       *string  `json:"public_key,omitempty"`
+	Relay           *bool    `json:"relay,omitempty"`
+	Revision        *int32   `json:"revision,omitempty"`
+	SecurityGroupId *string  `json:"security_group_id,omitempty"`
+	SymmetricNat    *bool    `json:"symmetric_nat,omitempty"`
+	VpcId           *string  `json:"vpc_id,omitempty"`
 }
 
 // NewModelsDevice instantiates a new ModelsDevice object
@@ -185,6 +189,38 @@ func (o *ModelsDevice) SetEndpoints(v []ModelsEndpoint) {
 	o.Endpoints = v
 }
 
+// GetEphemeral returns the Ephemeral field value if set, zero value otherwise.
+func (o *ModelsDevice) GetEphemeral() bool {
+	if o == nil || IsNil(o.Ephemeral) {
+		var ret bool
+		return ret
+	}
+	return *o.Ephemeral
+}
+
+// GetEphemeralOk returns a tuple with the Ephemeral field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsDevice) GetEphemeralOk() (*bool, bool) {
+	if o == nil || IsNil(o.Ephemeral) {
+		return nil, false
+	}
+	return o.Ephemeral, true
+}
+
+// HasEphemeral returns a boolean if a field has been set.
+func (o *ModelsDevice) HasEphemeral() bool {
+	if o != nil && !IsNil(o.Ephemeral) {
+		return true
+	}
+
+	return false
+}
+
+// SetEphemeral gets a reference to the given bool and assigns it to the Ephemeral field.
+func (o *ModelsDevice) SetEphemeral(v bool) {
+	o.Ephemeral = &v
+}
+
 // GetHostname returns the Hostname field value if set, zero value otherwise.
 func (o *ModelsDevice) GetHostname() string {
 	if o == nil || IsNil(o.Hostname) {
@@ -313,6 +349,38 @@ func (o *ModelsDevice) SetIpv6TunnelIps(v []ModelsTunnelIP) {
 	o.Ipv6TunnelIps = v
 }
 
+// GetLabels returns the Labels field value if set, zero value otherwise.
+func (o *ModelsDevice) GetLabels() map[string]string {
+	if o == nil || IsNil(o.Labels) {
+		var ret map[string]string
+		return ret
+	}
+	return o.Labels
+}
+
+// GetLabelsOk returns a tuple with the Labels field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsDevice) GetLabelsOk() (*map[string]string, bool) {
+	if o == nil || IsNil(o.Labels) {
+		return nil, false
+	}
+	return &o.Labels, true
+}
+
+// HasLabels returns a boolean if a field has been set.
+func (o *ModelsDevice) HasLabels() bool {
+	if o != nil && !IsNil(o.Labels) {
+		return true
+	}
+
+	return false
+}
+
+// SetLabels gets a reference to the given map[string]string and assigns it to the Labels field.
+func (o *ModelsDevice) SetLabels(v map[string]string) {
+	o.Labels = v
+}
+
 // GetOnline returns the Online field value if set, zero value otherwise.
 func (o *ModelsDevice) GetOnline() bool {
 	if o == nil || IsNil(o.Online) {
@@ -441,6 +509,70 @@ func (o *ModelsDevice) SetOwnerId(v string) {
 	o.OwnerId = &v
 }
 
+// GetPending returns the Pending field value if set, zero value otherwise.
+func (o *ModelsDevice) GetPending() bool {
+	if o == nil || IsNil(o.Pending) {
+		var ret bool
+		return ret
+	}
+	return *o.Pending
+}
+
+// GetPendingOk returns a tuple with the Pending field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsDevice) GetPendingOk() (*bool, bool) {
+	if o == nil || IsNil(o.Pending) {
+		return nil, false
+	}
+	return o.Pending, true
+}
+
+// HasPending returns a boolean if a field has been set.
+func (o *ModelsDevice) HasPending() bool {
+	if o != nil && !IsNil(o.Pending) {
+		return true
+	}
+
+	return false
+}
+
+// SetPending gets a reference to the given bool and assigns it to the Pending field.
+func (o *ModelsDevice) SetPending(v bool) {
+	o.Pending = &v
+}
+
+// GetPrimaryCidrs returns the PrimaryCidrs field value if set, zero value otherwise.
+func (o *ModelsDevice) GetPrimaryCidrs() []string {
+	if o == nil || IsNil(o.PrimaryCidrs) {
+		var ret []string
+		return ret
+	}
+	return o.PrimaryCidrs
+}
+
+// GetPrimaryCidrsOk returns a tuple with the PrimaryCidrs field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsDevice) GetPrimaryCidrsOk() ([]string, bool) {
+	if o == nil || IsNil(o.PrimaryCidrs) {
+		return nil, false
+	}
+	return o.PrimaryCidrs, true
+}
+
+// HasPrimaryCidrs returns a boolean if a field has been set.
+func (o *ModelsDevice) HasPrimaryCidrs() bool {
+	if o != nil && !IsNil(o.PrimaryCidrs) {
+		return true
+	}
+
+	return false
+}
+
+// SetPrimaryCidrs gets a reference to the given []string and assigns it to the PrimaryCidrs field.
+func (o *ModelsDevice) SetPrimaryCidrs(v []string) {
+	o.PrimaryCidrs = v
+}
+
 // GetPublicKey returns the PublicKey field value if set, zero value otherwise.
 func (o *ModelsDevice) GetPublicKey() string {
 	if o == nil || IsNil(o.PublicKey) {
@@ -655,6 +787,9 @@ func (o ModelsDevice) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.Endpoints) {
 		toSerialize["endpoints"] = o.Endpoints
 	}
+	if !IsNil(o.Ephemeral) {
+		toSerialize["ephemeral"] = o.Ephemeral
+	}
 	if !IsNil(o.Hostname) {
 		toSerialize["hostname"] = o.Hostname
 	}
@@ -667,6 +802,9 @@ func (o ModelsDevice) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.Ipv6TunnelIps) {
 		toSerialize["ipv6_tunnel_ips"] = o.Ipv6TunnelIps
 	}
+	if !IsNil(o.Labels) {
+		toSerialize["labels"] = o.Labels
+	}
 	if !IsNil(o.Online) {
 		toSerialize["online"] = o.Online
 	}
@@ -679,6 +817,12 @@ func (o ModelsDevice) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.OwnerId) {
 		toSerialize["owner_id"] = o.OwnerId
 	}
+	if !IsNil(o.Pending) {
+		toSerialize["pending"] = o.Pending
+	}
+	if !IsNil(o.PrimaryCidrs) {
+		toSerialize["primary_cidrs"] = o.PrimaryCidrs
+	}
 	if !IsNil(o.PublicKey) {
 		toSerialize["public_key"] = o.PublicKey
 	}
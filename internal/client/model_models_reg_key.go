@@ -19,23 +19,39 @@ var _ MappedNullable = &ModelsRegKey{}
 
 // ModelsRegKey struct for ModelsRegKey
 type ModelsRegKey struct {
+	// AllowedSourceCidrs is optional, if set the registration key can only be used from these networks.
+	AllowedSourceCidrs []string `json:"allowed_source_cidrs,omitempty"`
 	// BearerToken is the bearer token the client should use to authenticate the device registration request.
 	BearerToken *string `json:"bearer_token,omitempty"`
 	// Description of the registration key.
 	Description *string `json:"description,omitempty"`
 	// DeviceId is set if the RegKey was created for single use
 	DeviceId *string `json:"device_id,omitempty"`
+	// DeviceLabels are added to the labels of the devices registered with the registration key.
+	DeviceLabels map[string]string `json:"device_labels,omitempty"`
+	// DeviceMetadata is copied to the metadata of the devices registered with the registration key.
+	DeviceMetadata map[string]interface{} `json:"device_metadata,omitempty"`
+	// Ephemeral devices are deleted after they have been offline for the ephemeral device grace period.
+	Ephemeral *bool `json:"ephemeral,omitempty"`
 	// ExpiresAt is optional, if set the registration key is only valid until the ExpiresAt time.
 	ExpiresAt *string `json:"expires_at,omitempty"`
-	Id        *string `json:"id,omitempty"`
+	// HostnameTemplate is optional, if set it is used to name the devices registered with the registration key.
+	HostnameTemplate *string `json:"hostname_template,omitempty"`
+	Id               *string `json:"id,omitempty"`
+	// MaxUses is optional, if set the registration key can only register that many devices.
+	MaxUses *int32 `json:"max_uses,omitempty"`
 	// OwnerID is the ID of the user that created the registration key.
 	OwnerId *string `json:"owner_id,omitempty"`
+	// RemainingUses is the number of devices that can still be registered when MaxUses is set.
+	RemainingUses *int32 `json:"remaining_uses,omitempty"`
 	// SecurityGroupId is the ID of the security group to assign to the device.
 	SecurityGroupId *string `json:"security_group_id,omitempty"`
 	// ServiceNetworkID is the ID of the Service Network the device can join.
 	ServiceNetworkId *string `json:"service_network_id,omitempty"`
 	// Settings contains general settings for the device.
 	Settings map[string]interface{} `json:"settings,omitempty"`
+	// Uses is the number of devices registered with the registration key.
+	Uses *int32 `json:"uses,omitempty"`
 	// VpcID is the ID of the VPC the device can join.
 	VpcId *string `json:"vpc_id,omitempty"`
 }
@@ -57,6 +73,38 @@ func NewModelsRegKeyWithDefaults() *ModelsRegKey {
 	return &this
 }
 
+// GetAllowedSourceCidrs returns the AllowedSourceCidrs field value if set, zero value otherwise.
+func (o *ModelsRegKey) GetAllowedSourceCidrs() []string {
+	if o == nil || IsNil(o.AllowedSourceCidrs) {
+		var ret []string
+		return ret
+	}
+	return o.AllowedSourceCidrs
+}
+
+// GetAllowedSourceCidrsOk returns a tuple with the AllowedSourceCidrs field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsRegKey) GetAllowedSourceCidrsOk() ([]string, bool) {
+	if o == nil || IsNil(o.AllowedSourceCidrs) {
+		return nil, false
+	}
+	return o.AllowedSourceCidrs, true
+}
+
+// HasAllowedSourceCidrs returns a boolean if a field has been set.
+func (o *ModelsRegKey) HasAllowedSourceCidrs() bool {
+	if o != nil && !IsNil(o.AllowedSourceCidrs) {
+		return true
+	}
+
+	return false
+}
+
+// SetAllowedSourceCidrs gets a reference to the given []string and assigns it to the AllowedSourceCidrs field.
+func (o *ModelsRegKey) SetAllowedSourceCidrs(v []string) {
+	o.AllowedSourceCidrs = v
+}
+
 // GetBearerToken returns the BearerToken field value if set, zero value otherwise.
 func (o *ModelsRegKey) GetBearerToken() string {
 	if o == nil || IsNil(o.BearerToken) {
@@ -153,6 +201,102 @@ func (o *ModelsRegKey) SetDeviceId(v string) {
 	o.DeviceId = &v
 }
 
+// GetDeviceLabels returns the DeviceLabels field value if set, zero value otherwise.
+func (o *ModelsRegKey) GetDeviceLabels() map[string]string {
+	if o == nil || IsNil(o.DeviceLabels) {
+		var ret map[string]string
+		return ret
+	}
+	return o.DeviceLabels
+}
+
+// GetDeviceLabelsOk returns a tuple with the DeviceLabels field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsRegKey) GetDeviceLabelsOk() (*map[string]string, bool) {
+	if o == nil || IsNil(o.DeviceLabels) {
+		return nil, false
+	}
+	return &o.DeviceLabels, true
+}
+
+// HasDeviceLabels returns a boolean if a field has been set.
+func (o *ModelsRegKey) HasDeviceLabels() bool {
+	if o != nil && !IsNil(o.DeviceLabels) {
+		return true
+	}
+
+	return false
+}
+
+// SetDeviceLabels gets a reference to the given map[string]string and assigns it to the DeviceLabels field.
+func (o *ModelsRegKey) SetDeviceLabels(v map[string]string) {
+	o.DeviceLabels = v
+}
+
+// GetDeviceMetadata returns the DeviceMetadata field value if set, zero value otherwise.
+func (o *ModelsRegKey) GetDeviceMetadata() map[string]interface{} {
+	if o == nil || IsNil(o.DeviceMetadata) {
+		var ret map[string]interface{}
+		return ret
+	}
+	return o.DeviceMetadata
+}
+
+// GetDeviceMetadataOk returns a tuple with the DeviceMetadata field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsRegKey) GetDeviceMetadataOk() (map[string]interface{}, bool) {
+	if o == nil || IsNil(o.DeviceMetadata) {
+		return map[string]interface{}{}, false
+	}
+	return o.DeviceMetadata, true
+}
+
+// HasDeviceMetadata returns a boolean if a field has been set.
+func (o *ModelsRegKey) HasDeviceMetadata() bool {
+	if o != nil && !IsNil(o.DeviceMetadata) {
+		return true
+	}
+
+	return false
+}
+
+// SetDeviceMetadata gets a reference to the given map[string]interface{} and assigns it to the DeviceMetadata field.
+func (o *ModelsRegKey) SetDeviceMetadata(v map[string]interface{}) {
+	o.DeviceMetadata = v
+}
+
+// GetEphemeral returns the Ephemeral field value if set, zero value otherwise.
+func (o *ModelsRegKey) GetEphemeral() bool {
+	if o == nil || IsNil(o.Ephemeral) {
+		var ret bool
+		return ret
+	}
+	return *o.Ephemeral
+}
+
+// GetEphemeralOk returns a tuple with the Ephemeral field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsRegKey) GetEphemeralOk() (*bool, bool) {
+	if o == nil || IsNil(o.Ephemeral) {
+		return nil, false
+	}
+	return o.Ephemeral, true
+}
+
+// HasEphemeral returns a boolean if a field has been set.
+func (o *ModelsRegKey) HasEphemeral() bool {
+	if o != nil && !IsNil(o.Ephemeral) {
+		return true
+	}
+
+	return false
+}
+
+// SetEphemeral gets a reference to the given bool and assigns it to the Ephemeral field.
+func (o *ModelsRegKey) SetEphemeral(v bool) {
+	o.Ephemeral = &v
+}
+
 // GetExpiresAt returns the ExpiresAt field value if set, zero value otherwise.
 func (o *ModelsRegKey) GetExpiresAt() string {
 	if o == nil || IsNil(o.ExpiresAt) {
@@ -185,6 +329,38 @@ func (o *ModelsRegKey) SetExpiresAt(v string) {
 	o.ExpiresAt = &v
 }
 
+// GetHostnameTemplate returns the HostnameTemplate field value if set, zero value otherwise.
+func (o *ModelsRegKey) GetHostnameTemplate() string {
+	if o == nil || IsNil(o.HostnameTemplate) {
+		var ret string
+		return ret
+	}
+	return *o.HostnameTemplate
+}
+
+// GetHostnameTemplateOk returns a tuple with the HostnameTemplate field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsRegKey) GetHostnameTemplateOk() (*string, bool) {
+	if o == nil || IsNil(o.HostnameTemplate) {
+		return nil, false
+	}
+	return o.HostnameTemplate, true
+}
+
+// HasHostnameTemplate returns a boolean if a field has been set.
+func (o *ModelsRegKey) HasHostnameTemplate() bool {
+	if o != nil && !IsNil(o.HostnameTemplate) {
+		return true
+	}
+
+	return false
+}
+
+// SetHostnameTemplate gets a reference to the given string and assigns it to the HostnameTemplate field.
+func (o *ModelsRegKey) SetHostnameTemplate(v string) {
+	o.HostnameTemplate = &v
+}
+
 // GetId returns the Id field value if set, zero value otherwise.
 func (o *ModelsRegKey) GetId() string {
 	if o == nil || IsNil(o.Id) {
@@ -217,6 +393,38 @@ func (o *ModelsRegKey) SetId(v string) {
 	o.Id = &v
 }
 
+// GetMaxUses returns the MaxUses field value if set, zero value otherwise.
+func (o *ModelsRegKey) GetMaxUses() int32 {
+	if o == nil || IsNil(o.MaxUses) {
+		var ret int32
+		return ret
+	}
+	return *o.MaxUses
+}
+
+// GetMaxUsesOk returns a tuple with the MaxUses field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsRegKey) GetMaxUsesOk() (*int32, bool) {
+	if o == nil || IsNil(o.MaxUses) {
+		return nil, false
+	}
+	return o.MaxUses, true
+}
+
+// HasMaxUses returns a boolean if a field has been set.
+func (o *ModelsRegKey) HasMaxUses() bool {
+	if o != nil && !IsNil(o.MaxUses) {
+		return true
+	}
+
+	return false
+}
+
+// SetMaxUses gets a reference to the given int32 and assigns it to the MaxUses field.
+func (o *ModelsRegKey) SetMaxUses(v int32) {
+	o.MaxUses = &v
+}
+
 // GetOwnerId returns the OwnerId field value if set, zero value otherwise.
 func (o *ModelsRegKey) GetOwnerId() string {
 	if o == nil || IsNil(o.OwnerId) {
@@ -249,6 +457,38 @@ func (o *ModelsRegKey) SetOwnerId(v string) {
 	o.OwnerId = &v
 }
 
+// GetRemainingUses returns the RemainingUses field value if set, zero value otherwise.
+func (o *ModelsRegKey) GetRemainingUses() int32 {
+	if o == nil || IsNil(o.RemainingUses) {
+		var ret int32
+		return ret
+	}
+	return *o.RemainingUses
+}
+
+// GetRemainingUsesOk returns a tuple with the RemainingUses field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsRegKey) GetRemainingUsesOk() (*int32, bool) {
+	if o == nil || IsNil(o.RemainingUses) {
+		return nil, false
+	}
+	return o.RemainingUses, true
+}
+
+// HasRemainingUses returns a boolean if a field has been set.
+func (o *ModelsRegKey) HasRemainingUses() bool {
+	if o != nil && !IsNil(o.RemainingUses) {
+		return true
+	}
+
+	return false
+}
+
+// SetRemainingUses gets a reference to the given int32 and assigns it to the RemainingUses field.
+func (o *ModelsRegKey) SetRemainingUses(v int32) {
+	o.RemainingUses = &v
+}
+
 // GetSecurityGroupId returns the SecurityGroupId field value if set, zero value otherwise.
 func (o *ModelsRegKey) GetSecurityGroupId() string {
 	if o == nil || IsNil(o.SecurityGroupId) {
@@ -345,6 +585,38 @@ func (o *ModelsRegKey) SetSettings(v map[string]interface{}) {
 	o.Settings = v
 }
 
+// GetUses returns the Uses field value if set, zero value otherwise.
+func (o *ModelsRegKey) GetUses() int32 {
+	if o == nil || IsNil(o.Uses) {
+		var ret int32
+		return ret
+	}
+	return *o.Uses
+}
+
+// GetUsesOk returns a tuple with the Uses field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsRegKey) GetUsesOk() (*int32, bool) {
+	if o == nil || IsNil(o.Uses) {
+		return nil, false
+	}
+	return o.Uses, true
+}
+
+// HasUses returns a boolean if a field has been set.
+func (o *ModelsRegKey) HasUses() bool {
+	if o != nil && !IsNil(o.Uses) {
+		return true
+	}
+
+	return false
+}
+
+// SetUses gets a reference to the given int32 and assigns it to the Uses field.
+func (o *ModelsRegKey) SetUses(v int32) {
+	o.Uses = &v
+}
+
 // GetVpcId returns the VpcId field value if set, zero value otherwise.
 func (o *ModelsRegKey) GetVpcId() string {
 	if o == nil || IsNil(o.VpcId) {
@@ -387,6 +659,9 @@ func (o ModelsRegKey) MarshalJSON() ([]byte, error) {
 
 func (o ModelsRegKey) ToMap() (map[string]interface{}, error) {
 	toSerialize := map[string]interface{}{}
+	if !IsNil(o.AllowedSourceCidrs) {
+		toSerialize["allowed_source_cidrs"] = o.AllowedSourceCidrs
+	}
 	if !IsNil(o.BearerToken) {
 		toSerialize["bearer_token"] = o.BearerToken
 	}
@@ -396,15 +671,33 @@ func (o ModelsRegKey) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.DeviceId) {
 		toSerialize["device_id"] = o.DeviceId
 	}
+	if !IsNil(o.DeviceLabels) {
+		toSerialize["device_labels"] = o.DeviceLabels
+	}
+	if !IsNil(o.DeviceMetadata) {
+		toSerialize["device_metadata"] = o.DeviceMetadata
+	}
+	if !IsNil(o.Ephemeral) {
+		toSerialize["ephemeral"] = o.Ephemeral
+	}
 	if !IsNil(o.ExpiresAt) {
 		toSerialize["expires_at"] = o.ExpiresAt
 	}
+	if !IsNil(o.HostnameTemplate) {
+		toSerialize["hostname_template"] = o.HostnameTemplate
+	}
 	if !IsNil(o.Id) {
 		toSerialize["id"] = o.Id
 	}
+	if !IsNil(o.MaxUses) {
+		toSerialize["max_uses"] = o.MaxUses
+	}
 	if !IsNil(o.OwnerId) {
 		toSerialize["owner_id"] = o.OwnerId
 	}
+	if !IsNil(o.RemainingUses) {
+		toSerialize["remaining_uses"] = o.RemainingUses
+	}
 	if !IsNil(o.SecurityGroupId) {
 		toSerialize["security_group_id"] = o.SecurityGroupId
 	}
@@ -414,6 +707,9 @@ func (o ModelsRegKey) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.Settings) {
 		toSerialize["settings"] = o.Settings
 	}
+	if !IsNil(o.Uses) {
+		toSerialize["uses"] = o.Uses
+	}
 	if !IsNil(o.VpcId) {
 		toSerialize["vpc_id"] = o.VpcId
 	}
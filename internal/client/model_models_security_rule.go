@@ -22,7 +22,9 @@ type ModelsSecurityRule struct {
 	FromPort   *int32   `json:"from_port,omitempty"`
 	IpProtocol *string  `json:"ip_protocol,omitempty"`
 	IpRanges   []string `json:"ip_ranges,omitempty"`
-	ToPort     *int32   `json:"to_port,omitempty"`
+	// PeerSelector matches the tunnel IPs of the devices in the VPC that carry all the labels, e.g. role=db,env=prod
+	PeerSelector *string `json:"peer_selector,omitempty"`
+	ToPort       *int32  `json:"to_port,omitempty"`
 }
 
 // NewModelsSecurityRule instantiates a new ModelsSecurityRule object
@@ -138,6 +140,38 @@ func (o *ModelsSecurityRule) SetIpRanges(v []string) {
 	o.IpRanges = v
 }
 
+// GetPeerSelector returns the PeerSelector field value if set, zero value otherwise.
+func (o *ModelsSecurityRule) GetPeerSelector() string {
+	if o == nil || IsNil(o.PeerSelector) {
+		var ret string
+		return ret
+	}
+	return *o.PeerSelector
+}
+
+// GetPeerSelectorOk returns a tuple with the PeerSelector field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsSecurityRule) GetPeerSelectorOk() (*string, bool) {
+	if o == nil || IsNil(o.PeerSelector) {
+		return nil, false
+	}
+	return o.PeerSelector, true
+}
+
+// HasPeerSelector returns a boolean if a field has been set.
+func (o *ModelsSecurityRule) HasPeerSelector() bool {
+	if o != nil && !IsNil(o.PeerSelector) {
+		return true
+	}
+
+	return false
+}
+
+// SetPeerSelector gets a reference to the given string and assigns it to the PeerSelector field.
+func (o *ModelsSecurityRule) SetPeerSelector(v string) {
+	o.PeerSelector = &v
+}
+
 // GetToPort returns the ToPort field value if set, zero value otherwise.
 func (o *ModelsSecurityRule) GetToPort() int32 {
 	if o == nil || IsNil(o.ToPort) {
@@ -189,6 +223,9 @@ func (o ModelsSecurityRule) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.IpRanges) {
 		toSerialize["ip_ranges"] = o.IpRanges
 	}
+	if !IsNil(o.PeerSelector) {
+		toSerialize["peer_selector"] = o.PeerSelector
+	}
 	if !IsNil(o.ToPort) {
 		toSerialize["to_port"] = o.ToPort
 	}
@@ -19,14 +19,17 @@ var _ MappedNullable = &ModelsUpdateDevice{}
 
 // ModelsUpdateDevice struct for ModelsUpdateDevice
 type ModelsUpdateDevice struct {
-	AdvertiseCidrs  []string         `json:"advertise_cidrs,omitempty"`
-	Endpoints       []ModelsEndpoint `json:"endpoints,omitempty"`
-	Hostname        *string          `json:"hostname,omitempty"`
-	Relay           *bool            `json:"relay,omitempty"`
-	Revision        *int32           `json:"revision,omitempty"`
-	SecurityGroupId *string          `json:"security_group_id,omitempty"`
-	SymmetricNat    *bool            `json:"symmetric_nat,omitempty"`
-	VpcId           *string          `json:"vpc_id,omitempty"`
+	AdvertiseCidrs []string          `json:"advertise_cidrs,omitempty"`
+	Endpoints      []ModelsEndpoint  `json:"endpoints,omitempty"`
+	Hostname       *string           `json:"hostname,omitempty"`
+	Labels         map[string]string `json:"labels,omitempty"`
+	// PublicKey rotates the wireguard key of the device, the revision of the device must be given with it.
+	PublicKey       *string `json:"public_key,omitempty"`
+	Relay           *bool   `json:"relay,omitempty"`
+	Revision        *int32  `json:"revision,omitempty"`
+	SecurityGroupId *string `json:"security_group_id,omitempty"`
+	SymmetricNat    *bool   `json:"symmetric_nat,omitempty"`
+	VpcId           *string `json:"vpc_id,omitempty"`
 }
 
 // NewModelsUpdateDevice instantiates a new ModelsUpdateDevice object
@@ -142,6 +145,70 @@ func (o *ModelsUpdateDevice) SetHostname(v string) {
 	o.Hostname = &v
 }
 
+// GetLabels returns the Labels field value if set, zero value otherwise.
+func (o *ModelsUpdateDevice) GetLabels() map[string]string {
+	if o == nil || IsNil(o.Labels) {
+		var ret map[string]string
+		return ret
+	}
+	return o.Labels
+}
+
+// GetLabelsOk returns a tuple with the Labels field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsUpdateDevice) GetLabelsOk() (*map[string]string, bool) {
+	if o == nil || IsNil(o.Labels) {
+		return nil, false
+	}
+	return &o.Labels, true
+}
+
+// HasLabels returns a boolean if a field has been set.
+func (o *ModelsUpdateDevice) HasLabels() bool {
+	if o != nil && !IsNil(o.Labels) {
+		return true
+	}
+
+	return false
+}
+
+// SetLabels gets a reference to the given map[string]string and assigns it to the Labels field.
+func (o *ModelsUpdateDevice) SetLabels(v map[string]string) {
+	o.Labels = v
+}
+
+// GetPublicKey returns the PublicKey field value if set, zero value otherwise.
+func (o *ModelsUpdateDevice) GetPublicKey() string {
+	if o == nil || IsNil(o.PublicKey) {
+		var ret string
+		return ret
+	}
+	return *o.PublicKey
+}
+
+// GetPublicKeyOk returns a tuple with the PublicKey field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsUpdateDevice) GetPublicKeyOk() (*string, bool) {
+	if o == nil || IsNil(o.PublicKey) {
+		return nil, false
+	}
+	return o.PublicKey, true
+}
+
+// HasPublicKey returns a boolean if a field has been set.
+func (o *ModelsUpdateDevice) HasPublicKey() bool {
+	if o != nil && !IsNil(o.PublicKey) {
+		return true
+	}
+
+	return false
+}
+
+// SetPublicKey gets a reference to the given string and assigns it to the PublicKey field.
+func (o *ModelsUpdateDevice) SetPublicKey(v string) {
+	o.PublicKey = &v
+}
+
 // GetRelay returns the Relay field value if set, zero value otherwise.
 func (o *ModelsUpdateDevice) GetRelay() bool {
 	if o == nil || IsNil(o.Relay) {
@@ -321,6 +388,12 @@ func (o ModelsUpdateDevice) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.Hostname) {
 		toSerialize["hostname"] = o.Hostname
 	}
+	if !IsNil(o.Labels) {
+		toSerialize["labels"] = o.Labels
+	}
+	if !IsNil(o.PublicKey) {
+		toSerialize["public_key"] = o.PublicKey
+	}
 	if !IsNil(o.Relay) {
 		toSerialize["relay"] = o.Relay
 	}
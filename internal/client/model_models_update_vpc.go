@@ -19,7 +19,9 @@ var _ MappedNullable = &ModelsUpdateVPC{}
 
 // ModelsUpdateVPC struct for ModelsUpdateVPC
 type ModelsUpdateVPC struct {
-	Description *string `json:"description,omitempty"`
+	Description           *string `json:"description,omitempty"`
+	PresharedKeys         *bool   `json:"preshared_keys,omitempty"`
+	RequireDeviceApproval *bool   `json:"require_device_approval,omitempty"`
 }
 
 // NewModelsUpdateVPC instantiates a new ModelsUpdateVPC object
@@ -71,6 +73,70 @@ func (o *ModelsUpdateVPC) SetDescription(v string) {
 	o.Description = &v
 }
 
+// GetPresharedKeys returns the PresharedKeys field value if set, zero value otherwise.
+func (o *ModelsUpdateVPC) GetPresharedKeys() bool {
+	if o == nil || IsNil(o.PresharedKeys) {
+		var ret bool
+		return ret
+	}
+	return *o.PresharedKeys
+}
+
+// GetPresharedKeysOk returns a tuple with the PresharedKeys field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsUpdateVPC) GetPresharedKeysOk() (*bool, bool) {
+	if o == nil || IsNil(o.PresharedKeys) {
+		return nil, false
+	}
+	return o.PresharedKeys, true
+}
+
+// HasPresharedKeys returns a boolean if a field has been set.
+func (o *ModelsUpdateVPC) HasPresharedKeys() bool {
+	if o != nil && !IsNil(o.PresharedKeys) {
+		return true
+	}
+
+	return false
+}
+
+// SetPresharedKeys gets a reference to the given bool and assigns it to the PresharedKeys field.
+func (o *ModelsUpdateVPC) SetPresharedKeys(v bool) {
+	o.PresharedKeys = &v
+}
+
+// GetRequireDeviceApproval returns the RequireDeviceApproval field value if set, zero value otherwise.
+func (o *ModelsUpdateVPC) GetRequireDeviceApproval() bool {
+	if o == nil || IsNil(o.RequireDeviceApproval) {
+		var ret bool
+		return ret
+	}
+	return *o.RequireDeviceApproval
+}
+
+// GetRequireDeviceApprovalOk returns a tuple with the RequireDeviceApproval field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsUpdateVPC) GetRequireDeviceApprovalOk() (*bool, bool) {
+	if o == nil || IsNil(o.RequireDeviceApproval) {
+		return nil, false
+	}
+	return o.RequireDeviceApproval, true
+}
+
+// HasRequireDeviceApproval returns a boolean if a field has been set.
+func (o *ModelsUpdateVPC) HasRequireDeviceApproval() bool {
+	if o != nil && !IsNil(o.RequireDeviceApproval) {
+		return true
+	}
+
+	return false
+}
+
+// SetRequireDeviceApproval gets a reference to the given bool and assigns it to the RequireDeviceApproval field.
+func (o *ModelsUpdateVPC) SetRequireDeviceApproval(v bool) {
+	o.RequireDeviceApproval = &v
+}
+
 func (o ModelsUpdateVPC) MarshalJSON() ([]byte, error) {
 	toSerialize, err := o.ToMap()
 	if err != nil {
@@ -84,6 +150,12 @@ func (o ModelsUpdateVPC) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.Description) {
 		toSerialize["description"] = o.Description
 	}
+	if !IsNil(o.PresharedKeys) {
+		toSerialize["preshared_keys"] = o.PresharedKeys
+	}
+	if !IsNil(o.RequireDeviceApproval) {
+		toSerialize["require_device_approval"] = o.RequireDeviceApproval
+	}
 	return toSerialize, nil
 }
 
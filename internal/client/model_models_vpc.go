@@ -24,8 +24,11 @@ type ModelsVPC struct {
 	Ipv4Cidr       *string `json:"ipv4_cidr,omitempty"`
 	Ipv6Cidr       *string `json:"ipv6_cidr,omitempty"`
 	OrganizationId *string `json:"organization_id,omitempty"`
-	PrivateCidr    *bool   `json:"private_cidr,omitempty"`
-	Revision       *int32  `json:"revision,omitempty"`
+	// PresharedKeys adds a wireguard pre-shared key to the peering of every pair of devices in the VPC.
+	PresharedKeys         *bool  `json:"preshared_keys,omitempty"`
+	PrivateCidr           *bool  `json:"private_cidr,omitempty"`
+	RequireDeviceApproval *bool  `json:"require_device_approval,omitempty"`
+	Revision              *int32 `json:"revision,omitempty"`
 }
 
 // NewModelsVPC instantiates a new ModelsVPC object
@@ -205,6 +208,38 @@ func (o *ModelsVPC) SetOrganizationId(v string) {
 	o.OrganizationId = &v
 }
 
+// GetPresharedKeys returns the PresharedKeys field value if set, zero value otherwise.
+func (o *ModelsVPC) GetPresharedKeys() bool {
+	if o == nil || IsNil(o.PresharedKeys) {
+		var ret bool
+		return ret
+	}
+	return *o.PresharedKeys
+}
+
+// GetPresharedKeysOk returns a tuple with the PresharedKeys field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsVPC) GetPresharedKeysOk() (*bool, bool) {
+	if o == nil || IsNil(o.PresharedKeys) {
+		return nil, false
+	}
+	return o.PresharedKeys, true
+}
+
+// HasPresharedKeys returns a boolean if a field has been set.
+func (o *ModelsVPC) HasPresharedKeys() bool {
+	if o != nil && !IsNil(o.PresharedKeys) {
+		return true
+	}
+
+	return false
+}
+
+// SetPresharedKeys gets a reference to the given bool and assigns it to the PresharedKeys field.
+func (o *ModelsVPC) SetPresharedKeys(v bool) {
+	o.PresharedKeys = &v
+}
+
 // GetPrivateCidr returns the PrivateCidr field value if set, zero value otherwise.
 func (o *ModelsVPC) GetPrivateCidr() bool {
 	if o == nil || IsNil(o.PrivateCidr) {
@@ -237,6 +272,38 @@ func (o *ModelsVPC) SetPrivateCidr(v bool) {
 	o.PrivateCidr = &v
 }
 
+// GetRequireDeviceApproval returns the RequireDeviceApproval field value if set, zero value otherwise.
+func (o *ModelsVPC) GetRequireDeviceApproval() bool {
+	if o == nil || IsNil(o.RequireDeviceApproval) {
+		var ret bool
+		return ret
+	}
+	return *o.RequireDeviceApproval
+}
+
+// GetRequireDeviceApprovalOk returns a tuple with the RequireDeviceApproval field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ModelsVPC) GetRequireDeviceApprovalOk() (*bool, bool) {
+	if o == nil || IsNil(o.RequireDeviceApproval) {
+		return nil, false
+	}
+	return o.RequireDeviceApproval, true
+}
+
+// HasRequireDeviceApproval returns a boolean if a field has been set.
+func (o *ModelsVPC) HasRequireDeviceApproval() bool {
+	if o != nil && !IsNil(o.RequireDeviceApproval) {
+		return true
+	}
+
+	return false
+}
+
+// SetRequireDeviceApproval gets a reference to the given bool and assigns it to the RequireDeviceApproval field.
+func (o *ModelsVPC) SetRequireDeviceApproval(v bool) {
+	o.RequireDeviceApproval = &v
+}
+
 // GetRevision returns the Revision field value if set, zero value otherwise.
 func (o *ModelsVPC) GetRevision() int32 {
 	if o == nil || IsNil(o.Revision) {
@@ -294,9 +361,15 @@ func (o ModelsVPC) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.OrganizationId) {
 		toSerialize["organization_id"] = o.OrganizationId
 	}
+	if !IsNil(o.PresharedKeys) {
+		toSerialize["preshared_keys"] = o.PresharedKeys
+	}
 	if !IsNil(o.PrivateCidr) {
 		toSerialize["private_cidr"] = o.PrivateCidr
 	}
+	if !IsNil(o.RequireDeviceApproval) {
+		toSerialize["require_device_approval"] = o.RequireDeviceApproval
+	}
 	if !IsNil(o.Revision) {
 		toSerialize["revision"] = o.Revision
 	}
@@ -16,11 +16,28 @@ import (
 	_ "github.com/nexodus-io/nexodus/internal/database/migration_20231211_0000"
 	_ "github.com/nexodus-io/nexodus/internal/database/migration_20240221_0000"
 	_ "github.com/nexodus-io/nexodus/internal/database/migration_20240227_0000"
+	_ "github.com/nexodus-io/nexodus/internal/database/migration_20240305_0000"
+	_ "github.com/nexodus-io/nexodus/internal/database/migration_20240306_0000"
+	_ "github.com/nexodus-io/nexodus/internal/database/migration_20240307_0000"
+	_ "github.com/nexodus-io/nexodus/internal/database/migration_20240308_0000"
+	_ "github.com/nexodus-io/nexodus/internal/database/migration_20240309_0000"
+	_ "github.com/nexodus-io/nexodus/internal/database/migration_20240310_0000"
+	_ "github.com/nexodus-io/nexodus/internal/database/migration_20240311_0000"
+	_ "github.com/nexodus-io/nexodus/internal/database/migration_20240312_0000"
+	_ "github.com/nexodus-io/nexodus/internal/database/migration_20240313_0000"
+	_ "github.com/nexodus-io/nexodus/internal/database/migration_20240314_0000"
+	_ "github.com/nexodus-io/nexodus/internal/database/migration_20240315_0000"
+	_ "github.com/nexodus-io/nexodus/internal/database/migration_20240316_0000"
+	_ "github.com/nexodus-io/nexodus/internal/database/migration_20240317_0000"
+	_ "github.com/nexodus-io/nexodus/internal/database/migration_20240318_0000"
+	_ "github.com/nexodus-io/nexodus/internal/database/migration_20240319_0000"
 	"sort"
 
 	"github.com/cenkalti/backoff/v4"
 	"github.com/go-gormigrate/gormigrate/v2"
 	"github.com/nexodus-io/nexodus/internal/database/migrations"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/uptrace/opentelemetry-go-extra/otelgorm"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/trace"
@@ -69,6 +86,14 @@ func NewDatabase(
 	if err := db.Use(otelgorm.NewPlugin()); err != nil {
 		return nil, "", err
 	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, "", err
+	}
+	// expose the connection pool stats with the rest of the apiserver metrics
+	if err := prometheus.Register(collectors.NewDBStatsCollector(sqlDB, dbname)); err != nil {
+		logger.Warnf("failed to register the database metrics: %v", err)
+	}
 	return db, dsn, nil
 }
 
@@ -80,6 +105,8 @@ func NewTestDatabase() (*gorm.DB, error) {
 	gormLogger := NewLogger(logger.Sugar())
 	config := &gorm.Config{
 		Logger: gormLogger,
+		// report unique violations as gorm.ErrDuplicatedKey, the way IsDuplicateError detects them on sqlite
+		TranslateError: true,
 	}
 	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), config)
 	if err != nil {
@@ -55,11 +55,15 @@ func (j *StringArray) Scan(value interface{}) error {
 		bytes = v
 	case string:
 		bytes = []byte(v)
+	case nil:
+		// columns added by a migration are NULL for the existing rows
+		*j = nil
+		return nil
 	default:
 		return errors.New(fmt.Sprint("Failed to unmarshal string array value:", value))
 	}
 
-	if len(bytes) == 0 {
+	if len(bytes) == 0 || string(bytes) == "null" {
 		*j = nil
 		return nil
 	}
@@ -0,0 +1,39 @@
+package migration_20240305_0000
+
+import (
+	"github.com/google/uuid"
+	"github.com/nexodus-io/nexodus/internal/database/migration_20231031_0000"
+	. "github.com/nexodus-io/nexodus/internal/database/migrations"
+)
+
+type DNSRecord struct {
+	migration_20231031_0000.Base
+	VpcID          uuid.UUID `gorm:"type:uuid;index"`
+	OrganizationID uuid.UUID `gorm:"type:uuid"`
+	Name           string
+	Addresses      []string `gorm:"type:JSONB; serializer:json"`
+	Description    string
+	Revision       uint64 `gorm:"type:bigserial;index:"`
+}
+
+func init() {
+	migrationId := "20240305-0000"
+	CreateMigrationFromActions(migrationId,
+		CreateTableAction(&DNSRecord{}),
+		ExecActionIf(`
+			CREATE OR REPLACE FUNCTION dns_records_revision_trigger() RETURNS TRIGGER LANGUAGE plpgsql AS '
+			BEGIN
+			NEW.revision := nextval(''dns_records_revision_seq'');
+			RETURN NEW;
+			END;'
+		`, `
+			DROP FUNCTION IF EXISTS dns_records_revision_trigger
+		`, NotOnSqlLite),
+		ExecActionIf(`
+			CREATE OR REPLACE TRIGGER dns_records_revision_trigger BEFORE INSERT OR UPDATE ON dns_records
+			FOR EACH ROW EXECUTE PROCEDURE dns_records_revision_trigger();
+		`, `
+			DROP TRIGGER IF EXISTS dns_records_revision_trigger ON dns_records
+		`, NotOnSqlLite),
+	)
+}
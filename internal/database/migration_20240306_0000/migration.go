@@ -0,0 +1,16 @@
+package migration_20240306_0000
+
+import (
+	. "github.com/nexodus-io/nexodus/internal/database/migrations"
+)
+
+type Device struct {
+	Labels map[string]string `gorm:"type:JSONB; serializer:json"`
+}
+
+func init() {
+	migrationId := "20240306-0000"
+	CreateMigrationFromActions(migrationId,
+		AddTableColumnAction(&Device{}, "labels"),
+	)
+}
@@ -0,0 +1,45 @@
+package migration_20240307_0000
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/nexodus-io/nexodus/internal/database/migrations"
+)
+
+type AuditEvent struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key"`
+	CreatedAt      time.Time  `gorm:"index"`
+	OrganizationID uuid.UUID  `gorm:"type:uuid;index"`
+	ActorUserID    uuid.UUID  `gorm:"type:uuid"`
+	ActorDeviceID  *uuid.UUID `gorm:"type:uuid"`
+	TokenScope     string
+	Action         string
+	ResourceKind   string
+	ResourceID     uuid.UUID              `gorm:"type:uuid;index"`
+	Before         map[string]interface{} `gorm:"type:JSONB; serializer:json"`
+	After          map[string]interface{} `gorm:"type:JSONB; serializer:json"`
+	SourceIP       string
+}
+
+func init() {
+	migrationId := "20240307-0000"
+	CreateMigrationFromActions(migrationId,
+		CreateTableAction(&AuditEvent{}),
+		// audit events are never changed once written
+		ExecActionIf(`
+			CREATE OR REPLACE FUNCTION audit_events_immutable_trigger() RETURNS TRIGGER LANGUAGE plpgsql AS '
+			BEGIN
+			RAISE EXCEPTION ''audit events can not be modified'';
+			END;'
+		`, `
+			DROP FUNCTION IF EXISTS audit_events_immutable_trigger
+		`, NotOnSqlLite),
+		ExecActionIf(`
+			CREATE OR REPLACE TRIGGER audit_events_immutable_trigger BEFORE UPDATE OR DELETE ON audit_events
+			FOR EACH ROW EXECUTE PROCEDURE audit_events_immutable_trigger();
+		`, `
+			DROP TRIGGER IF EXISTS audit_events_immutable_trigger ON audit_events
+		`, NotOnSqlLite),
+	)
+}
@@ -0,0 +1,25 @@
+package migration_20240308_0000
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nexodus-io/nexodus/internal/database/migration_20231031_0000"
+	. "github.com/nexodus-io/nexodus/internal/database/migrations"
+)
+
+type APIToken struct {
+	migration_20231031_0000.Base
+	UserID      uuid.UUID `gorm:"type:uuid;index"`
+	Description string
+	Scopes      []string `gorm:"type:JSONB; serializer:json"`
+	ExpiresAt   *time.Time
+	LastUsedAt  *time.Time
+}
+
+func init() {
+	migrationId := "20240308-0000"
+	CreateMigrationFromActions(migrationId,
+		CreateTableAction(&APIToken{}),
+	)
+}
@@ -0,0 +1,43 @@
+package migration_20240309_0000
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nexodus-io/nexodus/internal/database/migration_20231031_0000"
+	. "github.com/nexodus-io/nexodus/internal/database/migrations"
+)
+
+type Webhook struct {
+	migration_20231031_0000.Base
+	OrganizationID  uuid.UUID `gorm:"type:uuid;index"`
+	URL             string
+	Description     string
+	EventKinds      []string `gorm:"type:JSONB; serializer:json"`
+	Secret          string
+	Cursors         map[string]uint64 `gorm:"type:JSONB; serializer:json"`
+	DispatchVersion uint64
+}
+
+type WebhookDelivery struct {
+	ID            uuid.UUID `gorm:"type:uuid;primary_key"`
+	CreatedAt     time.Time
+	WebhookID     uuid.UUID `gorm:"type:uuid;index"`
+	EventKind     string
+	EventType     string
+	Payload       string
+	Status        string `gorm:"index"`
+	Attempts      int
+	NextAttemptAt *time.Time `gorm:"index"`
+	ResponseCode  int
+	Error         string
+	DeliveredAt   *time.Time
+}
+
+func init() {
+	migrationId := "20240309-0000"
+	CreateMigrationFromActions(migrationId,
+		CreateTableAction(&Webhook{}),
+		CreateTableAction(&WebhookDelivery{}),
+	)
+}
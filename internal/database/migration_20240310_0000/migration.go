@@ -0,0 +1,29 @@
+package migration_20240310_0000
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/nexodus-io/nexodus/internal/database/migrations"
+)
+
+type IpamPrefix struct {
+	Namespace uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Cidr      string    `gorm:"primaryKey"`
+	CreatedAt time.Time
+}
+
+type IpamAddress struct {
+	Namespace uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Address   string    `gorm:"primaryKey"`
+	Cidr      string    `gorm:"index"`
+	CreatedAt time.Time
+}
+
+func init() {
+	migrationId := "20240310-0000"
+	CreateMigrationFromActions(migrationId,
+		CreateTableAction(&IpamPrefix{}),
+		CreateTableAction(&IpamAddress{}),
+	)
+}
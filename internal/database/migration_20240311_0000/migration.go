@@ -0,0 +1,21 @@
+package migration_20240311_0000
+
+import (
+	. "github.com/nexodus-io/nexodus/internal/database/migrations"
+)
+
+type Device struct {
+	Pending bool `gorm:"not null;default:false"`
+}
+
+type VPC struct {
+	RequireDeviceApproval bool `gorm:"not null;default:false"`
+}
+
+func init() {
+	migrationId := "20240311-0000"
+	CreateMigrationFromActions(migrationId,
+		AddTableColumnAction(&Device{}, "pending"),
+		AddTableColumnAction(&VPC{}, "require_device_approval"),
+	)
+}
@@ -0,0 +1,21 @@
+package migration_20240312_0000
+
+import (
+	. "github.com/nexodus-io/nexodus/internal/database/migrations"
+)
+
+type Device struct {
+	Ephemeral bool `gorm:"not null;default:false"`
+}
+
+type RegKey struct {
+	Ephemeral bool `gorm:"not null;default:false"`
+}
+
+func init() {
+	migrationId := "20240312-0000"
+	CreateMigrationFromActions(migrationId,
+		AddTableColumnAction(&Device{}, "ephemeral"),
+		AddTableColumnAction(&RegKey{}, "ephemeral"),
+	)
+}
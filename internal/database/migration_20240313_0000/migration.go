@@ -0,0 +1,21 @@
+package migration_20240313_0000
+
+import (
+	"github.com/nexodus-io/nexodus/internal/database/datatype"
+	. "github.com/nexodus-io/nexodus/internal/database/migrations"
+)
+
+type RegKey struct {
+	MaxUses            int64 `gorm:"not null;default:0"`
+	Uses               int64 `gorm:"not null;default:0"`
+	AllowedSourceCidrs datatype.StringArray
+}
+
+func init() {
+	migrationId := "20240313-0000"
+	CreateMigrationFromActions(migrationId,
+		AddTableColumnAction(&RegKey{}, "max_uses"),
+		AddTableColumnAction(&RegKey{}, "uses"),
+		AddTableColumnAction(&RegKey{}, "allowed_source_cidrs"),
+	)
+}
@@ -0,0 +1,20 @@
+package migration_20240314_0000
+
+import (
+	. "github.com/nexodus-io/nexodus/internal/database/migrations"
+)
+
+type RegKey struct {
+	DeviceLabels     map[string]string      `gorm:"type:JSONB; serializer:json"`
+	DeviceMetadata   map[string]interface{} `gorm:"type:JSONB; serializer:json"`
+	HostnameTemplate string
+}
+
+func init() {
+	migrationId := "20240314-0000"
+	CreateMigrationFromActions(migrationId,
+		AddTableColumnAction(&RegKey{}, "device_labels"),
+		AddTableColumnAction(&RegKey{}, "device_metadata"),
+		AddTableColumnAction(&RegKey{}, "hostname_template"),
+	)
+}
@@ -0,0 +1,18 @@
+package migration_20240315_0000
+
+import (
+	. "github.com/nexodus-io/nexodus/internal/database/migrations"
+)
+
+type VPC struct {
+	PresharedKeys      bool `gorm:"not null;default:false"`
+	PresharedKeySecret string
+}
+
+func init() {
+	migrationId := "20240315-0000"
+	CreateMigrationFromActions(migrationId,
+		AddTableColumnAction(&VPC{}, "preshared_keys"),
+		AddTableColumnAction(&VPC{}, "preshared_key_secret"),
+	)
+}
@@ -0,0 +1,17 @@
+package migration_20240316_0000
+
+import (
+	"github.com/lib/pq"
+	. "github.com/nexodus-io/nexodus/internal/database/migrations"
+)
+
+type Device struct {
+	PrimaryCidrs pq.StringArray `gorm:"type:text[]"`
+}
+
+func init() {
+	migrationId := "20240316-0000"
+	CreateMigrationFromActions(migrationId,
+		AddTableColumnAction(&Device{}, "primary_cidrs"),
+	)
+}
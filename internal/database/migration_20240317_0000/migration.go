@@ -0,0 +1,24 @@
+package migration_20240317_0000
+
+import (
+	"github.com/google/uuid"
+	"github.com/nexodus-io/nexodus/internal/database/datatype"
+	"github.com/nexodus-io/nexodus/internal/database/migration_20231031_0000"
+	. "github.com/nexodus-io/nexodus/internal/database/migrations"
+)
+
+type Role struct {
+	migration_20231031_0000.Base
+	OrganizationID uuid.UUID `gorm:"type:uuid;index"`
+	Name           string
+	Description    string
+	Permissions    map[string][]string `gorm:"type:JSONB; serializer:json"`
+	Grants         datatype.StringArray
+}
+
+func init() {
+	migrationId := "20240317-0000"
+	CreateMigrationFromActions(migrationId,
+		CreateTableAction(&Role{}),
+	)
+}
@@ -0,0 +1,28 @@
+package migration_20240318_0000
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/nexodus-io/nexodus/internal/database/migrations"
+)
+
+type DeviceDeniedFlow struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key"`
+	CreatedAt      time.Time `gorm:"index"`
+	OrganizationID uuid.UUID `gorm:"type:uuid;index"`
+	DeviceID       uuid.UUID `gorm:"type:uuid;index"`
+	Time           time.Time
+	Direction      string
+	Protocol       string
+	Source         string
+	Destination    string
+	Port           int
+}
+
+func init() {
+	migrationId := "20240318-0000"
+	CreateMigrationFromActions(migrationId,
+		CreateTableAction(&DeviceDeniedFlow{}),
+	)
+}
@@ -0,0 +1,17 @@
+package migration_20240319_0000
+
+import (
+	. "github.com/nexodus-io/nexodus/internal/database/migrations"
+)
+
+func init() {
+	migrationId := "20240319-0000"
+	CreateMigrationFromActions(migrationId,
+		// a name can only be used by one live dns record of a vpc, deleted records keep their name
+		// until they are garbage collected.
+		ExecAction(
+			`CREATE UNIQUE INDEX IF NOT EXISTS "idx_dns_records_vpc_id_name" ON "dns_records" ("vpc_id", "name") WHERE deleted_at IS NULL`,
+			`DROP INDEX IF EXISTS idx_dns_records_vpc_id_name`,
+		),
+	)
+}
@@ -0,0 +1,8 @@
+package dnsserver
+
+// Register the CoreDNS plugins that the Corefiles generated by nexd make use of.
+import (
+	_ "github.com/coredns/coredns/plugin/bind"
+	_ "github.com/coredns/coredns/plugin/forward"
+	_ "github.com/coredns/coredns/plugin/template"
+)
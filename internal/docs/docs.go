@@ -228,6 +228,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ConflictsError"
                         }
                     },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationError"
+                        }
+                    },
                     "429": {
                         "description": "Too Many Requests",
                         "schema": {
@@ -410,6 +416,220 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ConflictsError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/devices/{id}/approve": {
+            "post": {
+                "description": "Approves a device that is pending approval to join its VPC",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Devices"
+                ],
+                "summary": "Approve Device",
+                "operationId": "ApproveDevice",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Device"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/devices/{id}/denied-flows": {
+            "get": {
+                "description": "Lists the flows reported as denied by the security group of a device during the last week, most recent first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Devices"
+                ],
+                "summary": "List Denied Flows",
+                "operationId": "ListDeniedFlows",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.DeviceDeniedFlow"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Records the flows denied by the security group of a device, they are kept for a week",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Devices"
+                ],
+                "summary": "Report Denied Flows",
+                "operationId": "ReportDeniedFlows",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Denied Flows",
+                        "name": "flows",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReportDeniedFlows"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
                     "429": {
                         "description": "Too Many Requests",
                         "schema": {
@@ -633,9 +853,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/events": {
-            "post": {
-                "description": "Watches events occurring in the control plane",
+        "/api/devices/{id}/preshared-keys": {
+            "get": {
+                "description": "Gets the wireguard pre-shared keys a device uses with its peers, each key is sealed to the public key of the device",
                 "consumes": [
                     "application/json"
                 ],
@@ -643,29 +863,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Events"
+                    "Devices"
                 ],
-                "summary": "Watch events occurring in the control plane",
-                "operationId": "Watch",
+                "summary": "Get Device Pre-shared Keys",
+                "operationId": "GetDevicePresharedKeys",
                 "parameters": [
                     {
-                        "description": "List of events to watch",
-                        "name": "Watches",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Watch"
-                            }
-                        }
+                        "type": "string",
+                        "description": "Device ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.WatchEvent"
+                            "$ref": "#/definitions/models.DevicePresharedKeys"
                         }
                     },
                     "400": {
@@ -680,6 +895,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationError"
+                        }
+                    },
                     "429": {
                         "description": "Too Many Requests",
                         "schema": {
@@ -695,9 +922,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/fflags": {
-            "get": {
-                "description": "Lists all feature flags",
+        "/api/devices/{id}/reject": {
+            "post": {
+                "description": "Rejects a device that is pending approval to join its VPC, the device is deleted",
                 "consumes": [
                     "application/json"
                 ],
@@ -705,10 +932,135 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "FFlag"
+                    "Devices"
+                ],
+                "summary": "Reject Device",
+                "operationId": "RejectDevice",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "List Feature Flags",
-                "operationId": "ListFeatureFlags",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Device"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/events": {
+            "post": {
+                "description": "Watches events occurring in the control plane",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Watch events occurring in the control plane",
+                "operationId": "Watch",
+                "parameters": [
+                    {
+                        "description": "List of events to watch",
+                        "name": "Watches",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Watch"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.WatchEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/fflags": {
+            "get": {
+                "description": "Lists all feature flags",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FFlag"
+                ],
+                "summary": "List Feature Flags",
+                "operationId": "ListFeatureFlags",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -875,6 +1227,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.NotAllowedError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1300,9 +1658,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/organizations/{id}/users": {
+        "/api/organizations/{id}/audit-events": {
             "get": {
-                "description": "Lists all the users of an organization",
+                "description": "Lists the changes made to the resources of an organization",
                 "consumes": [
                     "application/json"
                 ],
@@ -1312,8 +1670,8 @@ const docTemplate = `{
                 "tags": [
                     "Organizations"
                 ],
-                "summary": "List Organization Users",
-                "operationId": "ListOrganizationUsers",
+                "summary": "List Audit Events",
+                "operationId": "ListAuditEventsInOrganization",
                 "parameters": [
                     {
                         "type": "string",
@@ -1329,16 +1687,28 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.UserOrganization"
+                                "$ref": "#/definitions/models.AuditEvent"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
                     "429": {
                         "description": "Too Many Requests",
                         "schema": {
@@ -1354,9 +1724,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/organizations/{id}/users/{uid}": {
+        "/api/organizations/{id}/roles": {
             "get": {
-                "description": "Gets a Organization User by Organization ID and User ID",
+                "description": "Lists the custom roles of an organization, the built-in roles are not listed",
                 "consumes": [
                     "application/json"
                 ],
@@ -1366,8 +1736,8 @@ const docTemplate = `{
                 "tags": [
                     "Organizations"
                 ],
-                "summary": "Get Organization User",
-                "operationId": "GetOrganizationUser",
+                "summary": "List Roles",
+                "operationId": "ListRolesInOrganization",
                 "parameters": [
                     {
                         "type": "string",
@@ -1375,20 +1745,16 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "uid",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.UserOrganization"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Role"
+                            }
                         }
                     },
                     "400": {
@@ -1423,8 +1789,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
-                "description": "Deletes an existing organization user",
+            "post": {
+                "description": "Creates a custom role that can be granted to the users of an organization",
                 "consumes": [
                     "application/json"
                 ],
@@ -1434,8 +1800,8 @@ const docTemplate = `{
                 "tags": [
                     "Organizations"
                 ],
-                "summary": "Delete a Organization User",
-                "operationId": "DeleteOrganizationUser",
+                "summary": "Create Role",
+                "operationId": "CreateRoleInOrganization",
                 "parameters": [
                     {
                         "type": "string",
@@ -1445,69 +1811,50 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "uid",
-                        "in": "path",
-                        "required": true
+                        "description": "Add Role",
+                        "name": "Role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AddRole"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.UserOrganization"
+                            "$ref": "#/definitions/models.Role"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/models.ValidationError"
+                            "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/models.InternalServerError"
+                            "$ref": "#/definitions/models.BaseError"
                         }
-                    }
-                }
-            }
-        },
-        "/api/reg-keys": {
-            "get": {
-                "description": "Lists all reg keys",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "RegKey"
-                ],
-                "summary": "List reg keys",
-                "operationId": "ListRegKeys",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.RegKey"
-                            }
+                            "$ref": "#/definitions/models.ConflictsError"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
-                            "$ref": "#/definitions/models.BaseError"
+                            "$ref": "#/definitions/models.ValidationError"
                         }
                     },
                     "429": {
@@ -1523,9 +1870,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Create a RegKey for a vpc",
+            }
+        },
+        "/api/organizations/{id}/roles/{rid}": {
+            "get": {
+                "description": "Gets a custom role of an organization by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1533,26 +1882,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "RegKey"
+                    "Organizations"
                 ],
-                "summary": "Create a RegKey",
-                "operationId": "CreateRegKey",
+                "summary": "Get Role",
+                "operationId": "GetRoleInOrganization",
                 "parameters": [
                     {
-                        "description": "Add RegKey",
-                        "name": "RegKey",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.AddRegKey"
-                        }
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role ID",
+                        "name": "rid",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.RegKey"
+                            "$ref": "#/definitions/models.Role"
                         }
                     },
                     "400": {
@@ -1561,6 +1915,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1580,11 +1940,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/reg-keys/{id}": {
-            "get": {
-                "description": "Gets a RegKey by RegKey ID",
+            },
+            "delete": {
+                "description": "Deletes a custom role, a role that is granted to users or pending invitations can not be deleted",
                 "consumes": [
                     "application/json"
                 ],
@@ -1592,24 +1950,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "RegKey"
+                    "Organizations"
                 ],
-                "summary": "Get a RegKey",
-                "operationId": "GetRegKey",
+                "summary": "Delete Role",
+                "operationId": "DeleteRoleInOrganization",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "RegKey ID",
+                        "description": "Organization ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role ID",
+                        "name": "rid",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.RegKey"
+                            "$ref": "#/definitions/models.Role"
                         }
                     },
                     "400": {
@@ -1630,6 +1995,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationError"
+                        }
+                    },
                     "429": {
                         "description": "Too Many Requests",
                         "schema": {
@@ -1644,8 +2015,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
-                "description": "Deletes an existing RegKey",
+            "patch": {
+                "description": "Updates the description and the permissions of a custom role, the users it is granted to get the new permissions",
                 "consumes": [
                     "application/json"
                 ],
@@ -1653,77 +2024,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "RegKey"
+                    "Organizations"
                 ],
-                "summary": "Delete RegKey",
-                "operationId": "DeleteRegKey",
+                "summary": "Update Role",
+                "operationId": "UpdateRoleInOrganization",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "RegKey ID",
+                        "description": "Organization ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content",
-                        "schema": {
-                            "$ref": "#/definitions/models.RegKey"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/models.BaseError"
-                        }
                     },
-                    "405": {
-                        "description": "Method Not Allowed",
-                        "schema": {
-                            "$ref": "#/definitions/models.BaseError"
-                        }
-                    },
-                    "429": {
-                        "description": "Too Many Requests",
-                        "schema": {
-                            "$ref": "#/definitions/models.BaseError"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/models.InternalServerError"
-                        }
-                    }
-                }
-            },
-            "patch": {
-                "description": "Updates a RegKey by ID",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "RegKey"
-                ],
-                "summary": "Update RegKey",
-                "operationId": "UpdateRegKey",
-                "parameters": [
                     {
                         "type": "string",
-                        "description": "RegKey ID",
-                        "name": "id",
+                        "description": "Role ID",
+                        "name": "rid",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "RegKey Update",
+                        "description": "Role Update",
                         "name": "update",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateRegKey"
+                            "$ref": "#/definitions/models.UpdateRole"
                         }
                     }
                 ],
@@ -1731,7 +2057,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.RegKey"
+                            "$ref": "#/definitions/models.Role"
                         }
                     },
                     "400": {
@@ -1773,23 +2099,27 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/security-groups": {
+        "/api/organizations/{id}/users": {
             "get": {
-                "description": "Lists all Security Groups",
+                "description": "Lists all the users of an organization",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "SecurityGroup"
+                    "Organizations"
                 ],
-                "summary": "List Security Groups",
-                "operationId": "ListSecurityGroups",
+                "summary": "List Organization Users",
+                "operationId": "ListOrganizationUsers",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "greater than revision",
-                        "name": "gt_revision",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1798,7 +2128,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.SecurityGroup"
+                                "$ref": "#/definitions/models.UserOrganization"
                             }
                         }
                     },
@@ -1821,33 +2151,43 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Adds a new Security Group",
+            }
+        },
+        "/api/organizations/{id}/users/{uid}": {
+            "get": {
+                "description": "Gets a Organization User by Organization ID and User ID",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "SecurityGroup"
+                    "Organizations"
                 ],
-                "summary": "Add SecurityGroup",
-                "operationId": "CreateSecurityGroup",
+                "summary": "Get Organization User",
+                "operationId": "GetOrganizationUser",
                 "parameters": [
                     {
-                        "description": "Add SecurityGroup",
-                        "name": "SecurityGroup",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.AddSecurityGroup"
-                        }
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "uid",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.SecurityGroup"
+                            "$ref": "#/definitions/models.UserOrganization"
                         }
                     },
                     "400": {
@@ -1862,16 +2202,10 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
-                        "schema": {
-                            "$ref": "#/definitions/models.ConflictsError"
-                        }
-                    },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/models.ValidationError"
+                            "$ref": "#/definitions/models.BaseError"
                         }
                     },
                     "429": {
@@ -1887,45 +2221,47 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/security-groups/{id}": {
-            "get": {
-                "description": "Gets a security group by ID",
+            },
+            "delete": {
+                "description": "Deletes an existing organization user",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "SecurityGroup"
+                    "Organizations"
                 ],
-                "summary": "Get SecurityGroup",
-                "operationId": "GetSecurityGroup",
+                "summary": "Delete a Organization User",
+                "operationId": "DeleteOrganizationUser",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Security Group ID",
+                        "description": "Organization ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "uid",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content",
                         "schema": {
-                            "$ref": "#/definitions/models.SecurityGroup"
+                            "$ref": "#/definitions/models.UserOrganization"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/models.BaseError"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/models.BaseError"
+                            "$ref": "#/definitions/models.ValidationError"
                         }
                     },
                     "404": {
@@ -1934,12 +2270,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "429": {
-                        "description": "Too Many Requests",
-                        "schema": {
-                            "$ref": "#/definitions/models.BaseError"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1947,35 +2277,34 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Deletes an existing SecurityGroup",
+            }
+        },
+        "/api/reg-keys": {
+            "get": {
+                "description": "Lists all reg keys",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "SecurityGroup"
-                ],
-                "summary": "Delete SecurityGroup",
-                "operationId": "DeleteSecurityGroup",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Security Group ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "RegKey"
                 ],
+                "summary": "List reg keys",
+                "operationId": "ListRegKeys",
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.SecurityGroup"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.RegKey"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.BaseError"
                         }
@@ -1994,39 +2323,35 @@ const docTemplate = `{
                     }
                 }
             },
-            "patch": {
-                "description": "Updates a Security Group by ID",
+            "post": {
+                "description": "Create a RegKey for a vpc",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "SecurityGroup"
+                    "RegKey"
                 ],
-                "summary": "Update Security Group",
-                "operationId": "UpdateSecurityGroup",
+                "summary": "Create a RegKey",
+                "operationId": "CreateRegKey",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Security Group ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Security Group Update",
-                        "name": "update",
+                        "description": "Add RegKey",
+                        "name": "RegKey",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateSecurityGroup"
+                            "$ref": "#/definitions/models.AddRegKey"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.SecurityGroup"
+                            "$ref": "#/definitions/models.RegKey"
                         }
                     },
                     "400": {
@@ -2035,24 +2360,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/models.BaseError"
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
-                        "schema": {
-                            "$ref": "#/definitions/models.ValidationError"
-                        }
-                    },
                     "429": {
                         "description": "Too Many Requests",
                         "schema": {
@@ -2068,52 +2381,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/service-networks": {
+        "/api/reg-keys/{id}": {
             "get": {
-                "description": "Lists all ServiceNetworks",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "ServiceNetwork"
-                ],
-                "summary": "List ServiceNetworks",
-                "operationId": "ListServiceNetworks",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.ServiceNetwork"
-                            }
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/models.BaseError"
-                        }
-                    },
-                    "429": {
-                        "description": "Too Many Requests",
-                        "schema": {
-                            "$ref": "#/definitions/models.BaseError"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/models.InternalServerError"
-                        }
-                    }
-                }
-            },
-            "post": {
-                "description": "Creates a named serviceNetwork with the given CIDR",
+                "description": "Gets a RegKey by RegKey ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -2121,26 +2391,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ServiceNetwork"
+                    "RegKey"
                 ],
-                "summary": "Create an ServiceNetwork",
-                "operationId": "CreateServiceNetwork",
+                "summary": "Get a RegKey",
+                "operationId": "GetRegKey",
                 "parameters": [
                     {
-                        "description": "Add ServiceNetwork",
-                        "name": "ServiceNetwork",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.AddServiceNetwork"
-                        }
+                        "type": "string",
+                        "description": "RegKey ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ServiceNetwork"
+                            "$ref": "#/definitions/models.RegKey"
                         }
                     },
                     "400": {
@@ -2155,18 +2423,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "405": {
-                        "description": "Method Not Allowed",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
-                        "schema": {
-                            "$ref": "#/definitions/models.ConflictsError"
-                        }
-                    },
                     "429": {
                         "description": "Too Many Requests",
                         "schema": {
@@ -2180,11 +2442,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/service-networks/{id}": {
-            "get": {
-                "description": "Gets a ServiceNetwork by ServiceNetwork ID",
+            },
+            "delete": {
+                "description": "Deletes an existing RegKey",
                 "consumes": [
                     "application/json"
                 ],
@@ -2192,24 +2452,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ServiceNetwork"
+                    "RegKey"
                 ],
-                "summary": "Get ServiceNetworks",
-                "operationId": "GetServiceNetwork",
+                "summary": "Delete RegKey",
+                "operationId": "DeleteRegKey",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ServiceNetwork ID",
+                        "description": "RegKey ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content",
                         "schema": {
-                            "$ref": "#/definitions/models.ServiceNetwork"
+                            "$ref": "#/definitions/models.RegKey"
                         }
                     },
                     "400": {
@@ -2218,14 +2478,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/models.BaseError"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                    "405": {
+                        "description": "Method Not Allowed",
                         "schema": {
                             "$ref": "#/definitions/models.BaseError"
                         }
@@ -2244,33 +2498,39 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
-                "description": "Deletes an existing serviceNetwork",
-                "consumes": [
-                    "application/json"
-                ],
+            "patch": {
+                "description": "Updates a RegKey by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ServiceNetwork"
+                    "RegKey"
                 ],
-                "summary": "Delete ServiceNetwork",
-                "operationId": "DeleteServiceNetwork",
+                "summary": "Update RegKey",
+                "operationId": "UpdateRegKey",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ServiceNetwork ID",
+                        "description": "RegKey ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "RegKey Update",
+                        "name": "update",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateRegKey"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ServiceNetwork"
+                            "$ref": "#/definitions/models.RegKey"
                         }
                     },
                     "400": {
@@ -2279,12 +2539,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "405": {
-                        "description": "Method Not Allowed",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationError"
+                        }
+                    },
                     "429": {
                         "description": "Too Many Requests",
                         "schema": {
@@ -2298,9 +2570,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Updates a serviceNetwork by ID",
+            }
+        },
+        "/api/reg-keys/{id}/devices": {
+            "get": {
+                "description": "Lists the devices that were registered with a RegKey",
                 "consumes": [
                     "application/json"
                 ],
@@ -2308,33 +2582,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ServiceNetwork"
+                    "RegKey"
                 ],
-                "summary": "Update ServiceNetworks",
-                "operationId": "UpdateServiceNetwork",
+                "summary": "List the devices of a RegKey",
+                "operationId": "ListDevicesForRegKey",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ServiceNetwork ID",
+                        "description": "RegKey ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "ServiceNetwork Update",
-                        "name": "update",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UpdateServiceNetwork"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ServiceNetwork"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Device"
+                            }
                         }
                     },
                     "400": {
@@ -2370,27 +2638,32 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/sites": {
+        "/api/security-groups": {
             "get": {
-                "description": "Lists all sites",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Lists all Security Groups",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Sites"
+                    "SecurityGroup"
+                ],
+                "summary": "List Security Groups",
+                "operationId": "ListSecurityGroups",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "greater than revision",
+                        "name": "gt_revision",
+                        "in": "query"
+                    }
                 ],
-                "summary": "List Sites",
-                "operationId": "ListSites",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Site"
+                                "$ref": "#/definitions/models.SecurityGroup"
                             }
                         }
                     },
@@ -2415,26 +2688,23 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Adds a new site",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Adds a new Security Group",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Sites"
+                    "SecurityGroup"
                 ],
-                "summary": "Add Sites",
-                "operationId": "CreateSite",
+                "summary": "Add SecurityGroup",
+                "operationId": "CreateSecurityGroup",
                 "parameters": [
                     {
-                        "description": "Add Site",
-                        "name": "Site",
+                        "description": "Add SecurityGroup",
+                        "name": "SecurityGroup",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.AddSite"
+                            "$ref": "#/definitions/models.AddSecurityGroup"
                         }
                     }
                 ],
@@ -2442,7 +2712,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.Site"
+                            "$ref": "#/definitions/models.SecurityGroup"
                         }
                     },
                     "400": {
@@ -2463,6 +2733,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ConflictsError"
                         }
                     },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationError"
+                        }
+                    },
                     "429": {
                         "description": "Too Many Requests",
                         "schema": {
@@ -2478,24 +2754,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/sites/{id}": {
+        "/api/security-groups/{id}": {
             "get": {
-                "description": "Gets a site by ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Gets a security group by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Sites"
+                    "SecurityGroup"
                 ],
-                "summary": "Get Sites",
-                "operationId": "GetSite",
+                "summary": "Get SecurityGroup",
+                "operationId": "GetSecurityGroup",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Site ID",
+                        "description": "Security Group ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2505,7 +2778,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Site"
+                            "$ref": "#/definitions/models.SecurityGroup"
                         }
                     },
                     "400": {
@@ -2541,22 +2814,19 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Deletes an existing site and associated IPAM lease",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Deletes an existing SecurityGroup",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Sites"
+                    "SecurityGroup"
                 ],
-                "summary": "Delete Site",
-                "operationId": "DeleteSite",
+                "summary": "Delete SecurityGroup",
+                "operationId": "DeleteSecurityGroup",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Site ID",
+                        "description": "Security Group ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2566,7 +2836,7 @@ const docTemplate = `{
                     "204": {
                         "description": "No Content",
                         "schema": {
-                            "$ref": "#/definitions/models.Site"
+                            "$ref": "#/definitions/models.SecurityGroup"
                         }
                     },
                     "400": {
@@ -2590,33 +2860,30 @@ const docTemplate = `{
                 }
             },
             "patch": {
-                "description": "Updates a site by ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Updates a Security Group by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Sites"
+                    "SecurityGroup"
                 ],
-                "summary": "Update Sites",
-                "operationId": "UpdateSite",
+                "summary": "Update Security Group",
+                "operationId": "UpdateSecurityGroup",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Site ID",
+                        "description": "Security Group ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Site Update",
+                        "description": "Security Group Update",
                         "name": "update",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateSite"
+                            "$ref": "#/definitions/models.UpdateSecurityGroup"
                         }
                     }
                 ],
@@ -2624,7 +2891,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Site"
+                            "$ref": "#/definitions/models.SecurityGroup"
                         }
                     },
                     "400": {
@@ -2645,6 +2912,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationError"
+                        }
+                    },
                     "429": {
                         "description": "Too Many Requests",
                         "schema": {
@@ -2660,9 +2933,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/users": {
+        "/api/service-networks": {
             "get": {
-                "description": "Lists all users",
+                "description": "Lists all ServiceNetworks",
                 "consumes": [
                     "application/json"
                 ],
@@ -2670,17 +2943,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "ServiceNetwork"
                 ],
-                "summary": "List Users",
-                "operationId": "ListUsers",
+                "summary": "List ServiceNetworks",
+                "operationId": "ListServiceNetworks",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.User"
+                                "$ref": "#/definitions/models.ServiceNetwork"
                             }
                         }
                     },
@@ -2703,11 +2976,80 @@ const docTemplate = `{
                         }
                     }
                 }
+            },
+            "post": {
+                "description": "Creates a named serviceNetwork with the given CIDR",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ServiceNetwork"
+                ],
+                "summary": "Create an ServiceNetwork",
+                "operationId": "CreateServiceNetwork",
+                "parameters": [
+                    {
+                        "description": "Add ServiceNetwork",
+                        "name": "ServiceNetwork",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AddServiceNetwork"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.ServiceNetwork"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "405": {
+                        "description": "Method Not Allowed",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ConflictsError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
             }
         },
-        "/api/users/{id}": {
+        "/api/service-networks/{id}": {
             "get": {
-                "description": "Gets a user",
+                "description": "Gets a ServiceNetwork by ServiceNetwork ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -2715,14 +3057,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "ServiceNetwork"
                 ],
-                "summary": "Get User",
-                "operationId": "GetUser",
+                "summary": "Get ServiceNetworks",
+                "operationId": "GetServiceNetwork",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "ServiceNetwork ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2732,7 +3074,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "$ref": "#/definitions/models.ServiceNetwork"
                         }
                     },
                     "400": {
@@ -2768,7 +3110,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete a user",
+                "description": "Deletes an existing serviceNetwork",
                 "consumes": [
                     "application/json"
                 ],
@@ -2776,36 +3118,1752 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "ServiceNetwork"
                 ],
-                "summary": "Delete User",
-                "operationId": "DeleteUser",
+                "summary": "Delete ServiceNetwork",
+                "operationId": "DeleteServiceNetwork",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "ServiceNetwork ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "$ref": "#/definitions/models.ServiceNetwork"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "405": {
+                        "description": "Method Not Allowed",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Updates a serviceNetwork by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ServiceNetwork"
+                ],
+                "summary": "Update ServiceNetworks",
+                "operationId": "UpdateServiceNetwork",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ServiceNetwork ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "ServiceNetwork Update",
+                        "name": "update",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateServiceNetwork"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "$ref": "#/definitions/models.ServiceNetwork"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/models.NotAllowedError"
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
                         }
                     },
                     "429": {
                         "description": "Too Many Requests",
                         "schema": {
-                            "$ref": "#/definitions/models.BaseError"
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/sites": {
+            "get": {
+                "description": "Lists all sites",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sites"
+                ],
+                "summary": "List Sites",
+                "operationId": "ListSites",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Site"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a new site",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sites"
+                ],
+                "summary": "Add Sites",
+                "operationId": "CreateSite",
+                "parameters": [
+                    {
+                        "description": "Add Site",
+                        "name": "Site",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AddSite"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Site"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ConflictsError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/sites/{id}": {
+            "get": {
+                "description": "Gets a site by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sites"
+                ],
+                "summary": "Get Sites",
+                "operationId": "GetSite",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Site ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Site"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes an existing site and associated IPAM lease",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sites"
+                ],
+                "summary": "Delete Site",
+                "operationId": "DeleteSite",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Site ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "$ref": "#/definitions/models.Site"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Updates a site by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sites"
+                ],
+                "summary": "Update Sites",
+                "operationId": "UpdateSite",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Site ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Site Update",
+                        "name": "update",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateSite"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Site"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users": {
+            "get": {
+                "description": "Lists all users",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "List Users",
+                "operationId": "ListUsers",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.User"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/{id}": {
+            "get": {
+                "description": "Gets a user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get User",
+                "operationId": "GetUser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Delete User",
+                "operationId": "DeleteUser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.NotAllowedError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/{id}/organizations/{organization}": {
+            "delete": {
+                "description": "Deletes an existing organization associated to a user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Remove a User from an Organization",
+                "operationId": "DeleteUserFromOrganization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/{id}/tokens": {
+            "get": {
+                "description": "Lists the API tokens of the user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "List API tokens",
+                "operationId": "ListAPITokens",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.APIToken"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a long-lived API token for the user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Create an API token",
+                "operationId": "CreateAPIToken",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Add API Token",
+                        "name": "token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AddAPIToken"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIToken"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.NotAllowedError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/{id}/tokens/{token_id}": {
+            "get": {
+                "description": "Gets an API token of the user by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get API token",
+                "operationId": "GetAPIToken",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API Token ID",
+                        "name": "token_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIToken"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Revokes an API token of the user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Revoke API token",
+                "operationId": "DeleteAPIToken",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API Token ID",
+                        "name": "token_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIToken"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/vpc/{id}/events": {
+            "post": {
+                "description": "Watches events occurring in the vpc",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VPC"
+                ],
+                "summary": "Watch events occurring in the vpc",
+                "operationId": "WatchEvents",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "connect as the device with the given public key, device will be considered to be online for the duration of this request",
+                        "name": "public_key",
+                        "in": "query"
+                    },
+                    {
+                        "description": "List of events to watch",
+                        "name": "Watches",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Watch"
+                            }
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "VPC ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.WatchEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/vpcs": {
+            "get": {
+                "description": "Lists all VPCs",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VPC"
+                ],
+                "summary": "List VPCs",
+                "operationId": "ListVPCs",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.VPC"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a named vpc with the given CIDR",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VPC"
+                ],
+                "summary": "Create an VPC",
+                "operationId": "CreateVPC",
+                "parameters": [
+                    {
+                        "description": "Add VPC",
+                        "name": "VPC",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AddVPC"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.VPC"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "405": {
+                        "description": "Method Not Allowed",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ConflictsError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/vpcs/{id}": {
+            "get": {
+                "description": "Gets a VPC by VPC ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VPC"
+                ],
+                "summary": "Get VPCs",
+                "operationId": "GetVPC",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "VPC ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.VPC"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes an existing vpc and associated IPAM prefix",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VPC"
+                ],
+                "summary": "Delete VPC",
+                "operationId": "DeleteVPC",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "VPC ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "$ref": "#/definitions/models.VPC"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "405": {
+                        "description": "Method Not Allowed",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Updates a vpc by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VPC"
+                ],
+                "summary": "Update VPCs",
+                "operationId": "UpdateVPC",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "VPC ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "VPC Update",
+                        "name": "update",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateVPC"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.VPC"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/vpcs/{id}/devices": {
+            "get": {
+                "description": "Lists all devices for this VPC",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VPC"
+                ],
+                "summary": "List Devices",
+                "operationId": "ListDevicesInVPC",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "greater than revision",
+                        "name": "gt_revision",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "VPC ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Device"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/vpcs/{id}/dns-records": {
+            "get": {
+                "description": "Lists all the custom DNS records in a VPC",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VPC"
+                ],
+                "summary": "List DNS Records in a VPC",
+                "operationId": "ListDNSRecordsInVPC",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "greater than revision",
+                        "name": "gt_revision",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "VPC ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.DNSRecord"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a new custom DNS record to a VPC",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VPC"
+                ],
+                "summary": "Add DNS Record",
+                "operationId": "CreateDNSRecordInVPC",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "VPC ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Add DNS Record",
+                        "name": "DNSRecord",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AddDNSRecord"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.DNSRecord"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ConflictsError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/vpcs/{id}/dns-records/{record_id}": {
+            "get": {
+                "description": "Gets a DNS record in a VPC by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VPC"
+                ],
+                "summary": "Get DNS Record",
+                "operationId": "GetDNSRecordInVPC",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "VPC ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "DNS Record ID",
+                        "name": "record_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.DNSRecord"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a DNS record from a VPC",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VPC"
+                ],
+                "summary": "Delete DNS Record",
+                "operationId": "DeleteDNSRecordInVPC",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "VPC ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "DNS Record ID",
+                        "name": "record_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.DNSRecord"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Updates a DNS record in a VPC by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VPC"
+                ],
+                "summary": "Update DNS Record",
+                "operationId": "UpdateDNSRecordInVPC",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "VPC ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "DNS Record ID",
+                        "name": "record_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "DNS Record Update",
+                        "name": "update",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateDNSRecord"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.DNSRecord"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/vpcs/{id}/metadata": {
+            "get": {
+                "description": "Lists metadata for a device",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "VPC"
+                ],
+                "summary": "List Device Metadata",
+                "operationId": "ListMetadataInVPC",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "VPC ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "greater than revision",
+                        "name": "gt_revision",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "used to filter down to the specified key prefixes",
+                        "name": "prefix",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "used to filter down to the specified key",
+                        "name": "key",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.DeviceMetadata"
+                            }
                         }
                     },
                     "500": {
@@ -2817,9 +4875,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/users/{id}/organizations/{organization}": {
-            "delete": {
-                "description": "Deletes an existing organization associated to a user",
+        "/api/vpcs/{id}/routes": {
+            "get": {
+                "description": "Lists the cidrs advertised by the devices of a VPC, along with the device that is the primary router of each cidr and the standby devices that take over when it goes offline",
                 "consumes": [
                     "application/json"
                 ],
@@ -2827,31 +4885,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "VPC"
                 ],
-                "summary": "Remove a User from an Organization",
-                "operationId": "DeleteUserFromOrganization",
+                "summary": "List Routes in a VPC",
+                "operationId": "ListRoutesInVPC",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "VPC ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Organization ID",
-                        "name": "organization",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.AdvertisedRoute"
+                            }
                         }
                     },
                     "400": {
@@ -2860,6 +4914,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2869,39 +4941,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/vpc/{id}/events": {
-            "post": {
-                "description": "Watches events occurring in the vpc",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/vpcs/{id}/security-groups": {
+            "get": {
+                "description": "Lists all Security Groups in a VPC",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "VPC"
                 ],
-                "summary": "Watch events occurring in the vpc",
-                "operationId": "WatchEvents",
+                "summary": "List Security Groups in a VPC",
+                "operationId": "ListSecurityGroupsInVPC",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "connect as the device with the given public key, device will be considered to be online for the duration of this request",
-                        "name": "public_key",
+                        "type": "integer",
+                        "description": "greater than revision",
+                        "name": "gt_revision",
                         "in": "query"
                     },
-                    {
-                        "description": "List of events to watch",
-                        "name": "Watches",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Watch"
-                            }
-                        }
-                    },
                     {
                         "type": "string",
                         "description": "VPC ID",
@@ -2914,13 +4971,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.WatchEvent"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/models.BaseError"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.SecurityGroup"
+                            }
                         }
                     },
                     "401": {
@@ -2944,27 +4998,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/vpcs": {
+        "/api/webhooks": {
             "get": {
-                "description": "Lists all VPCs",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Lists the webhooks of the organizations of the user",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "VPC"
+                    "Webhooks"
                 ],
-                "summary": "List VPCs",
-                "operationId": "ListVPCs",
+                "summary": "List Webhooks",
+                "operationId": "ListWebhooks",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.VPC"
+                                "$ref": "#/definitions/models.Webhook"
                             }
                         }
                     },
@@ -2989,26 +5040,23 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Creates a named vpc with the given CIDR",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Creates a webhook that the change events of the resources of an organization are delivered to",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "VPC"
+                    "Webhooks"
                 ],
-                "summary": "Create an VPC",
-                "operationId": "CreateVPC",
+                "summary": "Create Webhook",
+                "operationId": "CreateWebhook",
                 "parameters": [
                     {
-                        "description": "Add VPC",
-                        "name": "VPC",
+                        "description": "Add Webhook",
+                        "name": "Webhook",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.AddVPC"
+                            "$ref": "#/definitions/models.AddWebhook"
                         }
                     }
                 ],
@@ -3016,7 +5064,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.VPC"
+                            "$ref": "#/definitions/models.Webhook"
                         }
                     },
                     "400": {
@@ -3031,16 +5079,16 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "405": {
-                        "description": "Method Not Allowed",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
-                            "$ref": "#/definitions/models.ConflictsError"
+                            "$ref": "#/definitions/models.ValidationError"
                         }
                     },
                     "429": {
@@ -3058,24 +5106,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/vpcs/{id}": {
+        "/api/webhooks/{id}": {
             "get": {
-                "description": "Gets a VPC by VPC ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Gets a webhook by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "VPC"
+                    "Webhooks"
                 ],
-                "summary": "Get VPCs",
-                "operationId": "GetVPC",
+                "summary": "Get Webhook",
+                "operationId": "GetWebhook",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "VPC ID",
+                        "description": "Webhook ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -3085,7 +5130,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.VPC"
+                            "$ref": "#/definitions/models.Webhook"
                         }
                     },
                     "400": {
@@ -3121,32 +5166,29 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Deletes an existing vpc and associated IPAM prefix",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Deletes a webhook, its pending deliveries are not retried",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "VPC"
+                    "Webhooks"
                 ],
-                "summary": "Delete VPC",
-                "operationId": "DeleteVPC",
+                "summary": "Delete Webhook",
+                "operationId": "DeleteWebhook",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "VPC ID",
+                        "description": "Webhook ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.VPC"
+                            "$ref": "#/definitions/models.Webhook"
                         }
                     },
                     "400": {
@@ -3155,8 +5197,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "405": {
-                        "description": "Method Not Allowed",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.BaseError"
                         }
@@ -3176,33 +5224,30 @@ const docTemplate = `{
                 }
             },
             "patch": {
-                "description": "Updates a vpc by ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Updates a webhook by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "VPC"
+                    "Webhooks"
                 ],
-                "summary": "Update VPCs",
-                "operationId": "UpdateVPC",
+                "summary": "Update Webhook",
+                "operationId": "UpdateWebhook",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "VPC ID",
+                        "description": "Webhook ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "VPC Update",
+                        "description": "Webhook Update",
                         "name": "update",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateVPC"
+                            "$ref": "#/definitions/models.UpdateWebhook"
                         }
                     }
                 ],
@@ -3210,7 +5255,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.VPC"
+                            "$ref": "#/definitions/models.Webhook"
                         }
                     },
                     "400": {
@@ -3231,6 +5276,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationError"
+                        }
+                    },
                     "429": {
                         "description": "Too Many Requests",
                         "schema": {
@@ -3246,30 +5297,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/vpcs/{id}/devices": {
+        "/api/webhooks/{id}/deliveries": {
             "get": {
-                "description": "Lists all devices for this VPC",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Lists the delivery log of a webhook, most recent first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "VPC"
+                    "Webhooks"
                 ],
-                "summary": "List Devices",
-                "operationId": "ListDevicesInVPC",
+                "summary": "List Webhook Deliveries",
+                "operationId": "ListWebhookDeliveries",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "greater than revision",
-                        "name": "gt_revision",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "VPC ID",
+                        "description": "Webhook ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -3281,7 +5323,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Device"
+                                "$ref": "#/definitions/models.WebhookDelivery"
                             }
                         }
                     },
@@ -3297,74 +5339,16 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
-                    "429": {
-                        "description": "Too Many Requests",
-                        "schema": {
-                            "$ref": "#/definitions/models.BaseError"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/models.InternalServerError"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/vpcs/{id}/metadata": {
-            "get": {
-                "description": "Lists metadata for a device",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "VPC"
-                ],
-                "summary": "List Device Metadata",
-                "operationId": "ListMetadataInVPC",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "VPC ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "greater than revision",
-                        "name": "gt_revision",
-                        "in": "query"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "used to filter down to the specified key prefixes",
-                        "name": "prefix",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "used to filter down to the specified key",
-                        "name": "key",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.DeviceMetadata"
-                            }
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
                         }
                     },
                     "500": {
@@ -3376,27 +5360,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/vpcs/{id}/security-groups": {
-            "get": {
-                "description": "Lists all Security Groups in a VPC",
+        "/api/webhooks/{id}/test": {
+            "post": {
+                "description": "Delivers a test event to the webhook and returns the result of the delivery",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "VPC"
+                    "Webhooks"
                 ],
-                "summary": "List Security Groups in a VPC",
-                "operationId": "ListSecurityGroupsInVPC",
+                "summary": "Test Webhook",
+                "operationId": "TestWebhook",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "greater than revision",
-                        "name": "gt_revision",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "VPC ID",
+                        "description": "Webhook ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -3406,10 +5384,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.SecurityGroup"
-                            }
+                            "$ref": "#/definitions/models.WebhookDelivery"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
                         }
                     },
                     "401": {
@@ -3418,6 +5399,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.BaseError"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.BaseError"
+                        }
+                    },
                     "429": {
                         "description": "Too Many Requests",
                         "schema": {
@@ -3699,6 +5686,91 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "models.APIToken": {
+            "type": "object",
+            "properties": {
+                "bearer_token": {
+                    "description": "BearerToken is the token to send in the authorization header, it is only returned when the token is created.",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "Description of the token.",
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "ExpiresAt is optional, if set the token is only valid until the ExpiresAt time.",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "aa22666c-0f57-45cb-a449-16efecc04f2e"
+                },
+                "last_used_at": {
+                    "description": "LastUsedAt is the last time the token was used to access the API.",
+                    "type": "string"
+                },
+                "scopes": {
+                    "description": "Scopes are the API scopes the token grants.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "read:organizations",
+                        "read:devices"
+                    ]
+                },
+                "user_id": {
+                    "description": "UserID is the ID of the user the token acts on behalf of.",
+                    "type": "string"
+                }
+            }
+        },
+        "models.AddAPIToken": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "description": "Description of the token.",
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "ExpiresAt is optional, if set the token is only valid until the ExpiresAt time.",
+                    "type": "string"
+                },
+                "scopes": {
+                    "description": "Scopes are the API scopes the token grants.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "read:organizations",
+                        "read:devices"
+                    ]
+                }
+            }
+        },
+        "models.AddDNSRecord": {
+            "type": "object",
+            "properties": {
+                "addresses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "100.64.0.1"
+                    ]
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "db.prod"
+                }
+            }
+        },
         "models.AddDevice": {
             "type": "object",
             "properties": {
@@ -3727,6 +5799,12 @@ const docTemplate = `{
                         "$ref": "#/definitions/models.TunnelIP"
                     }
                 },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
                 "os": {
                     "type": "string"
                 },
@@ -3786,14 +5864,45 @@ const docTemplate = `{
         "models.AddRegKey": {
             "type": "object",
             "properties": {
+                "allowed_source_cidrs": {
+                    "description": "AllowedSourceCidrs is optional, if set the registration key can only be used from these networks.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "description": {
                     "description": "Description of the registration key.",
                     "type": "string"
                 },
+                "device_labels": {
+                    "description": "DeviceLabels are added to the labels of the devices registered with the registration key.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "device_metadata": {
+                    "description": "DeviceMetadata is copied to the metadata of the devices registered with the registration key.",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "ephemeral": {
+                    "description": "Ephemeral devices are deleted after they have been offline for the ephemeral device grace period.",
+                    "type": "boolean"
+                },
                 "expires_at": {
                     "description": "ExpiresAt is optional, if set the registration key is only valid until the ExpiresAt time.",
                     "type": "string"
                 },
+                "hostname_template": {
+                    "description": "HostnameTemplate is optional, if set it is used to name the devices registered with the registration key, it is a Go template with the .Hostname and .Index fields.",
+                    "type": "string"
+                },
+                "max_uses": {
+                    "description": "MaxUses is optional, if set the registration key can only register that many devices.",
+                    "type": "integer"
+                },
                 "security_group_id": {
                     "description": "SecurityGroupId is the ID of the security group to assign to the device.",
                     "type": "string"
@@ -3817,6 +5926,27 @@ const docTemplate = `{
                 }
             }
         },
+        "models.AddRole": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "auditor"
+                },
+                "permissions": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
         "models.AddSecurityGroup": {
             "type": "object",
             "properties": {
@@ -3889,8 +6019,113 @@ const docTemplate = `{
                 "organization_id": {
                     "type": "string"
                 },
+                "preshared_keys": {
+                    "description": "PresharedKeys adds a wireguard pre-shared key to the peering of every pair of devices in the VPC.",
+                    "type": "boolean"
+                },
                 "private_cidr": {
                     "type": "boolean"
+                },
+                "require_device_approval": {
+                    "description": "RequireDeviceApproval makes the new devices of the VPC pending until they are approved.",
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.AddWebhook": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "event_kinds": {
+                    "description": "EventKinds filters the kinds of events delivered, all kinds are delivered when empty.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "device",
+                        "security-group"
+                    ]
+                },
+                "organization_id": {
+                    "type": "string"
+                },
+                "secret": {
+                    "description": "Secret is the HMAC key the payloads are signed with, a random secret is generated when not set.",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/nexodus-events"
+                }
+            }
+        },
+        "models.AdvertisedRoute": {
+            "type": "object",
+            "properties": {
+                "cidr": {
+                    "type": "string",
+                    "example": "172.16.42.0/24"
+                },
+                "primary_device_id": {
+                    "type": "string"
+                },
+                "standby_device_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.AuditEvent": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "update"
+                },
+                "actor_device_id": {
+                    "description": "ActorDeviceID is set when the change was made with a device token.",
+                    "type": "string"
+                },
+                "actor_user_id": {
+                    "type": "string"
+                },
+                "after": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "before": {
+                    "description": "Before and After hold the fields of the resource that were changed, before and after the change.",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "aa22666c-0f57-45cb-a449-16efecc04f2e"
+                },
+                "organization_id": {
+                    "type": "string"
+                },
+                "resource_id": {
+                    "type": "string"
+                },
+                "resource_kind": {
+                    "type": "string",
+                    "example": "security-groups"
+                },
+                "source_ip": {
+                    "type": "string",
+                    "example": "192.0.2.10"
+                },
+                "token_scope": {
+                    "type": "string"
                 }
             }
         },
@@ -3943,16 +6178,76 @@ const docTemplate = `{
                 }
             }
         },
-        "models.ConflictsError": {
+        "models.ConflictsError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "something bad"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "a1fae5de-dd96-4b20-8362-95f6a574c4b1"
+                }
+            }
+        },
+        "models.DNSRecord": {
+            "type": "object",
+            "properties": {
+                "addresses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "100.64.0.1"
+                    ]
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "aa22666c-0f57-45cb-a449-16efecc04f2e"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "db.prod"
+                },
+                "revision": {
+                    "type": "integer"
+                },
+                "vpc_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DeniedFlow": {
             "type": "object",
             "properties": {
-                "error": {
+                "destination": {
                     "type": "string",
-                    "example": "something bad"
+                    "example": "100.64.0.1"
                 },
-                "id": {
+                "direction": {
                     "type": "string",
-                    "example": "a1fae5de-dd96-4b20-8362-95f6a574c4b1"
+                    "example": "inbound"
+                },
+                "port": {
+                    "description": "Port is the destination port of the tcp and udp packets.",
+                    "type": "integer",
+                    "example": 22
+                },
+                "protocol": {
+                    "type": "string",
+                    "example": "tcp"
+                },
+                "source": {
+                    "type": "string",
+                    "example": "100.64.0.2"
+                },
+                "time": {
+                    "type": "string"
                 }
             }
         },
@@ -3981,6 +6276,10 @@ const docTemplate = `{
                         "$ref": "#/definitions/models.Endpoint"
                     }
                 },
+                "ephemeral": {
+                    "description": "ephemeral devices are deleted after they have been offline for the ephemeral device grace period.",
+                    "type": "boolean"
+                },
                 "hostname": {
                     "type": "string"
                 },
@@ -4000,6 +6299,12 @@ const docTemplate = `{
                         "$ref": "#/definitions/models.TunnelIP"
                     }
                 },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
                 "online": {
                     "type": "boolean"
                 },
@@ -4012,6 +6317,17 @@ const docTemplate = `{
                 "owner_id": {
                     "type": "string"
                 },
+                "pending": {
+                    "description": "pending devices wait for approval before they are served to the other devices of the VPC.",
+                    "type": "boolean"
+                },
+                "primary_cidrs": {
+                    "description": "PrimaryCidrs are the advertised cidrs the device is the primary router for in its VPC.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "public_key": {
                     "type": "string"
                 },
@@ -4033,6 +6349,47 @@ const docTemplate = `{
                 }
             }
         },
+        "models.DeviceDeniedFlow": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "destination": {
+                    "type": "string",
+                    "example": "100.64.0.1"
+                },
+                "device_id": {
+                    "type": "string"
+                },
+                "direction": {
+                    "type": "string",
+                    "example": "inbound"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "aa22666c-0f57-45cb-a449-16efecc04f2e"
+                },
+                "organization_id": {
+                    "type": "string"
+                },
+                "port": {
+                    "type": "integer",
+                    "example": 22
+                },
+                "protocol": {
+                    "type": "string",
+                    "example": "tcp"
+                },
+                "source": {
+                    "type": "string",
+                    "example": "100.64.0.2"
+                },
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
         "models.DeviceMetadata": {
             "type": "object",
             "properties": {
@@ -4048,6 +6405,25 @@ const docTemplate = `{
                 "value": {}
             }
         },
+        "models.DevicePresharedKeys": {
+            "type": "object",
+            "properties": {
+                "device_id": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "description": "Enabled is set when the VPC of the device uses pre-shared keys.",
+                    "type": "boolean"
+                },
+                "preshared_keys": {
+                    "description": "PresharedKeys maps the IDs of the peer devices to the pre-shared key of the pair, sealed to the public key of the device.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "models.DeviceStartResponse": {
             "type": "object",
             "properties": {
@@ -4213,6 +6589,13 @@ const docTemplate = `{
         "models.RegKey": {
             "type": "object",
             "properties": {
+                "allowed_source_cidrs": {
+                    "description": "AllowedSourceCidrs is optional, if set the registration key can only be used from these networks.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "bearer_token": {
                     "description": "BearerToken is the bearer token the client should use to authenticate the device registration request.",
                     "type": "string"
@@ -4225,18 +6608,46 @@ const docTemplate = `{
                     "description": "DeviceId is set if the RegKey was created for single use",
                     "type": "string"
                 },
+                "device_labels": {
+                    "description": "DeviceLabels are added to the labels of the devices registered with the registration key.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "device_metadata": {
+                    "description": "DeviceMetadata is copied to the metadata of the devices registered with the registration key.",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "ephemeral": {
+                    "description": "Ephemeral devices are deleted after they have been offline for the ephemeral device grace period.",
+                    "type": "boolean"
+                },
                 "expires_at": {
                     "description": "ExpiresAt is optional, if set the registration key is only valid until the ExpiresAt time.",
                     "type": "string"
                 },
+                "hostname_template": {
+                    "description": "HostnameTemplate is optional, if set it is used to name the devices registered with the registration key.",
+                    "type": "string"
+                },
                 "id": {
                     "type": "string",
                     "example": "aa22666c-0f57-45cb-a449-16efecc04f2e"
                 },
+                "max_uses": {
+                    "description": "MaxUses is optional, if set the registration key can only register that many devices.",
+                    "type": "integer"
+                },
                 "owner_id": {
                     "description": "OwnerID is the ID of the user that created the registration key.",
                     "type": "string"
                 },
+                "remaining_uses": {
+                    "description": "RemainingUses is the number of devices that can still be registered when MaxUses is set.",
+                    "type": "integer"
+                },
                 "security_group_id": {
                     "description": "SecurityGroupId is the ID of the security group to assign to the device.",
                     "type": "string"
@@ -4250,12 +6661,56 @@ const docTemplate = `{
                     "type": "object",
                     "additionalProperties": true
                 },
+                "uses": {
+                    "description": "Uses is the number of devices registered with the registration key.",
+                    "type": "integer"
+                },
                 "vpc_id": {
                     "description": "VpcID is the ID of the VPC the device can join.",
                     "type": "string"
                 }
             }
         },
+        "models.ReportDeniedFlows": {
+            "type": "object",
+            "properties": {
+                "denied_flows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DeniedFlow"
+                    }
+                }
+            }
+        },
+        "models.Role": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "aa22666c-0f57-45cb-a449-16efecc04f2e"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "auditor"
+                },
+                "organization_id": {
+                    "type": "string"
+                },
+                "permissions": {
+                    "description": "Permissions holds the verbs the role allows on each resource type",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
         "models.SecurityGroup": {
             "type": "object",
             "properties": {
@@ -4301,6 +6756,11 @@ const docTemplate = `{
                         "type": "string"
                     }
                 },
+                "peer_selector": {
+                    "description": "PeerSelector matches the tunnel IPs of the devices in the VPC that carry all the labels, e.g. role=db,env=prod",
+                    "type": "string",
+                    "example": "role=db,env=prod"
+                },
                 "to_port": {
                     "type": "integer"
                 }
@@ -4393,6 +6853,23 @@ const docTemplate = `{
                 }
             }
         },
+        "models.UpdateDNSRecord": {
+            "type": "object",
+            "properties": {
+                "addresses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "100.64.0.1"
+                    ]
+                },
+                "description": {
+                    "type": "string"
+                }
+            }
+        },
         "models.UpdateDevice": {
             "type": "object",
             "properties": {
@@ -4415,6 +6892,16 @@ const docTemplate = `{
                     "type": "string",
                     "example": "myhost"
                 },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "public_key": {
+                    "description": "PublicKey rotates the wireguard key of the device, the revision of the device must be given with it.",
+                    "type": "string"
+                },
                 "relay": {
                     "type": "boolean"
                 },
@@ -4436,14 +6923,41 @@ const docTemplate = `{
         "models.UpdateRegKey": {
             "type": "object",
             "properties": {
+                "allowed_source_cidrs": {
+                    "description": "AllowedSourceCidrs is optional, if set the registration key can only be used from these networks, an empty list removes the restriction.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "description": {
                     "description": "Description of the registration key.",
                     "type": "string"
                 },
+                "device_labels": {
+                    "description": "DeviceLabels are added to the labels of the devices registered with the registration key.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "device_metadata": {
+                    "description": "DeviceMetadata is copied to the metadata of the devices registered with the registration key.",
+                    "type": "object",
+                    "additionalProperties": true
+                },
                 "expires_at": {
                     "description": "ExpiresAt is optional, if set the registration key is only valid until the ExpiresAt time.",
                     "type": "string"
                 },
+                "hostname_template": {
+                    "description": "HostnameTemplate is optional, if set it is used to name the devices registered with the registration key, an empty string removes it.",
+                    "type": "string"
+                },
+                "max_uses": {
+                    "description": "MaxUses is optional, if set the registration key can only register that many devices, 0 removes the limit.",
+                    "type": "integer"
+                },
                 "security_group_id": {
                     "description": "SecurityGroupId is the ID of the security group to assign to the device.",
                     "type": "string"
@@ -4455,6 +6969,23 @@ const docTemplate = `{
                 }
             }
         },
+        "models.UpdateRole": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "permissions": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
         "models.UpdateSecurityGroup": {
             "type": "object",
             "properties": {
@@ -4508,6 +7039,37 @@ const docTemplate = `{
                 "description": {
                     "type": "string",
                     "example": "The Red Zone"
+                },
+                "preshared_keys": {
+                    "type": "boolean"
+                },
+                "require_device_approval": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.UpdateWebhook": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "event_kinds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "device",
+                        "security-group"
+                    ]
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/nexodus-events"
                 }
             }
         },
@@ -4597,9 +7159,17 @@ const docTemplate = `{
                 "organization_id": {
                     "type": "string"
                 },
+                "preshared_keys": {
+                    "description": "PresharedKeys adds a wireguard pre-shared key to the peering of every pair of devices in the VPC.",
+                    "type": "boolean"
+                },
                 "private_cidr": {
                     "type": "boolean"
                 },
+                "require_device_approval": {
+                    "description": "RequireDeviceApproval makes the new devices of the VPC pending until they are approved.",
+                    "type": "boolean"
+                },
                 "revision": {
                     "type": "integer"
                 }
@@ -4649,6 +7219,87 @@ const docTemplate = `{
                 },
                 "value": {}
             }
+        },
+        "models.Webhook": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "event_kinds": {
+                    "description": "EventKinds filters the kinds of events delivered, all kinds are delivered when empty.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "device",
+                        "security-group"
+                    ]
+                },
+                "id": {
+                    "type": "string",
+                    "example": "aa22666c-0f57-45cb-a449-16efecc04f2e"
+                },
+                "organization_id": {
+                    "type": "string"
+                },
+                "secret": {
+                    "description": "Secret is the HMAC key the payloads are signed with, it is only returned when the webhook is created.",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/nexodus-events"
+                }
+            }
+        },
+        "models.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "event_kind": {
+                    "type": "string",
+                    "example": "device"
+                },
+                "event_type": {
+                    "type": "string",
+                    "example": "change"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "aa22666c-0f57-45cb-a449-16efecc04f2e"
+                },
+                "next_attempt_at": {
+                    "description": "NextAttemptAt is when a pending delivery is retried.",
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "string"
+                },
+                "response_code": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "Status is one of pending, succeeded or failed.",
+                    "type": "string",
+                    "example": "succeeded"
+                },
+                "webhook_id": {
+                    "type": "string"
+                }
+            }
         }
     },
     "securityDefinitions": {
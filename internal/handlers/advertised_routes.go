@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/nexodus-io/nexodus/internal/models"
+	"github.com/nexodus-io/nexodus/internal/util"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slices"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// routedCidrs returns the sorted cidrs advertised by the devices, the default routes are left out
+// since exit node clients select their exit node origin themselves.
+func routedCidrs(devices []models.Device) []string {
+	var cidrs []string
+	for _, device := range devices {
+		for _, cidr := range device.AdvertiseCidrs {
+			if !util.IsDefaultIPRoute(cidr) && !slices.Contains(cidrs, cidr) {
+				cidrs = append(cidrs, cidr)
+			}
+		}
+	}
+	slices.Sort(cidrs)
+	return cidrs
+}
+
+// electRoutePrimaries elects the primary router of every cidr advertised by the devices, which are
+// ordered by registration. The current primary is kept while it is online, otherwise the first online
+// device that advertises the cidr takes over. It returns the cidrs each device is the primary router for.
+func electRoutePrimaries(devices []models.Device) map[uuid.UUID][]string {
+	primaries := map[uuid.UUID][]string{}
+	for _, cidr := range routedCidrs(devices) {
+		var current, firstOnline, first *models.Device
+		for i := range devices {
+			device := &devices[i]
+			if !slices.Contains(device.AdvertiseCidrs, cidr) {
+				continue
+			}
+			if first == nil {
+				first = device
+			}
+			if firstOnline == nil && device.Online {
+				firstOnline = device
+			}
+			if current == nil && slices.Contains(device.PrimaryCidrs, cidr) {
+				current = device
+			}
+		}
+
+		primary := current
+		if primary == nil || (!primary.Online && firstOnline != nil) {
+			primary = firstOnline
+		}
+		if primary == nil {
+			primary = first
+		}
+		primaries[primary.ID] = append(primaries[primary.ID], cidr)
+	}
+	return primaries
+}
+
+// listRouters lists the devices of the VPC that can route advertised cidrs, pending devices are
+// not peers of the other devices until they are approved.
+func listRouters(tx *gorm.DB, vpcId uuid.UUID) ([]models.Device, error) {
+	var devices []models.Device
+	if res := tx.
+		Select("id", "advertise_cidrs", "primary_cidrs", "online", "created_at").
+		Where("vpc_id = ? AND pending = ?", vpcId, false).
+		Order("created_at, id").
+		Find(&devices); res.Error != nil {
+		return nil, res.Error
+	}
+	return devices, nil
+}
+
+// updateRoutePrimaries re-elects the primary routers of the cidrs advertised in the VPC and stores the
+// cidrs each device is the primary router for, which bumps the revision of the devices whose primary
+// cidrs changed. device, if not nil, is a device of the VPC that was just saved, it is updated in place.
+func (api *API) updateRoutePrimaries(tx *gorm.DB, vpcId uuid.UUID, device *models.Device) error {
+	devices, err := listRouters(tx, vpcId)
+	if err != nil {
+		return err
+	}
+
+	primaries := electRoutePrimaries(devices)
+	for _, router := range devices {
+		primaryCidrs := pq.StringArray(primaries[router.ID])
+		if advertiseCidrEquals(router.PrimaryCidrs, primaryCidrs) {
+			continue
+		}
+		if device != nil && device.ID == router.ID {
+			device.PrimaryCidrs = primaryCidrs
+			if res := tx.
+				Clauses(clause.Returning{Columns: []clause.Column{{Name: "revision"}}}).
+				Select("primary_cidrs").
+				Updates(device); res.Error != nil {
+				return res.Error
+			}
+			continue
+		}
+		if res := tx.Model(&models.Device{}).
+			Where("id = ?", router.ID).
+			Update("primary_cidrs", primaryCidrs); res.Error != nil {
+			return res.Error
+		}
+	}
+	return nil
+}
+
+// updateRoutePrimariesOnline re-elects the primary routers of the VPC after a device that advertises
+// cidrs came online or went offline
+func (api *API) updateRoutePrimariesOnline(ctx context.Context, device *models.Device) error {
+	if len(device.AdvertiseCidrs) == 0 {
+		return nil
+	}
+	return api.transaction(ctx, func(tx *gorm.DB) error {
+		return api.updateRoutePrimaries(tx, device.VpcID, nil)
+	})
+}
+
+// ListRoutesInVPC lists the cidrs advertised in a VPC along with their primary and standby routers
+// @Summary      List Routes in a VPC
+// @Description  Lists the cidrs advertised by the devices of a VPC, along with the device that is the primary router of each cidr and the standby devices that take over when it goes offline
+// @Id  		 ListRoutesInVPC
+// @Tags         VPC
+// @Accepts		 json
+// @Produce      json
+// @Param        id   path      string  true "VPC ID"
+// @Success      200  {object}  []models.AdvertisedRoute
+// @Failure      400  {object}  models.BaseError
+// @Failure		 401  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/vpcs/{id}/routes [get]
+func (api *API) ListRoutesInVPC(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "ListRoutesInVPC",
+		trace.WithAttributes(
+			attribute.String("vpc_id", c.Param("id")),
+		))
+	defer span.End()
+
+	if !api.FlagCheck(c, "devices") {
+		return
+	}
+
+	vpcId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+	var vpc models.VPC
+	db := api.db.WithContext(ctx)
+	result := api.VPCIsReadableByCurrentUser(c, db).
+		First(&vpc, "id = ?", vpcId.String())
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, models.NewNotFoundError("vpc"))
+		} else {
+			api.SendInternalServerError(c, result.Error)
+		}
+		return
+	}
+
+	devices, err := listRouters(db, vpcId)
+	if err != nil {
+		api.SendInternalServerError(c, err)
+		return
+	}
+
+	routes := []models.AdvertisedRoute{}
+	for _, cidr := range routedCidrs(devices) {
+		route := models.AdvertisedRoute{
+			Cidr:             cidr,
+			StandbyDeviceIDs: []uuid.UUID{},
+		}
+		for _, device := range devices {
+			if !slices.Contains(device.AdvertiseCidrs, cidr) {
+				continue
+			}
+			if route.PrimaryDeviceID == uuid.Nil && slices.Contains(device.PrimaryCidrs, cidr) {
+				route.PrimaryDeviceID = device.ID
+			} else {
+				route.StandbyDeviceIDs = append(route.StandbyDeviceIDs, device.ID)
+			}
+		}
+		routes = append(routes, route)
+	}
+	c.JSON(http.StatusOK, routes)
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/nexodus-io/nexodus/internal/models"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+func (suite *HandlerTestSuite) TestAdvertisedRoutes() {
+	require := suite.Require()
+
+	create := func(advertiseCidrs ...string) models.Device {
+		privateKey, err := wgtypes.GeneratePrivateKey()
+		require.NoError(err)
+		_, res, err := suite.ServeRequest(
+			http.MethodPost,
+			"/", "/",
+			suite.api.CreateDevice, bytes.NewBuffer(suite.jsonMarshal(models.AddDevice{
+				VpcID:          suite.testUserID,
+				PublicKey:      privateKey.PublicKey().String(),
+				AdvertiseCidrs: advertiseCidrs,
+			})),
+		)
+		require.NoError(err)
+		require.Equal(http.StatusCreated, res.Code, "HTTP error: %s", res.Body.String())
+		var device models.Device
+		require.NoError(json.Unmarshal(res.Body.Bytes(), &device))
+		return device
+	}
+	listRoutes := func() []models.AdvertisedRoute {
+		_, res, err := suite.ServeRequest(
+			http.MethodGet, "/:id/routes", fmt.Sprintf("/%s/routes", suite.testUserID),
+			suite.api.ListRoutesInVPC, nil,
+		)
+		require.NoError(err)
+		require.Equal(http.StatusOK, res.Code, "HTTP error: %s", res.Body.String())
+		var routes []models.AdvertisedRoute
+		require.NoError(json.Unmarshal(res.Body.Bytes(), &routes))
+		return routes
+	}
+	setOnline := func(device models.Device, online bool) {
+		device.Online = online
+		require.NoError(suite.api.db.Select("online").Updates(&device).Error)
+		require.NoError(suite.api.updateRoutePrimariesOnline(context.Background(), &device))
+	}
+
+	deviceA := create("172.16.42.0/24", "0.0.0.0/0")
+	require.Equal([]string{"172.16.42.0/24"}, []string(deviceA.PrimaryCidrs))
+	deviceB := create("172.16.42.0/24")
+	require.Empty(deviceB.PrimaryCidrs)
+
+	// the default route is left to the exit node clients
+	require.Equal([]models.AdvertisedRoute{{
+		Cidr:             "172.16.42.0/24",
+		PrimaryDeviceID:  deviceA.ID,
+		StandbyDeviceIDs: []uuid.UUID{deviceB.ID},
+	}}, listRoutes())
+
+	// the standby takes over when the primary is offline, and keeps the cidr when the primary comes back
+	setOnline(deviceB, true)
+	require.Equal(deviceB.ID, listRoutes()[0].PrimaryDeviceID)
+	setOnline(deviceA, true)
+	require.Equal(deviceB.ID, listRoutes()[0].PrimaryDeviceID)
+
+	_, res, err := suite.ServeRequest(
+		http.MethodDelete, "/:id", fmt.Sprintf("/%s", deviceB.ID),
+		suite.api.DeleteDevice, nil,
+	)
+	require.NoError(err)
+	require.Equal(http.StatusOK, res.Code, "HTTP error: %s", res.Body.String())
+	require.Equal([]models.AdvertisedRoute{{
+		Cidr:             "172.16.42.0/24",
+		PrimaryDeviceID:  deviceA.ID,
+		StandbyDeviceIDs: []uuid.UUID{},
+	}}, listRoutes())
+}
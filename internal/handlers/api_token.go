@@ -0,0 +1,342 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"github.com/nexodus-io/nexodus/internal/models"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// APITokenScope is the token scope of the JWTs issued for api tokens
+const APITokenScope = "api-token"
+
+// apiTokenScopes are the API scopes that can be granted to an api token
+var apiTokenScopes = []string{
+	"read:organizations",
+	"write:organizations",
+	"read:devices",
+	"write:devices",
+	"read:users",
+	"write:users",
+}
+
+// apiTokenLastUsedInterval limits how often the last use of an api token is written to the database
+const apiTokenLastUsedInterval = time.Minute
+
+var ErrAPITokenNotValid = errors.New("api token is not valid")
+
+// apiTokenUserID returns the user the api token path refers to, which must be the current user.
+func (api *API) apiTokenUserID(c *gin.Context) (uuid.UUID, bool) {
+	currentUserId := api.GetCurrentUserID(c)
+	if c.Param("id") == "me" {
+		return currentUserId, true
+	}
+	userId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return uuid.Nil, false
+	}
+	if userId != currentUserId {
+		c.JSON(http.StatusNotFound, models.NewNotFoundError("user"))
+		return uuid.Nil, false
+	}
+	return userId, true
+}
+
+// ValidateAPITokenScopes checks that all the scopes can be granted to an api token
+func ValidateAPITokenScopes(scopes []string) error {
+	if len(scopes) == 0 {
+		return fmt.Errorf("at least one scope is required")
+	}
+	for _, scope := range scopes {
+		valid := false
+		for _, s := range apiTokenScopes {
+			if scope == s {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("invalid scope %q, must be one of: %s", scope, strings.Join(apiTokenScopes, ", "))
+		}
+	}
+	return nil
+}
+
+// CreateAPIToken creates an api token
+// @Summary      Create an API token
+// @Description  Creates a long-lived API token for the user
+// @Id           CreateAPIToken
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        id  path       string  true  "User ID"
+// @Param        token  body    models.AddAPIToken  true  "Add API Token"
+// @Success      201  {object}  models.APIToken
+// @Failure      400  {object}  models.BaseError
+// @Failure		 401  {object}  models.BaseError
+// @Failure      403  {object}  models.NotAllowedError
+// @Failure      404  {object}  models.BaseError
+// @Failure      422  {object}  models.ValidationError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/users/{id}/tokens [post]
+func (api *API) CreateAPIToken(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "CreateAPIToken",
+		trace.WithAttributes(
+			attribute.String("id", c.Param("id")),
+		))
+	defer span.End()
+
+	userId, ok := api.apiTokenUserID(c)
+	if !ok {
+		return
+	}
+
+	var request models.AddAPIToken
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPayloadError(err))
+		return
+	}
+	if err := ValidateAPITokenScopes(request.Scopes); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError("scopes", err.Error()))
+		return
+	}
+	if request.ExpiresAt != nil && request.ExpiresAt.Before(time.Now()) {
+		c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError("expires_at", "must be in the future"))
+		return
+	}
+
+	// api tokens can't be used to issue more api tokens
+	if tokenClaims, err := NxodusClaims(c, api.db.WithContext(ctx)); err == nil && strings.Contains(tokenClaims.Scope, APITokenScope) {
+		c.JSON(http.StatusForbidden, models.NewNotAllowedError("api tokens can not create api tokens"))
+		return
+	}
+
+	var token models.APIToken
+	err := api.transaction(ctx, func(tx *gorm.DB) error {
+		var user models.User
+		if res := tx.First(&user, "id = ?", userId); res.Error != nil {
+			return res.Error
+		}
+
+		token = models.APIToken{
+			UserID:      userId,
+			Description: request.Description,
+			Scopes:      request.Scopes,
+			ExpiresAt:   request.ExpiresAt,
+		}
+		if res := tx.Create(&token); res.Error != nil {
+			return res.Error
+		}
+
+		claims := models.NexodusClaims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:   api.URL,
+				ID:       token.ID.String(),
+				Subject:  user.IdpID,
+				IssuedAt: jwt.NewNumericDate(token.CreatedAt),
+			},
+			Scope: strings.Join(append([]string{APITokenScope}, token.Scopes...), " "),
+		}
+		if token.ExpiresAt != nil {
+			claims.ExpiresAt = jwt.NewNumericDate(*token.ExpiresAt)
+		}
+
+		var err error
+		token.BearerToken, err = jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(api.PrivateKey)
+		if err != nil {
+			return fmt.Errorf("failed to sign the api token: %w", err)
+		}
+
+		span.SetAttributes(attribute.String("token_id", token.ID.String()))
+		// api tokens belong to a user, their events are recorded in the default organization of the user
+		return api.recordAuditEvent(c, tx, AuditActionCreate, AuditResourceAPITokens, userId, token.ID, nil, token)
+	})
+	if err != nil {
+		api.SendInternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, token)
+}
+
+// ListAPITokens lists the api tokens of a user
+// @Summary      List API tokens
+// @Description  Lists the API tokens of the user
+// @Id           ListAPITokens
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        id  path       string  true  "User ID"
+// @Success      200  {object}  []models.APIToken
+// @Failure      400  {object}  models.BaseError
+// @Failure		 401  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/users/{id}/tokens [get]
+func (api *API) ListAPITokens(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "ListAPITokens",
+		trace.WithAttributes(
+			attribute.String("id", c.Param("id")),
+		))
+	defer span.End()
+
+	userId, ok := api.apiTokenUserID(c)
+	if !ok {
+		return
+	}
+
+	tokens := make([]models.APIToken, 0)
+	db := api.db.WithContext(ctx)
+	db = db.Where("user_id = ?", userId)
+	db = FilterAndPaginate(db, &models.APIToken{}, c, "created_at")
+	if res := db.Find(&tokens); res.Error != nil {
+		api.SendInternalServerError(c, res.Error)
+		return
+	}
+	c.JSON(http.StatusOK, tokens)
+}
+
+// GetAPIToken gets an api token of a user
+// @Summary      Get API token
+// @Description  Gets an API token of the user by ID
+// @Id           GetAPIToken
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        id  path       string  true  "User ID"
+// @Param        token_id  path string  true  "API Token ID"
+// @Success      200  {object}  models.APIToken
+// @Failure      400  {object}  models.BaseError
+// @Failure		 401  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/users/{id}/tokens/{token_id} [get]
+func (api *API) GetAPIToken(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "GetAPIToken",
+		trace.WithAttributes(
+			attribute.String("id", c.Param("id")),
+			attribute.String("token_id", c.Param("token_id")),
+		))
+	defer span.End()
+
+	userId, ok := api.apiTokenUserID(c)
+	if !ok {
+		return
+	}
+	tokenId, err := uuid.Parse(c.Param("token_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("token_id"))
+		return
+	}
+
+	var token models.APIToken
+	db := api.db.WithContext(ctx)
+	if res := db.First(&token, "id = ? AND user_id = ?", tokenId, userId); res.Error != nil {
+		if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, models.NewNotFoundError("api token"))
+		} else {
+			api.SendInternalServerError(c, res.Error)
+		}
+		return
+	}
+	c.JSON(http.StatusOK, token)
+}
+
+// DeleteAPIToken revokes an api token of a user
+// @Summary      Revoke API token
+// @Description  Revokes an API token of the user
+// @Id           DeleteAPIToken
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        id  path       string  true  "User ID"
+// @Param        token_id  path string  true  "API Token ID"
+// @Success      200  {object}  models.APIToken
+// @Failure      400  {object}  models.BaseError
+// @Failure		 401  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/users/{id}/tokens/{token_id} [delete]
+func (api *API) DeleteAPIToken(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "DeleteAPIToken",
+		trace.WithAttributes(
+			attribute.String("id", c.Param("id")),
+			attribute.String("token_id", c.Param("token_id")),
+		))
+	defer span.End()
+
+	userId, ok := api.apiTokenUserID(c)
+	if !ok {
+		return
+	}
+	tokenId, err := uuid.Parse(c.Param("token_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("token_id"))
+		return
+	}
+
+	var token models.APIToken
+	err = api.transaction(ctx, func(tx *gorm.DB) error {
+		if res := tx.First(&token, "id = ? AND user_id = ?", tokenId, userId); res.Error != nil {
+			return res.Error
+		}
+		if res := tx.Delete(&token); res.Error != nil {
+			return res.Error
+		}
+		return api.recordAuditEvent(c, tx, AuditActionDelete, AuditResourceAPITokens, userId, token.ID, token, nil)
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, models.NewNotFoundError("api token"))
+		} else {
+			api.SendInternalServerError(c, err)
+		}
+		return
+	}
+	c.JSON(http.StatusOK, token)
+}
+
+// CheckAPIToken verifies that the api token has not been revoked or expired and records its use.
+// It returns the ID of the user the token acts on behalf of.
+func (api *API) CheckAPIToken(ctx context.Context, tokenId string) (uuid.UUID, error) {
+	id, err := uuid.Parse(tokenId)
+	if err != nil {
+		return uuid.Nil, ErrAPITokenNotValid
+	}
+
+	var token models.APIToken
+	db := api.db.WithContext(ctx)
+	if res := db.First(&token, "id = ?", id); res.Error != nil {
+		if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+			return uuid.Nil, ErrAPITokenNotValid
+		}
+		return uuid.Nil, res.Error
+	}
+
+	now := time.Now()
+	if token.ExpiresAt != nil && token.ExpiresAt.Before(now) {
+		return uuid.Nil, ErrAPITokenNotValid
+	}
+
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) > apiTokenLastUsedInterval {
+		if res := db.Model(&token).UpdateColumn("last_used_at", now); res.Error != nil {
+			api.logger.Warnf("failed to record the use of api token %s: %v", token.ID, res.Error)
+		}
+	}
+	return token.UserID, nil
+}
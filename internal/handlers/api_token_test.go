@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/nexodus-io/nexodus/internal/models"
+)
+
+func (suite *HandlerTestSuite) TestCreateListRevokeAPIToken() {
+	require := suite.Require()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(err)
+	suite.api.PrivateKey = key
+
+	_, res, err := suite.ServeRequest(
+		http.MethodPost, "/users/:id/tokens", "/users/me/tokens",
+		suite.api.CreateAPIToken,
+		bytes.NewBuffer(suite.jsonMarshal(models.AddAPIToken{
+			Description: "ci",
+			Scopes:      []string{"read:organizations", "read:devices"},
+		})),
+	)
+	require.NoError(err)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(err)
+	require.Equal(http.StatusCreated, res.Code, "HTTP error: %s", string(body))
+
+	var token models.APIToken
+	require.NoError(json.Unmarshal(body, &token))
+	require.Equal(suite.testUserID, token.UserID)
+	require.NotEmpty(token.BearerToken)
+
+	claims := models.NexodusClaims{}
+	_, err = jwt.ParseWithClaims(token.BearerToken, &claims, func(*jwt.Token) (interface{}, error) {
+		return &key.PublicKey, nil
+	})
+	require.NoError(err)
+	require.Equal(token.ID.String(), claims.ID)
+	require.Equal(TestUserIdpID, claims.Subject)
+	require.Equal("api-token read:organizations read:devices", claims.Scope)
+
+	userId, err := suite.api.CheckAPIToken(context.Background(), claims.ID)
+	require.NoError(err)
+	require.Equal(suite.testUserID, userId)
+
+	_, res, err = suite.ServeRequest(
+		http.MethodGet, "/users/:id/tokens", fmt.Sprintf("/users/%s/tokens", suite.testUserID),
+		suite.api.ListAPITokens, nil,
+	)
+	require.NoError(err)
+	body, err = io.ReadAll(res.Body)
+	require.NoError(err)
+	require.Equal(http.StatusOK, res.Code, "HTTP error: %s", string(body))
+
+	var tokens []models.APIToken
+	require.NoError(json.Unmarshal(body, &tokens))
+	require.Len(tokens, 1)
+	require.Empty(tokens[0].BearerToken)
+	require.NotNil(tokens[0].LastUsedAt)
+
+	// the tokens of other users are not visible
+	_, res, err = suite.ServeRequest(
+		http.MethodGet, "/users/:id/tokens", fmt.Sprintf("/users/%s/tokens", suite.testUser2ID),
+		suite.api.ListAPITokens, nil,
+	)
+	require.NoError(err)
+	require.Equal(http.StatusNotFound, res.Code)
+
+	_, res, err = suite.ServeRequest(
+		http.MethodDelete, "/users/:id/tokens/:token_id", fmt.Sprintf("/users/me/tokens/%s", token.ID),
+		suite.api.DeleteAPIToken, nil,
+	)
+	require.NoError(err)
+	require.Equal(http.StatusOK, res.Code)
+
+	_, err = suite.api.CheckAPIToken(context.Background(), claims.ID)
+	require.ErrorIs(err, ErrAPITokenNotValid)
+}
+
+func (suite *HandlerTestSuite) TestCreateAPITokenInvalidScope() {
+	require := suite.Require()
+	for _, scopes := range [][]string{nil, {"read:organizations", "admin"}} {
+		_, res, err := suite.ServeRequest(
+			http.MethodPost, "/users/:id/tokens", "/users/me/tokens",
+			suite.api.CreateAPIToken,
+			bytes.NewBuffer(suite.jsonMarshal(models.AddAPIToken{
+				Scopes: scopes,
+			})),
+		)
+		require.NoError(err)
+		require.Equal(http.StatusUnprocessableEntity, res.Code, strings.Join(scopes, ","))
+	}
+}
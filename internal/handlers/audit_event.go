@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nexodus-io/nexodus/internal/models"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// The actions recorded by audit events
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+)
+
+// The kinds of the audited resources that are not granted permissions on by the roles, they are
+// changed with the permissions of the resource they belong to.
+const (
+	AuditResourceDeviceMetadata    = "device-metadata"
+	AuditResourceOrganizationUsers = "organization-users"
+	AuditResourceAPITokens         = "api-tokens"
+)
+
+// auditIgnoredFields are not recorded in audit events, they either hold secrets or change on every write.
+var auditIgnoredFields = []string{"bearer_token", "secret", "link_secret", "revision"}
+
+// recordAuditEvent records a change made to a resource in the transaction of the change. before is
+// nil when the resource is created and after is nil when it is deleted. Only the fields that differ
+// between before and after are recorded.
+func (api *API) recordAuditEvent(c *gin.Context, tx *gorm.DB, action string, resourceKind string, orgID uuid.UUID, resourceID uuid.UUID, before any, after any) error {
+	beforeFields, err := auditFields(before)
+	if err != nil {
+		return err
+	}
+	afterFields, err := auditFields(after)
+	if err != nil {
+		return err
+	}
+	for key, value := range beforeFields {
+		if afterValue, ok := afterFields[key]; ok && reflect.DeepEqual(value, afterValue) {
+			delete(beforeFields, key)
+			delete(afterFields, key)
+		}
+	}
+
+	event := models.AuditEvent{
+		OrganizationID: orgID,
+		Action:         action,
+		ResourceKind:   resourceKind,
+		ResourceID:     resourceID,
+		Before:         beforeFields,
+		After:          afterFields,
+	}
+	// mutations made by the apiserver itself, like the removal of ephemeral devices, have no actor.
+	if c == nil {
+		return tx.Create(&event).Error
+	}
+	event.ActorUserID = api.GetCurrentUserID(c)
+	event.SourceIP = c.ClientIP()
+	if claims, apiErr := NxodusClaims(c, tx); apiErr == nil {
+		event.TokenScope = claims.Scope
+		if claims.Scope == "device-token" {
+			event.ActorDeviceID = claims.AgentID
+		}
+	}
+	return tx.Create(&event).Error
+}
+
+// auditFields converts a resource into the map of its JSON fields
+func auditFields(resource any) (map[string]interface{}, error) {
+	if resource == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for _, key := range auditIgnoredFields {
+		delete(fields, key)
+	}
+	return fields, nil
+}
+
+// ListAuditEventsInOrganization lists the audit events of an organization
+// @Summary      List Audit Events
+// @Description  Lists the changes made to the resources of an organization
+// @Id 			 ListAuditEventsInOrganization
+// @Tags         Organizations
+// @Accept       json
+// @Produce      json
+// @Param		 id   path      string true "Organization ID"
+// @Success      200  {object}  []models.AuditEvent
+// @Failure      400  {object}  models.BaseError
+// @Failure		 401  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/organizations/{id}/audit-events [get]
+func (api *API) ListAuditEventsInOrganization(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "ListAuditEventsInOrganization",
+		trace.WithAttributes(
+			attribute.String("id", c.Param("id")),
+		))
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+
+	db := api.db.WithContext(ctx)
+	var org models.Organization
+	if res := api.CurrentUserHasPermission(c, db, "id", ResourceAuditEvents, VerbRead).
+		First(&org, "id = ?", id); res.Error != nil {
+		if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, models.NewNotFoundError("organization"))
+		} else {
+			api.SendInternalServerError(c, res.Error)
+		}
+		return
+	}
+
+	db = db.Where("organization_id = ?", id)
+	db = FilterAndPaginate(db, &models.AuditEvent{}, c, "created_at DESC")
+
+	events := make([]models.AuditEvent, 0)
+	if res := db.Find(&events); res.Error != nil {
+		api.SendInternalServerError(c, res.Error)
+		return
+	}
+	c.JSON(http.StatusOK, events)
+}
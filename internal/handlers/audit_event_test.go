@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nexodus-io/nexodus/internal/models"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+func (suite *HandlerTestSuite) TestListAuditEvents() {
+	require := suite.Require()
+	vpcUri := fmt.Sprintf("/vpcs/%s/dns-records", suite.testUserID)
+
+	_, res, err := suite.ServeRequest(
+		http.MethodPost, "/vpcs/:id/dns-records", vpcUri,
+		suite.api.CreateDNSRecordInVPC,
+		bytes.NewBuffer(suite.jsonMarshal(models.AddDNSRecord{
+			Name:      "audited",
+			Addresses: []string{"100.64.0.40"},
+		})),
+	)
+	require.NoError(err)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(err)
+	require.Equal(http.StatusCreated, res.Code, "HTTP error: %s", string(body))
+
+	var record models.DNSRecord
+	require.NoError(json.Unmarshal(body, &record))
+
+	_, res, err = suite.ServeRequest(
+		http.MethodPatch, "/vpcs/:id/dns-records/:record_id", fmt.Sprintf("%s/%s", vpcUri, record.ID),
+		suite.api.UpdateDNSRecordInVPC,
+		bytes.NewBuffer(suite.jsonMarshal(models.UpdateDNSRecord{
+			Addresses: []string{"100.64.0.41"},
+		})),
+	)
+	require.NoError(err)
+	require.Equal(http.StatusOK, res.Code)
+
+	_, res, err = suite.ServeRequest(
+		http.MethodDelete, "/vpcs/:id/dns-records/:record_id", fmt.Sprintf("%s/%s", vpcUri, record.ID),
+		suite.api.DeleteDNSRecordInVPC, nil,
+	)
+	require.NoError(err)
+	require.Equal(http.StatusOK, res.Code)
+
+	filter := fmt.Sprintf(`{"resource_id":"%s"}`, record.ID)
+	_, res, err = suite.ServeRequest(
+		http.MethodGet, "/organizations/:id/audit-events", fmt.Sprintf("/organizations/%s/audit-events?sort=%s&filter=%s", suite.testUserID, `["created_at","ASC"]`, filter),
+		suite.api.ListAuditEventsInOrganization, nil,
+	)
+	require.NoError(err)
+	body, err = io.ReadAll(res.Body)
+	require.NoError(err)
+	require.Equal(http.StatusOK, res.Code, "HTTP error: %s", string(body))
+
+	var events []models.AuditEvent
+	require.NoError(json.Unmarshal(body, &events))
+	require.Len(events, 3)
+
+	require.Equal(AuditActionCreate, events[0].Action)
+	require.Equal(ResourceDNSRecords, events[0].ResourceKind)
+	require.Equal(suite.testUserID, events[0].ActorUserID)
+	require.Nil(events[0].Before)
+	require.Equal("audited", events[0].After["name"])
+
+	// only the changed fields are recorded for an update
+	require.Equal(AuditActionUpdate, events[1].Action)
+	require.Equal(map[string]interface{}{"addresses": []interface{}{"100.64.0.40"}}, events[1].Before)
+	require.Equal(map[string]interface{}{"addresses": []interface{}{"100.64.0.41"}}, events[1].After)
+
+	require.Equal(AuditActionDelete, events[2].Action)
+	require.Equal("audited", events[2].Before["name"])
+	require.Nil(events[2].After)
+
+	// the audit events of an organization the user does not own are not found
+	_, res, err = suite.ServeRequest(
+		http.MethodGet, "/organizations/:id/audit-events", fmt.Sprintf("/organizations/%s/audit-events", suite.testUser2ID),
+		suite.api.ListAuditEventsInOrganization, nil,
+	)
+	require.NoError(err)
+	require.Equal(http.StatusNotFound, res.Code)
+}
+
+func (suite *HandlerTestSuite) TestAuditedMutations() {
+	require := suite.Require()
+
+	serve := func(method, path, uri string, handler func(*gin.Context), body any, user uuid.UUID) *httptest.ResponseRecorder {
+		var reader io.Reader
+		if body != nil {
+			reader = bytes.NewBuffer(suite.jsonMarshal(body))
+		}
+		_, res, err := suite.ServeRequest(method, path, uri, func(c *gin.Context) {
+			c.Set(gin.AuthUserKey, user)
+			c.Set("nexodus.fflag.multi-organization", true)
+			c.Set("nexodus.fflag.sites", true)
+			handler(c)
+		}, reader)
+		require.NoError(err)
+		require.Less(res.Code, 300, "HTTP error: %s", res.Body.String())
+		return res
+	}
+	decode := func(res *httptest.ResponseRecorder, v any) {
+		require.NoError(json.Unmarshal(res.Body.Bytes(), v))
+	}
+	// the events are read from the database, the events of a deleted organization can not be listed
+	auditedActions := func(orgID uuid.UUID) []string {
+		var events []models.AuditEvent
+		require.NoError(suite.api.db.Order("created_at").Find(&events, "organization_id = ?", orgID).Error)
+		actions := []string{}
+		for _, event := range events {
+			actions = append(actions, event.ResourceKind+":"+event.Action)
+		}
+		return actions
+	}
+	user1, user2 := suite.testUserID, suite.testUser2ID
+
+	var org models.Organization
+	decode(serve(http.MethodPost, "/", "/", suite.api.CreateOrganization, models.AddOrganization{Name: "audited"}, user1), &org)
+	serve(http.MethodDelete, "/:id", fmt.Sprintf("/%s", org.ID), suite.api.DeleteOrganization, nil, user1)
+	require.Equal([]string{
+		"organizations:create",
+		"organizations:delete",
+	}, auditedActions(org.ID))
+
+	var serviceNetwork models.ServiceNetwork
+	decode(serve(http.MethodPost, "/", "/", suite.api.CreateServiceNetwork, models.AddServiceNetwork{OrganizationID: user1}, user1), &serviceNetwork)
+	description := "audited"
+	serve(http.MethodPatch, "/:id", fmt.Sprintf("/%s", serviceNetwork.ID), suite.api.UpdateServiceNetwork, models.UpdateServiceNetwork{Description: &description}, user1)
+
+	var site models.Site
+	decode(serve(http.MethodPost, "/", "/", suite.api.CreateSite, models.AddSite{ServiceNetworkID: serviceNetwork.ID, PublicKey: "audited-site"}, user1), &site)
+	hostname := "audited"
+	serve(http.MethodPatch, "/:id", fmt.Sprintf("/%s", site.ID), suite.api.UpdateSite, models.UpdateSite{Hostname: &hostname}, user1)
+	serve(http.MethodDelete, "/:id", fmt.Sprintf("/%s", site.ID), suite.api.DeleteSite, nil, user1)
+	serve(http.MethodDelete, "/:id", fmt.Sprintf("/%s", serviceNetwork.ID), suite.api.DeleteServiceNetwork, nil, user1)
+
+	var device models.Device
+	privateKey, err := wgtypes.GeneratePrivateKey()
+	require.NoError(err)
+	decode(serve(http.MethodPost, "/", "/", suite.api.CreateDevice, models.AddDevice{VpcID: user1, PublicKey: privateKey.PublicKey().String()}, user1), &device)
+	metadataUri := fmt.Sprintf("/%s/metadata/audited", device.ID)
+	serve(http.MethodPut, "/:id/metadata/:key", metadataUri, suite.api.UpdateDeviceMetadataKey, map[string]int{"version": 1}, user1)
+	serve(http.MethodPut, "/:id/metadata/:key", metadataUri, suite.api.UpdateDeviceMetadataKey, map[string]int{"version": 2}, user1)
+	serve(http.MethodDelete, "/:id/metadata/:key", metadataUri, suite.api.DeleteDeviceMetadataKey, nil, user1)
+
+	var invitation models.Invitation
+	addInvitation := models.AddInvitation{OrganizationID: user1, UserID: &user2}
+	decode(serve(http.MethodPost, "/", "/", suite.api.CreateInvitation, addInvitation, user1), &invitation)
+	serve(http.MethodDelete, "/:id", fmt.Sprintf("/%s", invitation.ID), suite.api.DeleteInvitation, nil, user1)
+	decode(serve(http.MethodPost, "/", "/", suite.api.CreateInvitation, addInvitation, user1), &invitation)
+	serve(http.MethodPost, "/:id/accept", fmt.Sprintf("/%s/accept", invitation.ID), suite.api.AcceptInvitation, nil, user2)
+	serve(http.MethodDelete, "/organizations/:id/users/:uid", fmt.Sprintf("/organizations/%s/users/%s", user1, user2), suite.api.DeleteOrganizationUser, nil, user1)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(err)
+	suite.api.PrivateKey = key
+	var token models.APIToken
+	decode(serve(http.MethodPost, "/users/:id/tokens", "/users/me/tokens", suite.api.CreateAPIToken, models.AddAPIToken{Scopes: []string{"read:organizations"}}, user1), &token)
+	serve(http.MethodDelete, "/users/:id/tokens/:token_id", fmt.Sprintf("/users/me/tokens/%s", token.ID), suite.api.DeleteAPIToken, nil, user1)
+
+	require.Equal([]string{
+		"service-networks:create",
+		"service-networks:update",
+		"sites:create",
+		"sites:update",
+		"sites:delete",
+		"service-networks:delete",
+		"devices:create",
+		"device-metadata:create",
+		"device-metadata:update",
+		"device-metadata:delete",
+		"invitations:create",
+		"invitations:delete",
+		"invitations:create",
+		"invitations:delete",
+		"organization-users:create",
+		"organization-users:delete",
+		"api-tokens:create",
+		"api-tokens:delete",
+	}, auditedActions(user1))
+}
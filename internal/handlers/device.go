@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/nexodus-io/nexodus/internal/handlers/fetchmgr"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/nexodus-io/nexodus/internal/ipam"
 	"github.com/nexodus-io/nexodus/internal/models"
 	"github.com/nexodus-io/nexodus/internal/util"
 	"github.com/nexodus-io/nexodus/internal/wgcrypto"
@@ -26,6 +28,7 @@ var (
 	errInvitationNotFound    = errors.New("invitation not found")
 	errSecurityGroupNotFound = errors.New("security group not found")
 	errRegKeyExhausted       = errors.New("single use reg key exhausted")
+	errRegKeyUsesExhausted   = errors.New("reg key has reached its maximum number of uses")
 )
 
 type deviceList []*models.Device
@@ -62,7 +65,7 @@ func (api *API) ListDevices(c *gin.Context) {
 	devices := make([]models.Device, 0)
 
 	db := api.db.WithContext(ctx)
-	db = api.DeviceIsOwnedByCurrentUser(c, db)
+	db = api.DeviceIsReadableByCurrentUser(c, db)
 	db = FilterAndPaginate(db, &models.Device{}, c, "hostname")
 	result := db.Find(&devices)
 	if result.Error != nil {
@@ -119,9 +122,21 @@ func hideDeviceBearerToken(device *models.Device, claims *models.NexodusClaims,
 	device.BearerToken = ""
 }
 
+func (api *API) DeviceIsReadableByCurrentUser(c *gin.Context, db *gorm.DB) *gorm.DB {
+	return api.deviceIsOwnedByCurrentUserOr(c, db, VerbRead)
+}
+
 func (api *API) DeviceIsOwnedByCurrentUser(c *gin.Context, db *gorm.DB) *gorm.DB {
+	return api.deviceIsOwnedByCurrentUserOr(c, db, VerbWrite)
+}
+
+// deviceIsOwnedByCurrentUserOr limits the query to the devices owned by the current user and the
+// devices of the organizations in which the current user is granted the verb on devices.
+func (api *API) deviceIsOwnedByCurrentUserOr(c *gin.Context, db *gorm.DB, verb string) *gorm.DB {
 	userId := api.GetCurrentUserID(c)
-	return db.Where("owner_id = ?", userId)
+	scope := db.Session(&gorm.Session{NewDB: true})
+	return db.Where(scope.Where("owner_id = ?", userId).
+		Or(api.CurrentUserHasPermission(c, scope, "organization_id", ResourceDevices, verb)))
 }
 
 // GetDevice gets a device by ID
@@ -157,7 +172,7 @@ func (api *API) GetDevice(c *gin.Context) {
 	var device models.Device
 
 	db := api.db.WithContext(ctx)
-	db = api.DeviceIsOwnedByCurrentUser(c, db)
+	db = api.DeviceIsReadableByCurrentUser(c, db)
 	result := db.First(&device, "id = ?", k)
 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 		c.Status(http.StatusNotFound)
@@ -189,6 +204,8 @@ func (api *API) GetDevice(c *gin.Context) {
 // @Failure		 401  {object}  models.BaseError
 // @Failure      400  {object}  models.BaseError
 // @Failure      404  {object}  models.BaseError
+// @Failure      409  {object}  models.ConflictsError
+// @Failure      422  {object}  models.ValidationError
 // @Failure		 429  {object}  models.BaseError
 // @Failure      500  {object}  models.InternalServerError "Internal Server Error"
 // @Router       /api/devices/{id} [patch]
@@ -213,10 +230,21 @@ func (api *API) UpdateDevice(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.NewBadPayloadError(err))
 		return
 	}
+	if err := util.ValidateLabels(request.Labels); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError("labels", err.Error()))
+		return
+	}
+	if request.PublicKey != "" {
+		if _, err := wgtypes.ParseKey(request.PublicKey); err != nil {
+			c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError("public_key", "not a wireguard public key"))
+			return
+		}
+	}
 
 	var device models.Device
 	var tokenClaims *models.NexodusClaims
 	err = api.transaction(ctx, func(tx *gorm.DB) error {
+		ipamCtx := ipam.WithTransaction(ctx, tx)
 
 		db := api.DeviceIsOwnedByCurrentUser(c, tx)
 		db = FilterAndPaginate(db, &models.Device{}, c, "hostname")
@@ -245,6 +273,12 @@ func (api *API) UpdateDevice(c *gin.Context) {
 			}
 		}
 
+		// snapshot the device for the audit event, the tunnel ips are changed in place
+		before, err := auditFields(device)
+		if err != nil {
+			return err
+		}
+
 		var vpc models.VPC
 		if result = tx.First(&vpc, "id = ?", device.VpcID); result.Error != nil {
 			return result.Error
@@ -255,7 +289,28 @@ func (api *API) UpdateDevice(c *gin.Context) {
 			originalIpamNamespace = vpc.ID
 		}
 
-		if request.Hostname != "" {
+		// rotate the wireguard key, the revision check makes sure the device agent rotates from the key it knows about
+		if request.PublicKey != "" && request.PublicKey != device.PublicKey {
+			if request.Revision == nil {
+				return NewApiResponseError(http.StatusBadRequest, models.NewFieldNotPresentError("revision"))
+			}
+			if *request.Revision != device.Revision {
+				return NewApiResponseError(http.StatusConflict, models.ConflictsError{
+					ID:        device.ID.String(),
+					BaseError: models.NewBaseError("device has changed since the given revision"),
+				})
+			}
+			var existing models.Device
+			res := tx.Select("id").Where("public_key = ?", request.PublicKey).First(&existing)
+			if res.Error == nil {
+				return NewApiResponseError(http.StatusConflict, models.NewConflictsError(existing.ID.String()))
+			} else if !errors.Is(res.Error, gorm.ErrRecordNotFound) {
+				return res.Error
+			}
+			device.PublicKey = request.PublicKey
+		}
+
+		if request.Hostname != "" && !api.regKeyNamesDevice(tx, device, tokenClaims) {
 			device.Hostname = request.Hostname
 		}
 
@@ -268,7 +323,7 @@ func (api *API) UpdateDevice(c *gin.Context) {
 		if request.VpcID != nil && *request.VpcID != device.OrganizationID {
 
 			var newVpc models.VPC
-			if result := api.VPCIsReadableByCurrentUser(c, tx).
+			if result := api.CurrentUserHasPermission(c, tx, "organization_id", ResourceDevices, VerbCreate).
 				Preload("Organization").
 				First(&newVpc, "id = ?", request.VpcID); result.Error != nil {
 				return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("vpc_id"))
@@ -286,25 +341,25 @@ func (api *API) UpdateDevice(c *gin.Context) {
 					address := t.Address
 					cidr := t.CIDR
 					if address != "" && cidr != "" {
-						if err := api.ipam.ReleaseToPool(c.Request.Context(), originalIpamNamespace, address, cidr); err != nil {
+						if err := api.ipam.ReleaseToPool(ipamCtx, originalIpamNamespace, address, cidr); err != nil {
 							return fmt.Errorf("failed to release the ip address to pool: %w", err)
 						}
 					}
 				}
 				for _, cidr := range device.AdvertiseCidrs {
-					if err := api.ipam.ReleaseCIDR(c.Request.Context(), originalIpamNamespace, cidr); err != nil {
+					if err := api.ipam.ReleaseCIDR(ipamCtx, originalIpamNamespace, cidr); err != nil {
 						return fmt.Errorf("failed to release cidr: %w", err)
 					}
 				}
 
 				device.IPv4TunnelIPs[0].CIDR = newVpc.Ipv4Cidr
-				device.IPv4TunnelIPs[0].Address, err = api.ipam.AssignFromPool(ctx, newIpamNamespace, newVpc.Ipv4Cidr)
+				device.IPv4TunnelIPs[0].Address, err = api.ipam.AssignFromPool(ipamCtx, newIpamNamespace, newVpc.Ipv4Cidr)
 				if err != nil {
 					return fmt.Errorf("failed to request ipam address: %w", err)
 				}
 
 				device.IPv6TunnelIPs[0].CIDR = newVpc.Ipv6Cidr
-				device.IPv6TunnelIPs[0].Address, err = api.ipam.AssignFromPool(ctx, newIpamNamespace, newVpc.Ipv6Cidr)
+				device.IPv6TunnelIPs[0].Address, err = api.ipam.AssignFromPool(ipamCtx, newIpamNamespace, newVpc.Ipv6Cidr)
 				if err != nil {
 					return fmt.Errorf("failed to request ipam address: %w", err)
 				}
@@ -316,7 +371,7 @@ func (api *API) UpdateDevice(c *gin.Context) {
 					}
 					// Skip the prefix assignment if it's an IPv4 or IPv6 default route
 					if !util.IsDefaultIPv4Route(cidr) && !util.IsDefaultIPv6Route(cidr) {
-						if err := api.ipam.AssignCIDR(ctx, newIpamNamespace, cidr); err != nil {
+						if err := api.ipam.AssignCIDR(ipamCtx, newIpamNamespace, cidr); err != nil {
 							return fmt.Errorf("failed to assign cidr: %w", err)
 						}
 					}
@@ -329,6 +384,8 @@ func (api *API) UpdateDevice(c *gin.Context) {
 			}
 
 			device.VpcID = *request.VpcID
+			// devices moving into a VPC that requires approval have to be approved for it
+			device.Pending = device.Pending || newVpc.RequireDeviceApproval
 		}
 		if request.SymmetricNat != nil {
 			device.SymmetricNat = *request.SymmetricNat
@@ -346,6 +403,10 @@ func (api *API) UpdateDevice(c *gin.Context) {
 			device.SecurityGroupId = *request.SecurityGroupId
 		}
 
+		if request.Labels != nil {
+			device.Labels = request.Labels
+		}
+
 		// check if the updated device advertised CIDRs match the existing device advertised CIDRs
 		if request.AdvertiseCidrs != nil && !advertiseCidrEquals(device.AdvertiseCidrs, request.AdvertiseCidrs) {
 			cidrAllocated := make(map[string]struct{})
@@ -363,12 +424,12 @@ func (api *API) UpdateDevice(c *gin.Context) {
 				}
 				// lookup miss of prefix means we need to release it
 				if _, ok := cidrAllocated[cidr]; ok {
-					if err := api.ipam.ReleaseCIDR(ctx, originalIpamNamespace, cidr); err != nil {
+					if err := api.ipam.ReleaseCIDR(ipamCtx, originalIpamNamespace, cidr); err != nil {
 						return err
 					}
 				} else {
 					// otherwise we need to allocate it
-					if err := api.ipam.AssignCIDR(ctx, originalIpamNamespace, cidr); err != nil {
+					if err := api.ipam.AssignCIDR(ipamCtx, originalIpamNamespace, cidr); err != nil {
 						return err
 					}
 				}
@@ -383,7 +444,19 @@ func (api *API) UpdateDevice(c *gin.Context) {
 			return res.Error
 		}
 
-		return nil
+		// re-elect the primary routers of the cidrs the device advertised or stopped advertising
+		if vpc.ID != device.VpcID {
+			if err := api.updateRoutePrimaries(tx, vpc.ID, nil); err != nil {
+				return err
+			}
+		}
+		if len(device.AdvertiseCidrs) > 0 || len(device.PrimaryCidrs) > 0 {
+			if err := api.updateRoutePrimaries(tx, device.VpcID, &device); err != nil {
+				return err
+			}
+		}
+
+		return api.recordAuditEvent(c, tx, AuditActionUpdate, ResourceDevices, device.OrganizationID, device.ID, before, device)
 	})
 
 	if err != nil {
@@ -445,6 +518,7 @@ func getAllowedIPs(ip string, ip6 string, relay bool) ([]string, error) {
 // @Failure      400  {object}  models.BaseError
 // @Failure		 401  {object}  models.BaseError
 // @Failure      409  {object}  models.ConflictsError
+// @Failure      422  {object}  models.ValidationError
 // @Failure		 429  {object}  models.BaseError
 // @Failure      500  {object}  models.InternalServerError "Internal Server Error"
 // @Router       /api/devices [post]
@@ -471,14 +545,20 @@ func (api *API) CreateDevice(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.NewFieldNotPresentError("vpc_id"))
 		return
 	}
+	if err := util.ValidateLabels(request.Labels); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError("labels", err.Error()))
+		return
+	}
 
 	userId := api.GetCurrentUserID(c)
 	var tokenClaims *models.NexodusClaims
 	var device models.Device
+	var regKey models.RegKey
 	err := api.transaction(ctx, func(tx *gorm.DB) error {
+		ipamCtx := ipam.WithTransaction(ctx, tx)
 
 		var vpc models.VPC
-		if result := api.VPCIsReadableByCurrentUser(c, tx).
+		if result := api.CurrentUserHasPermission(c, tx, "organization_id", ResourceDevices, VerbCreate).
 			Preload("Organization").
 			First(&vpc, "id = ?", request.VpcID); result.Error != nil {
 			return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("vpc"))
@@ -509,6 +589,28 @@ func (api *API) CreateDevice(c *gin.Context) {
 			if err != nil {
 				return NewApiResponseError(http.StatusBadRequest, fmt.Errorf("invalid reg key id"))
 			}
+			if err = tx.First(&regKey, "id = ?", regKeyID).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return NewApiResponseError(http.StatusUnauthorized, models.NewBaseError("invalid reg key"))
+				}
+				return err
+			}
+			if !regKeyAllowsSourceIP(regKey, c.ClientIP()) {
+				return NewApiResponseError(http.StatusForbidden, models.NewNotAllowedError("reg key can not be used from this address"))
+			}
+			// count the use, the update is atomic so concurrent registrations can't exceed the limit
+			res := tx.Model(&models.RegKey{}).
+				Where("id = ? AND (max_uses = 0 OR uses < max_uses)", regKeyID).
+				Update("uses", gorm.Expr("uses + 1"))
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				return NewApiResponseError(http.StatusBadRequest, models.NewApiError(errRegKeyUsesExhausted))
+			}
+			if err = tx.Select("uses").First(&regKey, "id = ?", regKeyID).Error; err != nil {
+				return err
+			}
 
 			// is the user token restricted to operating on a single device?
 			if tokenClaims.AgentID != nil {
@@ -530,6 +632,28 @@ func (api *API) CreateDevice(c *gin.Context) {
 			deviceId = uuid.New()
 		}
 
+		// the reg key labels the devices it registers, they take precedence over the labels the device asked for
+		labels := request.Labels
+		if len(regKey.DeviceLabels) > 0 {
+			labels = map[string]string{}
+			for k, v := range request.Labels {
+				labels[k] = v
+			}
+			for k, v := range regKey.DeviceLabels {
+				labels[k] = v
+			}
+		}
+		hostname := request.Hostname
+		if regKey.HostnameTemplate != "" {
+			hostname, err = renderHostnameTemplate(regKey.HostnameTemplate, hostnameTemplateData{
+				Hostname: request.Hostname,
+				Index:    regKey.Uses,
+			})
+			if err != nil {
+				return NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError("hostname_template", err.Error()))
+			}
+		}
+
 		ipamNamespace := defaultIPAMNamespace
 		if vpc.PrivateCidr {
 			ipamNamespace = vpc.ID
@@ -549,19 +673,19 @@ func (api *API) CreateDevice(c *gin.Context) {
 		if len(request.IPv4TunnelIPs) > 1 {
 			return NewApiResponseError(http.StatusBadRequest, models.NewFieldValidationError("tunnel_ips_v4", "can only specify a single IPv4 address request"))
 		} else if len(request.IPv4TunnelIPs) == 1 {
-			ipamIP, err = api.ipam.AssignSpecificTunnelIP(ctx, ipamNamespace, vpc.Ipv4Cidr, request.IPv4TunnelIPs[0].Address)
+			ipamIP, err = api.ipam.AssignSpecificTunnelIP(ipamCtx, ipamNamespace, vpc.Ipv4Cidr, request.IPv4TunnelIPs[0].Address)
 			if err != nil {
 				return fmt.Errorf("failed to request specific ipam address: %w", err)
 			}
 		} else {
-			ipamIP, err = api.ipam.AssignFromPool(ctx, ipamNamespace, vpc.Ipv4Cidr)
+			ipamIP, err = api.ipam.AssignFromPool(ipamCtx, ipamNamespace, vpc.Ipv4Cidr)
 			if err != nil {
 				return fmt.Errorf("failed to request ipam address: %w", err)
 			}
 		}
 
 		// Currently only support v4 requesting of specific addresses
-		ipamIPv6, err = api.ipam.AssignFromPool(ctx, ipamNamespace, vpc.Ipv6Cidr)
+		ipamIPv6, err = api.ipam.AssignFromPool(ipamCtx, ipamNamespace, vpc.Ipv6Cidr)
 		if err != nil {
 			return fmt.Errorf("failed to request ipam v6 address: %w", err)
 		}
@@ -573,7 +697,7 @@ func (api *API) CreateDevice(c *gin.Context) {
 			}
 			// Skip the prefix assignment if it's an IPv4 or IPv6 default route
 			if !util.IsDefaultIPv4Route(cidr) && !util.IsDefaultIPv6Route(cidr) {
-				if err := api.ipam.AssignCIDR(ctx, ipamNamespace, cidr); err != nil {
+				if err := api.ipam.AssignCIDR(ipamCtx, ipamNamespace, cidr); err != nil {
 					return fmt.Errorf("failed to assign cidr: %w", err)
 				}
 			}
@@ -615,11 +739,14 @@ func (api *API) CreateDevice(c *gin.Context) {
 			AdvertiseCidrs:  request.AdvertiseCidrs,
 			Relay:           request.Relay,
 			SymmetricNat:    request.SymmetricNat,
-			Hostname:        request.Hostname,
+			Hostname:        hostname,
 			Os:              request.Os,
 			SecurityGroupId: vpc.ID,
+			Labels:          labels,
 			RegKeyID:        regKeyID,
 			BearerToken:     "DT:" + deviceToken.String(),
+			Pending:         vpc.RequireDeviceApproval,
+			Ephemeral:       regKey.Ephemeral,
 		}
 
 		if res := tx.
@@ -630,7 +757,25 @@ func (api *API) CreateDevice(c *gin.Context) {
 		span.SetAttributes(
 			attribute.String("id", device.ID.String()),
 		)
-		return nil
+
+		for key, value := range regKey.DeviceMetadata {
+			metadata := models.DeviceMetadata{
+				DeviceID: device.ID,
+				Key:      key,
+				Value:    value,
+			}
+			if res := tx.
+				Clauses(clause.Returning{Columns: []clause.Column{{Name: "revision"}}}).
+				Create(&metadata); res.Error != nil {
+				return res.Error
+			}
+		}
+		if len(device.AdvertiseCidrs) > 0 {
+			if err := api.updateRoutePrimaries(tx, device.VpcID, &device); err != nil {
+				return err
+			}
+		}
+		return api.recordAuditEvent(c, tx, AuditActionCreate, ResourceDevices, device.OrganizationID, device.ID, nil, device)
 	})
 
 	if err != nil {
@@ -645,9 +790,28 @@ func (api *API) CreateDevice(c *gin.Context) {
 
 	hideDeviceBearerToken(&device, tokenClaims, userId)
 	api.signalBus.Notify(fmt.Sprintf("/devices/vpc=%s", device.VpcID.String()))
+	if len(regKey.DeviceMetadata) > 0 {
+		api.signalBus.Notify(fmt.Sprintf("/metadata/vpc=%s", device.VpcID.String()))
+	}
 	c.JSON(http.StatusCreated, device)
 }
 
+// regKeyNamesDevice checks if the device agent is updating a device that was named with the hostname template
+// of its reg key, the agent keeps reporting its own hostname on reconnect.
+func (api *API) regKeyNamesDevice(tx *gorm.DB, device models.Device, tokenClaims *models.NexodusClaims) bool {
+	if tokenClaims == nil || (tokenClaims.Scope != "reg-token" && tokenClaims.Scope != "device-token") {
+		return false
+	}
+	if device.RegKeyID == uuid.Nil {
+		return false
+	}
+	var regKey models.RegKey
+	if res := tx.Select("hostname_template").First(&regKey, "id = ?", device.RegKeyID); res.Error != nil {
+		return false
+	}
+	return regKey.HostnameTemplate != ""
+}
+
 // DeleteDevice handles deleting an existing device and associated ipam lease
 // @Summary      Delete Device
 // @Description  Deletes an existing device and associated IPAM lease
@@ -688,11 +852,25 @@ func (api *API) DeleteDevice(c *gin.Context) {
 		return
 	}
 
+	if err := api.deleteDevice(c, ctx, device); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, models.NewNotFoundError("device"))
+		} else {
+			api.SendInternalServerError(c, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, device)
+}
+
+// deleteDevice deletes the device and releases its tunnel addresses and advertised cidrs. c is nil
+// when the apiserver deletes the device on its own. gorm.ErrRecordNotFound is returned if the device
+// was already deleted.
+func (api *API) deleteDevice(c *gin.Context, ctx context.Context, device models.Device) error {
 	var vpc models.VPC
-	result := db.
-		First(&vpc, "id = ?", device.VpcID)
-	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-		api.SendInternalServerError(c, result.Error)
+	if err := api.db.WithContext(ctx).First(&vpc, "id = ?", device.VpcID).Error; err != nil {
+		return err
 	}
 
 	ipamNamespace := defaultIPAMNamespace
@@ -702,49 +880,83 @@ func (api *API) DeleteDevice(c *gin.Context) {
 
 	ipamAddress := device.IPv4TunnelIPs[0].Address
 	orgPrefix := device.IPv4TunnelIPs[0].CIDR
-	advertiseCidrs := device.AdvertiseCidrs
-
-	// Null out unique fields to that a new device can be created later with the same values
-	if res := api.db.WithContext(ctx).
-		Model(&device).
-		Clauses(clause.Returning{Columns: []clause.Column{{Name: "revision"}}}).
-		Where("id = ?", device.Base.ID).
-		Updates(map[string]interface{}{
-			"bearer_token": nil,
-			"public_key":   nil,
-			"deleted_at":   gorm.DeletedAt{Time: time.Now(), Valid: true},
-		}); res.Error != nil {
-		api.SendInternalServerError(c, res.Error)
-		return
-	}
+	ipamAddressV6 := device.IPv6TunnelIPs[0].Address
+	orgPrefixV6 := device.IPv6TunnelIPs[0].CIDR
 
-	api.signalBus.Notify(fmt.Sprintf("/devices/vpc=%s", device.VpcID.String()))
+	err := api.transaction(ctx, func(tx *gorm.DB) error {
+		ipamCtx := ipam.WithTransaction(ctx, tx)
 
-	if ipamAddress != "" && orgPrefix != "" {
-		if err := api.ipam.ReleaseToPool(c.Request.Context(), ipamNamespace, ipamAddress, orgPrefix); err != nil {
-			api.SendInternalServerError(c, fmt.Errorf("failed to release the v4 address to pool: %w", err))
-			return
+		if err := api.recordAuditEvent(c, tx, AuditActionDelete, ResourceDevices, device.OrganizationID, device.ID, device, nil); err != nil {
+			return err
 		}
-	}
 
-	for _, cidr := range advertiseCidrs {
-		if err := api.ipam.ReleaseCIDR(c.Request.Context(), ipamNamespace, cidr); err != nil {
-			api.SendInternalServerError(c, fmt.Errorf("failed to release cidr: %w", err))
-			return
+		// Null out unique fields to that a new device can be created later with the same values
+		res := tx.
+			Model(&device).
+			Clauses(clause.Returning{Columns: []clause.Column{{Name: "revision"}}}).
+			Where("id = ?", device.Base.ID).
+			Updates(map[string]interface{}{
+				"bearer_token": nil,
+				"public_key":   nil,
+				"deleted_at":   gorm.DeletedAt{Time: time.Now(), Valid: true},
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		// the device was deleted concurrently, its addresses have already been released.
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
 		}
-	}
 
-	ipamAddressV6 := device.IPv6TunnelIPs[0].Address
-	orgPrefixV6 := device.IPv6TunnelIPs[0].CIDR
+		var releaseCidrs []string
+		if len(device.AdvertiseCidrs) > 0 {
+			// a standby router takes over the cidrs the device was the primary router for
+			if err := api.updateRoutePrimaries(tx, device.VpcID, nil); err != nil {
+				return err
+			}
+			// the cidrs that are still advertised by other devices of the VPC stay assigned
+			var others []models.Device
+			if res := tx.Select("advertise_cidrs").Where("vpc_id = ?", device.VpcID).Find(&others); res.Error != nil {
+				return res.Error
+			}
+			advertised := map[string]bool{}
+			for _, other := range others {
+				for _, cidr := range other.AdvertiseCidrs {
+					advertised[cidr] = true
+				}
+			}
+			for _, cidr := range device.AdvertiseCidrs {
+				if !advertised[cidr] {
+					releaseCidrs = append(releaseCidrs, cidr)
+				}
+			}
+		}
 
-	if ipamAddressV6 != "" && orgPrefixV6 != "" {
-		if err := api.ipam.ReleaseToPool(c.Request.Context(), ipamNamespace, ipamAddressV6, orgPrefixV6); err != nil {
-			api.SendInternalServerError(c, fmt.Errorf("failed to release the v6 address to pool: %w", err))
-			return
+		// the addresses are released in the transaction so that the device is not deleted
+		// while its addresses stay allocated.
+		if ipamAddress != "" && orgPrefix != "" {
+			if err := api.ipam.ReleaseToPool(ipamCtx, ipamNamespace, ipamAddress, orgPrefix); err != nil {
+				return fmt.Errorf("failed to release the v4 address to pool: %w", err)
+			}
 		}
+		if ipamAddressV6 != "" && orgPrefixV6 != "" {
+			if err := api.ipam.ReleaseToPool(ipamCtx, ipamNamespace, ipamAddressV6, orgPrefixV6); err != nil {
+				return fmt.Errorf("failed to release the v6 address to pool: %w", err)
+			}
+		}
+		for _, cidr := range releaseCidrs {
+			if err := api.ipam.ReleaseCIDR(ipamCtx, ipamNamespace, cidr); err != nil {
+				return fmt.Errorf("failed to release cidr: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	c.JSON(http.StatusOK, device)
+	api.signalBus.Notify(fmt.Sprintf("/devices/vpc=%s", device.VpcID.String()))
+	return nil
 }
 
 func advertiseCidrEquals(existingPrefix, newPrefix []string) bool {
@@ -823,7 +1035,8 @@ func (api *API) ListDevicesInVPC(c *gin.Context) {
 	}
 
 	api.sendList(c, ctx, func(db *gorm.DB) (fetchmgr.ResourceList, error) {
-		db = db.Where("vpc_id = ?", vpcId.String())
+		// pending devices are not peers of the other devices until they are approved
+		db = db.Where("vpc_id = ? AND pending = ?", vpcId.String(), false)
 		db = FilterAndPaginateWithQuery(db, &models.Device{}, c, query, "hostname")
 
 		var items deviceList
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nexodus-io/nexodus/internal/models"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ApproveDevice approves a pending device
+// @Summary      Approve Device
+// @Description  Approves a device that is pending approval to join its VPC
+// @Id  		 ApproveDevice
+// @Tags         Devices
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true "Device ID"
+// @Success      200  {object}  models.Device
+// @Failure		 401  {object}  models.BaseError
+// @Failure      400  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/devices/{id}/approve [post]
+func (api *API) ApproveDevice(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "ApproveDevice", trace.WithAttributes(
+		attribute.String("id", c.Param("id")),
+	))
+	defer span.End()
+
+	if !api.FlagCheck(c, "devices") {
+		return
+	}
+
+	deviceId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+
+	var device models.Device
+	err = api.transaction(ctx, func(tx *gorm.DB) error {
+		// the owner of a device can not approve it, it takes the permission to manage the devices of the organization.
+		result := api.CurrentUserHasPermission(c, tx, "organization_id", ResourceDevices, VerbWrite).
+			First(&device, "id = ?", deviceId)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("device"))
+		} else if result.Error != nil {
+			return result.Error
+		}
+		if !device.Pending {
+			return nil
+		}
+
+		before := device
+		device.Pending = false
+		if res := tx.
+			Clauses(clause.Returning{Columns: []clause.Column{{Name: "revision"}}}).
+			Select("pending").
+			Updates(&device); res.Error != nil {
+			return res.Error
+		}
+		if len(device.AdvertiseCidrs) > 0 {
+			if err := api.updateRoutePrimaries(tx, device.VpcID, &device); err != nil {
+				return err
+			}
+		}
+		return api.recordAuditEvent(c, tx, AuditActionUpdate, ResourceDevices, device.OrganizationID, device.ID, before, device)
+	})
+	if err != nil {
+		var apiResponseError *ApiResponseError
+		if errors.As(err, &apiResponseError) {
+			c.JSON(apiResponseError.Status, apiResponseError.Body)
+		} else {
+			api.SendInternalServerError(c, err)
+		}
+		return
+	}
+
+	api.signalBus.Notify(fmt.Sprintf("/devices/vpc=%s", device.VpcID.String()))
+	hideDeviceBearerToken(&device, nil, api.GetCurrentUserID(c))
+	c.JSON(http.StatusOK, device)
+}
+
+// RejectDevice rejects a pending device
+// @Summary      Reject Device
+// @Description  Rejects a device that is pending approval to join its VPC, the device is deleted
+// @Id  		 RejectDevice
+// @Tags         Devices
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true "Device ID"
+// @Success      200  {object}  models.Device
+// @Failure		 401  {object}  models.BaseError
+// @Failure      400  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/devices/{id}/reject [post]
+func (api *API) RejectDevice(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "RejectDevice", trace.WithAttributes(
+		attribute.String("id", c.Param("id")),
+	))
+	defer span.End()
+
+	if !api.FlagCheck(c, "devices") {
+		return
+	}
+
+	deviceId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+
+	var device models.Device
+	result := api.CurrentUserHasPermission(c, api.db.WithContext(ctx), "organization_id", ResourceDevices, VerbWrite).
+		First(&device, "id = ?", deviceId)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, models.NewNotFoundError("device"))
+		return
+	} else if result.Error != nil {
+		api.SendInternalServerError(c, result.Error)
+		return
+	}
+	if !device.Pending {
+		c.JSON(http.StatusBadRequest, models.NewBaseError("device is not pending approval"))
+		return
+	}
+
+	if err := api.deleteDevice(c, ctx, device); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, models.NewNotFoundError("device"))
+		} else {
+			api.SendInternalServerError(c, err)
+		}
+		return
+	}
+
+	hideDeviceBearerToken(&device, nil, api.GetCurrentUserID(c))
+	c.JSON(http.StatusOK, device)
+}
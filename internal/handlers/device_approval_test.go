@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/nexodus-io/nexodus/internal/models"
+)
+
+func (suite *HandlerTestSuite) TestDeviceApproval() {
+	require := suite.Require()
+
+	requireDeviceApproval := true
+	_, res, err := suite.ServeRequest(
+		http.MethodPatch, "/:id", fmt.Sprintf("/%s", suite.testUserID),
+		suite.api.UpdateVPC, bytes.NewBuffer(suite.jsonMarshal(models.UpdateVPC{
+			RequireDeviceApproval: &requireDeviceApproval,
+		})),
+	)
+	require.NoError(err)
+	require.Equal(http.StatusOK, res.Code, "HTTP error: %s", res.Body.String())
+
+	createDevice := func(publicKey string) models.Device {
+		_, res, err := suite.ServeRequest(
+			http.MethodPost,
+			"/", "/",
+			suite.api.CreateDevice, bytes.NewBuffer(suite.jsonMarshal(models.AddDevice{
+				VpcID:     suite.testUserID,
+				PublicKey: publicKey,
+			})),
+		)
+		require.NoError(err)
+		body, err := io.ReadAll(res.Body)
+		require.NoError(err)
+		require.Equal(http.StatusCreated, res.Code, "HTTP error: %s", string(body))
+		var device models.Device
+		require.NoError(json.Unmarshal(body, &device))
+		require.True(device.Pending)
+		return device
+	}
+	listVpcDevices := func() []models.Device {
+		_, res, err := suite.ServeRequest(
+			http.MethodGet, "/:id/devices", fmt.Sprintf("/%s/devices", suite.testUserID),
+			suite.api.ListDevicesInVPC, nil,
+		)
+		require.NoError(err)
+		body, err := io.ReadAll(res.Body)
+		require.NoError(err)
+		require.Equal(http.StatusOK, res.Code, "HTTP error: %s", string(body))
+		var devices []models.Device
+		require.NoError(json.Unmarshal(body, &devices))
+		return devices
+	}
+
+	approved := createDevice("apendingpubkey")
+	rejected := createDevice("arejectedpubkey")
+
+	// pending devices are not served to the vpc
+	require.Len(listVpcDevices(), 0)
+
+	_, res, err = suite.ServeRequest(
+		http.MethodPost, "/:id/approve", fmt.Sprintf("/%s/approve", approved.ID),
+		suite.api.ApproveDevice, nil,
+	)
+	require.NoError(err)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(err)
+	require.Equal(http.StatusOK, res.Code, "HTTP error: %s", string(body))
+	var device models.Device
+	require.NoError(json.Unmarshal(body, &device))
+	require.False(device.Pending)
+
+	devices := listVpcDevices()
+	require.Len(devices, 1)
+	require.Equal(approved.ID, devices[0].ID)
+
+	// approved devices can not be rejected
+	_, res, err = suite.ServeRequest(
+		http.MethodPost, "/:id/reject", fmt.Sprintf("/%s/reject", approved.ID),
+		suite.api.RejectDevice, nil,
+	)
+	require.NoError(err)
+	require.Equal(http.StatusBadRequest, res.Code)
+
+	_, res, err = suite.ServeRequest(
+		http.MethodPost, "/:id/reject", fmt.Sprintf("/%s/reject", rejected.ID),
+		suite.api.RejectDevice, nil,
+	)
+	require.NoError(err)
+	require.Equal(http.StatusOK, res.Code, "HTTP error: %s", res.Body.String())
+
+	_, res, err = suite.ServeRequest(
+		http.MethodGet, "/:id", fmt.Sprintf("/%s", rejected.ID),
+		suite.api.GetDevice, nil,
+	)
+	require.NoError(err)
+	require.Equal(http.StatusNotFound, res.Code)
+}
@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nexodus-io/nexodus/internal/models"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const (
+	// maxReportedDeniedFlows is the most denied flows a device can report at once
+	maxReportedDeniedFlows = 100
+	// deniedFlowRetention is how long the reported denied flows are kept
+	deniedFlowRetention = 7 * 24 * time.Hour
+)
+
+// ReportDeniedFlows records the flows denied by the security group of a device
+// @Summary      Report Denied Flows
+// @Description  Records the flows denied by the security group of a device, they are kept for a week
+// @Id  		 ReportDeniedFlows
+// @Tags         Devices
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true "Device ID"
+// @Param        flows  body    models.ReportDeniedFlows  true  "Denied Flows"
+// @Success      204
+// @Failure		 401  {object}  models.BaseError
+// @Failure      400  {object}  models.BaseError
+// @Failure      403  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/devices/{id}/denied-flows [post]
+func (api *API) ReportDeniedFlows(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "ReportDeniedFlows", trace.WithAttributes(
+		attribute.String("id", c.Param("id")),
+	))
+	defer span.End()
+
+	if !api.FlagCheck(c, "devices") {
+		return
+	}
+
+	deviceId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+
+	var request models.ReportDeniedFlows
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPayloadError(err))
+		return
+	}
+	if len(request.DeniedFlows) == 0 || len(request.DeniedFlows) > maxReportedDeniedFlows {
+		c.JSON(http.StatusBadRequest, models.NewFieldValidationError("denied_flows", fmt.Sprintf("must hold between 1 and %d flows", maxReportedDeniedFlows)))
+		return
+	}
+
+	err = api.transaction(ctx, func(tx *gorm.DB) error {
+		var device models.Device
+		result := api.DeviceIsOwnedByCurrentUser(c, tx).First(&device, "id = ?", deviceId)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("device"))
+		} else if result.Error != nil {
+			return result.Error
+		}
+
+		// a device can only report the flows denied by its own security group
+		tokenClaims, apiErr := NxodusClaims(c, tx)
+		if apiErr != nil {
+			return apiErr
+		}
+		if tokenClaims != nil && tokenClaims.Scope == "device-token" && tokenClaims.ID != device.ID.String() {
+			return NewApiResponseError(http.StatusForbidden, models.NewApiError(errors.New("device token does not have access")))
+		}
+
+		flows := make([]models.DeviceDeniedFlow, 0, len(request.DeniedFlows))
+		for _, flow := range request.DeniedFlows {
+			flows = append(flows, models.DeviceDeniedFlow{
+				OrganizationID: device.OrganizationID,
+				DeviceID:       device.ID,
+				Time:           flow.Time,
+				Direction:      flow.Direction,
+				Protocol:       flow.Protocol,
+				Source:         flow.Source,
+				Destination:    flow.Destination,
+				Port:           flow.Port,
+			})
+		}
+		return tx.Create(&flows).Error
+	})
+	if err != nil {
+		var apiResponseError *ApiResponseError
+		if errors.As(err, &apiResponseError) {
+			c.JSON(apiResponseError.Status, apiResponseError.Body)
+		} else {
+			api.SendInternalServerError(c, err)
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListDeniedFlows lists the flows denied by the security group of a device
+// @Summary      List Denied Flows
+// @Description  Lists the flows reported as denied by the security group of a device during the last week, most recent first
+// @Id  		 ListDeniedFlows
+// @Tags         Devices
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true "Device ID"
+// @Success      200  {object}  []models.DeviceDeniedFlow
+// @Failure		 401  {object}  models.BaseError
+// @Failure      400  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/devices/{id}/denied-flows [get]
+func (api *API) ListDeniedFlows(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "ListDeniedFlows", trace.WithAttributes(
+		attribute.String("id", c.Param("id")),
+	))
+	defer span.End()
+
+	if !api.FlagCheck(c, "devices") {
+		return
+	}
+
+	deviceId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+
+	var device models.Device
+	db := api.db.WithContext(ctx)
+	if res := api.DeviceIsReadableByCurrentUser(c, db).
+		First(&device, "id = ?", deviceId); res.Error != nil {
+		if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, models.NewNotFoundError("device"))
+		} else {
+			api.SendInternalServerError(c, res.Error)
+		}
+		return
+	}
+
+	flows := make([]models.DeviceDeniedFlow, 0)
+	db = db.Where("device_id = ? AND created_at >= ?", device.ID, time.Now().Add(-deniedFlowRetention))
+	db = FilterAndPaginate(db, &models.DeviceDeniedFlow{}, c, "time DESC")
+	if res := db.Find(&flows); res.Error != nil {
+		api.SendInternalServerError(c, res.Error)
+		return
+	}
+	c.JSON(http.StatusOK, flows)
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/nexodus-io/nexodus/internal/models"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+func (suite *HandlerTestSuite) TestReportDeniedFlows() {
+	require := suite.Require()
+
+	privateKey, err := wgtypes.GeneratePrivateKey()
+	require.NoError(err)
+	_, res, err := suite.ServeRequest(
+		http.MethodPost,
+		"/", "/",
+		suite.api.CreateDevice, bytes.NewBuffer(suite.jsonMarshal(models.AddDevice{
+			VpcID:     suite.testUserID,
+			PublicKey: privateKey.PublicKey().String(),
+		})),
+	)
+	require.NoError(err)
+	require.Equal(http.StatusCreated, res.Code, "HTTP error: %s", res.Body.String())
+	var device models.Device
+	require.NoError(json.Unmarshal(res.Body.Bytes(), &device))
+
+	report := func(flows []models.DeniedFlow) int {
+		_, res, err := suite.ServeRequest(
+			http.MethodPost, "/:id/denied-flows", fmt.Sprintf("/%s/denied-flows", device.ID),
+			suite.api.ReportDeniedFlows, bytes.NewBuffer(suite.jsonMarshal(models.ReportDeniedFlows{
+				DeniedFlows: flows,
+			})),
+		)
+		require.NoError(err)
+		return res.Code
+	}
+
+	flow := models.DeniedFlow{
+		Time:        time.Now().UTC(),
+		Direction:   "inbound",
+		Protocol:    "tcp",
+		Source:      "100.64.0.2",
+		Destination: "100.64.0.1",
+		Port:        22,
+	}
+	require.Equal(http.StatusNoContent, report([]models.DeniedFlow{flow}))
+
+	// a report holds at least one flow and at most maxReportedDeniedFlows
+	require.Equal(http.StatusBadRequest, report(nil))
+	tooMany := make([]models.DeniedFlow, maxReportedDeniedFlows+1)
+	for i := range tooMany {
+		tooMany[i] = flow
+	}
+	require.Equal(http.StatusBadRequest, report(tooMany))
+
+	// a flow reported before the retention period is no longer listed, and is garbage collected
+	require.NoError(suite.api.db.Create(&models.DeviceDeniedFlow{
+		CreatedAt:      time.Now().Add(-deniedFlowRetention - time.Hour),
+		OrganizationID: suite.testUserID,
+		DeviceID:       device.ID,
+		Time:           time.Now().Add(-deniedFlowRetention - time.Hour),
+		Direction:      "inbound",
+		Protocol:       "udp",
+		Source:         "100.64.0.3",
+		Destination:    "100.64.0.1",
+		Port:           53,
+	}).Error)
+
+	_, res, err = suite.ServeRequest(
+		http.MethodGet, "/:id/denied-flows", fmt.Sprintf("/%s/denied-flows", device.ID),
+		suite.api.ListDeniedFlows, nil,
+	)
+	require.NoError(err)
+	require.Equal(http.StatusOK, res.Code, "HTTP error: %s", res.Body.String())
+	var flows []models.DeviceDeniedFlow
+	require.NoError(json.Unmarshal(res.Body.Bytes(), &flows))
+	require.Len(flows, 1)
+	require.Equal(device.ID, flows[0].DeviceID)
+	require.Equal(suite.testUserID, flows[0].OrganizationID)
+	require.Equal("100.64.0.2", flows[0].Source)
+	require.Equal(22, flows[0].Port)
+
+	_, res, err = suite.ServeRequest(
+		http.MethodPost, "/admin/gc", "/admin/gc",
+		suite.api.GarbageCollect, nil,
+	)
+	require.NoError(err)
+	require.Equal(http.StatusNoContent, res.Code)
+	var count int64
+	require.NoError(suite.api.db.Model(&models.DeviceDeniedFlow{}).Where("device_id = ?", device.ID).Count(&count).Error)
+	require.Equal(int64(1), count)
+
+	// the denied flows are not recorded as audit events, those only hold the changes made to the resources
+	filter := fmt.Sprintf(`{"resource_id":"%s"}`, device.ID)
+	_, res, err = suite.ServeRequest(
+		http.MethodGet, "/organizations/:id/audit-events", fmt.Sprintf("/organizations/%s/audit-events?sort=%s&filter=%s", suite.testUserID, `["created_at","ASC"]`, filter),
+		suite.api.ListAuditEventsInOrganization, nil,
+	)
+	require.NoError(err)
+	require.Equal(http.StatusOK, res.Code, "HTTP error: %s", res.Body.String())
+
+	var events []models.AuditEvent
+	require.NoError(json.Unmarshal(res.Body.Bytes(), &events))
+	require.Len(events, 1)
+	require.Equal(AuditActionCreate, events[0].Action)
+}
@@ -49,7 +49,7 @@ func (api *API) ListDeviceMetadata(c *gin.Context) {
 
 	var device models.Device
 	db := api.db.WithContext(ctx)
-	result := api.DeviceIsOwnedByCurrentUser(c, db).
+	result := api.DeviceIsReadableByCurrentUser(c, db).
 		First(&device, "id = ?", deviceId)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
@@ -222,7 +222,7 @@ func (api *API) GetDeviceMetadataKey(c *gin.Context) {
 	err = api.transaction(ctx, func(tx *gorm.DB) error {
 		var device models.Device
 		db := api.db.WithContext(ctx)
-		result := api.DeviceIsOwnedByCurrentUser(c, db).
+		result := api.DeviceIsReadableByCurrentUser(c, db).
 			First(&device, "id = ?", deviceId)
 		if result.Error != nil {
 			return result.Error
@@ -293,10 +293,25 @@ func (api *API) UpdateDeviceMetadataKey(c *gin.Context) {
 			return result.Error
 		}
 
+		action := AuditActionUpdate
+		var before models.DeviceMetadata
+		result = tx.First(&before, "device_id = ? AND key = ?", deviceId, key)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			action = AuditActionCreate
+		} else if result.Error != nil {
+			return result.Error
+		}
+
 		result = tx.
 			Clauses(clause.Returning{Columns: []clause.Column{{Name: "revision"}}}).
 			Save(&metadataInstance)
-		return result.Error
+		if result.Error != nil {
+			return result.Error
+		}
+		if action == AuditActionCreate {
+			return api.recordAuditEvent(c, tx, action, AuditResourceDeviceMetadata, device.OrganizationID, device.ID, nil, metadataInstance)
+		}
+		return api.recordAuditEvent(c, tx, action, AuditResourceDeviceMetadata, device.OrganizationID, device.ID, before, metadataInstance)
 	})
 
 	if err != nil {
@@ -343,8 +358,20 @@ func (api *API) DeleteDeviceMetadata(c *gin.Context) {
 			return result.Error
 		}
 
+		var metadata []models.DeviceMetadata
+		if result = tx.Find(&metadata, "device_id = ?", deviceId); result.Error != nil {
+			return result.Error
+		}
 		result = tx.Delete(&models.DeviceMetadata{}, "device_id", deviceId)
-		return result.Error
+		if result.Error != nil {
+			return result.Error
+		}
+		for _, item := range metadata {
+			if err := api.recordAuditEvent(c, tx, AuditActionDelete, AuditResourceDeviceMetadata, device.OrganizationID, device.ID, item, nil); err != nil {
+				return err
+			}
+		}
+		return nil
 	})
 
 	if err != nil {
@@ -391,11 +418,22 @@ func (api *API) DeleteDeviceMetadataKey(c *gin.Context) {
 			return result.Error
 		}
 
+		var metadata models.DeviceMetadata
+		result = tx.First(&metadata, "device_id = ? AND key = ?", deviceId, key)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil
+		} else if result.Error != nil {
+			return result.Error
+		}
+
 		result = tx.Delete(&models.DeviceMetadata{
 			DeviceID: deviceId,
 			Key:      key,
 		})
-		return result.Error
+		if result.Error != nil {
+			return result.Error
+		}
+		return api.recordAuditEvent(c, tx, AuditActionDelete, AuditResourceDeviceMetadata, device.OrganizationID, device.ID, metadata, nil)
 	})
 
 	if err != nil {
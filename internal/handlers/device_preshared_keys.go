@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nexodus-io/nexodus/internal/models"
+	"github.com/nexodus-io/nexodus/internal/wgcrypto"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+	"gorm.io/gorm"
+)
+
+// GetDevicePresharedKeys gets the wireguard pre-shared keys of a device
+// @Summary      Get Device Pre-shared Keys
+// @Description  Gets the wireguard pre-shared keys a device uses with its peers, each key is sealed to the public key of the device
+// @Id  		 GetDevicePresharedKeys
+// @Tags         Devices
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true "Device ID"
+// @Success      200  {object}  models.DevicePresharedKeys
+// @Failure		 401  {object}  models.BaseError
+// @Failure      400  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure      422  {object}  models.ValidationError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/devices/{id}/preshared-keys [get]
+func (api *API) GetDevicePresharedKeys(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "GetDevicePresharedKeys", trace.WithAttributes(
+		attribute.String("id", c.Param("id")),
+	))
+	defer span.End()
+
+	if !api.FlagCheck(c, "devices") {
+		return
+	}
+
+	deviceId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+
+	db := api.db.WithContext(ctx)
+	var device models.Device
+	result := api.DeviceIsReadableByCurrentUser(c, db).First(&device, "id = ?", deviceId)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, models.NewNotFoundError("device"))
+		return
+	} else if result.Error != nil {
+		api.SendInternalServerError(c, fmt.Errorf("error fetching device: %w", result.Error))
+		return
+	}
+
+	var vpc models.VPC
+	if result := db.First(&vpc, "id = ?", device.VpcID); result.Error != nil {
+		api.SendInternalServerError(c, fmt.Errorf("error fetching vpc: %w", result.Error))
+		return
+	}
+
+	keys := models.DevicePresharedKeys{
+		DeviceID: device.ID,
+		Enabled:  vpc.PresharedKeys && vpc.PresharedKeySecret != "",
+	}
+	if !keys.Enabled || device.Pending {
+		c.JSON(http.StatusOK, keys)
+		return
+	}
+
+	publicKey, err := wgtypes.ParseKey(device.PublicKey)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError("public_key", "not a wireguard public key"))
+		return
+	}
+
+	// pending devices are not peers of the other devices until they are approved
+	var peerIds []uuid.UUID
+	if result := db.Model(&models.Device{}).
+		Where("vpc_id = ? AND pending = ? AND id <> ?", vpc.ID.String(), false, device.ID.String()).
+		Pluck("id", &peerIds); result.Error != nil {
+		api.SendInternalServerError(c, fmt.Errorf("error fetching devices: %w", result.Error))
+		return
+	}
+
+	keys.PresharedKeys = make(map[string]string, len(peerIds))
+	for _, peerId := range peerIds {
+		psk, err := derivePresharedKey(vpc.PresharedKeySecret, device.ID, peerId)
+		if err != nil {
+			api.SendInternalServerError(c, err)
+			return
+		}
+		sealed, err := wgcrypto.SealV1(publicKey[:], psk[:])
+		if err != nil {
+			api.SendInternalServerError(c, fmt.Errorf("failed to seal the pre-shared key: %w", err))
+			return
+		}
+		keys.PresharedKeys[peerId.String()] = sealed.String()
+	}
+
+	c.JSON(http.StatusOK, keys)
+}
+
+// newPresharedKeySecret generates the secret the pre-shared keys of a VPC are derived from
+func newPresharedKeySecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate the pre-shared key secret: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(secret), nil
+}
+
+// derivePresharedKey derives the pre-shared key of a device pair, both devices of the pair get the same key
+// since the device ids are ordered before they are mixed with the secret.
+func derivePresharedKey(secret string, a, b uuid.UUID) (wgtypes.Key, error) {
+	key, err := base64.StdEncoding.DecodeString(secret)
+	if err != nil {
+		return wgtypes.Key{}, fmt.Errorf("invalid pre-shared key secret: %w", err)
+	}
+	if a.String() > b.String() {
+		a, b = b, a
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(a[:])
+	mac.Write(b[:])
+	return wgtypes.NewKey(mac.Sum(nil))
+}
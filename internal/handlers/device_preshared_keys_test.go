@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/nexodus-io/nexodus/internal/models"
+	"github.com/nexodus-io/nexodus/internal/wgcrypto"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+func (suite *HandlerTestSuite) TestDevicePresharedKeys() {
+	require := suite.Require()
+
+	create := func(privateKey wgtypes.Key) models.Device {
+		_, res, err := suite.ServeRequest(
+			http.MethodPost,
+			"/", "/",
+			suite.api.CreateDevice, bytes.NewBuffer(suite.jsonMarshal(models.AddDevice{
+				VpcID:     suite.testUserID,
+				PublicKey: privateKey.PublicKey().String(),
+			})),
+		)
+		require.NoError(err)
+		require.Equal(http.StatusCreated, res.Code, "HTTP error: %s", res.Body.String())
+		var device models.Device
+		require.NoError(json.Unmarshal(res.Body.Bytes(), &device))
+		return device
+	}
+	getKeys := func(device models.Device) models.DevicePresharedKeys {
+		_, res, err := suite.ServeRequest(
+			http.MethodGet, "/:id/preshared-keys", fmt.Sprintf("/%s/preshared-keys", device.ID),
+			suite.api.GetDevicePresharedKeys, nil,
+		)
+		require.NoError(err)
+		require.Equal(http.StatusOK, res.Code, "HTTP error: %s", res.Body.String())
+		var keys models.DevicePresharedKeys
+		require.NoError(json.Unmarshal(res.Body.Bytes(), &keys))
+		return keys
+	}
+	setPresharedKeys := func(enabled bool) {
+		_, res, err := suite.ServeRequest(
+			http.MethodPatch, "/:id", fmt.Sprintf("/%s", suite.testUserID),
+			suite.api.UpdateVPC, bytes.NewBuffer(suite.jsonMarshal(models.UpdateVPC{
+				PresharedKeys: &enabled,
+			})),
+		)
+		require.NoError(err)
+		require.Equal(http.StatusOK, res.Code, "HTTP error: %s", res.Body.String())
+	}
+	open := func(privateKey wgtypes.Key, value string) wgtypes.Key {
+		sealed, err := wgcrypto.ParseSealed(value)
+		require.NoError(err)
+		data, err := sealed.Open(privateKey[:])
+		require.NoError(err)
+		psk, err := wgtypes.NewKey(data)
+		require.NoError(err)
+		return psk
+	}
+
+	keyA, err := wgtypes.GeneratePrivateKey()
+	require.NoError(err)
+	keyB, err := wgtypes.GeneratePrivateKey()
+	require.NoError(err)
+	deviceA := create(keyA)
+	deviceB := create(keyB)
+
+	keys := getKeys(deviceA)
+	require.False(keys.Enabled)
+	require.Empty(keys.PresharedKeys)
+
+	setPresharedKeys(true)
+
+	keysA := getKeys(deviceA)
+	require.True(keysA.Enabled)
+	require.Contains(keysA.PresharedKeys, deviceB.ID.String())
+	keysB := getKeys(deviceB)
+	require.True(keysB.Enabled)
+	require.Contains(keysB.PresharedKeys, deviceA.ID.String())
+
+	// both devices of the pair use the same key, and only they can open it
+	pskA := open(keyA, keysA.PresharedKeys[deviceB.ID.String()])
+	pskB := open(keyB, keysB.PresharedKeys[deviceA.ID.String()])
+	require.Equal(pskA, pskB)
+	sealed, err := wgcrypto.ParseSealed(keysA.PresharedKeys[deviceB.ID.String()])
+	require.NoError(err)
+	_, err = sealed.Open(keyB[:])
+	require.Error(err)
+
+	// turning the setting off and on again replaces the keys
+	setPresharedKeys(false)
+	require.False(getKeys(deviceA).Enabled)
+	setPresharedKeys(true)
+	keysA = getKeys(deviceA)
+	require.NotEqual(pskA, open(keyA, keysA.PresharedKeys[deviceB.ID.String()]))
+}
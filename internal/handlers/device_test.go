@@ -2,14 +2,20 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/google/uuid"
+	"github.com/nexodus-io/nexodus/internal/ipam"
 	"github.com/nexodus-io/nexodus/internal/models"
 	"github.com/stretchr/testify/assert"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
 )
 
 func (suite *HandlerTestSuite) TestCreateGetDevice() {
@@ -60,6 +66,173 @@ func (suite *HandlerTestSuite) TestCreateGetDevice() {
 	assert.Equal(actual, device)
 }
 
+func (suite *HandlerTestSuite) TestDeviceLabels() {
+	require := suite.Require()
+	newDevice := models.AddDevice{
+		VpcID:     suite.testUserID,
+		PublicKey: "alabeledpubkey",
+		Labels:    map[string]string{"role": "db"},
+	}
+
+	_, res, err := suite.ServeRequest(
+		http.MethodPost,
+		"/", "/",
+		suite.api.CreateDevice, bytes.NewBuffer(suite.jsonMarshal(newDevice)),
+	)
+	require.NoError(err)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(err)
+	require.Equal(http.StatusCreated, res.Code, "HTTP error: %s", string(body))
+
+	var device models.Device
+	require.NoError(json.Unmarshal(body, &device))
+	require.Equal(map[string]string{"role": "db"}, device.Labels)
+
+	_, res, err = suite.ServeRequest(
+		http.MethodPatch, "/:id", fmt.Sprintf("/%s", device.ID),
+		suite.api.UpdateDevice, bytes.NewBuffer(suite.jsonMarshal(models.UpdateDevice{
+			Labels: map[string]string{"role": "db", "env": "prod"},
+		})),
+	)
+	require.NoError(err)
+	body, err = io.ReadAll(res.Body)
+	require.NoError(err)
+	require.Equal(http.StatusOK, res.Code, "HTTP error: %s", string(body))
+
+	var updated models.Device
+	require.NoError(json.Unmarshal(body, &updated))
+	require.Equal(map[string]string{"role": "db", "env": "prod"}, updated.Labels)
+
+	_, res, err = suite.ServeRequest(
+		http.MethodPatch, "/:id", fmt.Sprintf("/%s", device.ID),
+		suite.api.UpdateDevice, bytes.NewBuffer(suite.jsonMarshal(models.UpdateDevice{
+			Labels: map[string]string{"role": "d,b"},
+		})),
+	)
+	require.NoError(err)
+	require.Equal(http.StatusUnprocessableEntity, res.Code)
+}
+
+func (suite *HandlerTestSuite) TestDeviceKeyRotation() {
+	require := suite.Require()
+	newKey := func() string {
+		key, err := wgtypes.GeneratePrivateKey()
+		require.NoError(err)
+		return key.PublicKey().String()
+	}
+	create := func(publicKey string) models.Device {
+		_, res, err := suite.ServeRequest(
+			http.MethodPost,
+			"/", "/",
+			suite.api.CreateDevice, bytes.NewBuffer(suite.jsonMarshal(models.AddDevice{
+				VpcID:     suite.testUserID,
+				PublicKey: publicKey,
+			})),
+		)
+		require.NoError(err)
+		require.Equal(http.StatusCreated, res.Code, "HTTP error: %s", res.Body.String())
+		var device models.Device
+		require.NoError(json.Unmarshal(res.Body.Bytes(), &device))
+		return device
+	}
+	rotate := func(device models.Device, update models.UpdateDevice) *httptest.ResponseRecorder {
+		_, res, err := suite.ServeRequest(
+			http.MethodPatch, "/:id", fmt.Sprintf("/%s", device.ID),
+			suite.api.UpdateDevice, bytes.NewBuffer(suite.jsonMarshal(update)),
+		)
+		require.NoError(err)
+		return res
+	}
+
+	device := create(newKey())
+	other := create(newKey())
+	rotatedKey := newKey()
+
+	res := rotate(device, models.UpdateDevice{PublicKey: "not-a-key"})
+	require.Equal(http.StatusUnprocessableEntity, res.Code, "HTTP error: %s", res.Body.String())
+
+	res = rotate(device, models.UpdateDevice{PublicKey: rotatedKey})
+	require.Equal(http.StatusBadRequest, res.Code, "HTTP error: %s", res.Body.String())
+
+	staleRevision := device.Revision + 1
+	res = rotate(device, models.UpdateDevice{PublicKey: rotatedKey, Revision: &staleRevision})
+	require.Equal(http.StatusConflict, res.Code, "HTTP error: %s", res.Body.String())
+
+	res = rotate(device, models.UpdateDevice{PublicKey: other.PublicKey, Revision: &device.Revision})
+	require.Equal(http.StatusConflict, res.Code, "HTTP error: %s", res.Body.String())
+	var conflict models.ConflictsError
+	require.NoError(json.Unmarshal(res.Body.Bytes(), &conflict))
+	require.Equal(other.ID.String(), conflict.ID)
+
+	res = rotate(device, models.UpdateDevice{PublicKey: rotatedKey, Revision: &device.Revision})
+	require.Equal(http.StatusOK, res.Code, "HTTP error: %s", res.Body.String())
+	var updated models.Device
+	require.NoError(json.Unmarshal(res.Body.Bytes(), &updated))
+	require.Equal(rotatedKey, updated.PublicKey)
+}
+
+// failingReleaseIPAM is an IPAM that fails to release addresses
+type failingReleaseIPAM struct {
+	ipam.IPAM
+}
+
+func (i failingReleaseIPAM) ReleaseToPool(ctx context.Context, namespace uuid.UUID, address, cidr string) error {
+	return errors.New("ipam is unavailable")
+}
+
+func (suite *HandlerTestSuite) TestDeleteDeviceFailedRelease() {
+	require := suite.Require()
+
+	_, res, err := suite.ServeRequest(
+		http.MethodPost,
+		"/", "/",
+		suite.api.CreateDevice, bytes.NewBuffer(suite.jsonMarshal(models.AddDevice{
+			VpcID:     suite.testUserID,
+			PublicKey: "areleasedpubkey",
+		})),
+	)
+	require.NoError(err)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(err)
+	require.Equal(http.StatusCreated, res.Code, "HTTP error: %s", string(body))
+	var device models.Device
+	require.NoError(json.Unmarshal(body, &device))
+
+	remote := suite.api.ipam
+	suite.api.ipam = failingReleaseIPAM{IPAM: remote}
+	_, res, err = suite.ServeRequest(
+		http.MethodDelete, "/:id", fmt.Sprintf("/%s", device.ID),
+		suite.api.DeleteDevice, nil,
+	)
+	suite.api.ipam = remote
+	require.NoError(err)
+	require.Equal(http.StatusInternalServerError, res.Code)
+
+	// the device is kept along with its addresses when they can not be released
+	_, res, err = suite.ServeRequest(
+		http.MethodGet, "/:id", fmt.Sprintf("/%s", device.ID),
+		suite.api.GetDevice, nil,
+	)
+	require.NoError(err)
+	body, err = io.ReadAll(res.Body)
+	require.NoError(err)
+	require.Equal(http.StatusOK, res.Code, "HTTP error: %s", string(body))
+	var actual models.Device
+	require.NoError(json.Unmarshal(body, &actual))
+	require.Equal(device.IPv4TunnelIPs, actual.IPv4TunnelIPs)
+
+	var events []models.AuditEvent
+	require.NoError(suite.api.db.Find(&events, "resource_id = ? AND action = ?", device.ID, AuditActionDelete).Error)
+	require.Empty(events)
+
+	_, res, err = suite.ServeRequest(
+		http.MethodDelete, "/:id", fmt.Sprintf("/%s", device.ID),
+		suite.api.DeleteDevice, nil,
+	)
+	require.NoError(err)
+	require.Equal(http.StatusOK, res.Code)
+}
+
 func TestAdvertiseCidrEquals(t *testing.T) {
 	tests := []struct {
 		name           string
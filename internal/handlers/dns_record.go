@@ -0,0 +1,436 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nexodus-io/nexodus/internal/database"
+	"github.com/nexodus-io/nexodus/internal/handlers/fetchmgr"
+	"github.com/nexodus-io/nexodus/internal/models"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type dnsRecordList []*models.DNSRecord
+
+func (d dnsRecordList) Item(i int) (any, string, uint64, gorm.DeletedAt) {
+	item := d[i]
+	return item, item.ID.String(), item.Revision, item.DeletedAt
+}
+
+func (d dnsRecordList) Len() int {
+	return len(d)
+}
+
+func (api *API) DNSRecordIsReadableByCurrentUser(c *gin.Context, db *gorm.DB) *gorm.DB {
+	return api.CurrentUserHasPermission(c, db, "organization_id", ResourceDNSRecords, VerbRead)
+}
+
+func (api *API) DNSRecordIsWriteableByCurrentUser(c *gin.Context, db *gorm.DB) *gorm.DB {
+	return api.CurrentUserHasPermission(c, db, "organization_id", ResourceDNSRecords, VerbWrite)
+}
+
+// ListDNSRecordsInVPC lists all the DNS records in a VPC
+// @Summary      List DNS Records in a VPC
+// @Description  Lists all the custom DNS records in a VPC
+// @Id  		 ListDNSRecordsInVPC
+// @Tags         VPC
+// @Accepts		 json
+// @Produce      json
+// @Param		 gt_revision       query     uint64 false "greater than revision"
+// @Param        id                path      string  true "VPC ID"
+// @Success      200  {object}  []models.DNSRecord
+// @Failure		 401  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/vpcs/{id}/dns-records [get]
+func (api *API) ListDNSRecordsInVPC(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "ListDNSRecordsInVPC",
+		trace.WithAttributes(
+			attribute.String("vpc_id", c.Param("id")),
+		))
+	defer span.End()
+
+	vpcId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+	var vpc models.VPC
+	db := api.db.WithContext(ctx)
+	result := api.VPCIsReadableByCurrentUser(c, db).
+		First(&vpc, "id = ?", vpcId.String())
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, models.NewNotFoundError("vpc"))
+		} else {
+			api.SendInternalServerError(c, result.Error)
+		}
+		return
+	}
+
+	var query Query
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, models.NewApiError(err))
+		return
+	}
+
+	api.sendList(c, ctx, func(db *gorm.DB) (fetchmgr.ResourceList, error) {
+		var items dnsRecordList
+		db = db.Where("vpc_id = ?", vpcId.String())
+		db = FilterAndPaginateWithQuery(db, &models.DNSRecord{}, c, query, "name")
+		result := db.Find(&items)
+		if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, result.Error
+		}
+		return items, nil
+	})
+}
+
+// GetDNSRecordInVPC gets a DNS record in a VPC
+// @Summary      Get DNS Record
+// @Description  Gets a DNS record in a VPC by ID
+// @Id  		 GetDNSRecordInVPC
+// @Tags         VPC
+// @Accepts		 json
+// @Produce      json
+// @Param        id          path      string  true "VPC ID"
+// @Param        record_id   path      string  true "DNS Record ID"
+// @Success      200  {object}  models.DNSRecord
+// @Failure		 401  {object}  models.BaseError
+// @Failure      400  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/vpcs/{id}/dns-records/{record_id} [get]
+func (api *API) GetDNSRecordInVPC(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "GetDNSRecordInVPC", trace.WithAttributes(
+		attribute.String("vpc_id", c.Param("id")),
+		attribute.String("id", c.Param("record_id")),
+	))
+	defer span.End()
+
+	vpcId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+	recordId, err := uuid.Parse(c.Param("record_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("record_id"))
+		return
+	}
+
+	var record models.DNSRecord
+	db := api.db.WithContext(ctx)
+	result := api.DNSRecordIsReadableByCurrentUser(c, db).
+		First(&record, "id = ? AND vpc_id = ?", recordId, vpcId)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, models.NewNotFoundError("dns_record"))
+		} else {
+			api.SendInternalServerError(c, result.Error)
+		}
+		return
+	}
+	c.JSON(http.StatusOK, record)
+}
+
+// CreateDNSRecordInVPC handles adding a new DNS record to a VPC
+// @Summary      Add DNS Record
+// @Id  		 CreateDNSRecordInVPC
+// @Tags         VPC
+// @Description  Adds a new custom DNS record to a VPC
+// @Accepts		 json
+// @Produce      json
+// @Param        id          path   string               true "VPC ID"
+// @Param        DNSRecord   body   models.AddDNSRecord  true "Add DNS Record"
+// @Success      201  {object}  models.DNSRecord
+// @Failure      400  {object}  models.BaseError
+// @Failure      401  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure      409  {object}  models.ConflictsError
+// @Failure      422  {object}  models.ValidationError
+// @Failure      429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/vpcs/{id}/dns-records [post]
+func (api *API) CreateDNSRecordInVPC(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "CreateDNSRecordInVPC", trace.WithAttributes(
+		attribute.String("vpc_id", c.Param("id")),
+	))
+	defer span.End()
+
+	vpcId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+
+	var request models.AddDNSRecord
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPayloadError(err))
+		return
+	}
+
+	request.Name = strings.TrimSuffix(strings.ToLower(request.Name), ".")
+	if request.Name == "" {
+		c.JSON(http.StatusBadRequest, models.NewFieldNotPresentError("name"))
+		return
+	}
+	if err := validateDNSRecordName(request.Name); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError("name", err.Error()))
+		return
+	}
+	if err := validateDNSRecordAddresses(request.Addresses); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError("addresses", err.Error()))
+		return
+	}
+
+	var record models.DNSRecord
+	err = api.transaction(ctx, func(tx *gorm.DB) error {
+		var vpc models.VPC
+		if res := api.CurrentUserHasPermission(c, tx, "organization_id", ResourceDNSRecords, VerbCreate).
+			First(&vpc, "id = ?", vpcId); res.Error != nil {
+			if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+				return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("vpc"))
+			}
+			return res.Error
+		}
+
+		var existing models.DNSRecord
+		res := tx.Where("vpc_id = ? AND name = ?", vpc.ID, request.Name).First(&existing)
+		if res.Error == nil {
+			return NewApiResponseError(http.StatusConflict, models.NewConflictsError(existing.ID.String()))
+		} else if !errors.Is(res.Error, gorm.ErrRecordNotFound) {
+			return res.Error
+		}
+
+		record = models.DNSRecord{
+			VpcID:          vpc.ID,
+			OrganizationID: vpc.OrganizationID,
+			Name:           request.Name,
+			Addresses:      request.Addresses,
+			Description:    request.Description,
+		}
+		if res := tx.
+			Clauses(clause.Returning{Columns: []clause.Column{{Name: "revision"}}}).
+			Create(&record); res.Error != nil {
+			if database.IsDuplicateError(res.Error) {
+				return NewApiResponseError(http.StatusConflict, models.NewConflictsError(record.ID.String()))
+			}
+			return res.Error
+		}
+
+		span.SetAttributes(attribute.String("id", record.ID.String()))
+		api.logger.Infof("New dns record created [ %s ] in vpc [ %s ]", record.Name, vpc.ID)
+		return api.recordAuditEvent(c, tx, AuditActionCreate, ResourceDNSRecords, record.OrganizationID, record.ID, nil, record)
+	})
+
+	if err != nil {
+		var apiResponseError *ApiResponseError
+		if errors.As(err, &apiResponseError) {
+			c.JSON(apiResponseError.Status, apiResponseError.Body)
+		} else {
+			api.SendInternalServerError(c, err)
+		}
+		return
+	}
+
+	api.signalBus.Notify(fmt.Sprintf("/dns-records/vpc=%s", record.VpcID.String()))
+	c.JSON(http.StatusCreated, record)
+}
+
+// UpdateDNSRecordInVPC updates a DNS record in a VPC
+// @Summary      Update DNS Record
+// @Description  Updates a DNS record in a VPC by ID
+// @Id           UpdateDNSRecordInVPC
+// @Tags         VPC
+// @Accepts      json
+// @Produce      json
+// @Param        id          path      string  true "VPC ID"
+// @Param        record_id   path      string  true "DNS Record ID"
+// @Param        update      body      models.UpdateDNSRecord true "DNS Record Update"
+// @Success      200  {object}     models.DNSRecord
+// @Failure      400  {object}     models.BaseError
+// @Failure      401  {object}     models.BaseError
+// @Failure      404  {object}     models.BaseError
+// @Failure      422  {object}     models.ValidationError
+// @Failure      429  {object}     models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/vpcs/{id}/dns-records/{record_id} [patch]
+func (api *API) UpdateDNSRecordInVPC(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "UpdateDNSRecordInVPC", trace.WithAttributes(
+		attribute.String("vpc_id", c.Param("id")),
+		attribute.String("id", c.Param("record_id")),
+	))
+	defer span.End()
+
+	vpcId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+	recordId, err := uuid.Parse(c.Param("record_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("record_id"))
+		return
+	}
+
+	var request models.UpdateDNSRecord
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPayloadError(err))
+		return
+	}
+	if request.Addresses != nil {
+		if err := validateDNSRecordAddresses(request.Addresses); err != nil {
+			c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError("addresses", err.Error()))
+			return
+		}
+	}
+
+	var record models.DNSRecord
+	err = api.transaction(ctx, func(tx *gorm.DB) error {
+		result := api.DNSRecordIsWriteableByCurrentUser(c, tx).
+			First(&record, "id = ? AND vpc_id = ?", recordId, vpcId)
+		if result.Error != nil {
+			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+				return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("dns_record"))
+			}
+			return result.Error
+		}
+		before := record
+
+		if request.Addresses != nil {
+			record.Addresses = request.Addresses
+		}
+		if request.Description != nil {
+			record.Description = *request.Description
+		}
+
+		if res := tx.
+			Clauses(clause.Returning{Columns: []clause.Column{{Name: "revision"}}}).
+			Save(&record); res.Error != nil {
+			return res.Error
+		}
+		return api.recordAuditEvent(c, tx, AuditActionUpdate, ResourceDNSRecords, record.OrganizationID, record.ID, before, record)
+	})
+
+	if err != nil {
+		var apiResponseError *ApiResponseError
+		if errors.As(err, &apiResponseError) {
+			c.JSON(apiResponseError.Status, apiResponseError.Body)
+		} else {
+			api.SendInternalServerError(c, err)
+		}
+		return
+	}
+
+	api.signalBus.Notify(fmt.Sprintf("/dns-records/vpc=%s", record.VpcID.String()))
+	c.JSON(http.StatusOK, record)
+}
+
+// DeleteDNSRecordInVPC handles deleting a DNS record from a VPC
+// @Summary      Delete DNS Record
+// @Description  Deletes a DNS record from a VPC
+// @Id 			 DeleteDNSRecordInVPC
+// @Tags         VPC
+// @Accepts		 json
+// @Produce      json
+// @Param        id          path      string  true "VPC ID"
+// @Param        record_id   path      string  true "DNS Record ID"
+// @Success      200  {object}  models.DNSRecord
+// @Failure      400  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/vpcs/{id}/dns-records/{record_id} [delete]
+func (api *API) DeleteDNSRecordInVPC(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "DeleteDNSRecordInVPC", trace.WithAttributes(
+		attribute.String("vpc_id", c.Param("id")),
+		attribute.String("id", c.Param("record_id")),
+	))
+	defer span.End()
+
+	vpcId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+	recordId, err := uuid.Parse(c.Param("record_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("record_id"))
+		return
+	}
+
+	var record models.DNSRecord
+	err = api.transaction(ctx, func(tx *gorm.DB) error {
+		result := api.DNSRecordIsWriteableByCurrentUser(c, tx).
+			First(&record, "id = ? AND vpc_id = ?", recordId, vpcId)
+		if result.Error != nil {
+			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+				return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("dns_record"))
+			}
+			return result.Error
+		}
+		if res := tx.Delete(&record); res.Error != nil {
+			return res.Error
+		}
+		return api.recordAuditEvent(c, tx, AuditActionDelete, ResourceDNSRecords, record.OrganizationID, record.ID, record, nil)
+	})
+
+	if err != nil {
+		var apiResponseError *ApiResponseError
+		if errors.As(err, &apiResponseError) {
+			c.JSON(apiResponseError.Status, apiResponseError.Body)
+		} else {
+			api.SendInternalServerError(c, err)
+		}
+		return
+	}
+
+	api.signalBus.Notify(fmt.Sprintf("/dns-records/vpc=%s", record.VpcID.String()))
+	c.JSON(http.StatusOK, record)
+}
+
+// validateDNSRecordName checks that the name is made up of valid DNS labels. Names are relative
+// to the zone of the VPC, so they must leave room for the <vpc-id>.nexodus.internal suffix.
+func validateDNSRecordName(name string) error {
+	if len(name) > 190 {
+		return fmt.Errorf("must be at most 190 characters")
+	}
+	for _, label := range strings.Split(name, ".") {
+		if label == "" || len(label) > 63 {
+			return fmt.Errorf("labels must be between 1 and 63 characters")
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return fmt.Errorf("labels must not start or end with a hyphen")
+		}
+		for _, r := range label {
+			if !((r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-') {
+				return fmt.Errorf("labels may only contain letters, digits and hyphens")
+			}
+		}
+	}
+	return nil
+}
+
+// validateDNSRecordAddresses checks that at least one valid IPv4 or IPv6 address is given
+func validateDNSRecordAddresses(addresses []string) error {
+	if len(addresses) == 0 {
+		return fmt.Errorf("at least one address is required")
+	}
+	for _, address := range addresses {
+		if _, err := netip.ParseAddr(address); err != nil {
+			return fmt.Errorf("invalid IP address: %s", address)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/nexodus-io/nexodus/internal/database"
+	"github.com/nexodus-io/nexodus/internal/models"
+)
+
+func (suite *HandlerTestSuite) TestCreateGetDeleteDNSRecords() {
+	require := suite.Require()
+	vpcUri := fmt.Sprintf("/vpcs/%s/dns-records", suite.testUserID)
+
+	_, res, err := suite.ServeRequest(
+		http.MethodPost, "/vpcs/:id/dns-records", vpcUri,
+		suite.api.CreateDNSRecordInVPC,
+		bytes.NewBuffer(suite.jsonMarshal(models.AddDNSRecord{
+			Name:      "DB.prod",
+			Addresses: []string{"100.64.0.10", "200::10"},
+		})),
+	)
+	require.NoError(err)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(err)
+	require.Equal(http.StatusCreated, res.Code, "HTTP error: %s", string(body))
+
+	var record models.DNSRecord
+	require.NoError(json.Unmarshal(body, &record))
+	require.Equal("db.prod", record.Name)
+	require.Equal(suite.testUserID, record.VpcID)
+
+	// the same name can't be added twice to the vpc
+	_, res, err = suite.ServeRequest(
+		http.MethodPost, "/vpcs/:id/dns-records", vpcUri,
+		suite.api.CreateDNSRecordInVPC,
+		bytes.NewBuffer(suite.jsonMarshal(models.AddDNSRecord{
+			Name:      "db.prod",
+			Addresses: []string{"100.64.0.11"},
+		})),
+	)
+	require.NoError(err)
+	require.Equal(http.StatusConflict, res.Code)
+
+	// the database enforces it as well for concurrent requests
+	res2 := suite.api.db.Create(&models.DNSRecord{
+		VpcID:          record.VpcID,
+		OrganizationID: record.OrganizationID,
+		Name:           record.Name,
+	})
+	require.True(database.IsDuplicateError(res2.Error), "expected a duplicate error: %v", res2.Error)
+
+	_, res, err = suite.ServeRequest(
+		http.MethodGet, "/vpcs/:id/dns-records/:record_id", fmt.Sprintf("%s/%s", vpcUri, record.ID),
+		suite.api.GetDNSRecordInVPC, nil,
+	)
+	require.NoError(err)
+	body, err = io.ReadAll(res.Body)
+	require.NoError(err)
+	require.Equal(http.StatusOK, res.Code, "HTTP error: %s", string(body))
+
+	var actual models.DNSRecord
+	require.NoError(json.Unmarshal(body, &actual))
+	require.Equal(record, actual)
+
+	_, res, err = suite.ServeRequest(
+		http.MethodGet, "/vpcs/:id/dns-records", vpcUri,
+		suite.api.ListDNSRecordsInVPC, nil,
+	)
+	require.NoError(err)
+	body, err = io.ReadAll(res.Body)
+	require.NoError(err)
+	require.Equal(http.StatusOK, res.Code, "HTTP error: %s", string(body))
+
+	var records []models.DNSRecord
+	require.NoError(json.Unmarshal(body, &records))
+	require.Len(records, 1)
+
+	_, res, err = suite.ServeRequest(
+		http.MethodDelete, "/vpcs/:id/dns-records/:record_id", fmt.Sprintf("%s/%s", vpcUri, record.ID),
+		suite.api.DeleteDNSRecordInVPC, nil,
+	)
+	require.NoError(err)
+	require.Equal(http.StatusOK, res.Code)
+
+	_, res, err = suite.ServeRequest(
+		http.MethodGet, "/vpcs/:id/dns-records/:record_id", fmt.Sprintf("%s/%s", vpcUri, record.ID),
+		suite.api.GetDNSRecordInVPC, nil,
+	)
+	require.NoError(err)
+	require.Equal(http.StatusNotFound, res.Code)
+
+	// the name of a deleted record can be reused
+	_, res, err = suite.ServeRequest(
+		http.MethodPost, "/vpcs/:id/dns-records", vpcUri,
+		suite.api.CreateDNSRecordInVPC,
+		bytes.NewBuffer(suite.jsonMarshal(models.AddDNSRecord{
+			Name:      "db.prod",
+			Addresses: []string{"100.64.0.11"},
+		})),
+	)
+	require.NoError(err)
+	require.Equal(http.StatusCreated, res.Code)
+}
+
+func (suite *HandlerTestSuite) TestUpdateDNSRecord() {
+	require := suite.Require()
+	vpcUri := fmt.Sprintf("/vpcs/%s/dns-records", suite.testUserID)
+
+	_, res, err := suite.ServeRequest(
+		http.MethodPost, "/vpcs/:id/dns-records", vpcUri,
+		suite.api.CreateDNSRecordInVPC,
+		bytes.NewBuffer(suite.jsonMarshal(models.AddDNSRecord{
+			Name:      "web",
+			Addresses: []string{"100.64.0.20"},
+		})),
+	)
+	require.NoError(err)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(err)
+	require.Equal(http.StatusCreated, res.Code, "HTTP error: %s", string(body))
+
+	var record models.DNSRecord
+	require.NoError(json.Unmarshal(body, &record))
+
+	_, res, err = suite.ServeRequest(
+		http.MethodPatch, "/vpcs/:id/dns-records/:record_id", fmt.Sprintf("%s/%s", vpcUri, record.ID),
+		suite.api.UpdateDNSRecordInVPC,
+		bytes.NewBuffer(suite.jsonMarshal(models.UpdateDNSRecord{
+			Addresses: []string{"100.64.0.21", "100.64.0.22"},
+		})),
+	)
+	require.NoError(err)
+	body, err = io.ReadAll(res.Body)
+	require.NoError(err)
+	require.Equal(http.StatusOK, res.Code, "HTTP error: %s", string(body))
+
+	var updated models.DNSRecord
+	require.NoError(json.Unmarshal(body, &updated))
+	require.Equal([]string{"100.64.0.21", "100.64.0.22"}, updated.Addresses)
+
+	_, res, err = suite.ServeRequest(
+		http.MethodPatch, "/vpcs/:id/dns-records/:record_id", fmt.Sprintf("%s/%s", vpcUri, record.ID),
+		suite.api.UpdateDNSRecordInVPC,
+		bytes.NewBuffer(suite.jsonMarshal(models.UpdateDNSRecord{
+			Addresses: []string{"not-an-ip"},
+		})),
+	)
+	require.NoError(err)
+	require.Equal(http.StatusUnprocessableEntity, res.Code)
+}
+
+func (suite *HandlerTestSuite) TestInvalidDNSRecordName() {
+	require := suite.Require()
+	vpcUri := fmt.Sprintf("/vpcs/%s/dns-records", suite.testUserID)
+
+	for _, name := range []string{"-db", "db..prod", "db_prod", "db prod"} {
+		_, res, err := suite.ServeRequest(
+			http.MethodPost, "/vpcs/:id/dns-records", vpcUri,
+			suite.api.CreateDNSRecordInVPC,
+			bytes.NewBuffer(suite.jsonMarshal(models.AddDNSRecord{
+				Name:      name,
+				Addresses: []string{"100.64.0.30"},
+			})),
+		)
+		require.NoError(err)
+		require.Equal(http.StatusUnprocessableEntity, res.Code, name)
+	}
+}
@@ -42,7 +42,7 @@ func (api *API) Check(ctx context.Context, checkReq *auth.CheckRequest) (*auth.C
 		// Does it look like a reg key?
 		if strings.HasPrefix(authorizationHeader, "Bearer RK:") {
 			token := strings.TrimPrefix(authorizationHeader, "Bearer ")
-			return checkRegistrationToken(ctx, api, token)
+			return checkRegistrationToken(ctx, api, token, checkRequestClientIP(checkReq))
 		} else if strings.HasPrefix(authorizationHeader, "Bearer DT:") {
 			token := strings.TrimPrefix(authorizationHeader, "Bearer ")
 			return checkDeviceToken(ctx, api, token)
@@ -98,7 +98,16 @@ func (api *API) Check(ctx context.Context, checkReq *auth.CheckRequest) (*auth.C
 	}, nil
 }
 
-func checkRegistrationToken(ctx context.Context, api *API, token string) (*auth.CheckResponse, error) {
+// checkRequestClientIP returns the address of the client that sent the request, envoy puts it first
+// in the x-forwarded-for header when it is behind other proxies.
+func checkRequestClientIP(checkReq *auth.CheckRequest) string {
+	if forwardedFor := checkReq.Attributes.Request.Http.Headers["x-forwarded-for"]; forwardedFor != "" {
+		return strings.TrimSpace(strings.Split(forwardedFor, ",")[0])
+	}
+	return checkReq.Attributes.GetSource().GetAddress().GetSocketAddress().GetAddress()
+}
+
+func checkRegistrationToken(ctx context.Context, api *API, token string, clientIP string) (*auth.CheckResponse, error) {
 	var regToken models.RegKey
 	db := api.db.WithContext(ctx)
 	result := db.First(&regToken, "bearer_token = ?", token)
@@ -111,6 +120,10 @@ func checkRegistrationToken(ctx context.Context, api *API, token string) (*auth.
 		return denyCheckResponse(401, models.NewBaseError(message))
 	}
 
+	if !regKeyAllowsSourceIP(regToken, clientIP) {
+		return denyCheckResponse(403, models.NewBaseError("reg key can not be used from this address"))
+	}
+
 	var user models.User
 	result = db.First(&user, "id = ?", regToken.OwnerID)
 	if result.Error != nil {
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nexodus-io/nexodus/internal/models"
+	"github.com/nexodus-io/nexodus/internal/util"
+	"gorm.io/gorm"
+)
+
+const (
+	ephemeralDeviceReapInterval = 30 * time.Second
+	ephemeralDeviceReapLimit    = 100
+)
+
+// StartEphemeralDeviceReaper starts the background worker that deletes the ephemeral devices that
+// have been offline for longer than gracePeriod, and releases their addresses back to IPAM. Devices
+// that never came online are measured from their creation time.
+func (api *API) StartEphemeralDeviceReaper(ctx context.Context, wg *sync.WaitGroup, gracePeriod time.Duration) {
+	util.GoWithWaitGroup(wg, func() {
+		for {
+			more, err := api.reapEphemeralDevices(ctx, gracePeriod)
+			if err != nil {
+				api.logger.Warnf("failed to reap ephemeral devices: %v", err)
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			if more && err == nil {
+				continue
+			}
+			if waitForCancelTimeoutOrNotification(ctx, ephemeralDeviceReapInterval) == -2 {
+				return
+			}
+		}
+	})
+}
+
+// reapEphemeralDevices deletes a batch of the expired ephemeral devices, it returns true when
+// there may be more devices to delete.
+func (api *API) reapEphemeralDevices(ctx context.Context, gracePeriod time.Duration) (bool, error) {
+	var devices []models.Device
+	res := api.db.WithContext(ctx).
+		Where("ephemeral = ? AND online = ? AND COALESCE(online_at, created_at) < ?", true, false, time.Now().Add(-gracePeriod)).
+		Limit(ephemeralDeviceReapLimit).
+		Find(&devices)
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	var errs []error
+	for _, device := range devices {
+		err := api.deleteDevice(nil, ctx, device)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// another apiserver deleted the device concurrently
+			continue
+		} else if err != nil {
+			errs = append(errs, fmt.Errorf("device %s: %w", device.ID, err))
+			continue
+		}
+		api.logger.Infof("deleted ephemeral device %s (%s) after it went offline", device.ID, device.Hostname)
+	}
+	return len(devices) >= ephemeralDeviceReapLimit, errors.Join(errs...)
+}
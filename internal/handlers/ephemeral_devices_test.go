@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/nexodus-io/nexodus/internal/models"
+)
+
+func (suite *HandlerTestSuite) TestReapEphemeralDevices() {
+	require := suite.Require()
+
+	createDevice := func(publicKey string) models.Device {
+		_, res, err := suite.ServeRequest(
+			http.MethodPost,
+			"/", "/",
+			suite.api.CreateDevice, bytes.NewBuffer(suite.jsonMarshal(models.AddDevice{
+				VpcID:     suite.testUserID,
+				PublicKey: publicKey,
+			})),
+		)
+		require.NoError(err)
+		body, err := io.ReadAll(res.Body)
+		require.NoError(err)
+		require.Equal(http.StatusCreated, res.Code, "HTTP error: %s", string(body))
+		var device models.Device
+		require.NoError(json.Unmarshal(body, &device))
+		return device
+	}
+	getDevice := func(device models.Device) int {
+		_, res, err := suite.ServeRequest(
+			http.MethodGet, "/:id", fmt.Sprintf("/%s", device.ID),
+			suite.api.GetDevice, nil,
+		)
+		require.NoError(err)
+		return res.Code
+	}
+
+	offlineAt := time.Now().Add(-time.Hour)
+	expired := createDevice("anexpiredpubkey")
+	recent := createDevice("arecentpubkey")
+	permanent := createDevice("apermanentpubkey")
+	require.NoError(suite.api.db.Model(&models.Device{}).Where("id = ?", expired.ID).
+		Updates(map[string]interface{}{"ephemeral": true, "online": false, "online_at": offlineAt}).Error)
+	require.NoError(suite.api.db.Model(&models.Device{}).Where("id = ?", recent.ID).
+		Updates(map[string]interface{}{"ephemeral": true, "online": false, "online_at": time.Now()}).Error)
+	require.NoError(suite.api.db.Model(&models.Device{}).Where("id = ?", permanent.ID).
+		Updates(map[string]interface{}{"online": false, "online_at": offlineAt}).Error)
+
+	more, err := suite.api.reapEphemeralDevices(context.Background(), 10*time.Minute)
+	require.NoError(err)
+	require.False(more)
+
+	require.Equal(http.StatusNotFound, getDevice(expired))
+	require.Equal(http.StatusOK, getDevice(recent))
+	require.Equal(http.StatusOK, getDevice(permanent))
+
+	// the tunnel ip of the deleted device was released and can be assigned again
+	_, res, err := suite.ServeRequest(
+		http.MethodPost,
+		"/", "/",
+		suite.api.CreateDevice, bytes.NewBuffer(suite.jsonMarshal(models.AddDevice{
+			VpcID:         suite.testUserID,
+			PublicKey:     "areusedpubkey",
+			IPv4TunnelIPs: expired.IPv4TunnelIPs,
+		})),
+	)
+	require.NoError(err)
+	require.Equal(http.StatusCreated, res.Code, "HTTP error: %s", res.Body.String())
+}
@@ -161,7 +161,7 @@ func (api *API) WatchEvents(c *gin.Context) {
 				if gtRevision != 0 {
 					db = db.Where("revision > ?", gtRevision)
 				}
-				db = db.Where("vpc_id = ?", vpcId.String())
+				db = db.Where("vpc_id = ? AND pending = ?", vpcId.String(), false)
 				result := db.Find(&items)
 				if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
 					return nil, result.Error
@@ -242,7 +242,7 @@ func (api *API) WatchEvents(c *gin.Context) {
 				// verify we can read the device...
 				var device models.Device
 				db := api.db.WithContext(ctx)
-				result := api.DeviceIsOwnedByCurrentUser(c, db).
+				result := api.DeviceIsReadableByCurrentUser(c, db).
 					First(&device, "id = ?", watchOptions.DeviceId)
 				if result.Error != nil {
 					if errors.Is(result.Error, gorm.ErrRecordNotFound) {
@@ -530,7 +530,7 @@ func (api *API) WatchEventsInVPC(c *gin.Context) {
 				if gtRevision != 0 {
 					db = db.Where("revision > ?", gtRevision)
 				}
-				db = db.Where("vpc_id = ?", vpcId.String())
+				db = db.Where("vpc_id = ? AND pending = ?", vpcId.String(), false)
 				result := db.Find(&items)
 				if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
 					return nil, result.Error
@@ -641,6 +641,27 @@ func (api *API) WatchEventsInVPC(c *gin.Context) {
 				},
 			})
 
+		case "dns-record":
+			watches = append(watches, Watch{
+				kind:       r.Kind,
+				gtRevision: r.GtRevision,
+				atTail:     r.AtTail,
+				signal:     fmt.Sprintf("/dns-records/vpc=%s", vpcId.String()),
+				fetch: func(db *gorm.DB, gtRevision uint64) (fetchmgr.ResourceList, error) {
+					var items dnsRecordList
+					db = db.Unscoped().Limit(100).Order("revision")
+					if gtRevision != 0 {
+						db = db.Where("revision > ?", gtRevision)
+					}
+					db = db.Where("vpc_id = ?", vpcId.String())
+					result := db.Find(&items)
+					if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+						return nil, result.Error
+					}
+					return items, nil
+				},
+			})
+
 		case "vpc":
 			watches = append(watches, Watch{
 				kind:       r.Kind,
@@ -684,9 +705,12 @@ func (api *API) sendMultiWatch(c *gin.Context, ctx context.Context, watches []Wa
 		idsSent map[string]struct{}
 	}
 
+	activeWatchStreams.Inc()
 	var states []*watchState
 	defer func() {
+		activeWatchStreams.Dec()
 		for _, w := range states {
+			activeWatches.WithLabelValues(w.kind).Dec()
 			if w.sub != nil {
 				w.sub.Close()
 			}
@@ -701,6 +725,8 @@ func (api *API) sendMultiWatch(c *gin.Context, ctx context.Context, watches []Wa
 		// fmt.Sprintf("/devices/vpc=%s", k.String())
 		state.sub = api.signalBus.Subscribe(w.signal)
 
+		activeWatches.WithLabelValues(w.kind).Inc()
+
 		state.idx = 1
 		state.atTail = w.atTail
 		if !state.atTail {
@@ -59,6 +59,40 @@ func (api *API) GarbageCollect(c *gin.Context) {
 		return
 	}
 
+	err = db.Unscoped().
+		Debug().
+		Where("deleted_at < ?", time.Now().Add(-d)).
+		Delete(&models.APIToken{}).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	err = db.Unscoped().
+		Debug().
+		Where("deleted_at < ?", time.Now().Add(-d)).
+		Delete(&models.Webhook{}).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	err = db.Debug().
+		Where("created_at < ? AND status <> ?", time.Now().Add(-webhookDeliveryRetention), models.WebhookDeliveryPending).
+		Delete(&models.WebhookDelivery{}).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	err = db.Debug().
+		Where("created_at < ?", time.Now().Add(-deniedFlowRetention)).
+		Delete(&models.DeviceDeniedFlow{}).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
 	err = db.Unscoped().
 		Debug().
 		Where("deleted_at < ?", time.Now().Add(-d)).
@@ -86,6 +120,15 @@ func (api *API) GarbageCollect(c *gin.Context) {
 		return
 	}
 
+	err = db.Unscoped().
+		Debug().
+		Where("deleted_at < ?", time.Now().Add(-d)).
+		Delete(&models.DNSRecord{}).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
 	err = db.Unscoped().
 		Debug().
 		Where("deleted_at < ?", time.Now().Add(-d)).
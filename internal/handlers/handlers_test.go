@@ -63,7 +63,7 @@ func (suite *HandlerTestSuite) SetupSuite() {
 		}
 	}()
 
-	ipamClient := ipam.NewIPAM(suite.logger, ipamClientAddr)
+	ipamClient := ipam.NewRemoteIPAM(suite.logger, ipamClientAddr)
 
 	redisClient := redis.NewClient(&redis.Options{
 		Addr:             "localhost:6379",
@@ -81,10 +81,12 @@ func (suite *HandlerTestSuite) SetupSuite() {
 }
 
 func (suite *HandlerTestSuite) BeforeTest(_, _ string) {
+	suite.api.db.Exec("DELETE FROM device_denied_flows")
 	suite.api.db.Exec("DELETE FROM devices")
 	suite.api.db.Exec("DELETE FROM vpcs")
 	suite.api.db.Exec("DELETE FROM user_organizations")
 	suite.api.db.Exec("DELETE FROM organizations")
+	suite.api.db.Exec("DELETE FROM roles")
 	suite.api.db.Exec("DELETE FROM user_identities")
 	suite.api.db.Exec("DELETE FROM users")
 	var err error
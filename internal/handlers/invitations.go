@@ -2,11 +2,13 @@ package handlers
 
 import (
 	"errors"
-	"github.com/nexodus-io/nexodus/internal/util"
+	"fmt"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
@@ -16,9 +18,43 @@ import (
 	"gorm.io/gorm"
 )
 
-var allowedRoles = map[string]struct{}{
-	"member": {},
-	"owner":  {},
+// allowedRoles returns the roles that can be granted with an invitation to the organization,
+// the built-in roles and the custom roles of the organization, keyed by name.
+func allowedRoles(db *gorm.DB, orgId uuid.UUID) (map[string]models.Role, error) {
+	roles := map[string]models.Role{}
+	for name, role := range Roles {
+		roles[name] = role
+	}
+	var custom []models.Role
+	if res := db.Where("organization_id = ?", orgId).Find(&custom); res.Error != nil {
+		return nil, res.Error
+	}
+	for _, role := range custom {
+		roles[role.Name] = role
+	}
+	return roles, nil
+}
+
+// checkInvitationRoles checks that the roles of an invitation do not grant more than the roles held by
+// the user that sends it, and that only owners invite owners.
+func checkInvitationRoles(roles map[string]models.Role, inviterRoles []string, requested []string) error {
+	granted := map[string]bool{}
+	for _, name := range inviterRoles {
+		for _, grant := range models.PermissionGrants(roles[name].Permissions) {
+			granted[grant] = true
+		}
+	}
+	for _, name := range requested {
+		if name == "owner" && !slices.Contains(inviterRoles, "owner") {
+			return errors.New("only owners can invite owners")
+		}
+		for _, grant := range models.PermissionGrants(roles[name].Permissions) {
+			if !granted[grant] {
+				return fmt.Errorf("the %s role grants %s, which you do not have", name, grant)
+			}
+		}
+	}
+	return nil
 }
 
 // CreateInvitation creates an invitation
@@ -31,6 +67,7 @@ var allowedRoles = map[string]struct{}{
 // @Param        Invitation  body     models.AddInvitation  true  "Add Invitation"
 // @Success      201  {object}  models.Invitation
 // @Failure      400  {object}  models.BaseError
+// @Failure      403  {object}  models.NotAllowedError
 // @Failure      404  {object}  models.BaseError
 // @Failure		 429  {object}  models.BaseError
 // @Failure      500  {object}  models.InternalServerError "Internal Server Error"
@@ -56,24 +93,44 @@ func (api *API) CreateInvitation(c *gin.Context) {
 	if request.UserID != nil && request.Email != nil {
 		c.JSON(http.StatusBadRequest, models.NewFieldNotPresentError("both email and user_id present"))
 	}
-	if len(util.FilterOutAllowed(request.Roles, allowedRoles)) > 0 {
-		c.JSON(http.StatusBadRequest, models.NewFieldValidationError("roles", "allowed values are: "+strings.Join(maps.Keys(allowedRoles), ", ")))
-		return
-	}
 	if len(request.Roles) == 0 {
 		request.Roles = []string{"member"}
 	}
 
 	db := api.db.WithContext(ctx)
 
-	// Only allow org owners to create invites...
+	// Only allow the users with a role that can invite to create invites...
 	var org models.Organization
-	if res := api.OrganizationIsOwnedByCurrentUser(c, db).
+	if res := api.CurrentUserHasPermission(c, db, "id", ResourceInvitations, VerbCreate).
 		First(&org, "id = ?", request.OrganizationID); res.Error != nil {
 		c.JSON(http.StatusNotFound, models.NewNotFoundError("organization"))
 		return
 	}
 
+	roles, err := allowedRoles(db, org.ID)
+	if err != nil {
+		api.SendInternalServerError(c, err)
+		return
+	}
+	for _, name := range request.Roles {
+		if _, found := roles[name]; !found {
+			names := maps.Keys(roles)
+			sort.Strings(names)
+			c.JSON(http.StatusBadRequest, models.NewFieldValidationError("roles", "allowed values are: "+strings.Join(names, ", ")))
+			return
+		}
+	}
+
+	var membership models.UserOrganization
+	if res := db.First(&membership, "user_id = ? AND organization_id = ?", api.GetCurrentUserID(c), org.ID); res.Error != nil && !errors.Is(res.Error, gorm.ErrRecordNotFound) {
+		api.SendInternalServerError(c, res.Error)
+		return
+	}
+	if err := checkInvitationRoles(roles, membership.Roles, request.Roles); err != nil {
+		c.JSON(http.StatusForbidden, models.NewNotAllowedError(err.Error()))
+		return
+	}
+
 	// invitation expires after 1 week
 	expiry := time.Now().Add(time.Hour * 24 * 7)
 	invite := models.Invitation{
@@ -155,8 +212,14 @@ func (api *API) CreateInvitation(c *gin.Context) {
 	}
 	invite.FromID = from.ID
 
-	if res := db.Create(&invite); res.Error != nil {
-		api.SendInternalServerError(c, res.Error)
+	err = api.transaction(ctx, func(tx *gorm.DB) error {
+		if res := tx.Create(&invite); res.Error != nil {
+			return res.Error
+		}
+		return api.recordAuditEvent(c, tx, AuditActionCreate, ResourceInvitations, invite.OrganizationID, invite.ID, nil, invite)
+	})
+	if err != nil {
+		api.SendInternalServerError(c, err)
 		return
 	}
 
@@ -187,7 +250,7 @@ func (api *API) ListInvitations(c *gin.Context) {
 	defer span.End()
 	invitations := make([]*models.Invitation, 0)
 	db := api.db.WithContext(ctx)
-	db = api.InvitationIsForCurrentUserOrOrgOwner(c, db)
+	db = api.InvitationIsReadableByCurrentUser(c, db)
 	db = FilterAndPaginate(db, &models.Invitation{}, c, "id")
 	result := db.
 		Joins("From").
@@ -205,9 +268,15 @@ func (api *API) InvitationIsForCurrentUser(c *gin.Context, db *gorm.DB) *gorm.DB
 	return db.Where("user_id = ?", userId)
 }
 
-func (api *API) InvitationIsForCurrentUserOrOrgOwner(c *gin.Context, db *gorm.DB) *gorm.DB {
+func (api *API) InvitationIsReadableByCurrentUser(c *gin.Context, db *gorm.DB) *gorm.DB {
 	userId := api.GetCurrentUserID(c)
-	return db.Where(api.CurrentUserHasRole(c, db, "organization_id", OwnerRoles).
+	return db.Where(api.CurrentUserHasPermission(c, db, "organization_id", ResourceInvitations, VerbRead).
+		Or(db.Where("user_id = ?", userId)))
+}
+
+func (api *API) InvitationIsWriteableByCurrentUser(c *gin.Context, db *gorm.DB) *gorm.DB {
+	userId := api.GetCurrentUserID(c)
+	return db.Where(api.CurrentUserHasPermission(c, db, "organization_id", ResourceInvitations, VerbWrite).
 		Or(db.Where("user_id = ?", userId)))
 }
 
@@ -239,7 +308,7 @@ func (api *API) GetInvitation(c *gin.Context) {
 	}
 	var org models.Invitation
 	db := api.db.WithContext(ctx)
-	result := api.InvitationIsForCurrentUserOrOrgOwner(c, db).
+	result := api.InvitationIsReadableByCurrentUser(c, db).
 		Joins("From").
 		Joins("Organization").
 		// we have to qualify the column name here because of the join
@@ -307,7 +376,10 @@ func (api *API) AcceptInvitation(c *gin.Context) {
 		if res := tx.Delete(&invitation); res.Error != nil {
 			return res.Error
 		}
-		return nil
+		if err := api.recordAuditEvent(c, tx, AuditActionDelete, ResourceInvitations, invitation.OrganizationID, invitation.ID, invitation, nil); err != nil {
+			return err
+		}
+		return api.recordAuditEvent(c, tx, AuditActionCreate, AuditResourceOrganizationUsers, userOrganization.OrganizationID, userOrganization.UserID, nil, userOrganization)
 	})
 
 	if err != nil {
@@ -366,14 +438,20 @@ func (api *API) DeleteInvitation(c *gin.Context) {
 
 	var invitation models.Invitation
 	db := api.db.WithContext(ctx)
-	if res := api.InvitationIsForCurrentUserOrOrgOwner(c, db).
+	if res := api.InvitationIsWriteableByCurrentUser(c, db).
 		First(&invitation, "id = ?", k); res.Error != nil {
 		c.JSON(http.StatusNotFound, models.NewNotFoundError("invitation"))
 		return
 	}
 
-	if res := db.Delete(&models.Invitation{}, k); res.Error != nil {
-		api.SendInternalServerError(c, res.Error)
+	err = api.transaction(ctx, func(tx *gorm.DB) error {
+		if res := tx.Delete(&models.Invitation{}, k); res.Error != nil {
+			return res.Error
+		}
+		return api.recordAuditEvent(c, tx, AuditActionDelete, ResourceInvitations, invitation.OrganizationID, invitation.ID, invitation, nil)
+	})
+	if err != nil {
+		api.SendInternalServerError(c, err)
 		return
 	}
 	c.Status(http.StatusNoContent)
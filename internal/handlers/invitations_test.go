@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -147,3 +148,45 @@ func (suite *HandlerTestSuite) TestCreateAcceptRefuseInvitation() {
 		}
 	}
 }
+
+func (suite *HandlerTestSuite) TestInvitationRolesAreLimitedToTheInviter() {
+	require := suite.Require()
+
+	testUser3ID, err := suite.api.CreateUserIfNotExists(context.Background(), "testuser3-idp-id", "testuser3", nil)
+	require.NoError(err)
+
+	require.NoError(suite.api.db.Save(&models.UserOrganization{
+		UserID:         suite.testUser2ID,
+		OrganizationID: suite.testUserID,
+		Roles:          []string{"invite-only"},
+	}).Error)
+
+	invite := func(login uuid.UUID, roles ...string) int {
+		_, res, err := suite.ServeRequest(
+			http.MethodPost, "/", "/",
+			func(c *gin.Context) {
+				c.Set(gin.AuthUserKey, login)
+				suite.api.CreateInvitation(c)
+			}, bytes.NewBuffer(suite.jsonMarshal(models.AddInvitation{
+				UserID:         &testUser3ID,
+				OrganizationID: suite.testUserID,
+				Roles:          roles,
+			})),
+		)
+		require.NoError(err)
+		return res.Code
+	}
+
+	// an invite-only user can not invite owners, or grant permissions it does not have
+	require.Equal(http.StatusForbidden, invite(suite.testUser2ID, "owner"))
+	require.Equal(http.StatusForbidden, invite(suite.testUser2ID, "member"))
+	require.Equal(http.StatusForbidden, invite(suite.testUser2ID, "invite-only", "device-admin"))
+	require.Equal(http.StatusCreated, invite(suite.testUser2ID, "invite-only"))
+
+	var invitation models.Invitation
+	require.NoError(suite.api.db.First(&invitation, "user_id = ? AND organization_id = ?", testUser3ID, suite.testUserID).Error)
+	require.NoError(suite.api.db.Delete(&invitation).Error)
+
+	// owners can invite owners
+	require.Equal(http.StatusCreated, invite(suite.testUserID, "owner"))
+}
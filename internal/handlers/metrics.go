@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	activeWatchStreams = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "apiserver",
+		Subsystem: "watch",
+		Name:      "active_streams",
+		Help:      "Watch streams currently open to clients.",
+	})
+	activeWatches = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "apiserver",
+		Subsystem: "watch",
+		Name:      "active_watches",
+		Help:      "Watches currently served by the open watch streams, by kind of resource.",
+	}, []string{"kind"})
+	connectedAgents = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "apiserver",
+		Name:      "connected_agents",
+		Help:      "Devices and sites with an open watch stream to this apiserver, by organization.",
+	}, []string{"organization_id"})
+)
+
+func init() {
+	prometheus.MustRegister(activeWatchStreams, activeWatches, connectedAgents)
+}
+
+var (
+	connectedAgentsMu    sync.Mutex
+	connectedAgentsCount = map[string]int{}
+)
+
+// agentConnected counts an agent of the organization as connected
+func agentConnected(organizationId string) {
+	connectedAgentsMu.Lock()
+	defer connectedAgentsMu.Unlock()
+	connectedAgentsCount[organizationId]++
+	connectedAgents.WithLabelValues(organizationId).Set(float64(connectedAgentsCount[organizationId]))
+}
+
+// agentDisconnected counts an agent of the organization as disconnected, the series of the
+// organization is removed once it has no agents left so that the label values do not pile up.
+func agentDisconnected(organizationId string) {
+	connectedAgentsMu.Lock()
+	defer connectedAgentsMu.Unlock()
+	connectedAgentsCount[organizationId]--
+	if connectedAgentsCount[organizationId] <= 0 {
+		delete(connectedAgentsCount, organizationId)
+		connectedAgents.DeleteLabelValues(organizationId)
+		return
+	}
+	connectedAgents.WithLabelValues(organizationId).Set(float64(connectedAgentsCount[organizationId]))
+}
@@ -0,0 +1,24 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnectedAgentsMetric(t *testing.T) {
+	require := require.New(t)
+	orgId := "b5d2fb5f-5d2e-4e7c-9b4b-2b0b7c8f7a01"
+
+	agentConnected(orgId)
+	agentConnected(orgId)
+	require.Equal(float64(2), testutil.ToFloat64(connectedAgents.WithLabelValues(orgId)))
+
+	agentDisconnected(orgId)
+	require.Equal(float64(1), testutil.ToFloat64(connectedAgents.WithLabelValues(orgId)))
+
+	// the series of the organization goes away with its last agent
+	agentDisconnected(orgId)
+	require.Equal(0, testutil.CollectAndCount(connectedAgents))
+}
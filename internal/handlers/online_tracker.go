@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/nexodus-io/nexodus/internal/models"
@@ -79,6 +80,12 @@ func (at *AgentTracker) Connected(api *API, c *gin.Context, agentIdPtr *uuid.UUI
 		if err != nil {
 			logger.Warn("failed to update db state for device", zap.Error(err))
 			fn()
+		} else {
+			if err := api.updateRoutePrimariesOnline(c.Request.Context(), device); err != nil {
+				logger.Warn("failed to elect the primary routers of the vpc", zap.Error(err))
+			}
+			// let the watchers know the device came online
+			api.signalBus.Notify(fmt.Sprintf("/devices/vpc=%s", device.VpcID.String()))
 		}
 	}
 	if site.ID != uuid.Nil && !site.Online {
@@ -92,6 +99,13 @@ func (at *AgentTracker) Connected(api *API, c *gin.Context, agentIdPtr *uuid.UUI
 		}
 	}
 
+	organizationId := device.OrganizationID
+	if device.ID == uuid.Nil {
+		organizationId = site.OrganizationID
+	}
+	agentConnected(organizationId.String())
+	defer agentDisconnected(organizationId.String())
+
 	defer func() {
 		if err := at.disconnected(agentId); err != nil {
 			logger.Warn("failed to update offline redis state for agent", zap.Error(err))
@@ -114,6 +128,12 @@ func (at *AgentTracker) Connected(api *API, c *gin.Context, agentIdPtr *uuid.UUI
 				err = api.db.Select("online", "online_at").Where("online = true").Updates(device).Error
 				if err != nil {
 					logger.Warn("failed to update db state for device", zap.Error(err))
+				} else {
+					if err := api.updateRoutePrimariesOnline(context.Background(), device); err != nil {
+						logger.Warn("failed to elect the primary routers of the vpc", zap.Error(err))
+					}
+					// let the watchers know the device went offline
+					api.signalBus.Notify(fmt.Sprintf("/devices/vpc=%s", device.VpcID.String()))
 				}
 			}
 			if site.ID != uuid.Nil {
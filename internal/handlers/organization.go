@@ -14,9 +14,6 @@ import (
 	"time"
 )
 
-var OwnerRoles = []string{"owner"}
-var MemberRoles = []string{"owner", "member"}
-
 type errDuplicateOrganization struct {
 	ID string
 }
@@ -97,7 +94,7 @@ func (api *API) CreateOrganization(c *gin.Context) {
 
 		span.SetAttributes(attribute.String("id", org.ID.String()))
 		api.logger.Infof("New organization request [ %s ] request", org.Name)
-		return nil
+		return api.recordAuditEvent(c, tx, AuditActionCreate, ResourceOrganizations, org.ID, org.ID, nil, org)
 	})
 
 	if err != nil {
@@ -116,11 +113,11 @@ func (api *API) CreateOrganization(c *gin.Context) {
 }
 
 func (api *API) OrganizationIsReadableByCurrentUser(c *gin.Context, db *gorm.DB) *gorm.DB {
-	return api.CurrentUserHasRole(c, db, "id", MemberRoles)
+	return api.CurrentUserHasPermission(c, db, "id", ResourceOrganizations, VerbRead)
 }
 
 func (api *API) OrganizationIsOwnedByCurrentUser(c *gin.Context, db *gorm.DB) *gorm.DB {
-	return api.CurrentUserHasRole(c, db, "id", OwnerRoles)
+	return api.CurrentUserHasPermission(c, db, "id", ResourceOrganizations, VerbWrite)
 }
 
 func (api *API) CurrentUserHasRole(c *gin.Context, db *gorm.DB, orgIdField string, allowedRoles []string) *gorm.DB {
@@ -304,20 +301,24 @@ func (api *API) DeleteOrganization(c *gin.Context) {
 			return result.Error
 		}
 
-		return deleteOrganization(tx, orgID)
+		return api.deleteOrganization(c, tx, org)
 	})
-
-	var apiResponseError *ApiResponseError
-	if errors.As(err, &apiResponseError) {
-		c.JSON(apiResponseError.Status, apiResponseError.Body)
-	} else {
-		api.SendInternalServerError(c, err)
+	if err != nil {
+		var apiResponseError *ApiResponseError
+		if errors.As(err, &apiResponseError) {
+			c.JSON(apiResponseError.Status, apiResponseError.Body)
+		} else {
+			api.SendInternalServerError(c, err)
+		}
+		return
 	}
 
 	c.JSON(http.StatusOK, org)
 }
 
-func deleteOrganization(tx *gorm.DB, orgID uuid.UUID) error {
+// deleteOrganization deletes the organization with its resources and memberships
+func (api *API) deleteOrganization(c *gin.Context, tx *gorm.DB, org models.Organization) error {
+	orgID := org.ID
 	var count int64
 	result := tx.Model(&models.Device{}).Where("organization_id = ?", orgID).Count(&count)
 	if result.Error != nil {
@@ -347,6 +348,9 @@ func deleteOrganization(tx *gorm.DB, orgID uuid.UUID) error {
 	if res := tx.Where("organization_id = ?", orgID).Delete(&models.Invitation{}); res.Error != nil {
 		return result.Error
 	}
+	if res := tx.Where("organization_id = ?", orgID).Delete(&models.Webhook{}); res.Error != nil {
+		return result.Error
+	}
 
 	// Null out unique fields so that the org can be created later with the same values
 	if res := tx.Model(&models.Organization{}).
@@ -358,5 +362,5 @@ func deleteOrganization(tx *gorm.DB, orgID uuid.UUID) error {
 		return res.Error
 	}
 
-	return nil
+	return api.recordAuditEvent(c, tx, AuditActionDelete, ResourceOrganizations, orgID, orgID, org, nil)
 }
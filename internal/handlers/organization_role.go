@@ -0,0 +1,391 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nexodus-io/nexodus/internal/database"
+	"github.com/nexodus-io/nexodus/internal/models"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// ListRolesInOrganization lists the custom roles of an organization
+// @Summary      List Roles
+// @Description  Lists the custom roles of an organization, the built-in roles are not listed
+// @Id 			 ListRolesInOrganization
+// @Tags         Organizations
+// @Accept       json
+// @Produce      json
+// @Param		 id   path      string true "Organization ID"
+// @Success      200  {object}  []models.Role
+// @Failure      400  {object}  models.BaseError
+// @Failure		 401  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/organizations/{id}/roles [get]
+func (api *API) ListRolesInOrganization(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "ListRolesInOrganization",
+		trace.WithAttributes(
+			attribute.String("id", c.Param("id")),
+		))
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+
+	db := api.db.WithContext(ctx)
+	var org models.Organization
+	if res := api.CurrentUserHasPermission(c, db, "id", ResourceRoles, VerbRead).
+		First(&org, "id = ?", id); res.Error != nil {
+		if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, models.NewNotFoundError("organization"))
+		} else {
+			api.SendInternalServerError(c, res.Error)
+		}
+		return
+	}
+
+	db = db.Where("organization_id = ?", id)
+	db = FilterAndPaginate(db, &models.Role{}, c, "name")
+
+	roles := make([]models.Role, 0)
+	if res := db.Find(&roles); res.Error != nil {
+		api.SendInternalServerError(c, res.Error)
+		return
+	}
+	c.JSON(http.StatusOK, roles)
+}
+
+// GetRoleInOrganization gets a custom role of an organization
+// @Summary      Get Role
+// @Description  Gets a custom role of an organization by ID
+// @Id 			 GetRoleInOrganization
+// @Tags         Organizations
+// @Accept       json
+// @Produce      json
+// @Param		 id   path      string true "Organization ID"
+// @Param		 rid  path      string true "Role ID"
+// @Success      200  {object}  models.Role
+// @Failure      400  {object}  models.BaseError
+// @Failure		 401  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/organizations/{id}/roles/{rid} [get]
+func (api *API) GetRoleInOrganization(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "GetRoleInOrganization",
+		trace.WithAttributes(
+			attribute.String("id", c.Param("id")),
+			attribute.String("rid", c.Param("rid")),
+		))
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+	rid, err := uuid.Parse(c.Param("rid"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("rid"))
+		return
+	}
+
+	var role models.Role
+	db := api.db.WithContext(ctx)
+	if res := api.CurrentUserHasPermission(c, db, "organization_id", ResourceRoles, VerbRead).
+		First(&role, "id = ? AND organization_id = ?", rid, id); res.Error != nil {
+		if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, models.NewNotFoundError("role"))
+		} else {
+			api.SendInternalServerError(c, res.Error)
+		}
+		return
+	}
+	c.JSON(http.StatusOK, role)
+}
+
+// CreateRoleInOrganization creates a custom role in an organization
+// @Summary      Create Role
+// @Description  Creates a custom role that can be granted to the users of an organization
+// @Id 			 CreateRoleInOrganization
+// @Tags         Organizations
+// @Accept       json
+// @Produce      json
+// @Param		 id    path      string          true "Organization ID"
+// @Param        Role  body      models.AddRole  true "Add Role"
+// @Success      201  {object}  models.Role
+// @Failure      400  {object}  models.BaseError
+// @Failure		 401  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure      409  {object}  models.ConflictsError
+// @Failure      422  {object}  models.ValidationError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/organizations/{id}/roles [post]
+func (api *API) CreateRoleInOrganization(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "CreateRoleInOrganization",
+		trace.WithAttributes(
+			attribute.String("id", c.Param("id")),
+		))
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+
+	var request models.AddRole
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPayloadError(err))
+		return
+	}
+	if request.Name == "" {
+		c.JSON(http.StatusBadRequest, models.NewFieldNotPresentError("name"))
+		return
+	}
+	if _, found := Roles[request.Name]; found {
+		c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError("name", "is the name of a built-in role"))
+		return
+	}
+	if err := validateRolePermissions(request.Permissions); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError("permissions", err.Error()))
+		return
+	}
+
+	var role models.Role
+	err = api.transaction(ctx, func(tx *gorm.DB) error {
+		var org models.Organization
+		if res := api.CurrentUserHasPermission(c, tx, "id", ResourceRoles, VerbCreate).
+			First(&org, "id = ?", id); res.Error != nil {
+			if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+				return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("organization"))
+			}
+			return res.Error
+		}
+
+		var existing models.Role
+		if res := tx.First(&existing, "organization_id = ? AND name = ?", org.ID, request.Name); res.Error == nil {
+			return NewApiResponseError(http.StatusConflict, models.NewConflictsError(existing.ID.String()))
+		} else if !errors.Is(res.Error, gorm.ErrRecordNotFound) {
+			return res.Error
+		}
+
+		role = models.Role{
+			OrganizationID: org.ID,
+			Name:           request.Name,
+			Description:    request.Description,
+			Permissions:    request.Permissions,
+			Grants:         models.PermissionGrants(request.Permissions),
+		}
+		if res := tx.Create(&role); res.Error != nil {
+			return res.Error
+		}
+
+		span.SetAttributes(attribute.String("rid", role.ID.String()))
+		return api.recordAuditEvent(c, tx, AuditActionCreate, ResourceRoles, role.OrganizationID, role.ID, nil, role)
+	})
+	if err != nil {
+		var apiResponseError *ApiResponseError
+		if errors.As(err, &apiResponseError) {
+			c.JSON(apiResponseError.Status, apiResponseError.Body)
+		} else {
+			api.SendInternalServerError(c, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, role)
+}
+
+// UpdateRoleInOrganization updates a custom role of an organization
+// @Summary      Update Role
+// @Description  Updates the description and the permissions of a custom role, the users it is granted to get the new permissions
+// @Id 			 UpdateRoleInOrganization
+// @Tags         Organizations
+// @Accept       json
+// @Produce      json
+// @Param		 id      path   string             true "Organization ID"
+// @Param		 rid     path   string             true "Role ID"
+// @Param        update  body   models.UpdateRole  true "Role Update"
+// @Success      200  {object}  models.Role
+// @Failure      400  {object}  models.BaseError
+// @Failure		 401  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure      422  {object}  models.ValidationError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/organizations/{id}/roles/{rid} [patch]
+func (api *API) UpdateRoleInOrganization(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "UpdateRoleInOrganization",
+		trace.WithAttributes(
+			attribute.String("id", c.Param("id")),
+			attribute.String("rid", c.Param("rid")),
+		))
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+	rid, err := uuid.Parse(c.Param("rid"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("rid"))
+		return
+	}
+
+	var request models.UpdateRole
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPayloadError(err))
+		return
+	}
+	if err := validateRolePermissions(request.Permissions); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError("permissions", err.Error()))
+		return
+	}
+
+	var role models.Role
+	err = api.transaction(ctx, func(tx *gorm.DB) error {
+		if res := api.CurrentUserHasPermission(c, tx, "organization_id", ResourceRoles, VerbWrite).
+			First(&role, "id = ? AND organization_id = ?", rid, id); res.Error != nil {
+			if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+				return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("role"))
+			}
+			return res.Error
+		}
+		before := role
+
+		if request.Description != nil {
+			role.Description = *request.Description
+		}
+		if request.Permissions != nil {
+			role.Permissions = request.Permissions
+			role.Grants = models.PermissionGrants(request.Permissions)
+		}
+		if res := tx.Select("description", "permissions", "grants").Updates(&role); res.Error != nil {
+			return res.Error
+		}
+		return api.recordAuditEvent(c, tx, AuditActionUpdate, ResourceRoles, role.OrganizationID, role.ID, before, role)
+	})
+	if err != nil {
+		var apiResponseError *ApiResponseError
+		if errors.As(err, &apiResponseError) {
+			c.JSON(apiResponseError.Status, apiResponseError.Body)
+		} else {
+			api.SendInternalServerError(c, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, role)
+}
+
+// DeleteRoleInOrganization deletes a custom role of an organization
+// @Summary      Delete Role
+// @Description  Deletes a custom role, a role that is granted to users or pending invitations can not be deleted
+// @Id 			 DeleteRoleInOrganization
+// @Tags         Organizations
+// @Accept       json
+// @Produce      json
+// @Param		 id   path      string true "Organization ID"
+// @Param		 rid  path      string true "Role ID"
+// @Success      200  {object}  models.Role
+// @Failure      400  {object}  models.BaseError
+// @Failure		 401  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure      422  {object}  models.ValidationError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/organizations/{id}/roles/{rid} [delete]
+func (api *API) DeleteRoleInOrganization(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "DeleteRoleInOrganization",
+		trace.WithAttributes(
+			attribute.String("id", c.Param("id")),
+			attribute.String("rid", c.Param("rid")),
+		))
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+	rid, err := uuid.Parse(c.Param("rid"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("rid"))
+		return
+	}
+
+	var role models.Role
+	err = api.transaction(ctx, func(tx *gorm.DB) error {
+		if res := api.CurrentUserHasPermission(c, tx, "organization_id", ResourceRoles, VerbWrite).
+			First(&role, "id = ? AND organization_id = ?", rid, id); res.Error != nil {
+			if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+				return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("role"))
+			}
+			return res.Error
+		}
+
+		granted, err := api.roleIsGranted(tx, role)
+		if err != nil {
+			return err
+		}
+		if granted {
+			return NewApiResponseError(http.StatusUnprocessableEntity, models.NewFieldValidationError("rid", "the role is granted to users or pending invitations of the organization"))
+		}
+
+		if res := tx.Delete(&role); res.Error != nil {
+			return res.Error
+		}
+		return api.recordAuditEvent(c, tx, AuditActionDelete, ResourceRoles, role.OrganizationID, role.ID, role, nil)
+	})
+	if err != nil {
+		var apiResponseError *ApiResponseError
+		if errors.As(err, &apiResponseError) {
+			c.JSON(apiResponseError.Status, apiResponseError.Body)
+		} else {
+			api.SendInternalServerError(c, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, role)
+}
+
+// roleIsGranted returns true when a custom role is held by a user or granted by a pending invitation of its organization
+func (api *API) roleIsGranted(tx *gorm.DB, role models.Role) (bool, error) {
+	hasRole := "? = ANY(roles)"
+	if api.dialect == database.DialectSqlLite {
+		hasRole = "EXISTS (SELECT 1 FROM json_each(roles) AS role WHERE role.value = ?)"
+	}
+
+	var count int64
+	if res := tx.Model(&models.UserOrganization{}).
+		Where("organization_id = ?", role.OrganizationID).
+		Where(hasRole, role.Name).
+		Count(&count); res.Error != nil {
+		return false, fmt.Errorf("error counting the users of the role: %w", res.Error)
+	}
+	if count > 0 {
+		return true, nil
+	}
+	if res := tx.Model(&models.Invitation{}).
+		Where("organization_id = ? AND expires_at > ?", role.OrganizationID, time.Now()).
+		Where(hasRole, role.Name).
+		Count(&count); res.Error != nil {
+		return false, fmt.Errorf("error counting the invitations of the role: %w", res.Error)
+	}
+	return count > 0, nil
+}
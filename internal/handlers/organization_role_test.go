@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nexodus-io/nexodus/internal/models"
+)
+
+func (suite *HandlerTestSuite) TestCustomRoles() {
+	require := suite.Require()
+
+	create := func(request models.AddRole) (int, models.Role) {
+		_, res, err := suite.ServeRequest(
+			http.MethodPost, "/organizations/:id/roles", fmt.Sprintf("/organizations/%s/roles", suite.testUserID),
+			suite.api.CreateRoleInOrganization, bytes.NewBuffer(suite.jsonMarshal(request)),
+		)
+		require.NoError(err)
+		var role models.Role
+		if res.Code == http.StatusCreated {
+			require.NoError(json.Unmarshal(res.Body.Bytes(), &role))
+		}
+		return res.Code, role
+	}
+
+	// custom roles can not shadow the built-in roles or grant more than the owner role
+	code, _ := create(models.AddRole{Name: "owner"})
+	require.Equal(http.StatusUnprocessableEntity, code)
+	code, _ = create(models.AddRole{Name: "auditor", Permissions: map[string][]string{"unknown": {VerbRead}}})
+	require.Equal(http.StatusUnprocessableEntity, code)
+	code, _ = create(models.AddRole{Name: "auditor", Permissions: map[string][]string{ResourceRegKeys: {VerbWrite}}})
+	require.Equal(http.StatusUnprocessableEntity, code)
+
+	code, auditor := create(models.AddRole{
+		Name:        "auditor",
+		Description: "Reads the audit events",
+		Permissions: map[string][]string{
+			ResourceOrganizations: {VerbRead},
+			ResourceAuditEvents:   {VerbRead},
+		},
+	})
+	require.Equal(http.StatusCreated, code)
+	require.Equal(suite.testUserID, auditor.OrganizationID)
+	code, _ = create(models.AddRole{Name: "auditor"})
+	require.Equal(http.StatusConflict, code)
+
+	_, res, err := suite.ServeRequest(
+		http.MethodGet, "/organizations/:id/roles", fmt.Sprintf("/organizations/%s/roles", suite.testUserID),
+		suite.api.ListRolesInOrganization, nil,
+	)
+	require.NoError(err)
+	require.Equal(http.StatusOK, res.Code, "HTTP error: %s", res.Body.String())
+	var roles []models.Role
+	require.NoError(json.Unmarshal(res.Body.Bytes(), &roles))
+	require.Len(roles, 1)
+	require.Equal(auditor.ID, roles[0].ID)
+
+	// the custom roles of the organization can be granted with an invitation
+	invite := func(role string) int {
+		_, res, err := suite.ServeRequest(
+			http.MethodPost, "/", "/",
+			suite.api.CreateInvitation, bytes.NewBuffer(suite.jsonMarshal(models.AddInvitation{
+				OrganizationID: suite.testUserID,
+				UserID:         &suite.testUser2ID,
+				Roles:          []string{role},
+			})),
+		)
+		require.NoError(err)
+		return res.Code
+	}
+	require.Equal(http.StatusBadRequest, invite("unknown"))
+	require.Equal(http.StatusCreated, invite("auditor"))
+
+	// a role granted by a pending invitation can not be deleted
+	deleteRole := func(role models.Role) int {
+		_, res, err := suite.ServeRequest(
+			http.MethodDelete, "/organizations/:id/roles/:rid", fmt.Sprintf("/organizations/%s/roles/%s", suite.testUserID, role.ID),
+			suite.api.DeleteRoleInOrganization, nil,
+		)
+		require.NoError(err)
+		return res.Code
+	}
+	require.Equal(http.StatusUnprocessableEntity, deleteRole(auditor))
+
+	// the users holding a custom role get its permissions
+	require.NoError(suite.api.db.Save(&models.UserOrganization{
+		UserID:         suite.testUser2ID,
+		OrganizationID: suite.testUserID,
+		Roles:          []string{"auditor"},
+	}).Error)
+	listAuditEvents := func() int {
+		_, res, err := suite.ServeRequest(
+			http.MethodGet, "/organizations/:id/audit-events", fmt.Sprintf("/organizations/%s/audit-events", suite.testUserID),
+			func(c *gin.Context) {
+				c.Set(gin.AuthUserKey, suite.testUser2ID)
+				suite.api.ListAuditEventsInOrganization(c)
+			}, nil,
+		)
+		require.NoError(err)
+		return res.Code
+	}
+	require.Equal(http.StatusOK, listAuditEvents())
+
+	_, res, err = suite.ServeRequest(
+		http.MethodPatch, "/organizations/:id/roles/:rid", fmt.Sprintf("/organizations/%s/roles/%s", suite.testUserID, auditor.ID),
+		suite.api.UpdateRoleInOrganization, bytes.NewBuffer(suite.jsonMarshal(models.UpdateRole{
+			Permissions: map[string][]string{ResourceOrganizations: {VerbRead}},
+		})),
+	)
+	require.NoError(err)
+	require.Equal(http.StatusOK, res.Code, "HTTP error: %s", res.Body.String())
+	require.Equal(http.StatusNotFound, listAuditEvents())
+
+	code, unused := create(models.AddRole{Name: "unused"})
+	require.Equal(http.StatusCreated, code)
+	require.Equal(http.StatusOK, deleteRole(unused))
+}
@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
+	"text/template"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -48,6 +50,18 @@ func (api *API) CreateRegKey(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.NewFieldNotPresentError("vpc_id, service_network_id"))
 		return
 	}
+	if request.MaxUses < 0 {
+		c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError("max_uses", "must not be negative"))
+		return
+	}
+	if err := validateSourceCidrs(request.AllowedSourceCidrs); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError("allowed_source_cidrs", err.Error()))
+		return
+	}
+	if field, err := validateDeviceDefaults(request.DeviceLabels, request.DeviceMetadata, request.HostnameTemplate); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError(field, err.Error()))
+		return
+	}
 
 	// use a wg private key as the token, since it should be hard to guess.
 	token, err := wgtypes.GeneratePrivateKey()
@@ -64,19 +78,25 @@ func (api *API) CreateRegKey(c *gin.Context) {
 		// Let store the reg token... without the client id yet... to avoid creating
 		// clients in KC that are not correlated with our DB.
 		record = models.RegKey{
-			OwnerID:          userId,
-			VpcID:            request.VpcID,
-			ServiceNetworkID: request.ServiceNetworkID,
-			BearerToken:      "RK:" + token.String(),
-			Description:      request.Description,
-			ExpiresAt:        request.ExpiresAt,
-			Settings:         request.Settings,
+			OwnerID:            userId,
+			VpcID:              request.VpcID,
+			ServiceNetworkID:   request.ServiceNetworkID,
+			BearerToken:        "RK:" + token.String(),
+			Description:        request.Description,
+			ExpiresAt:          request.ExpiresAt,
+			Settings:           request.Settings,
+			Ephemeral:          request.Ephemeral,
+			MaxUses:            request.MaxUses,
+			AllowedSourceCidrs: request.AllowedSourceCidrs,
+			DeviceLabels:       request.DeviceLabels,
+			DeviceMetadata:     request.DeviceMetadata,
+			HostnameTemplate:   request.HostnameTemplate,
 		}
 
 		// User needs to be a member of the VPC's org
 		if request.VpcID != nil {
 			var vpc models.VPC
-			if res := api.VPCIsReadableByCurrentUser(c, tx).
+			if res := api.CurrentUserHasPermission(c, tx, "organization_id", ResourceRegKeys, VerbCreate).
 				First(&vpc, "id = ?", request.VpcID.String()); res.Error != nil {
 				return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("vpc"))
 			}
@@ -86,7 +106,7 @@ func (api *API) CreateRegKey(c *gin.Context) {
 		// User needs to be a member of the ServiceNetwork's org
 		if request.ServiceNetworkID != nil {
 			var sn models.ServiceNetwork
-			if res := api.ServiceNetworkIsReadableByCurrentUser(c, tx).
+			if res := api.CurrentUserHasPermission(c, tx, "organization_id", ResourceRegKeys, VerbCreate).
 				First(&sn, "id = ?", request.ServiceNetworkID.String()); res.Error != nil {
 				return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("service_network"))
 			}
@@ -111,7 +131,7 @@ func (api *API) CreateRegKey(c *gin.Context) {
 			return res.Error
 		}
 
-		return nil
+		return api.recordAuditEvent(c, tx, AuditActionCreate, ResourceRegKeys, regKeyOrganizationID(record), record.ID, nil, record)
 	})
 
 	if err != nil {
@@ -124,6 +144,7 @@ func (api *API) CreateRegKey(c *gin.Context) {
 		return
 	}
 
+	setRemainingUses(&record)
 	c.JSON(http.StatusCreated, record)
 }
 
@@ -161,6 +182,22 @@ func (api *API) UpdateRegKey(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.NewBadPayloadError(err))
 		return
 	}
+	if request.MaxUses != nil && *request.MaxUses < 0 {
+		c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError("max_uses", "must not be negative"))
+		return
+	}
+	if err := validateSourceCidrs(request.AllowedSourceCidrs); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError("allowed_source_cidrs", err.Error()))
+		return
+	}
+	hostnameTemplate := ""
+	if request.HostnameTemplate != nil {
+		hostnameTemplate = *request.HostnameTemplate
+	}
+	if field, err := validateDeviceDefaults(request.DeviceLabels, request.DeviceMetadata, hostnameTemplate); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError(field, err.Error()))
+		return
+	}
 
 	var regKey models.RegKey
 	err = api.transaction(ctx, func(tx *gorm.DB) error {
@@ -170,6 +207,7 @@ func (api *API) UpdateRegKey(c *gin.Context) {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("reg key"))
 		}
+		before := regKey
 
 		if request.SecurityGroupId != nil {
 			var sg models.SecurityGroup
@@ -193,13 +231,30 @@ func (api *API) UpdateRegKey(c *gin.Context) {
 		if request.Settings != nil {
 			regKey.Settings = request.Settings
 		}
+		if request.MaxUses != nil {
+			regKey.MaxUses = *request.MaxUses
+		}
+		if request.AllowedSourceCidrs != nil {
+			regKey.AllowedSourceCidrs = request.AllowedSourceCidrs
+		}
+		if request.DeviceLabels != nil {
+			regKey.DeviceLabels = request.DeviceLabels
+		}
+		if request.DeviceMetadata != nil {
+			regKey.DeviceMetadata = request.DeviceMetadata
+		}
+		if request.HostnameTemplate != nil {
+			regKey.HostnameTemplate = *request.HostnameTemplate
+		}
 
+		// uses is only changed by the devices registered with the key
 		if res := tx.
+			Omit("uses").
 			Save(&regKey); res.Error != nil {
 			return res.Error
 		}
 
-		return nil
+		return api.recordAuditEvent(c, tx, AuditActionUpdate, ResourceRegKeys, regKeyOrganizationID(regKey), regKey.ID, before, regKey)
 	})
 
 	if err != nil {
@@ -211,9 +266,92 @@ func (api *API) UpdateRegKey(c *gin.Context) {
 		}
 		return
 	}
+	setRemainingUses(&regKey)
 	c.JSON(http.StatusOK, regKey)
 }
 
+// regKeyOrganizationID returns the organization of the VPC or service network of the reg key
+func regKeyOrganizationID(regKey models.RegKey) uuid.UUID {
+	if regKey.OrganizationID != nil {
+		return *regKey.OrganizationID
+	}
+	if regKey.SNOrganizationID != nil {
+		return *regKey.SNOrganizationID
+	}
+	return uuid.Nil
+}
+
+// setRemainingUses fills in the number of devices the reg key can still register, if it is limited
+func setRemainingUses(regKey *models.RegKey) {
+	if regKey.MaxUses <= 0 {
+		regKey.RemainingUses = nil
+		return
+	}
+	remaining := regKey.MaxUses - regKey.Uses
+	if remaining < 0 {
+		remaining = 0
+	}
+	regKey.RemainingUses = &remaining
+}
+
+// regKeyAllowsSourceIP checks that the reg key can be used from the client ip
+func regKeyAllowsSourceIP(regKey models.RegKey, clientIP string) bool {
+	if len(regKey.AllowedSourceCidrs) == 0 {
+		return true
+	}
+	return util.PrefixesContainIP(regKey.AllowedSourceCidrs, clientIP)
+}
+
+func validateSourceCidrs(cidrs []string) error {
+	for _, cidr := range cidrs {
+		if !util.IsValidPrefix(cidr) {
+			return fmt.Errorf("invalid cidr: %s", cidr)
+		}
+	}
+	return nil
+}
+
+// hostnameTemplateData is the data available to the hostname template of a reg key
+type hostnameTemplateData struct {
+	Hostname string // Hostname is the hostname reported by the device
+	Index    int64  // Index counts the devices registered with the reg key, starting at 1
+}
+
+// renderHostnameTemplate renders the hostname of a device registered with a reg key
+func renderHostnameTemplate(hostnameTemplate string, data hostnameTemplateData) (string, error) {
+	t, err := template.New("hostname").Option("missingkey=error").Parse(hostnameTemplate)
+	if err != nil {
+		return "", err
+	}
+	var hostname strings.Builder
+	if err := t.Execute(&hostname, data); err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(hostname.String()) == "" {
+		return "", errors.New("the template renders an empty hostname")
+	}
+	return strings.TrimSpace(hostname.String()), nil
+}
+
+// validateDeviceDefaults validates the labels, metadata and hostname template a reg key applies to its devices,
+// it returns the name of the invalid field with the error.
+func validateDeviceDefaults(labels map[string]string, metadata map[string]interface{}, hostnameTemplate string) (string, error) {
+	if err := util.ValidateLabels(labels); err != nil {
+		return "device_labels", err
+	}
+	for key := range metadata {
+		if key == "" {
+			return "device_metadata", errors.New("metadata keys must not be empty")
+		}
+	}
+	if hostnameTemplate != "" {
+		if _, err := renderHostnameTemplate(hostnameTemplate, hostnameTemplateData{Hostname: "hostname", Index: 1}); err != nil {
+			return "hostname_template", err
+		}
+	}
+	return "", nil
+}
+
 func NxodusClaims(c *gin.Context, tx *gorm.DB) (*models.NexodusClaims, *ApiResponseError) {
 	claims := models.NexodusClaims{}
 	err := util.JsonUnmarshal(c.GetStringMap("_nexodus.Claims"), &claims)
@@ -249,6 +387,13 @@ func (api *API) ListRegKeys(c *gin.Context) {
 		api.SendInternalServerError(c, fmt.Errorf("error fetching keys from db: %w", result.Error))
 		return
 	}
+	for i := range records {
+		setRemainingUses(&records[i])
+	}
+	if err := api.hideRegKeyBearerTokens(c, api.db.WithContext(ctx), records); err != nil {
+		api.SendInternalServerError(c, err)
+		return
+	}
 	c.JSON(http.StatusOK, records)
 }
 
@@ -308,7 +453,77 @@ func (api *API) GetRegKey(c *gin.Context) {
 		}
 		return
 	}
-	c.JSON(http.StatusOK, record)
+	setRemainingUses(&record)
+	records := []models.RegKey{record}
+	if err := api.hideRegKeyBearerTokens(c, api.db.WithContext(ctx), records); err != nil {
+		api.SendInternalServerError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, records[0])
+}
+
+// ListDevicesForRegKey lists the devices registered with a RegKey
+// @Summary      List the devices of a RegKey
+// @Description  Lists the devices that were registered with a RegKey
+// @Id           ListDevicesForRegKey
+// @Tags         RegKey
+// @Accept       json
+// @Produce      json
+// @Param		 id   path      string true "RegKey ID"
+// @Success      200  {object}  []models.Device
+// @Failure      400  {object}  models.BaseError
+// @Failure		 401  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/reg-keys/{id}/devices [get]
+func (api *API) ListDevicesForRegKey(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "ListDevicesForRegKey",
+		trace.WithAttributes(
+			attribute.String("id", c.Param("id")),
+		))
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+
+	var regKey models.RegKey
+	db := api.db.WithContext(ctx)
+	result := api.RegKeyIsForCurrentUserOrOrgOwner(c, db).
+		First(&regKey, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, models.NewNotFoundError("reg key"))
+		} else {
+			api.SendInternalServerError(c, result.Error)
+		}
+		return
+	}
+
+	devices := make([]models.Device, 0)
+	db = api.DeviceIsReadableByCurrentUser(c, api.db.WithContext(ctx))
+	db = FilterAndPaginate(db, &models.Device{}, c, "hostname")
+	result = db.Where("reg_key_id = ?", regKey.ID).Find(&devices)
+	if result.Error != nil {
+		api.SendInternalServerError(c, fmt.Errorf("error fetching devices from db: %w", result.Error))
+		return
+	}
+
+	tokenClaims, apierr := NxodusClaims(c, api.db.WithContext(ctx))
+	if apierr != nil {
+		c.JSON(apierr.Status, apierr.Body)
+		return
+	}
+
+	// only show the device token when using the reg token that created the device.
+	currentUserID := api.GetCurrentUserID(c)
+	for i := range devices {
+		hideDeviceBearerToken(&devices[i], tokenClaims, currentUserID)
+	}
+	c.JSON(http.StatusOK, devices)
 }
 
 func (api *API) RegKeyIsForCurrentUser(c *gin.Context, db *gorm.DB) *gorm.DB {
@@ -322,11 +537,36 @@ func (api *API) RegKeyIsForCurrentUserOrOrgOwner(c *gin.Context, db *gorm.DB) *g
 	userId := api.GetCurrentUserID(c)
 	return db.Where(
 		db.Where("owner_id = ?", userId).
-			Or(api.CurrentUserHasRole(c, db, "organization_id", OwnerRoles)).
-			Or(api.CurrentUserHasRole(c, db, "sn_organization_id", OwnerRoles)),
+			Or(api.CurrentUserHasPermission(c, db, "organization_id", ResourceRegKeys, VerbRead)).
+			Or(api.CurrentUserHasPermission(c, db, "sn_organization_id", ResourceRegKeys, VerbRead)),
 	)
 }
 
+// hideRegKeyBearerTokens clears the bearer tokens of the reg keys the current user can only read. The token
+// enrolls devices, so it is only shown to the owner of the reg key and to the users allowed to create or
+// change the reg keys of its organization.
+func (api *API) hideRegKeyBearerTokens(c *gin.Context, db *gorm.DB, regKeys []models.RegKey) error {
+	managedOrgs := map[uuid.UUID]bool{}
+	for _, verb := range []string{VerbCreate, VerbWrite} {
+		var orgIds []uuid.UUID
+		if res := api.CurrentUserHasPermission(c, db.Model(&models.Organization{}), "id", ResourceRegKeys, verb).
+			Pluck("id", &orgIds); res.Error != nil {
+			return fmt.Errorf("error fetching organizations: %w", res.Error)
+		}
+		for _, orgId := range orgIds {
+			managedOrgs[orgId] = true
+		}
+	}
+
+	userId := api.GetCurrentUserID(c)
+	for i := range regKeys {
+		if regKeys[i].OwnerID != userId && !managedOrgs[regKeyOrganizationID(regKeys[i])] {
+			regKeys[i].BearerToken = ""
+		}
+	}
+	return nil
+}
+
 // DeleteRegKey handles deleting a RegKey
 // @Summary      Delete RegKey
 // @Description  Deletes an existing RegKey
@@ -366,7 +606,7 @@ func (api *API) DeleteRegKey(c *gin.Context) {
 		if res.Error != nil {
 			return res.Error
 		}
-		return nil
+		return api.recordAuditEvent(c, tx, AuditActionDelete, ResourceRegKeys, regKeyOrganizationID(record), record.ID, record, nil)
 	})
 
 	if errors.Is(err, gorm.ErrRecordNotFound) {
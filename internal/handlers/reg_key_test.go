@@ -0,0 +1,242 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nexodus-io/nexodus/internal/models"
+)
+
+func (suite *HandlerTestSuite) TestRegKeyUsageLimits() {
+	require := suite.Require()
+
+	_, res, err := suite.ServeRequest(
+		http.MethodPost,
+		"/", "/",
+		suite.api.CreateRegKey, bytes.NewBuffer(suite.jsonMarshal(models.AddRegKey{
+			VpcID:              &suite.testUserID,
+			Description:        "fleet rollout",
+			MaxUses:            -1,
+			AllowedSourceCidrs: []string{"192.0.2.0/24"},
+		})),
+	)
+	require.NoError(err)
+	require.Equal(http.StatusUnprocessableEntity, res.Code, "HTTP error: %s", res.Body.String())
+
+	_, res, err = suite.ServeRequest(
+		http.MethodPost,
+		"/", "/",
+		suite.api.CreateRegKey, bytes.NewBuffer(suite.jsonMarshal(models.AddRegKey{
+			VpcID:              &suite.testUserID,
+			Description:        "fleet rollout",
+			MaxUses:            2,
+			AllowedSourceCidrs: []string{"192.0.2.0/24"},
+		})),
+	)
+	require.NoError(err)
+	require.Equal(http.StatusCreated, res.Code, "HTTP error: %s", res.Body.String())
+	var regKey models.RegKey
+	require.NoError(json.Unmarshal(res.Body.Bytes(), &regKey))
+	require.NotNil(regKey.RemainingUses)
+	require.Equal(int64(2), *regKey.RemainingUses)
+
+	register := func(publicKey string, remoteAddr string) *httptest.ResponseRecorder {
+		return suite.registerWithRegKey(regKey, models.AddDevice{
+			VpcID:     suite.testUserID,
+			PublicKey: publicKey,
+		}, remoteAddr)
+	}
+
+	res = register("regkeylimitpubkey1", "198.51.100.7:4321")
+	require.Equal(http.StatusForbidden, res.Code, "HTTP error: %s", res.Body.String())
+
+	res = register("regkeylimitpubkey1", "192.0.2.10:4321")
+	require.Equal(http.StatusCreated, res.Code, "HTTP error: %s", res.Body.String())
+	res = register("regkeylimitpubkey2", "192.0.2.11:4321")
+	require.Equal(http.StatusCreated, res.Code, "HTTP error: %s", res.Body.String())
+	res = register("regkeylimitpubkey3", "192.0.2.12:4321")
+	require.Equal(http.StatusBadRequest, res.Code, "HTTP error: %s", res.Body.String())
+
+	_, res, err = suite.ServeRequest(
+		http.MethodGet, "/:id", fmt.Sprintf("/%s", regKey.ID),
+		suite.api.GetRegKey, nil,
+	)
+	require.NoError(err)
+	require.Equal(http.StatusOK, res.Code, "HTTP error: %s", res.Body.String())
+	require.NoError(json.Unmarshal(res.Body.Bytes(), &regKey))
+	require.Equal(int64(2), regKey.Uses)
+	require.NotNil(regKey.RemainingUses)
+	require.Equal(int64(0), *regKey.RemainingUses)
+
+	_, res, err = suite.ServeRequest(
+		http.MethodGet, "/:id/devices", fmt.Sprintf("/%s/devices", regKey.ID),
+		suite.api.ListDevicesForRegKey, nil,
+	)
+	require.NoError(err)
+	require.Equal(http.StatusOK, res.Code, "HTTP error: %s", res.Body.String())
+	var devices []models.Device
+	require.NoError(json.Unmarshal(res.Body.Bytes(), &devices))
+	require.Len(devices, 2)
+}
+
+func (suite *HandlerTestSuite) TestRegKeyDeviceDefaults() {
+	require := suite.Require()
+
+	_, res, err := suite.ServeRequest(
+		http.MethodPost,
+		"/", "/",
+		suite.api.CreateRegKey, bytes.NewBuffer(suite.jsonMarshal(models.AddRegKey{
+			VpcID:            &suite.testUserID,
+			HostnameTemplate: "edge-{{.Missing}}",
+		})),
+	)
+	require.NoError(err)
+	require.Equal(http.StatusUnprocessableEntity, res.Code, "HTTP error: %s", res.Body.String())
+
+	_, res, err = suite.ServeRequest(
+		http.MethodPost,
+		"/", "/",
+		suite.api.CreateRegKey, bytes.NewBuffer(suite.jsonMarshal(models.AddRegKey{
+			VpcID:            &suite.testUserID,
+			Description:      "rack 12",
+			DeviceLabels:     map[string]string{"site": "lab", "role": "edge"},
+			DeviceMetadata:   map[string]interface{}{"rack": "r12"},
+			HostnameTemplate: "edge-{{.Index}}-{{.Hostname}}",
+		})),
+	)
+	require.NoError(err)
+	require.Equal(http.StatusCreated, res.Code, "HTTP error: %s", res.Body.String())
+	var regKey models.RegKey
+	require.NoError(json.Unmarshal(res.Body.Bytes(), &regKey))
+
+	res = suite.registerWithRegKey(regKey, models.AddDevice{
+		VpcID:     suite.testUserID,
+		PublicKey: "regkeydefaultspubkey",
+		Hostname:  "node",
+		Labels:    map[string]string{"role": "worker", "zone": "a"},
+	}, "192.0.2.10:4321")
+	require.Equal(http.StatusCreated, res.Code, "HTTP error: %s", res.Body.String())
+	var device models.Device
+	require.NoError(json.Unmarshal(res.Body.Bytes(), &device))
+	require.Equal("edge-1-node", device.Hostname)
+	require.Equal(map[string]string{"site": "lab", "role": "edge", "zone": "a"}, device.Labels)
+
+	_, res, err = suite.ServeRequest(
+		http.MethodGet, "/:id/metadata/:key", fmt.Sprintf("/%s/metadata/rack", device.ID),
+		suite.api.GetDeviceMetadataKey, nil,
+	)
+	require.NoError(err)
+	require.Equal(http.StatusOK, res.Code, "HTTP error: %s", res.Body.String())
+	var metadata models.DeviceMetadata
+	require.NoError(json.Unmarshal(res.Body.Bytes(), &metadata))
+	require.Equal("r12", metadata.Value)
+}
+
+// registerWithRegKey registers a device with the reg key the way envoy passes its claims to the apiserver
+func (suite *HandlerTestSuite) registerWithRegKey(regKey models.RegKey, device models.AddDevice, remoteAddr string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set(gin.AuthUserKey, suite.testUserID)
+		c.Set("_nexodus.Claims", map[string]interface{}{
+			"jti":    regKey.ID.String(),
+			"scope":  "reg-token",
+			"vpc_id": suite.testUserID.String(),
+		})
+		c.Next()
+	})
+	r.POST("/", suite.api.CreateDevice)
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBuffer(suite.jsonMarshal(device)))
+	req.Header.Set("Content-Type", "application/json")
+	req.RemoteAddr = remoteAddr
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+	return res
+}
+
+func (suite *HandlerTestSuite) TestRegKeyBearerTokenVisibility() {
+	require := suite.Require()
+
+	_, res, err := suite.ServeRequest(
+		http.MethodPost,
+		"/", "/",
+		suite.api.CreateRegKey, bytes.NewBuffer(suite.jsonMarshal(models.AddRegKey{
+			VpcID:       &suite.testUserID,
+			Description: "visibility",
+		})),
+	)
+	require.NoError(err)
+	require.Equal(http.StatusCreated, res.Code, "HTTP error: %s", res.Body.String())
+	var regKey models.RegKey
+	require.NoError(json.Unmarshal(res.Body.Bytes(), &regKey))
+	require.NotEmpty(regKey.BearerToken)
+
+	// testuser2 joins the organization of testuser with the role under test
+	setRole := func(role string) {
+		require.NoError(suite.api.db.Save(&models.UserOrganization{
+			UserID:         suite.testUser2ID,
+			OrganizationID: suite.testUserID,
+			Roles:          []string{role},
+		}).Error)
+	}
+	asUser2 := func(handler func(*gin.Context)) func(*gin.Context) {
+		return func(c *gin.Context) {
+			c.Set(gin.AuthUserKey, suite.testUser2ID)
+			handler(c)
+		}
+	}
+	list := func() []models.RegKey {
+		_, res, err := suite.ServeRequest(http.MethodGet, "/", "/", asUser2(suite.api.ListRegKeys), nil)
+		require.NoError(err)
+		require.Equal(http.StatusOK, res.Code, "HTTP error: %s", res.Body.String())
+		var regKeys []models.RegKey
+		require.NoError(json.Unmarshal(res.Body.Bytes(), &regKeys))
+		return regKeys
+	}
+	get := func() *httptest.ResponseRecorder {
+		_, res, err := suite.ServeRequest(http.MethodGet, "/:id", fmt.Sprintf("/%s", regKey.ID), asUser2(suite.api.GetRegKey), nil)
+		require.NoError(err)
+		return res
+	}
+
+	// read-only members can not read the reg keys, whose tokens enroll devices
+	setRole("read-only")
+	for _, key := range list() {
+		require.NotEqual(regKey.ID, key.ID)
+		require.Empty(key.BearerToken)
+	}
+	require.Equal(http.StatusNotFound, get().Code)
+
+	// the roles that can create reg keys see their tokens
+	setRole("device-admin")
+	res = get()
+	require.Equal(http.StatusOK, res.Code, "HTTP error: %s", res.Body.String())
+	var got models.RegKey
+	require.NoError(json.Unmarshal(res.Body.Bytes(), &got))
+	require.Equal(regKey.BearerToken, got.BearerToken)
+
+	// a custom role that can only read the reg keys lists them without their tokens
+	require.NoError(suite.api.db.Create(&models.Role{
+		OrganizationID: suite.testUserID,
+		Name:           "reg-key-auditor",
+		Permissions:    map[string][]string{ResourceRegKeys: {VerbRead}},
+		Grants:         models.PermissionGrants(map[string][]string{ResourceRegKeys: {VerbRead}}),
+	}).Error)
+	setRole("reg-key-auditor")
+	res = get()
+	require.Equal(http.StatusOK, res.Code, "HTTP error: %s", res.Body.String())
+	got = models.RegKey{}
+	require.NoError(json.Unmarshal(res.Body.Bytes(), &got))
+	require.Equal(regKey.ID, got.ID)
+	require.Empty(got.BearerToken)
+	listed := false
+	for _, key := range list() {
+		require.Empty(key.BearerToken)
+		listed = listed || key.ID == regKey.ID
+	}
+	require.True(listed)
+}
@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nexodus-io/nexodus/internal/database"
+	"github.com/nexodus-io/nexodus/internal/models"
+	"gorm.io/gorm"
+)
+
+// The verbs a role can grant on a resource type. Write covers updating and deleting.
+const (
+	VerbRead   = "read"
+	VerbCreate = "create"
+	VerbWrite  = "write"
+)
+
+// The resource types permissions are granted on. The resources are scoped to an organization.
+const (
+	ResourceOrganizations   = "organizations"
+	ResourceInvitations     = "invitations"
+	ResourceVPCs            = "vpcs"
+	ResourceDevices         = "devices"
+	ResourceSecurityGroups  = "security-groups"
+	ResourceDNSRecords      = "dns-records"
+	ResourceRegKeys         = "reg-keys"
+	ResourceServiceNetworks = "service-networks"
+	ResourceSites           = "sites"
+	ResourceAuditEvents     = "audit-events"
+	ResourceWebhooks        = "webhooks"
+	ResourceRoles           = "roles"
+)
+
+var allVerbs = []string{VerbRead, VerbCreate, VerbWrite}
+
+// Roles are the built-in roles that can be granted to the users of every organization, keyed by name.
+// Organizations can define custom roles in addition to them.
+var Roles = map[string]models.Role{
+	"owner": {
+		Name:        "owner",
+		Description: "Full access to the organization and all of its resources",
+		Permissions: map[string][]string{
+			ResourceOrganizations:   {VerbRead, VerbWrite},
+			ResourceInvitations:     allVerbs,
+			ResourceVPCs:            allVerbs,
+			ResourceDevices:         allVerbs,
+			ResourceSecurityGroups:  allVerbs,
+			ResourceDNSRecords:      allVerbs,
+			ResourceRegKeys:         {VerbRead, VerbCreate},
+			ResourceServiceNetworks: allVerbs,
+			ResourceSites:           {VerbCreate},
+			ResourceAuditEvents:     {VerbRead},
+			ResourceWebhooks:        allVerbs,
+			ResourceRoles:           allVerbs,
+		},
+	},
+	"member": {
+		Name:        "member",
+		Description: "Read access to the organization, and can create VPCs and add their own devices",
+		Permissions: map[string][]string{
+			ResourceOrganizations:   {VerbRead},
+			ResourceVPCs:            {VerbRead, VerbCreate},
+			ResourceDevices:         {VerbCreate},
+			ResourceSecurityGroups:  {VerbRead},
+			ResourceDNSRecords:      {VerbRead},
+			ResourceRegKeys:         {VerbCreate},
+			ResourceServiceNetworks: {VerbRead, VerbCreate},
+			ResourceSites:           {VerbCreate},
+		},
+	},
+	"read-only": {
+		Name:        "read-only",
+		Description: "Read access to the organization and its resources, except its registration keys",
+		Permissions: map[string][]string{
+			ResourceOrganizations:   {VerbRead},
+			ResourceInvitations:     {VerbRead},
+			ResourceVPCs:            {VerbRead},
+			ResourceDevices:         {VerbRead},
+			ResourceSecurityGroups:  {VerbRead},
+			ResourceDNSRecords:      {VerbRead},
+			ResourceServiceNetworks: {VerbRead},
+			ResourceRoles:           {VerbRead},
+		},
+	},
+	"device-admin": {
+		Name:        "device-admin",
+		Description: "Manages all the devices and registration keys of the organization",
+		Permissions: map[string][]string{
+			ResourceOrganizations:  {VerbRead},
+			ResourceVPCs:           {VerbRead},
+			ResourceDevices:        allVerbs,
+			ResourceSecurityGroups: {VerbRead},
+			ResourceDNSRecords:     {VerbRead},
+			ResourceRegKeys:        {VerbRead, VerbCreate},
+		},
+	},
+	"security-group-editor": {
+		Name:        "security-group-editor",
+		Description: "Manages the security groups of the organization",
+		Permissions: map[string][]string{
+			ResourceOrganizations:  {VerbRead},
+			ResourceVPCs:           {VerbRead},
+			ResourceDevices:        {VerbRead},
+			ResourceSecurityGroups: allVerbs,
+		},
+	},
+	"invite-only": {
+		Name:        "invite-only",
+		Description: "Invites users to the organization",
+		Permissions: map[string][]string{
+			ResourceOrganizations: {VerbRead},
+			ResourceInvitations:   allVerbs,
+			ResourceRoles:         {VerbRead},
+		},
+	},
+}
+
+// RolesWithPermission returns the names of the roles that grant the verb on the resource type
+func RolesWithPermission(resource string, verb string) []string {
+	var names []string
+	for name, role := range Roles {
+		if role.Allows(resource, verb) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CurrentUserHasPermission limits the query to the records of the organizations in which the
+// current user holds a built-in role or a custom role of the organization that grants the verb on the
+// resource type.
+func (api *API) CurrentUserHasPermission(c *gin.Context, db *gorm.DB, orgIdField string, resource string, verb string) *gorm.DB {
+	userId := api.GetCurrentUserID(c)
+	builtinRoles := RolesWithPermission(resource, verb)
+	grant := models.RoleGrant(resource, verb)
+	if api.dialect == database.DialectSqlLite {
+		return db.Where(fmt.Sprintf("%s in (SELECT DISTINCT uo.organization_id FROM user_organizations AS uo, json_each(uo.roles) AS role where uo.user_id=? AND "+
+			"(role.value IN (?) OR EXISTS (SELECT 1 FROM roles AS r, json_each(r.grants) AS g WHERE r.organization_id = uo.organization_id AND r.deleted_at IS NULL AND r.name = role.value AND g.value = ?)))", orgIdField),
+			userId, builtinRoles, grant)
+	} else {
+		return db.Where(fmt.Sprintf("%s in (SELECT DISTINCT uo.organization_id FROM user_organizations AS uo where uo.user_id=? AND "+
+			"(uo.roles && ? OR EXISTS (SELECT 1 FROM roles AS r WHERE r.organization_id = uo.organization_id AND r.deleted_at IS NULL AND r.name = ANY(uo.roles) AND ? = ANY(r.grants))))", orgIdField),
+			userId, models.StringArray(builtinRoles), grant)
+	}
+}
+
+// validateRolePermissions checks that the permissions of a custom role only hold known resource types
+// and verbs, and do not grant more than the owner role.
+func validateRolePermissions(permissions map[string][]string) error {
+	owner := Roles["owner"]
+	for resource, verbs := range permissions {
+		if _, found := owner.Permissions[resource]; !found {
+			return fmt.Errorf("unknown resource type: %s", resource)
+		}
+		for _, verb := range verbs {
+			if !owner.Allows(resource, verb) {
+				return fmt.Errorf("%s can not be granted on %s", verb, resource)
+			}
+		}
+	}
+	return nil
+}
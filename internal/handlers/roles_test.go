@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRolesWithPermission(t *testing.T) {
+	assert.Equal(t, []string{"device-admin", "owner"}, RolesWithPermission(ResourceDevices, VerbWrite))
+	assert.Equal(t, []string{"owner", "security-group-editor"}, RolesWithPermission(ResourceSecurityGroups, VerbWrite))
+	assert.Equal(t, []string{"invite-only", "owner"}, RolesWithPermission(ResourceInvitations, VerbCreate))
+	assert.Equal(t, []string{"device-admin", "invite-only", "member", "owner", "read-only", "security-group-editor"}, RolesWithPermission(ResourceOrganizations, VerbRead))
+	assert.Equal(t, []string{"owner"}, RolesWithPermission(ResourceOrganizations, VerbWrite))
+	assert.Empty(t, RolesWithPermission("unknown", VerbRead))
+}
+
+func TestRoleAllows(t *testing.T) {
+	readOnly := Roles["read-only"]
+	assert.True(t, readOnly.Allows(ResourceDevices, VerbRead))
+	assert.False(t, readOnly.Allows(ResourceDevices, VerbCreate))
+	assert.False(t, readOnly.Allows(ResourceDevices, VerbWrite))
+}
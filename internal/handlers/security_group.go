@@ -53,11 +53,11 @@ func (d securityGroupList) Len() int {
 }
 
 func (api *API) SecurityGroupIsReadableByCurrentUser(c *gin.Context, db *gorm.DB) *gorm.DB {
-	return api.CurrentUserHasRole(c, db, "organization_id", MemberRoles)
+	return api.CurrentUserHasPermission(c, db, "organization_id", ResourceSecurityGroups, VerbRead)
 }
 
 func (api *API) SecurityGroupIsWriteableByCurrentUser(c *gin.Context, db *gorm.DB) *gorm.DB {
-	return api.CurrentUserHasRole(c, db, "organization_id", OwnerRoles)
+	return api.CurrentUserHasPermission(c, db, "organization_id", ResourceSecurityGroups, VerbWrite)
 }
 
 // ListSecurityGroups lists all Security Groups
@@ -242,6 +242,8 @@ func (api *API) CreateSecurityGroup(c *gin.Context) {
 			c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError("port_range", err.Error()))
 		case strings.Contains(err.Error(), "invalid IP range"):
 			c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError("ip_range", err.Error()))
+		case strings.Contains(err.Error(), "invalid peer selector"):
+			c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError("peer_selector", err.Error()))
 		default:
 			c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError("rule", "invalid rule"))
 		}
@@ -256,7 +258,7 @@ func (api *API) CreateSecurityGroup(c *gin.Context) {
 	var sg models.SecurityGroup
 	err := api.transaction(ctx, func(tx *gorm.DB) error {
 		var vpc models.VPC
-		if res := api.VPCIsOwnedByCurrentUser(c, tx).
+		if res := api.CurrentUserHasPermission(c, tx, "organization_id", ResourceSecurityGroups, VerbCreate).
 			First(&vpc, "id = ?", request.VpcId); res.Error != nil {
 			return res.Error
 		}
@@ -276,7 +278,7 @@ func (api *API) CreateSecurityGroup(c *gin.Context) {
 
 		span.SetAttributes(attribute.String("id", sg.ID.String()))
 		api.logger.Infof("New security group created [ %s ] in organization [ %s ]", sg.ID, vpc.ID)
-		return nil
+		return api.recordAuditEvent(c, tx, AuditActionCreate, ResourceSecurityGroups, sg.OrganizationID, sg.ID, nil, sg)
 	})
 
 	if err != nil {
@@ -371,7 +373,7 @@ func (api *API) DeleteSecurityGroup(c *gin.Context) {
 			return res.Error
 		}
 
-		return nil
+		return api.recordAuditEvent(c, tx, AuditActionDelete, ResourceSecurityGroups, sg.OrganizationID, sg.ID, sg, nil)
 	})
 
 	if err != nil {
@@ -439,6 +441,8 @@ func (api *API) UpdateSecurityGroup(c *gin.Context) {
 			c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError("port_range", err.Error()))
 		case strings.Contains(err.Error(), "invalid IP range"):
 			c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError("ip_range", err.Error()))
+		case strings.Contains(err.Error(), "invalid peer selector"):
+			c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError("peer_selector", err.Error()))
 		default:
 			c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError("rule", "invalid rule"))
 		}
@@ -453,6 +457,7 @@ func (api *API) UpdateSecurityGroup(c *gin.Context) {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return errSecurityGroupNotFound
 		}
+		before := securityGroup
 
 		if request.Description != nil {
 			securityGroup.Description = *request.Description
@@ -470,7 +475,7 @@ func (api *API) UpdateSecurityGroup(c *gin.Context) {
 			return res.Error
 		}
 
-		return nil
+		return api.recordAuditEvent(c, tx, AuditActionUpdate, ResourceSecurityGroups, securityGroup.OrganizationID, securityGroup.ID, before, securityGroup)
 	})
 
 	if err != nil {
@@ -570,5 +575,12 @@ func ValidateRule(rule models.SecurityRule) error {
 		}
 	}
 
+	// Validate the peer selector
+	if rule.PeerSelector != "" {
+		if _, err := util.ParseLabelSelector(rule.PeerSelector); err != nil {
+			return fmt.Errorf("invalid peer selector: %w", err)
+		}
+	}
+
 	return nil
 }
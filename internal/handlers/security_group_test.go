@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"testing"
 
 	"github.com/nexodus-io/nexodus/internal/util"
 
 	"github.com/gin-gonic/gin"
 	"github.com/nexodus-io/nexodus/internal/models"
+	"github.com/stretchr/testify/assert"
 )
 
 func (suite *HandlerTestSuite) TestCreateGetSecurityGroups() {
@@ -425,3 +427,9 @@ func (suite *HandlerTestSuite) TestInvalidUpdateSecurityGroup() {
 	// Should be http.StatusStatusUnprocessableEntity.
 	require.Equal(http.StatusUnprocessableEntity, res.Code)
 }
+
+func TestValidateRulePeerSelector(t *testing.T) {
+	assert.NoError(t, ValidateRule(models.SecurityRule{IpProtocol: "tcp", FromPort: 5432, ToPort: 5432, PeerSelector: "role=db,env=prod"}))
+	err := ValidateRule(models.SecurityRule{IpProtocol: "tcp", FromPort: 5432, ToPort: 5432, PeerSelector: "role"})
+	assert.ErrorContains(t, err, "invalid peer selector")
+}
@@ -51,7 +51,7 @@ func (api *API) CreateServiceNetwork(c *gin.Context) {
 	err := api.transaction(ctx, func(tx *gorm.DB) error {
 
 		var org models.Organization
-		if res := api.OrganizationIsReadableByCurrentUser(c, tx).
+		if res := api.CurrentUserHasPermission(c, tx, "id", ResourceServiceNetworks, VerbCreate).
 			First(&org, "id = ?", request.OrganizationID.String()); res.Error != nil {
 			return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("organization"))
 		}
@@ -69,7 +69,7 @@ func (api *API) CreateServiceNetwork(c *gin.Context) {
 			}
 			return fmt.Errorf("failed to create service_network: %w", res.Error)
 		}
-		return nil
+		return api.recordAuditEvent(c, tx, AuditActionCreate, ResourceServiceNetworks, serviceNetwork.OrganizationID, serviceNetwork.ID, nil, serviceNetwork)
 	})
 
 	if err != nil {
@@ -86,11 +86,11 @@ func (api *API) CreateServiceNetwork(c *gin.Context) {
 }
 
 func (api *API) ServiceNetworkIsReadableByCurrentUser(c *gin.Context, db *gorm.DB) *gorm.DB {
-	return api.CurrentUserHasRole(c, db, "organization_id", MemberRoles)
+	return api.CurrentUserHasPermission(c, db, "organization_id", ResourceServiceNetworks, VerbRead)
 }
 
 func (api *API) ServiceNetworkIsOwnedByCurrentUser(c *gin.Context, db *gorm.DB) *gorm.DB {
-	return api.CurrentUserHasRole(c, db, "organization_id", OwnerRoles)
+	return api.CurrentUserHasPermission(c, db, "organization_id", ResourceServiceNetworks, VerbWrite)
 }
 
 // ListServiceNetworks lists all ServiceNetworks
@@ -227,15 +227,18 @@ func (api *API) DeleteServiceNetwork(c *gin.Context) {
 		return
 	}
 
-	// Cascade delete related records
-	if res := db.Where("service_network_id = ?", id).Delete(&models.RegKey{}); res.Error != nil {
-		api.SendInternalServerError(c, res.Error)
-		return
-	}
-
-	result = db.Delete(&serviceNetwork)
-	if result.Error != nil {
-		api.SendInternalServerError(c, result.Error)
+	err = api.transaction(ctx, func(tx *gorm.DB) error {
+		// Cascade delete related records
+		if res := tx.Where("service_network_id = ?", id).Delete(&models.RegKey{}); res.Error != nil {
+			return res.Error
+		}
+		if res := tx.Delete(&serviceNetwork); res.Error != nil {
+			return res.Error
+		}
+		return api.recordAuditEvent(c, tx, AuditActionDelete, ResourceServiceNetworks, serviceNetwork.OrganizationID, serviceNetwork.ID, serviceNetwork, nil)
+	})
+	if err != nil {
+		api.SendInternalServerError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, serviceNetwork)
@@ -282,6 +285,7 @@ func (api *API) UpdateServiceNetwork(c *gin.Context) {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("service_network"))
 		}
+		before := serviceNetwork
 
 		if request.Description != nil {
 			serviceNetwork.Description = *request.Description
@@ -292,7 +296,7 @@ func (api *API) UpdateServiceNetwork(c *gin.Context) {
 			Save(&serviceNetwork); res.Error != nil {
 			return res.Error
 		}
-		return nil
+		return api.recordAuditEvent(c, tx, AuditActionUpdate, ResourceServiceNetworks, serviceNetwork.OrganizationID, serviceNetwork.ID, before, serviceNetwork)
 	})
 
 	if err != nil {
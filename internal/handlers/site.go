@@ -241,6 +241,7 @@ func (api *API) UpdateSite(c *gin.Context) {
 		if result = tx.First(&ServiceNetwork, "id = ?", site.ServiceNetworkID); result.Error != nil {
 			return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("service_network"))
 		}
+		before := site
 
 		if request.Hostname != nil {
 			site.Hostname = *request.Hostname
@@ -258,7 +259,7 @@ func (api *API) UpdateSite(c *gin.Context) {
 			return res.Error
 		}
 
-		return nil
+		return api.recordAuditEvent(c, tx, AuditActionUpdate, ResourceSites, site.OrganizationID, site.ID, before, site)
 	})
 
 	if err != nil {
@@ -321,7 +322,7 @@ func (api *API) CreateSite(c *gin.Context) {
 	err := api.transaction(ctx, func(tx *gorm.DB) error {
 
 		var ServiceNetwork models.ServiceNetwork
-		if result := api.ServiceNetworkIsReadableByCurrentUser(c, tx).
+		if result := api.CurrentUserHasPermission(c, tx, "organization_id", ResourceSites, VerbCreate).
 			Preload("Organization").
 			First(&ServiceNetwork, "id = ?", request.ServiceNetworkID); result.Error != nil {
 			return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("service_network"))
@@ -401,7 +402,7 @@ func (api *API) CreateSite(c *gin.Context) {
 		span.SetAttributes(
 			attribute.String("id", site.ID.String()),
 		)
-		return nil
+		return api.recordAuditEvent(c, tx, AuditActionCreate, ResourceSites, site.OrganizationID, site.ID, nil, site)
 	})
 
 	if err != nil {
@@ -467,17 +468,27 @@ func (api *API) DeleteSite(c *gin.Context) {
 		api.SendInternalServerError(c, result.Error)
 	}
 
-	// Null out unique fields to that a new site can be created later with the same values
-	if res := api.db.WithContext(ctx).
-		Model(&site).
-		Clauses(clause.Returning{Columns: []clause.Column{{Name: "revision"}}}).
-		Where("id = ?", site.Base.ID).
-		Updates(map[string]interface{}{
-			"bearer_token": nil,
-			"public_key":   nil,
-			"deleted_at":   gorm.DeletedAt{Time: time.Now(), Valid: true},
-		}); res.Error != nil {
-		api.SendInternalServerError(c, res.Error)
+	err = api.transaction(ctx, func(tx *gorm.DB) error {
+		if err := api.recordAuditEvent(c, tx, AuditActionDelete, ResourceSites, site.OrganizationID, site.ID, site, nil); err != nil {
+			return err
+		}
+
+		// Null out unique fields to that a new site can be created later with the same values
+		if res := tx.
+			Model(&site).
+			Clauses(clause.Returning{Columns: []clause.Column{{Name: "revision"}}}).
+			Where("id = ?", site.Base.ID).
+			Updates(map[string]interface{}{
+				"bearer_token": nil,
+				"public_key":   nil,
+				"deleted_at":   gorm.DeletedAt{Time: time.Now(), Valid: true},
+			}); res.Error != nil {
+			return res.Error
+		}
+		return nil
+	})
+	if err != nil {
+		api.SendInternalServerError(c, err)
 		return
 	}
 
@@ -350,6 +350,9 @@ func (api *API) DeleteUser(c *gin.Context) {
 		if res := tx.Where("user_id = ?", userId).Delete(&models.Invitation{}); res.Error != nil {
 			return result.Error
 		}
+		if res := tx.Where("user_id = ?", userId).Delete(&models.APIToken{}); res.Error != nil {
+			return result.Error
+		}
 
 		// find the organizations the user is an owner of
 		ownerRole := []string{"owner"}
@@ -383,15 +386,22 @@ func (api *API) DeleteUser(c *gin.Context) {
 
 			// if the user is the only owner, delete the organization
 			if count <= 1 {
-				err := deleteOrganization(tx, org.OrganizationID)
+				var organization models.Organization
+				if res := tx.First(&organization, "id = ?", org.OrganizationID); res.Error != nil {
+					return res.Error
+				}
+				err := api.deleteOrganization(c, tx, organization)
 				if err != nil {
 					return err
 				}
 			} else {
 				// remove the user from the organization
-				if res := tx.Where("user_id = ?", userId).
+				if res := tx.Where("user_id = ? AND organization_id = ?", userId, org.OrganizationID).
 					Delete(&models.UserOrganization{}); res.Error != nil {
-					return result.Error
+					return res.Error
+				}
+				if err := api.recordAuditEvent(c, tx, AuditActionDelete, AuditResourceOrganizationUsers, org.OrganizationID, org.UserID, org, nil); err != nil {
+					return err
 				}
 			}
 		}
@@ -468,19 +478,26 @@ func (api *API) DeleteUserFromOrganization(c *gin.Context) {
 	var user models.User
 	var organization models.Organization
 	err := api.transaction(ctx, func(tx *gorm.DB) error {
-		if res := api.db.First(&user, "id = ?", userID); res.Error != nil {
+		if res := tx.First(&user, "id = ?", userID); res.Error != nil {
 			return errUserNotFound
 		}
-		if res := api.db.First(&organization, "id = ?", orgID); res.Error != nil {
+		if res := tx.First(&organization, "id = ?", orgID); res.Error != nil {
 			return errOrgNotFound
 		}
-		if res := api.db.
+		var membership models.UserOrganization
+		if res := tx.First(&membership, "user_id = ? AND organization_id = ?", userID, orgID); res.Error != nil {
+			if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return res.Error
+		}
+		if res := tx.
 			Where("user_id = ?", userID).
 			Where("organization_id = ?", orgID).
 			Delete(&models.UserOrganization{}); res.Error != nil {
-			api.SendInternalServerError(c, fmt.Errorf("failed to remove the association from the user_organizations table: %w", res.Error))
+			return fmt.Errorf("failed to remove the association from the user_organizations table: %w", res.Error)
 		}
-		return nil
+		return api.recordAuditEvent(c, tx, AuditActionDelete, AuditResourceOrganizationUsers, membership.OrganizationID, membership.UserID, membership, nil)
 	})
 
 	if err != nil {
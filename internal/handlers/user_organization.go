@@ -226,6 +226,11 @@ func (api *API) DeleteOrganizationUser(c *gin.Context) {
 		if result.Error != nil {
 			return result.Error
 		}
+		membership := model
+		membership.User = nil
+		if err := api.recordAuditEvent(c, tx, AuditActionDelete, AuditResourceOrganizationUsers, model.OrganizationID, model.UserID, membership, nil); err != nil {
+			return err
+		}
 
 		result = tx.Where("organization_id=? AND owner_id=?", id, uid).
 			Delete(&models.RegKey{})
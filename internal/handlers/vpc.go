@@ -10,6 +10,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/nexodus-io/nexodus/internal/database"
+	"github.com/nexodus-io/nexodus/internal/ipam"
 	"github.com/nexodus-io/nexodus/internal/models"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -90,19 +91,29 @@ func (api *API) CreateVPC(c *gin.Context) {
 
 	var vpc models.VPC
 	err := api.transaction(ctx, func(tx *gorm.DB) error {
+		ipamCtx := ipam.WithTransaction(ctx, tx)
 
 		var org models.Organization
-		if res := api.OrganizationIsReadableByCurrentUser(c, tx).
+		if res := api.CurrentUserHasPermission(c, tx, "id", ResourceVPCs, VerbCreate).
 			First(&org, "id = ?", request.OrganizationID.String()); res.Error != nil {
 			return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("organization"))
 		}
 
 		vpc = models.VPC{
-			OrganizationID: request.OrganizationID,
-			Description:    request.Description,
-			PrivateCidr:    request.PrivateCidr,
-			Ipv4Cidr:       request.Ipv4Cidr,
-			Ipv6Cidr:       request.Ipv6Cidr,
+			OrganizationID:        request.OrganizationID,
+			Description:           request.Description,
+			PrivateCidr:           request.PrivateCidr,
+			Ipv4Cidr:              request.Ipv4Cidr,
+			Ipv6Cidr:              request.Ipv6Cidr,
+			RequireDeviceApproval: request.RequireDeviceApproval,
+			PresharedKeys:         request.PresharedKeys,
+		}
+		if vpc.PresharedKeys {
+			secret, err := newPresharedKeySecret()
+			if err != nil {
+				return err
+			}
+			vpc.PresharedKeySecret = secret
 		}
 
 		if res := tx.
@@ -118,15 +129,15 @@ func (api *API) CreateVPC(c *gin.Context) {
 		if vpc.PrivateCidr {
 			ipamNamespace = vpc.ID
 		}
-		if err := api.ipam.CreateNamespace(ctx, ipamNamespace); err != nil {
+		if err := api.ipam.CreateNamespace(ipamCtx, ipamNamespace); err != nil {
 			return fmt.Errorf("failed to create namespace: %w", err)
 		}
 
-		if err := api.ipam.AssignCIDR(ctx, ipamNamespace, request.Ipv4Cidr); err != nil {
+		if err := api.ipam.AssignCIDR(ipamCtx, ipamNamespace, request.Ipv4Cidr); err != nil {
 			return fmt.Errorf("failed to assign IPv4 prefix: %w", err)
 		}
 
-		if err := api.ipam.AssignCIDR(ctx, ipamNamespace, request.Ipv6Cidr); err != nil {
+		if err := api.ipam.AssignCIDR(ipamCtx, ipamNamespace, request.Ipv6Cidr); err != nil {
 			return fmt.Errorf("failed to assign IPv6 prefix: %w", err)
 		}
 
@@ -138,7 +149,7 @@ func (api *API) CreateVPC(c *gin.Context) {
 
 		span.SetAttributes(attribute.String("id", vpc.ID.String()))
 		api.logger.Infof("New vpc request [ %s ] ipam v4 [ %s ] ipam v6 [ %s ] request", vpc.ID.String(), vpc.Ipv4Cidr, vpc.Ipv6Cidr)
-		return nil
+		return api.recordAuditEvent(c, tx, AuditActionCreate, ResourceVPCs, vpc.OrganizationID, vpc.ID, nil, vpc)
 	})
 
 	if err != nil {
@@ -155,11 +166,11 @@ func (api *API) CreateVPC(c *gin.Context) {
 }
 
 func (api *API) VPCIsReadableByCurrentUser(c *gin.Context, db *gorm.DB) *gorm.DB {
-	return api.CurrentUserHasRole(c, db, "organization_id", MemberRoles)
+	return api.CurrentUserHasPermission(c, db, "organization_id", ResourceVPCs, VerbRead)
 }
 
 func (api *API) VPCIsOwnedByCurrentUser(c *gin.Context, db *gorm.DB) *gorm.DB {
-	return api.CurrentUserHasRole(c, db, "organization_id", OwnerRoles)
+	return api.CurrentUserHasPermission(c, db, "organization_id", ResourceVPCs, VerbWrite)
 }
 
 // ListVPCs lists all VPCs
@@ -296,19 +307,24 @@ func (api *API) DeleteVPC(c *gin.Context) {
 		return
 	}
 
-	// Cascade delete related records
-	if res := db.Where("vpc_id = ?", id).Delete(&models.RegKey{}); res.Error != nil {
-		api.SendInternalServerError(c, res.Error)
-		return
-	}
-	if res := db.Where("vpc_id = ?", id).Delete(&models.SecurityGroup{}); res.Error != nil {
-		api.SendInternalServerError(c, res.Error)
-		return
-	}
-
-	result = db.Delete(&vpc)
-	if result.Error != nil {
-		api.SendInternalServerError(c, result.Error)
+	err = api.transaction(ctx, func(tx *gorm.DB) error {
+		// Cascade delete related records
+		if res := tx.Where("vpc_id = ?", id).Delete(&models.RegKey{}); res.Error != nil {
+			return res.Error
+		}
+		if res := tx.Where("vpc_id = ?", id).Delete(&models.SecurityGroup{}); res.Error != nil {
+			return res.Error
+		}
+		if res := tx.Where("vpc_id = ?", id).Delete(&models.DNSRecord{}); res.Error != nil {
+			return res.Error
+		}
+		if res := tx.Delete(&vpc); res.Error != nil {
+			return res.Error
+		}
+		return api.recordAuditEvent(c, tx, AuditActionDelete, ResourceVPCs, vpc.OrganizationID, vpc.ID, vpc, nil)
+	})
+	if err != nil {
+		api.SendInternalServerError(c, err)
 		return
 	}
 
@@ -374,17 +390,34 @@ func (api *API) UpdateVPC(c *gin.Context) {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("vpc"))
 		}
+		before := vpc
 
 		if request.Description != nil {
 			vpc.Description = *request.Description
 		}
+		if request.RequireDeviceApproval != nil {
+			vpc.RequireDeviceApproval = *request.RequireDeviceApproval
+		}
+		if request.PresharedKeys != nil {
+			vpc.PresharedKeys = *request.PresharedKeys
+			// turning the pre-shared keys off and on again gives every device pair new keys
+			if !vpc.PresharedKeys {
+				vpc.PresharedKeySecret = ""
+			} else if vpc.PresharedKeySecret == "" {
+				secret, err := newPresharedKeySecret()
+				if err != nil {
+					return err
+				}
+				vpc.PresharedKeySecret = secret
+			}
+		}
 
 		if res := tx.
 			Clauses(clause.Returning{Columns: []clause.Column{{Name: "revision"}}}).
 			Save(&vpc); res.Error != nil {
 			return res.Error
 		}
-		return nil
+		return api.recordAuditEvent(c, tx, AuditActionUpdate, ResourceVPCs, vpc.OrganizationID, vpc.ID, before, vpc)
 	})
 
 	if err != nil {
@@ -0,0 +1,527 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nexodus-io/nexodus/internal/models"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// webhookAllowInternalDestinations disables the checks that keep webhooks from being
+// delivered to loopback, private and link-local addresses, it is only set by the tests.
+var webhookAllowInternalDestinations = false
+
+// sharedAddressSpace is the carrier grade NAT range that the device tunnels are addressed from
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// webhookEventKinds are the kinds of watch events that can be delivered to a webhook
+var webhookEventKinds = []string{"device", "security-group", "vpc", "dns-record"}
+
+func (api *API) WebhookIsReadableByCurrentUser(c *gin.Context, db *gorm.DB) *gorm.DB {
+	return api.CurrentUserHasPermission(c, db, "organization_id", ResourceWebhooks, VerbRead)
+}
+
+func (api *API) WebhookIsWriteableByCurrentUser(c *gin.Context, db *gorm.DB) *gorm.DB {
+	return api.CurrentUserHasPermission(c, db, "organization_id", ResourceWebhooks, VerbWrite)
+}
+
+// ListWebhooks lists the webhooks
+// @Summary      List Webhooks
+// @Description  Lists the webhooks of the organizations of the user
+// @Id  		 ListWebhooks
+// @Tags         Webhooks
+// @Accepts		 json
+// @Produce      json
+// @Success      200  {object}  []models.Webhook
+// @Failure		 401  {object}  models.BaseError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/webhooks [get]
+func (api *API) ListWebhooks(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "ListWebhooks")
+	defer span.End()
+
+	webhooks := make([]models.Webhook, 0)
+	db := api.WebhookIsReadableByCurrentUser(c, api.db.WithContext(ctx))
+	db = FilterAndPaginate(db, &models.Webhook{}, c, "url")
+	if res := db.Find(&webhooks); res.Error != nil {
+		api.SendInternalServerError(c, res.Error)
+		return
+	}
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+	c.JSON(http.StatusOK, webhooks)
+}
+
+// GetWebhook gets a webhook
+// @Summary      Get Webhook
+// @Description  Gets a webhook by ID
+// @Id  		 GetWebhook
+// @Tags         Webhooks
+// @Accepts		 json
+// @Produce      json
+// @Param        id   path      string  true "Webhook ID"
+// @Success      200  {object}  models.Webhook
+// @Failure      400  {object}  models.BaseError
+// @Failure		 401  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/webhooks/{id} [get]
+func (api *API) GetWebhook(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "GetWebhook",
+		trace.WithAttributes(
+			attribute.String("id", c.Param("id")),
+		))
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+
+	var webhook models.Webhook
+	db := api.db.WithContext(ctx)
+	if res := api.WebhookIsReadableByCurrentUser(c, db).
+		First(&webhook, "id = ?", id); res.Error != nil {
+		if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, models.NewNotFoundError("webhook"))
+		} else {
+			api.SendInternalServerError(c, res.Error)
+		}
+		return
+	}
+	webhook.Secret = ""
+	c.JSON(http.StatusOK, webhook)
+}
+
+// CreateWebhook creates a webhook
+// @Summary      Create Webhook
+// @Description  Creates a webhook that the change events of the resources of an organization are delivered to
+// @Id  		 CreateWebhook
+// @Tags         Webhooks
+// @Accepts		 json
+// @Produce      json
+// @Param        Webhook  body   models.AddWebhook  true "Add Webhook"
+// @Success      201  {object}  models.Webhook
+// @Failure      400  {object}  models.BaseError
+// @Failure		 401  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure      422  {object}  models.ValidationError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/webhooks [post]
+func (api *API) CreateWebhook(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "CreateWebhook")
+	defer span.End()
+
+	var request models.AddWebhook
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPayloadError(err))
+		return
+	}
+	if request.OrganizationID == uuid.Nil {
+		c.JSON(http.StatusBadRequest, models.NewFieldNotPresentError("organization_id"))
+		return
+	}
+	if err := validateWebhookURL(ctx, request.URL); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError("url", err.Error()))
+		return
+	}
+	if err := validateWebhookEventKinds(request.EventKinds); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError("event_kinds", err.Error()))
+		return
+	}
+	if request.Secret == "" {
+		secret, err := newWebhookSecret()
+		if err != nil {
+			api.SendInternalServerError(c, err)
+			return
+		}
+		request.Secret = secret
+	}
+
+	var webhook models.Webhook
+	err := api.transaction(ctx, func(tx *gorm.DB) error {
+		var org models.Organization
+		if res := api.CurrentUserHasPermission(c, tx, "id", ResourceWebhooks, VerbCreate).
+			First(&org, "id = ?", request.OrganizationID); res.Error != nil {
+			if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+				return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("organization"))
+			}
+			return res.Error
+		}
+
+		webhook = models.Webhook{
+			OrganizationID: org.ID,
+			URL:            request.URL,
+			Description:    request.Description,
+			EventKinds:     request.EventKinds,
+			Secret:         request.Secret,
+		}
+		if res := tx.Create(&webhook); res.Error != nil {
+			return res.Error
+		}
+
+		span.SetAttributes(attribute.String("id", webhook.ID.String()))
+		return api.recordAuditEvent(c, tx, AuditActionCreate, ResourceWebhooks, webhook.OrganizationID, webhook.ID, nil, webhook)
+	})
+	if err != nil {
+		var apiResponseError *ApiResponseError
+		if errors.As(err, &apiResponseError) {
+			c.JSON(apiResponseError.Status, apiResponseError.Body)
+		} else {
+			api.SendInternalServerError(c, err)
+		}
+		return
+	}
+
+	api.signalBus.Notify(webhooksSignal)
+	c.JSON(http.StatusCreated, webhook)
+}
+
+// UpdateWebhook updates a webhook
+// @Summary      Update Webhook
+// @Description  Updates a webhook by ID
+// @Id  		 UpdateWebhook
+// @Tags         Webhooks
+// @Accepts		 json
+// @Produce      json
+// @Param        id      path   string               true "Webhook ID"
+// @Param        update  body   models.UpdateWebhook true "Webhook Update"
+// @Success      200  {object}  models.Webhook
+// @Failure      400  {object}  models.BaseError
+// @Failure		 401  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure      422  {object}  models.ValidationError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/webhooks/{id} [patch]
+func (api *API) UpdateWebhook(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "UpdateWebhook",
+		trace.WithAttributes(
+			attribute.String("id", c.Param("id")),
+		))
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+
+	var request models.UpdateWebhook
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPayloadError(err))
+		return
+	}
+	if request.URL != nil {
+		if err := validateWebhookURL(ctx, *request.URL); err != nil {
+			c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError("url", err.Error()))
+			return
+		}
+	}
+	if err := validateWebhookEventKinds(request.EventKinds); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError("event_kinds", err.Error()))
+		return
+	}
+	if request.Secret != nil && *request.Secret == "" {
+		c.JSON(http.StatusUnprocessableEntity, models.NewFieldValidationError("secret", "must not be empty"))
+		return
+	}
+
+	var webhook models.Webhook
+	err = api.transaction(ctx, func(tx *gorm.DB) error {
+		if res := api.WebhookIsWriteableByCurrentUser(c, tx).
+			First(&webhook, "id = ?", id); res.Error != nil {
+			if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+				return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("webhook"))
+			}
+			return res.Error
+		}
+		before := webhook
+
+		if request.URL != nil {
+			webhook.URL = *request.URL
+		}
+		if request.Description != nil {
+			webhook.Description = *request.Description
+		}
+		if request.EventKinds != nil {
+			webhook.EventKinds = request.EventKinds
+		}
+		if request.Secret != nil {
+			webhook.Secret = *request.Secret
+		}
+		if res := tx.Select("url", "description", "event_kinds", "secret").Updates(&webhook); res.Error != nil {
+			return res.Error
+		}
+		return api.recordAuditEvent(c, tx, AuditActionUpdate, ResourceWebhooks, webhook.OrganizationID, webhook.ID, before, webhook)
+	})
+	if err != nil {
+		var apiResponseError *ApiResponseError
+		if errors.As(err, &apiResponseError) {
+			c.JSON(apiResponseError.Status, apiResponseError.Body)
+		} else {
+			api.SendInternalServerError(c, err)
+		}
+		return
+	}
+
+	api.signalBus.Notify(webhooksSignal)
+	webhook.Secret = ""
+	c.JSON(http.StatusOK, webhook)
+}
+
+// DeleteWebhook deletes a webhook
+// @Summary      Delete Webhook
+// @Description  Deletes a webhook, its pending deliveries are not retried
+// @Id  		 DeleteWebhook
+// @Tags         Webhooks
+// @Accepts		 json
+// @Produce      json
+// @Param        id   path      string  true "Webhook ID"
+// @Success      200  {object}  models.Webhook
+// @Failure      400  {object}  models.BaseError
+// @Failure		 401  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/webhooks/{id} [delete]
+func (api *API) DeleteWebhook(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "DeleteWebhook",
+		trace.WithAttributes(
+			attribute.String("id", c.Param("id")),
+		))
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+
+	var webhook models.Webhook
+	err = api.transaction(ctx, func(tx *gorm.DB) error {
+		if res := api.WebhookIsWriteableByCurrentUser(c, tx).
+			First(&webhook, "id = ?", id); res.Error != nil {
+			if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+				return NewApiResponseError(http.StatusNotFound, models.NewNotFoundError("webhook"))
+			}
+			return res.Error
+		}
+		if res := tx.Delete(&webhook); res.Error != nil {
+			return res.Error
+		}
+		if res := tx.Model(&models.WebhookDelivery{}).
+			Where("webhook_id = ? AND status = ?", webhook.ID, models.WebhookDeliveryPending).
+			Updates(map[string]interface{}{"status": models.WebhookDeliveryFailed, "error": "webhook deleted"}); res.Error != nil {
+			return res.Error
+		}
+		return api.recordAuditEvent(c, tx, AuditActionDelete, ResourceWebhooks, webhook.OrganizationID, webhook.ID, webhook, nil)
+	})
+	if err != nil {
+		var apiResponseError *ApiResponseError
+		if errors.As(err, &apiResponseError) {
+			c.JSON(apiResponseError.Status, apiResponseError.Body)
+		} else {
+			api.SendInternalServerError(c, err)
+		}
+		return
+	}
+
+	api.signalBus.Notify(webhooksSignal)
+	webhook.Secret = ""
+	c.JSON(http.StatusOK, webhook)
+}
+
+// ListWebhookDeliveries lists the deliveries made to a webhook
+// @Summary      List Webhook Deliveries
+// @Description  Lists the delivery log of a webhook, most recent first
+// @Id  		 ListWebhookDeliveries
+// @Tags         Webhooks
+// @Accepts		 json
+// @Produce      json
+// @Param        id   path      string  true "Webhook ID"
+// @Success      200  {object}  []models.WebhookDelivery
+// @Failure      400  {object}  models.BaseError
+// @Failure		 401  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/webhooks/{id}/deliveries [get]
+func (api *API) ListWebhookDeliveries(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "ListWebhookDeliveries",
+		trace.WithAttributes(
+			attribute.String("id", c.Param("id")),
+		))
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+
+	var webhook models.Webhook
+	db := api.db.WithContext(ctx)
+	if res := api.WebhookIsReadableByCurrentUser(c, db).
+		First(&webhook, "id = ?", id); res.Error != nil {
+		if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, models.NewNotFoundError("webhook"))
+		} else {
+			api.SendInternalServerError(c, res.Error)
+		}
+		return
+	}
+
+	deliveries := make([]models.WebhookDelivery, 0)
+	db = db.Where("webhook_id = ?", webhook.ID)
+	db = FilterAndPaginate(db, &models.WebhookDelivery{}, c, "created_at DESC")
+	if res := db.Find(&deliveries); res.Error != nil {
+		api.SendInternalServerError(c, res.Error)
+		return
+	}
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// TestWebhook sends a test event to a webhook
+// @Summary      Test Webhook
+// @Description  Delivers a test event to the webhook and returns the result of the delivery
+// @Id  		 TestWebhook
+// @Tags         Webhooks
+// @Accepts		 json
+// @Produce      json
+// @Param        id   path      string  true "Webhook ID"
+// @Success      200  {object}  models.WebhookDelivery
+// @Failure      400  {object}  models.BaseError
+// @Failure		 401  {object}  models.BaseError
+// @Failure      404  {object}  models.BaseError
+// @Failure		 429  {object}  models.BaseError
+// @Failure      500  {object}  models.InternalServerError "Internal Server Error"
+// @Router       /api/webhooks/{id}/test [post]
+func (api *API) TestWebhook(c *gin.Context) {
+	ctx, span := tracer.Start(c.Request.Context(), "TestWebhook",
+		trace.WithAttributes(
+			attribute.String("id", c.Param("id")),
+		))
+	defer span.End()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.NewBadPathParameterError("id"))
+		return
+	}
+
+	var webhook models.Webhook
+	db := api.db.WithContext(ctx)
+	if res := api.WebhookIsWriteableByCurrentUser(c, db).
+		First(&webhook, "id = ?", id); res.Error != nil {
+		if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, models.NewNotFoundError("webhook"))
+		} else {
+			api.SendInternalServerError(c, res.Error)
+		}
+		return
+	}
+
+	delivery, err := newWebhookDelivery(webhook, "ping", "test", map[string]string{
+		"message": "test event sent from the Nexodus API",
+	})
+	if err != nil {
+		api.SendInternalServerError(c, err)
+		return
+	}
+	// test deliveries are attempted once and not retried
+	delivery.Status = models.WebhookDeliveryFailed
+	delivery.Attempts = 1
+	delivery.NextAttemptAt = nil
+	if res := db.Create(&delivery); res.Error != nil {
+		api.SendInternalServerError(c, res.Error)
+		return
+	}
+
+	api.attemptWebhookDelivery(ctx, webhook, &delivery, false)
+	c.JSON(http.StatusOK, delivery)
+}
+
+func validateWebhookURL(ctx context.Context, value string) error {
+	if value == "" {
+		return fmt.Errorf("required")
+	}
+	u, err := url.Parse(value)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("scheme must be http or https")
+	}
+	if u.Hostname() == "" {
+		return fmt.Errorf("host is required")
+	}
+	if webhookAllowInternalDestinations {
+		return nil
+	}
+	// the addresses are checked again when the deliveries are dialed, in case the host
+	// resolves to a different address by then.
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, u.Hostname())
+	if err != nil {
+		return fmt.Errorf("host could not be resolved")
+	}
+	for _, addr := range addrs {
+		if err := checkWebhookDestination(addr.IP); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkWebhookDestination returns an error if the ip address is not a public destination
+// that webhooks may be delivered to.
+func checkWebhookDestination(ip net.IP) error {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() ||
+		sharedAddressSpace.Contains(ip) {
+		return fmt.Errorf("host resolves to a non public address: %s", ip)
+	}
+	return nil
+}
+
+func validateWebhookEventKinds(kinds []string) error {
+	for _, kind := range kinds {
+		valid := false
+		for _, k := range webhookEventKinds {
+			if kind == k {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("invalid event kind %q, must be one of: %s", kind, strings.Join(webhookEventKinds, ", "))
+		}
+	}
+	return nil
+}
+
+func newWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,486 @@
+package handlers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nexodus-io/nexodus/internal/handlers/fetchmgr"
+	"github.com/nexodus-io/nexodus/internal/models"
+	"github.com/nexodus-io/nexodus/internal/signalbus"
+	"github.com/nexodus-io/nexodus/internal/util"
+	"gorm.io/gorm"
+)
+
+// webhooksSignal is notified when the webhooks are changed
+const webhooksSignal = "/webhooks"
+
+const (
+	webhookFetchLimit      = 100
+	webhookSendLimit       = 50
+	webhookMaxAttempts     = 8
+	webhookInitialBackoff  = 10 * time.Second
+	webhookMaxBackoff      = time.Hour
+	webhookDeliveryTimeout = 10 * time.Second
+	webhookPollInterval    = 30 * time.Second
+	// webhookDeliveryRetention is how long the delivery log is kept
+	webhookDeliveryRetention = 7 * 24 * time.Hour
+)
+
+// webhookHTTPClient checks the address each delivery connects to so that a webhook host
+// can not be re-pointed at an internal address after its url was validated.
+var webhookHTTPClient = &http.Client{
+	Timeout: webhookDeliveryTimeout,
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: webhookDeliveryTimeout,
+			Control: webhookDialControl,
+		}).DialContext,
+		ForceAttemptHTTP2:   true,
+		TLSHandshakeTimeout: webhookDeliveryTimeout,
+		MaxIdleConns:        webhookSendLimit,
+		IdleConnTimeout:     90 * time.Second,
+	},
+}
+
+// webhookDialControl rejects connections to addresses that webhooks may not be delivered to,
+// it runs after the host name is resolved, right before the connection is made.
+func webhookDialControl(network string, address string, _ syscall.RawConn) error {
+	if webhookAllowInternalDestinations {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("invalid address: %s", address)
+	}
+	return checkWebhookDestination(ip)
+}
+
+// errWebhookDispatchConflict is returned when another apiserver dispatched the events of a webhook concurrently
+var errWebhookDispatchConflict = errors.New("webhook was dispatched concurrently")
+
+// StartWebhookDispatcher starts the background worker that delivers the change events of the
+// organizations to their webhooks. It follows the same revision ordered change stream and
+// signals as the watch events, the position in the stream is stored with each webhook so that
+// deliveries resume where they left off and are not duplicated across apiserver replicas.
+func (api *API) StartWebhookDispatcher(ctx context.Context, wg *sync.WaitGroup) {
+	util.GoWithWaitGroup(wg, func() {
+		subs := map[string]*signalbus.Subscription{}
+		webhooksSub := api.signalBus.Subscribe(webhooksSignal)
+		defer func() {
+			webhooksSub.Close()
+			for _, sub := range subs {
+				sub.Close()
+			}
+		}()
+
+		for {
+			failed := false
+			signals, more, err := api.collectWebhookEvents(ctx)
+			if err != nil {
+				api.logger.Warnf("failed to collect webhook events: %v", err)
+				failed = true
+			}
+
+			// subscribe to the change signals of the VPCs of the organizations with webhooks
+			active := map[string]struct{}{}
+			for _, signal := range signals {
+				active[signal] = struct{}{}
+				if _, found := subs[signal]; !found {
+					subs[signal] = api.signalBus.Subscribe(signal)
+				}
+			}
+			for signal, sub := range subs {
+				if _, found := active[signal]; !found {
+					sub.Close()
+					delete(subs, signal)
+				}
+			}
+
+			sent, next, err := api.sendWebhookDeliveries(ctx)
+			if err != nil {
+				api.logger.Warnf("failed to send webhook deliveries: %v", err)
+				failed = true
+			}
+
+			if ctx.Err() != nil {
+				return
+			}
+			// keep going while there is a backlog, errors wait for the next poll so the database isn't hammered
+			if (more || sent) && !failed {
+				continue
+			}
+
+			timeout := webhookPollInterval
+			if next != nil && time.Until(*next) < timeout {
+				timeout = time.Until(*next)
+			}
+			channels := []<-chan struct{}{webhooksSub.Signal()}
+			for _, sub := range subs {
+				channels = append(channels, sub.Signal())
+			}
+			if waitForCancelTimeoutOrNotification(ctx, timeout, channels...) == -2 {
+				return
+			}
+		}
+	})
+}
+
+// collectWebhookEvents turns the changes made since the last run into pending deliveries. It returns
+// the signals the webhooks depend on, and whether there are more changes left to collect.
+func (api *API) collectWebhookEvents(ctx context.Context) ([]string, bool, error) {
+	db := api.db.WithContext(ctx)
+	var webhooks []models.Webhook
+	if res := db.Find(&webhooks); res.Error != nil {
+		return nil, false, res.Error
+	}
+
+	var signals []string
+	more := false
+	for _, webhook := range webhooks {
+		// deleted VPCs are included so that the deletion of their resources is delivered
+		var vpcs []models.VPC
+		if res := db.Unscoped().Where("organization_id = ?", webhook.OrganizationID).Find(&vpcs); res.Error != nil {
+			return signals, more, res.Error
+		}
+		for _, vpc := range vpcs {
+			for _, kind := range webhookKinds(webhook) {
+				signals = append(signals, webhookSignal(kind, vpc.ID))
+			}
+		}
+
+		webhookMore, err := api.collectWebhookEventsOf(ctx, webhook, vpcs)
+		if err != nil && !errors.Is(err, errWebhookDispatchConflict) {
+			return signals, more, fmt.Errorf("webhook %s: %w", webhook.ID, err)
+		}
+		more = more || webhookMore
+	}
+	return signals, more, nil
+}
+
+func (api *API) collectWebhookEventsOf(ctx context.Context, webhook models.Webhook, vpcs []models.VPC) (bool, error) {
+	more := false
+	err := api.transaction(ctx, func(tx *gorm.DB) error {
+		more = false
+		cursors := map[string]uint64{}
+		for key, value := range webhook.Cursors {
+			cursors[key] = value
+		}
+		changed := false
+
+		var deliveries []models.WebhookDelivery
+		for _, vpc := range vpcs {
+			for _, kind := range webhookKinds(webhook) {
+				key := fmt.Sprintf("%s/%s", kind, vpc.ID)
+				cursor, found := cursors[key]
+				if !found && vpc.CreatedAt.Before(webhook.CreatedAt) {
+					// don't replay the changes made before the webhook was created
+					head, err := webhookHeadRevision(tx, kind, vpc.ID)
+					if err != nil {
+						return err
+					}
+					cursors[key] = head
+					changed = true
+					continue
+				}
+
+				list, err := webhookFetchFn(kind, vpc.ID).Fetch(tx, cursor)
+				if err != nil {
+					return err
+				}
+				for i := 0; i < list.Len(); i++ {
+					item, _, revision, deletedAt := list.Item(i)
+					eventType := "change"
+					if deletedAt.Valid {
+						eventType = "delete"
+					}
+					delivery, err := newWebhookDelivery(webhook, kind, eventType, item)
+					if err != nil {
+						return err
+					}
+					deliveries = append(deliveries, delivery)
+					cursor = revision
+				}
+				if list.Len() >= webhookFetchLimit {
+					more = true
+				}
+				if !found || cursor != cursors[key] {
+					cursors[key] = cursor
+					changed = true
+				}
+			}
+		}
+		if !changed && len(deliveries) == 0 {
+			return nil
+		}
+
+		res := tx.Model(&models.Webhook{}).
+			Where("id = ? AND dispatch_version = ?", webhook.ID, webhook.DispatchVersion).
+			Select("cursors", "dispatch_version").
+			Updates(&models.Webhook{Cursors: cursors, DispatchVersion: webhook.DispatchVersion + 1})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return errWebhookDispatchConflict
+		}
+		if len(deliveries) > 0 {
+			if res := tx.Create(&deliveries); res.Error != nil {
+				return res.Error
+			}
+		}
+		return nil
+	})
+	return more, err
+}
+
+// sendWebhookDeliveries attempts the pending deliveries that are due. It returns whether any were
+// attempted and when the next pending delivery is due.
+func (api *API) sendWebhookDeliveries(ctx context.Context) (bool, *time.Time, error) {
+	db := api.db.WithContext(ctx)
+	now := time.Now()
+
+	var deliveries []models.WebhookDelivery
+	if res := db.Where("status = ? AND next_attempt_at <= ?", models.WebhookDeliveryPending, now).
+		Order("next_attempt_at").
+		Limit(webhookSendLimit).
+		Find(&deliveries); res.Error != nil {
+		return false, nil, res.Error
+	}
+
+	for i := range deliveries {
+		delivery := &deliveries[i]
+
+		// claim the delivery so that only one apiserver replica attempts it
+		lease := now.Add(2 * webhookDeliveryTimeout)
+		res := db.Model(&models.WebhookDelivery{}).
+			Where("id = ? AND status = ? AND attempts = ?", delivery.ID, models.WebhookDeliveryPending, delivery.Attempts).
+			Updates(map[string]interface{}{"attempts": delivery.Attempts + 1, "next_attempt_at": lease})
+		if res.Error != nil {
+			return true, nil, res.Error
+		}
+		if res.RowsAffected == 0 {
+			continue
+		}
+		delivery.Attempts += 1
+
+		var webhook models.Webhook
+		if res := db.First(&webhook, "id = ?", delivery.WebhookID); res.Error != nil {
+			if !errors.Is(res.Error, gorm.ErrRecordNotFound) {
+				return true, nil, res.Error
+			}
+			delivery.Status = models.WebhookDeliveryFailed
+			delivery.NextAttemptAt = nil
+			delivery.Error = "webhook deleted"
+			if res := db.Select("status", "next_attempt_at", "error").Updates(delivery); res.Error != nil {
+				return true, nil, res.Error
+			}
+			continue
+		}
+		api.attemptWebhookDelivery(ctx, webhook, delivery, true)
+	}
+
+	var next models.WebhookDelivery
+	res := db.Where("status = ?", models.WebhookDeliveryPending).Order("next_attempt_at").Limit(1).Find(&next)
+	if res.Error != nil {
+		return len(deliveries) > 0, nil, res.Error
+	}
+	return len(deliveries) > 0, next.NextAttemptAt, nil
+}
+
+// attemptWebhookDelivery posts the delivery to the webhook and records the result. Failed
+// deliveries are retried with an exponential backoff when retry is set.
+func (api *API) attemptWebhookDelivery(ctx context.Context, webhook models.Webhook, delivery *models.WebhookDelivery, retry bool) {
+	code, err := postWebhookDelivery(ctx, webhook, *delivery)
+	now := time.Now()
+	delivery.ResponseCode = code
+	if err == nil {
+		delivery.Status = models.WebhookDeliverySucceeded
+		delivery.Error = ""
+		delivery.NextAttemptAt = nil
+		delivery.DeliveredAt = &now
+	} else {
+		delivery.Error = err.Error()
+		if retry && delivery.Attempts < webhookMaxAttempts {
+			next := now.Add(webhookBackoff(delivery.Attempts))
+			delivery.Status = models.WebhookDeliveryPending
+			delivery.NextAttemptAt = &next
+		} else {
+			delivery.Status = models.WebhookDeliveryFailed
+			delivery.NextAttemptAt = nil
+		}
+	}
+
+	res := api.db.WithContext(ctx).
+		Select("status", "attempts", "response_code", "error", "next_attempt_at", "delivered_at").
+		Updates(delivery)
+	if res.Error != nil {
+		api.logger.Warnf("failed to record the delivery %s to webhook %s: %v", delivery.ID, webhook.ID, res.Error)
+	}
+}
+
+// postWebhookDelivery posts the payload of the delivery to the webhook, it returns the response status code.
+func postWebhookDelivery(ctx context.Context, webhook models.Webhook, delivery models.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, strings.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Nexodus-Webhook")
+	req.Header.Set("X-Nexodus-Event", delivery.EventKind)
+	req.Header.Set("X-Nexodus-Delivery", delivery.ID.String())
+	req.Header.Set("X-Nexodus-Signature", "sha256="+signWebhookPayload(webhook.Secret, delivery.Payload))
+
+	res, err := webhookHTTPClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer util.IgnoreError(res.Body.Close)
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64*1024))
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode, fmt.Errorf("unexpected response status: %s", res.Status)
+	}
+	return res.StatusCode, nil
+}
+
+// signWebhookPayload returns the hex encoded HMAC-SHA256 of the payload keyed with the webhook secret
+func signWebhookPayload(secret string, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookBackoff returns how long to wait before retrying a delivery that failed the given number of attempts
+func webhookBackoff(attempts int) time.Duration {
+	backoff := webhookInitialBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= webhookMaxBackoff {
+			return webhookMaxBackoff
+		}
+	}
+	return backoff
+}
+
+func newWebhookDelivery(webhook models.Webhook, kind string, eventType string, value interface{}) (models.WebhookDelivery, error) {
+	payload, err := json.Marshal(models.WebhookEvent{
+		WebhookID:      webhook.ID,
+		OrganizationID: webhook.OrganizationID,
+		Kind:           kind,
+		Type:           eventType,
+		Value:          value,
+	})
+	if err != nil {
+		return models.WebhookDelivery{}, err
+	}
+	now := time.Now()
+	return models.WebhookDelivery{
+		WebhookID:     webhook.ID,
+		EventKind:     kind,
+		EventType:     eventType,
+		Payload:       string(payload),
+		Status:        models.WebhookDeliveryPending,
+		NextAttemptAt: &now,
+	}, nil
+}
+
+// webhookKinds returns the event kinds delivered to the webhook
+func webhookKinds(webhook models.Webhook) []string {
+	if len(webhook.EventKinds) == 0 {
+		return webhookEventKinds
+	}
+	return webhook.EventKinds
+}
+
+// webhookSignal returns the signal notified when resources of the kind change in the VPC
+func webhookSignal(kind string, vpcId uuid.UUID) string {
+	switch kind {
+	case "device":
+		return fmt.Sprintf("/devices/vpc=%s", vpcId.String())
+	case "security-group":
+		return fmt.Sprintf("/security-groups/vpc=%s", vpcId.String())
+	case "dns-record":
+		return fmt.Sprintf("/dns-records/vpc=%s", vpcId.String())
+	default:
+		return fmt.Sprintf("/vpc=%s", vpcId.String())
+	}
+}
+
+// webhookHeadRevision returns the latest revision of the resources of the kind in the VPC
+func webhookHeadRevision(db *gorm.DB, kind string, vpcId uuid.UUID) (uint64, error) {
+	var model interface{}
+	column := "vpc_id"
+	switch kind {
+	case "device":
+		model = &models.Device{}
+	case "security-group":
+		model = &models.SecurityGroup{}
+	case "dns-record":
+		model = &models.DNSRecord{}
+	default:
+		model = &models.VPC{}
+		column = "id"
+	}
+	var revision uint64
+	res := db.Unscoped().Model(model).
+		Where(column+" = ?", vpcId).
+		Select("COALESCE(MAX(revision), 0)").
+		Scan(&revision)
+	return revision, res.Error
+}
+
+// webhookFetchFn returns the changes to the resources of the kind in the VPC, the same way the
+// watch events fetch them.
+func webhookFetchFn(kind string, vpcId uuid.UUID) fetchmgr.FetchFn {
+	return func(db *gorm.DB, gtRevision uint64) (fetchmgr.ResourceList, error) {
+		db = db.Unscoped().Limit(webhookFetchLimit).Order("revision")
+		if gtRevision != 0 {
+			db = db.Where("revision > ?", gtRevision)
+		}
+
+		var items fetchmgr.ResourceList
+		var result *gorm.DB
+		switch kind {
+		case "device":
+			var devices deviceList
+			result = db.Where("vpc_id = ?", vpcId).Find(&devices)
+			for _, device := range devices {
+				device.BearerToken = ""
+			}
+			items = devices
+		case "security-group":
+			var securityGroups securityGroupList
+			result = db.Where("vpc_id = ?", vpcId).Find(&securityGroups)
+			items = securityGroups
+		case "dns-record":
+			var records dnsRecordList
+			result = db.Where("vpc_id = ?", vpcId).Find(&records)
+			items = records
+		default:
+			var vpcs vpcList
+			result = db.Where("id = ?", vpcId).Find(&vpcs)
+			items = vpcs
+		}
+		if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, result.Error
+		}
+		return items, nil
+	}
+}
@@ -0,0 +1,241 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nexodus-io/nexodus/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *HandlerTestSuite) TestCreateAndTestWebhook() {
+	require := suite.Require()
+
+	// the test receivers listen on the loopback address
+	webhookAllowInternalDestinations = true
+	defer func() { webhookAllowInternalDestinations = false }()
+
+	received := make(chan *http.Request, 1)
+	var receivedBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedBody, _ = io.ReadAll(r.Body)
+		received <- r
+	}))
+	defer server.Close()
+
+	_, res, err := suite.ServeRequest(
+		http.MethodPost, "/webhooks", "/webhooks",
+		suite.api.CreateWebhook,
+		bytes.NewBuffer(suite.jsonMarshal(models.AddWebhook{
+			OrganizationID: suite.testUserID,
+			URL:            server.URL,
+			EventKinds:     []string{"device"},
+			Secret:         "s3cret",
+		})),
+	)
+	require.NoError(err)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(err)
+	require.Equal(http.StatusCreated, res.Code, "HTTP error: %s", string(body))
+
+	var webhook models.Webhook
+	require.NoError(json.Unmarshal(body, &webhook))
+	require.Equal("s3cret", webhook.Secret)
+	require.Equal([]string{"device"}, webhook.EventKinds)
+
+	// the secret is only returned when the webhook is created
+	_, res, err = suite.ServeRequest(
+		http.MethodGet, "/webhooks/:id", fmt.Sprintf("/webhooks/%s", webhook.ID),
+		suite.api.GetWebhook, nil,
+	)
+	require.NoError(err)
+	body, err = io.ReadAll(res.Body)
+	require.NoError(err)
+	require.Equal(http.StatusOK, res.Code, "HTTP error: %s", string(body))
+	var actual models.Webhook
+	require.NoError(json.Unmarshal(body, &actual))
+	require.Empty(actual.Secret)
+
+	_, res, err = suite.ServeRequest(
+		http.MethodPost, "/webhooks/:id/test", fmt.Sprintf("/webhooks/%s/test", webhook.ID),
+		suite.api.TestWebhook, nil,
+	)
+	require.NoError(err)
+	body, err = io.ReadAll(res.Body)
+	require.NoError(err)
+	require.Equal(http.StatusOK, res.Code, "HTTP error: %s", string(body))
+
+	var delivery models.WebhookDelivery
+	require.NoError(json.Unmarshal(body, &delivery))
+	require.Equal(models.WebhookDeliverySucceeded, delivery.Status)
+	require.Equal(http.StatusOK, delivery.ResponseCode)
+
+	request := <-received
+	require.Equal("ping", request.Header.Get("X-Nexodus-Event"))
+	require.Equal(delivery.ID.String(), request.Header.Get("X-Nexodus-Delivery"))
+	require.Equal("sha256="+signWebhookPayload("s3cret", string(receivedBody)), request.Header.Get("X-Nexodus-Signature"))
+
+	var event models.WebhookEvent
+	require.NoError(json.Unmarshal(receivedBody, &event))
+	require.Equal(webhook.ID, event.WebhookID)
+	require.Equal("test", event.Type)
+
+	_, res, err = suite.ServeRequest(
+		http.MethodGet, "/webhooks/:id/deliveries", fmt.Sprintf("/webhooks/%s/deliveries", webhook.ID),
+		suite.api.ListWebhookDeliveries, nil,
+	)
+	require.NoError(err)
+	body, err = io.ReadAll(res.Body)
+	require.NoError(err)
+	require.Equal(http.StatusOK, res.Code, "HTTP error: %s", string(body))
+	var deliveries []models.WebhookDelivery
+	require.NoError(json.Unmarshal(body, &deliveries))
+	require.Len(deliveries, 1)
+
+	_, res, err = suite.ServeRequest(
+		http.MethodDelete, "/webhooks/:id", fmt.Sprintf("/webhooks/%s", webhook.ID),
+		suite.api.DeleteWebhook, nil,
+	)
+	require.NoError(err)
+	require.Equal(http.StatusOK, res.Code)
+}
+
+func (suite *HandlerTestSuite) TestWebhookDeliversChanges() {
+	require := suite.Require()
+
+	// the test receivers listen on the loopback address
+	webhookAllowInternalDestinations = true
+	defer func() { webhookAllowInternalDestinations = false }()
+
+	received := make(chan models.WebhookEvent, 100)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event models.WebhookEvent
+		_ = json.NewDecoder(r.Body).Decode(&event)
+		received <- event
+	}))
+	defer server.Close()
+
+	_, res, err := suite.ServeRequest(
+		http.MethodPost, "/webhooks", "/webhooks",
+		suite.api.CreateWebhook,
+		bytes.NewBuffer(suite.jsonMarshal(models.AddWebhook{
+			OrganizationID: suite.testUserID,
+			URL:            server.URL,
+			EventKinds:     []string{"dns-record"},
+		})),
+	)
+	require.NoError(err)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(err)
+	require.Equal(http.StatusCreated, res.Code, "HTTP error: %s", string(body))
+	var webhook models.Webhook
+	require.NoError(json.Unmarshal(body, &webhook))
+	require.NotEmpty(webhook.Secret)
+	defer func() {
+		_, _, _ = suite.ServeRequest(
+			http.MethodDelete, "/webhooks/:id", fmt.Sprintf("/webhooks/%s", webhook.ID),
+			suite.api.DeleteWebhook, nil,
+		)
+	}()
+
+	// the first run positions the webhook at the head of the change stream
+	ctx := context.Background()
+	_, _, err = suite.api.collectWebhookEvents(ctx)
+	require.NoError(err)
+
+	_, res, err = suite.ServeRequest(
+		http.MethodPost, "/vpcs/:id/dns-records", fmt.Sprintf("/vpcs/%s/dns-records", suite.testUserID),
+		suite.api.CreateDNSRecordInVPC,
+		bytes.NewBuffer(suite.jsonMarshal(models.AddDNSRecord{
+			Name:      "hooked",
+			Addresses: []string{"100.64.0.50"},
+		})),
+	)
+	require.NoError(err)
+	require.Equal(http.StatusCreated, res.Code)
+
+	_, _, err = suite.api.collectWebhookEvents(ctx)
+	require.NoError(err)
+	_, _, err = suite.api.sendWebhookDeliveries(ctx)
+	require.NoError(err)
+
+	for {
+		select {
+		case event := <-received:
+			require.Equal("dns-record", event.Kind)
+			if event.Value.(map[string]interface{})["name"] == "hooked" {
+				require.Equal("change", event.Type)
+				return
+			}
+		case <-time.After(5 * time.Second):
+			require.Fail("the change was not delivered")
+			return
+		}
+	}
+}
+
+func (suite *HandlerTestSuite) TestInvalidWebhook() {
+	require := suite.Require()
+
+	for _, request := range []models.AddWebhook{
+		{OrganizationID: suite.testUserID, URL: "ftp://example.com"},
+		{OrganizationID: suite.testUserID, URL: "https://"},
+		{OrganizationID: suite.testUserID, URL: "https://example.com", EventKinds: []string{"invitation"}},
+		{OrganizationID: suite.testUserID, URL: "http://localhost:8080/hook"},
+		{OrganizationID: suite.testUserID, URL: "http://127.0.0.1/hook"},
+		{OrganizationID: suite.testUserID, URL: "http://[::1]/hook"},
+		{OrganizationID: suite.testUserID, URL: "http://0.0.0.0/hook"},
+		{OrganizationID: suite.testUserID, URL: "http://10.1.2.3/hook"},
+		{OrganizationID: suite.testUserID, URL: "http://192.168.1.1/hook"},
+		{OrganizationID: suite.testUserID, URL: "http://169.254.169.254/latest/meta-data"},
+		{OrganizationID: suite.testUserID, URL: "http://[fe80::1]/hook"},
+		{OrganizationID: suite.testUserID, URL: "http://100.64.0.1/hook"},
+	} {
+		_, res, err := suite.ServeRequest(
+			http.MethodPost, "/webhooks", "/webhooks",
+			suite.api.CreateWebhook,
+			bytes.NewBuffer(suite.jsonMarshal(request)),
+		)
+		require.NoError(err)
+		require.Equal(http.StatusUnprocessableEntity, res.Code, request)
+	}
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	assert.Equal(t, 10*time.Second, webhookBackoff(1))
+	assert.Equal(t, 20*time.Second, webhookBackoff(2))
+	assert.Equal(t, 80*time.Second, webhookBackoff(4))
+	assert.Equal(t, time.Hour, webhookBackoff(20))
+}
+
+func TestCheckWebhookDestination(t *testing.T) {
+	for _, ip := range []string{"127.0.0.1", "::1", "0.0.0.0", "::", "10.0.0.1", "172.16.0.1", "192.168.0.1",
+		"fd00::1", "169.254.169.254", "fe80::1", "100.64.0.1", "224.0.0.1"} {
+		assert.Error(t, checkWebhookDestination(net.ParseIP(ip)), ip)
+	}
+	for _, ip := range []string{"8.8.8.8", "2001:4860:4860::8888"} {
+		assert.NoError(t, checkWebhookDestination(net.ParseIP(ip)), ip)
+	}
+}
+
+func TestWebhookDeliveryRejectsInternalAddressAtDialTime(t *testing.T) {
+	// a host that resolved to a public address when the webhook was validated can be
+	// re-pointed at an internal one, the address is checked again when it is dialed.
+	received := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = true
+	}))
+	defer server.Close()
+
+	_, err := postWebhookDelivery(context.Background(), models.Webhook{URL: server.URL}, models.WebhookDelivery{Payload: "{}"})
+	assert.ErrorContains(t, err, "non public address")
+	assert.False(t, received)
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
-	"github.com/lib/pq"
 	"github.com/nexodus-io/nexodus/internal/ipam"
 	"github.com/nexodus-io/nexodus/internal/models"
 	"github.com/nexodus-io/nexodus/internal/util"
@@ -15,79 +14,63 @@ import (
 
 var defaultIPAMNamespace = uuid.UUID{}
 
+// Rebuild allocates the prefixes of the VPCs and the addresses and advertised cidrs of the devices
+// recorded in the nexodus database in the given IPAM. It is used to recover the state of the ipam
+// service, and to move it to a different IPAM backend.
 func Rebuild(ctx context.Context, log *zap.Logger, db *gorm.DB, ipam ipam.IPAM) error {
 
-	type result struct {
-		ID             uuid.UUID      `gorm:"type:uuid;primary_key;" json:"id" example:"aa22666c-0f57-45cb-a449-16efecc04f2e"`
-		TunnelIP       string         `json:"tunnel_ip"`
-		TunnelIpV6     string         `json:"tunnel_ip_v6"`
-		AdvertiseCidrs pq.StringArray `json:"advertise_cidrs" gorm:"type:text[]" swaggertype:"array,string"`
-		OrganizationID uuid.UUID      `json:"organization_id"`
-		PrivateCidr    bool           `json:"private_cidr"`
-		IpCidr         string         `json:"ip_cidr"`
-		IpCidrV6       string         `json:"ip_cidr_v6"`
-	}
-	rows, err := db.Model(&models.Device{}).
-		Select("devices.id, organization_id, tunnel_ip, tunnel_ip_v6, advertise_cidrs, private_cidr, ip_cidr, ip_cidr_v6").
-		Joins("inner join organizations on organizations.id::text = devices.organization_id").
-		Rows()
-	if err != nil {
+	var vpcs []models.VPC
+	if err := db.Find(&vpcs).Error; err != nil {
 		return err
 	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var device result
-		err = db.ScanRows(rows, &device)
-		if err != nil {
-			return err
-		}
 
-		log.Info("processing", zap.String("device", device.ID.String()))
+	for _, vpc := range vpcs {
+		log.Info("processing", zap.String("vpc", vpc.ID.String()))
 
 		ipamNamespace := defaultIPAMNamespace
-		if device.PrivateCidr {
-			ipamNamespace = device.OrganizationID
+		if vpc.PrivateCidr {
+			ipamNamespace = vpc.ID
 		}
 
 		if err := ipam.CreateNamespace(ctx, ipamNamespace); err != nil {
 			return fmt.Errorf("failed to create ipam namespace: %w", err)
 		}
-
-		if err := ipam.AssignCIDR(ctx, ipamNamespace, device.IpCidr); err != nil {
+		if err := ipam.AssignCIDR(ctx, ipamNamespace, vpc.Ipv4Cidr); err != nil {
 			return fmt.Errorf("can't assign default ipam v4 prefix: %w", err)
 		}
-		if err := ipam.AssignCIDR(ctx, ipamNamespace, device.IpCidrV6); err != nil {
+		if err := ipam.AssignCIDR(ctx, ipamNamespace, vpc.Ipv6Cidr); err != nil {
 			return fmt.Errorf("can't assign default ipam v6 prefix: %w", err)
 		}
 
-		if device.TunnelIP != "" {
-			err = ipam.AcquireIP(ctx, ipamNamespace, device.IpCidr, device.TunnelIP)
-			if err != nil {
-				log.Sugar().Warnf("Failed to allocate ip %s for device %s", device.TunnelIP, device.ID)
-			}
+		var devices []models.Device
+		if err := db.Where("vpc_id = ?", vpc.ID).Find(&devices).Error; err != nil {
+			return err
 		}
+		for _, device := range devices {
+			log.Info("processing", zap.String("device", device.ID.String()))
 
-		if device.TunnelIpV6 != "" {
-			err = ipam.AcquireIP(ctx, ipamNamespace, device.IpCidrV6, device.TunnelIpV6)
-			if err != nil {
-				log.Sugar().Warnf("Failed to allocate ip %s for device %s", device.TunnelIpV6, device.ID)
+			for _, tunnelIP := range append(device.IPv4TunnelIPs, device.IPv6TunnelIPs...) {
+				if tunnelIP.Address == "" || tunnelIP.CIDR == "" {
+					continue
+				}
+				if err := ipam.AcquireIP(ctx, ipamNamespace, tunnelIP.CIDR, tunnelIP.Address); err != nil {
+					log.Sugar().Warnf("Failed to allocate ip %s for device %s: %v", tunnelIP.Address, device.ID, err)
+				}
 			}
-		}
 
-		// allocate a cidr if requested
-		for _, cidr := range device.AdvertiseCidrs {
-			if !util.IsValidPrefix(cidr) {
-				return fmt.Errorf("invalid cidr detected in the advertise_cidrs field of %s", cidr)
-			}
-			// Skip the prefix assignment if it's an IPv4 or IPv6 default route
-			if !util.IsDefaultIPv4Route(cidr) && !util.IsDefaultIPv6Route(cidr) {
-				if err := ipam.AssignCIDR(ctx, ipamNamespace, cidr); err != nil {
-					return fmt.Errorf("failed to assign cidr: %w", err)
+			// allocate a cidr if requested
+			for _, cidr := range device.AdvertiseCidrs {
+				if !util.IsValidPrefix(cidr) {
+					return fmt.Errorf("invalid cidr detected in the advertise_cidrs field of %s", cidr)
+				}
+				// Skip the prefix assignment if it's an IPv4 or IPv6 default route
+				if !util.IsDefaultIPv4Route(cidr) && !util.IsDefaultIPv6Route(cidr) {
+					if err := ipam.AssignCIDR(ctx, ipamNamespace, cidr); err != nil {
+						return fmt.Errorf("failed to assign cidr: %w", err)
+					}
 				}
 			}
 		}
-
 	}
 	return nil
 }
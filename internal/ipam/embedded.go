@@ -0,0 +1,282 @@
+package ipam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Prefix is a prefix tracked by the EmbeddedIPAM.
+type Prefix struct {
+	Namespace uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Cidr      string    `gorm:"primaryKey"`
+	CreatedAt time.Time
+}
+
+func (Prefix) TableName() string {
+	return "ipam_prefixes"
+}
+
+// Address is an address allocated from a Prefix by the EmbeddedIPAM.
+type Address struct {
+	Namespace uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Address   string    `gorm:"primaryKey"`
+	Cidr      string    `gorm:"index"`
+	CreatedAt time.Time
+}
+
+func (Address) TableName() string {
+	return "ipam_addresses"
+}
+
+var _ IPAM = &EmbeddedIPAM{} // type check the interface is implemented.
+
+// EmbeddedIPAM is an IPAM that stores the prefixes and addresses in the apiserver database.
+// Like the go-ipam service, it reserves the first address of every prefix and the broadcast
+// address of IPv4 prefixes, and allocates the lowest free address of a prefix.
+type EmbeddedIPAM struct {
+	logger *zap.SugaredLogger
+	db     *gorm.DB
+}
+
+func NewEmbeddedIPAM(logger *zap.SugaredLogger, db *gorm.DB) *EmbeddedIPAM {
+	return &EmbeddedIPAM{
+		logger: logger,
+		db:     db,
+	}
+}
+
+type transactionKey struct{}
+
+// WithTransaction returns a context that makes the EmbeddedIPAM run its queries in the given
+// transaction, so that the allocations are rolled back with it. The RemoteIPAM ignores it.
+func WithTransaction(ctx context.Context, tx *gorm.DB) context.Context {
+	return context.WithValue(ctx, transactionKey{}, tx)
+}
+
+func (i *EmbeddedIPAM) dbFor(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value(transactionKey{}).(*gorm.DB); ok && tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return i.db.WithContext(ctx)
+}
+
+// CreateNamespace is a no-op, namespaces exist as long as they contain prefixes.
+func (i *EmbeddedIPAM) CreateNamespace(ctx context.Context, namespace uuid.UUID) (err error) {
+	defer func(start time.Time) { observeCall("CreateNamespace", start, err) }(time.Now())
+	return nil
+}
+
+func (i *EmbeddedIPAM) DeleteNamespace(parent context.Context, namespace uuid.UUID) (err error) {
+	defer func(start time.Time) { observeCall("DeleteNamespace", start, err) }(time.Now())
+	ctx, span := tracer.Start(parent, "DeleteNamespace")
+	defer span.End()
+	return i.dbFor(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("namespace = ?", namespace).Delete(&Address{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("namespace = ?", namespace).Delete(&Prefix{}).Error
+	})
+}
+
+func (i *EmbeddedIPAM) AcquireIP(parent context.Context, namespace uuid.UUID, ipamPrefix string, TunnelIP string) (err error) {
+	defer func(start time.Time) { observeCall("AcquireIP", start, err) }(time.Now())
+	ctx, span := tracer.Start(parent, "AcquireIP")
+	defer span.End()
+	if err := validateIP(TunnelIP); err != nil {
+		return fmt.Errorf("Address %s is not valid", TunnelIP)
+	}
+	_, err = i.acquire(i.dbFor(ctx), namespace, ipamPrefix, TunnelIP)
+	return err
+}
+
+func (i *EmbeddedIPAM) AssignSpecificTunnelIP(parent context.Context, namespace uuid.UUID, ipamPrefix string, TunnelIP string) (_ string, err error) {
+	defer func(start time.Time) { observeCall("AssignSpecificTunnelIP", start, err) }(time.Now())
+	ctx, span := tracer.Start(parent, "AssignSpecificTunnelIP")
+	defer span.End()
+	if err := validateIP(TunnelIP); err != nil {
+		return "", fmt.Errorf("Address %s is not valid", TunnelIP)
+	}
+	ip, err := i.acquire(i.dbFor(ctx), namespace, ipamPrefix, TunnelIP)
+	if err != nil {
+		i.logger.Errorf("failed to assign the requested address %s, assigning an address from the pool: %v\n", TunnelIP, err)
+		return i.AssignFromPool(ctx, namespace, ipamPrefix)
+	}
+	return ip, nil
+}
+
+func (i *EmbeddedIPAM) AssignFromPool(parent context.Context, namespace uuid.UUID, ipamPrefix string) (_ string, err error) {
+	defer func(start time.Time) { observeCall("AssignFromPool", start, err) }(time.Now())
+	ctx, span := tracer.Start(parent, "AssignFromPool")
+	defer span.End()
+	ip, err := i.acquire(i.dbFor(ctx), namespace, ipamPrefix, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to acquire an IPAM assigned address %w\n", err)
+	}
+	return ip, nil
+}
+
+// acquire allocates the specific address from the prefix, or the lowest free address when
+// specific is empty.
+func (i *EmbeddedIPAM) acquire(db *gorm.DB, namespace uuid.UUID, ipamPrefix string, specific string) (string, error) {
+	cidr, err := cleanCidr(ipamPrefix)
+	if err != nil {
+		return "", fmt.Errorf("invalid prefix: %w", err)
+	}
+	prefix := netip.MustParsePrefix(cidr)
+
+	err = db.First(&Prefix{}, "namespace = ? AND cidr = ?", namespace, cidr).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", fmt.Errorf("unable to find prefix for cidr:%s", cidr)
+	} else if err != nil {
+		return "", err
+	}
+
+	if specific != "" {
+		ip, err := netip.ParseAddr(specific)
+		if err != nil {
+			return "", fmt.Errorf("given ip:%s in not valid", specific)
+		}
+		if !prefix.Contains(ip) {
+			return "", fmt.Errorf("given ip:%s is not in %s", specific, cidr)
+		}
+		if isReserved(prefix, ip) {
+			return "", fmt.Errorf("given ip:%s is already allocated", ip)
+		}
+		ok, err := insertAddress(db, namespace, cidr, ip)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			return "", fmt.Errorf("given ip:%s is already allocated", ip)
+		}
+		return ip.String(), nil
+	}
+
+	var allocated []string
+	if err := db.Model(&Address{}).Where("namespace = ? AND cidr = ?", namespace, cidr).Pluck("address", &allocated).Error; err != nil {
+		return "", err
+	}
+	used := make(map[string]struct{}, len(allocated))
+	for _, address := range allocated {
+		used[address] = struct{}{}
+	}
+
+	for ip := prefix.Addr(); prefix.Contains(ip); ip = ip.Next() {
+		if _, found := used[ip.String()]; found || isReserved(prefix, ip) {
+			continue
+		}
+		// a concurrent request may have taken the address since it was listed, if so try the next one.
+		ok, err := insertAddress(db, namespace, cidr, ip)
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			return ip.String(), nil
+		}
+	}
+	return "", fmt.Errorf("no more ips in prefix: %s left", cidr)
+}
+
+// insertAddress records the allocation of the address, it returns false if the address was
+// already allocated.
+func insertAddress(db *gorm.DB, namespace uuid.UUID, cidr string, ip netip.Addr) (bool, error) {
+	res := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&Address{
+		Namespace: namespace,
+		Address:   ip.String(),
+		Cidr:      cidr,
+	})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
+
+// isReserved reports if the address is the first address of the prefix or the broadcast
+// address of an IPv4 prefix.
+func isReserved(prefix netip.Prefix, ip netip.Addr) bool {
+	if ip == prefix.Addr() {
+		return true
+	}
+	return ip.Is4() && !prefix.Contains(ip.Next())
+}
+
+func (i *EmbeddedIPAM) AssignCIDR(parent context.Context, namespace uuid.UUID, cidr string) (err error) {
+	defer func(start time.Time) { observeCall("AssignCIDR", start, err) }(time.Now())
+	ctx, span := tracer.Start(parent, "AssignPrefix")
+	defer span.End()
+	cidr, err = cleanCidr(cidr)
+	if err != nil {
+		return fmt.Errorf("invalid prefix requested: %w", err)
+	}
+	prefix := netip.MustParsePrefix(cidr)
+
+	return i.dbFor(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing []string
+		if err := tx.Model(&Prefix{}).Where("namespace = ?", namespace).Pluck("cidr", &existing).Error; err != nil {
+			return err
+		}
+		for _, e := range existing {
+			if e == cidr {
+				return nil
+			}
+			if other, err := netip.ParsePrefix(e); err == nil && other.Overlaps(prefix) {
+				return fmt.Errorf("%s overlaps %s", cidr, e)
+			}
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&Prefix{
+			Namespace: namespace,
+			Cidr:      cidr,
+		}).Error
+	})
+}
+
+// ReleaseToPool release the ipam address back to the specified prefix
+func (i *EmbeddedIPAM) ReleaseToPool(ctx context.Context, namespace uuid.UUID, address, cidr string) (err error) {
+	defer func(start time.Time) { observeCall("ReleaseToPool", start, err) }(time.Now())
+	cidr, err = cleanCidr(cidr)
+	if err != nil {
+		return fmt.Errorf("failed to release IPAM address %w", err)
+	}
+	res := i.dbFor(ctx).Where("namespace = ? AND address = ? AND cidr = ?", namespace, address, cidr).Delete(&Address{})
+	if res.Error != nil {
+		return fmt.Errorf("failed to release IPAM address %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("failed to release IPAM address: %s is not allocated in prefix %s", address, cidr)
+	}
+	return nil
+}
+
+// ReleaseCIDR release the ipam address back to the specified prefix
+func (i *EmbeddedIPAM) ReleaseCIDR(ctx context.Context, namespace uuid.UUID, cidr string) (err error) {
+	defer func(start time.Time) { observeCall("ReleaseCIDR", start, err) }(time.Now())
+	cidr, err = cleanCidr(cidr)
+	if err != nil {
+		return fmt.Errorf("failed to release IPAM prefix %w", err)
+	}
+	return i.dbFor(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&Address{}).Where("namespace = ? AND cidr = ?", namespace, cidr).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to release IPAM prefix %w", err)
+		}
+		if count > 0 {
+			return fmt.Errorf("failed to release IPAM prefix: prefix %s has ips", cidr)
+		}
+		res := tx.Where("namespace = ? AND cidr = ?", namespace, cidr).Delete(&Prefix{})
+		if res.Error != nil {
+			return fmt.Errorf("failed to release IPAM prefix %w", res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return fmt.Errorf("failed to release IPAM prefix: %s not found", cidr)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,118 @@
+package ipam
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nexodus-io/nexodus/internal/database"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"gorm.io/gorm"
+)
+
+func TestEmbeddedIPAM(t *testing.T) {
+	require := require.New(t)
+	db, err := database.NewTestDatabase()
+	require.NoError(err)
+	ipam := NewEmbeddedIPAM(zaptest.NewLogger(t).Sugar(), db)
+
+	ctx := context.Background()
+	namespace := uuid.New()
+	prefix := "10.20.30.0/24"
+
+	require.NoError(ipam.CreateNamespace(ctx, namespace))
+	require.NoError(ipam.AssignCIDR(ctx, namespace, prefix))
+	// assigning the same prefix again is not an error
+	require.NoError(ipam.AssignCIDR(ctx, namespace, "10.20.30.1/24"))
+	require.Error(ipam.AssignCIDR(ctx, namespace, "10.20.0.0/16"))
+	// but it can overlap prefixes of other namespaces
+	require.NoError(ipam.AssignCIDR(ctx, uuid.New(), "10.20.0.0/16"))
+
+	_, err = ipam.AssignSpecificTunnelIP(ctx, namespace, prefix, "notanipaddress")
+	require.Error(err)
+
+	ip, err := ipam.AssignSpecificTunnelIP(ctx, namespace, prefix, "10.20.30.1")
+	require.NoError(err)
+	require.Equal("10.20.30.1", ip)
+
+	// conflicting and mismatched addresses are assigned from the pool
+	ip, err = ipam.AssignSpecificTunnelIP(ctx, namespace, prefix, "10.20.30.1")
+	require.NoError(err)
+	require.Equal("10.20.30.2", ip)
+	ip, err = ipam.AssignSpecificTunnelIP(ctx, namespace, prefix, "10.20.40.1")
+	require.NoError(err)
+	require.Equal("10.20.30.3", ip)
+
+	require.Error(ipam.AcquireIP(ctx, namespace, prefix, "10.20.30.2"))
+	require.Error(ipam.AcquireIP(ctx, namespace, prefix, "10.20.30.255"))
+	require.NoError(ipam.AcquireIP(ctx, namespace, prefix, "10.20.30.254"))
+
+	// released addresses are reused
+	require.NoError(ipam.ReleaseToPool(ctx, namespace, "10.20.30.2", prefix))
+	require.Error(ipam.ReleaseToPool(ctx, namespace, "10.20.30.2", prefix))
+	ip, err = ipam.AssignFromPool(ctx, namespace, prefix)
+	require.NoError(err)
+	require.Equal("10.20.30.2", ip)
+
+	_, err = ipam.AssignFromPool(ctx, namespace, "10.20.31.0/24")
+	require.Error(err)
+
+	// prefixes can only be released once their addresses are
+	require.Error(ipam.ReleaseCIDR(ctx, namespace, prefix))
+	require.NoError(ipam.DeleteNamespace(ctx, namespace))
+	require.Error(ipam.ReleaseCIDR(ctx, namespace, prefix))
+}
+
+func TestEmbeddedIPAMExhaustion(t *testing.T) {
+	require := require.New(t)
+	db, err := database.NewTestDatabase()
+	require.NoError(err)
+	ipam := NewEmbeddedIPAM(zaptest.NewLogger(t).Sugar(), db)
+
+	ctx := context.Background()
+	namespace := uuid.New()
+
+	require.NoError(ipam.AssignCIDR(ctx, namespace, "10.0.0.0/30"))
+	ip, err := ipam.AssignFromPool(ctx, namespace, "10.0.0.0/30")
+	require.NoError(err)
+	require.Equal("10.0.0.1", ip)
+	ip, err = ipam.AssignFromPool(ctx, namespace, "10.0.0.0/30")
+	require.NoError(err)
+	require.Equal("10.0.0.2", ip)
+	_, err = ipam.AssignFromPool(ctx, namespace, "10.0.0.0/30")
+	require.Error(err)
+
+	// ipv6 prefixes have no broadcast address
+	require.NoError(ipam.AssignCIDR(ctx, namespace, "200::/127"))
+	ip, err = ipam.AssignFromPool(ctx, namespace, "200::/127")
+	require.NoError(err)
+	require.Equal("200::1", ip)
+}
+
+func TestEmbeddedIPAMTransaction(t *testing.T) {
+	require := require.New(t)
+	db, err := database.NewTestDatabase()
+	require.NoError(err)
+	ipam := NewEmbeddedIPAM(zaptest.NewLogger(t).Sugar(), db)
+
+	ctx := context.Background()
+	namespace := uuid.New()
+	prefix := "10.30.0.0/24"
+	require.NoError(ipam.AssignCIDR(ctx, namespace, prefix))
+
+	rollback := errors.New("rollback")
+	err = db.Transaction(func(tx *gorm.DB) error {
+		ip, err := ipam.AssignFromPool(WithTransaction(ctx, tx), namespace, prefix)
+		require.NoError(err)
+		require.Equal("10.30.0.1", ip)
+		return rollback
+	})
+	require.ErrorIs(err, rollback)
+
+	// the address was released by the rollback
+	ip, err := ipam.AssignFromPool(ctx, namespace, prefix)
+	require.NoError(err)
+	require.Equal("10.30.0.1", ip)
+}
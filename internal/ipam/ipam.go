@@ -6,6 +6,7 @@ import (
 	"net"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/bufbuild/connect-go"
 	"github.com/google/uuid"
@@ -26,13 +27,33 @@ func uuidToNamespace(id uuid.UUID) string {
 	return strings.ReplaceAll(id.String(), "-", "_")
 }
 
-type IPAM struct {
+// IPAM allocates the tunnel addresses of the devices and tracks the prefixes they advertise.
+// Prefixes and addresses are grouped in namespaces, the default namespace is shared by all the
+// VPCs that do not use a private CIDR.
+type IPAM interface {
+	CreateNamespace(ctx context.Context, namespace uuid.UUID) error
+	DeleteNamespace(ctx context.Context, namespace uuid.UUID) error
+	// AcquireIP allocates the given address from the prefix, it fails if the address is in use.
+	AcquireIP(ctx context.Context, namespace uuid.UUID, ipamPrefix string, TunnelIP string) error
+	// AssignSpecificTunnelIP allocates the given address from the prefix, or the next free one if it is in use.
+	AssignSpecificTunnelIP(ctx context.Context, namespace uuid.UUID, ipamPrefix string, TunnelIP string) (string, error)
+	AssignFromPool(ctx context.Context, namespace uuid.UUID, ipamPrefix string) (string, error)
+	// AssignCIDR creates the prefix, it succeeds if the prefix already exists.
+	AssignCIDR(ctx context.Context, namespace uuid.UUID, cidr string) error
+	ReleaseToPool(ctx context.Context, namespace uuid.UUID, address, cidr string) error
+	ReleaseCIDR(ctx context.Context, namespace uuid.UUID, cidr string) error
+}
+
+var _ IPAM = &RemoteIPAM{} // type check the interface is implemented.
+
+// RemoteIPAM is an IPAM backed by a go-ipam service that is accessed over grpc.
+type RemoteIPAM struct {
 	logger *zap.SugaredLogger
 	client apiv1connect.IpamServiceClient
 }
 
-func NewIPAM(logger *zap.SugaredLogger, ipamAddress string) IPAM {
-	return IPAM{
+func NewRemoteIPAM(logger *zap.SugaredLogger, ipamAddress string) *RemoteIPAM {
+	return &RemoteIPAM{
 		logger: logger,
 		client: apiv1connect.NewIpamServiceClient(
 			http.DefaultClient,
@@ -41,32 +62,35 @@ func NewIPAM(logger *zap.SugaredLogger, ipamAddress string) IPAM {
 		)}
 }
 
-func (i *IPAM) CreateNamespace(parent context.Context, namespace uuid.UUID) error {
+func (i *RemoteIPAM) CreateNamespace(parent context.Context, namespace uuid.UUID) (err error) {
+	defer func(start time.Time) { observeCall("CreateNamespace", start, err) }(time.Now())
 	ctx, span := tracer.Start(parent, "CreateNamespace")
 	defer span.End()
-	_, err := i.client.CreateNamespace(ctx, connect.NewRequest(&apiv1.CreateNamespaceRequest{
+	_, err = i.client.CreateNamespace(ctx, connect.NewRequest(&apiv1.CreateNamespaceRequest{
 		Namespace: uuidToNamespace(namespace),
 	}))
 	return err
 }
 
-func (i *IPAM) DeleteNamespace(parent context.Context, namespace uuid.UUID) error {
+func (i *RemoteIPAM) DeleteNamespace(parent context.Context, namespace uuid.UUID) (err error) {
+	defer func(start time.Time) { observeCall("DeleteNamespace", start, err) }(time.Now())
 	ctx, span := tracer.Start(parent, "DeleteNamespace")
 	defer span.End()
-	_, err := i.client.DeleteNamespace(ctx, connect.NewRequest(&apiv1.DeleteNamespaceRequest{
+	_, err = i.client.DeleteNamespace(ctx, connect.NewRequest(&apiv1.DeleteNamespaceRequest{
 		Namespace: uuidToNamespace(namespace),
 	}))
 	return err
 }
 
-func (i *IPAM) AcquireIP(parent context.Context, namespace uuid.UUID, ipamPrefix string, TunnelIP string) error {
+func (i *RemoteIPAM) AcquireIP(parent context.Context, namespace uuid.UUID, ipamPrefix string, TunnelIP string) (err error) {
+	defer func(start time.Time) { observeCall("AcquireIP", start, err) }(time.Now())
 	ctx, span := tracer.Start(parent, "AssignSpecificTunnelIP")
 	defer span.End()
 	if err := validateIP(TunnelIP); err != nil {
 		return fmt.Errorf("Address %s is not valid", TunnelIP)
 	}
 	ns := uuidToNamespace(namespace)
-	_, err := i.client.AcquireIP(ctx, connect.NewRequest(&apiv1.AcquireIPRequest{
+	_, err = i.client.AcquireIP(ctx, connect.NewRequest(&apiv1.AcquireIPRequest{
 		PrefixCidr: ipamPrefix,
 		Ip:         &TunnelIP,
 		Namespace:  &ns,
@@ -74,7 +98,8 @@ func (i *IPAM) AcquireIP(parent context.Context, namespace uuid.UUID, ipamPrefix
 	return err
 }
 
-func (i *IPAM) AssignSpecificTunnelIP(parent context.Context, namespace uuid.UUID, ipamPrefix string, TunnelIP string) (string, error) {
+func (i *RemoteIPAM) AssignSpecificTunnelIP(parent context.Context, namespace uuid.UUID, ipamPrefix string, TunnelIP string) (_ string, err error) {
+	defer func(start time.Time) { observeCall("AssignSpecificTunnelIP", start, err) }(time.Now())
 	ctx, span := tracer.Start(parent, "AssignSpecificTunnelIP")
 	defer span.End()
 	if err := validateIP(TunnelIP); err != nil {
@@ -93,7 +118,8 @@ func (i *IPAM) AssignSpecificTunnelIP(parent context.Context, namespace uuid.UUI
 	return res.Msg.Ip.Ip, nil
 }
 
-func (i *IPAM) AssignFromPool(parent context.Context, namespace uuid.UUID, ipamPrefix string) (string, error) {
+func (i *RemoteIPAM) AssignFromPool(parent context.Context, namespace uuid.UUID, ipamPrefix string) (_ string, err error) {
+	defer func(start time.Time) { observeCall("AssignFromPool", start, err) }(time.Now())
 	ctx, span := tracer.Start(parent, "AssignFromPool")
 	defer span.End()
 	ns := uuidToNamespace(namespace)
@@ -107,10 +133,11 @@ func (i *IPAM) AssignFromPool(parent context.Context, namespace uuid.UUID, ipamP
 	return res.Msg.Ip.Ip, nil
 }
 
-func (i *IPAM) AssignCIDR(parent context.Context, namespace uuid.UUID, cidr string) error {
+func (i *RemoteIPAM) AssignCIDR(parent context.Context, namespace uuid.UUID, cidr string) (err error) {
+	defer func(start time.Time) { observeCall("AssignCIDR", start, err) }(time.Now())
 	ctx, span := tracer.Start(parent, "AssignPrefix")
 	defer span.End()
-	cidr, err := cleanCidr(cidr)
+	cidr, err = cleanCidr(cidr)
 	if err != nil {
 		return fmt.Errorf("invalid prefix requested: %w", err)
 	}
@@ -130,9 +157,10 @@ func (i *IPAM) AssignCIDR(parent context.Context, namespace uuid.UUID, cidr stri
 }
 
 // ReleaseToPool release the ipam address back to the specified prefix
-func (i *IPAM) ReleaseToPool(ctx context.Context, namespace uuid.UUID, address, cidr string) error {
+func (i *RemoteIPAM) ReleaseToPool(ctx context.Context, namespace uuid.UUID, address, cidr string) (err error) {
+	defer func(start time.Time) { observeCall("ReleaseToPool", start, err) }(time.Now())
 	ns := uuidToNamespace(namespace)
-	_, err := i.client.ReleaseIP(ctx, connect.NewRequest(&apiv1.ReleaseIPRequest{
+	_, err = i.client.ReleaseIP(ctx, connect.NewRequest(&apiv1.ReleaseIPRequest{
 		Ip:         address,
 		PrefixCidr: cidr,
 		Namespace:  &ns,
@@ -145,9 +173,10 @@ func (i *IPAM) ReleaseToPool(ctx context.Context, namespace uuid.UUID, address,
 }
 
 // ReleaseCIDR release the ipam address back to the specified prefix
-func (i *IPAM) ReleaseCIDR(ctx context.Context, namespace uuid.UUID, cidr string) error {
+func (i *RemoteIPAM) ReleaseCIDR(ctx context.Context, namespace uuid.UUID, cidr string) (err error) {
+	defer func(start time.Time) { observeCall("ReleaseCIDR", start, err) }(time.Now())
 	ns := uuidToNamespace(namespace)
-	_, err := i.client.DeletePrefix(ctx, connect.NewRequest(&apiv1.DeletePrefixRequest{
+	_, err = i.client.DeletePrefix(ctx, connect.NewRequest(&apiv1.DeletePrefixRequest{
 		Cidr:      cidr,
 		Namespace: &ns,
 	}))
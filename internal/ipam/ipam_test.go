@@ -27,7 +27,7 @@ type IpamTestSuite struct {
 func (suite *IpamTestSuite) SetupSuite() {
 	suite.server = NewTestIPAMServer()
 	suite.logger = zaptest.NewLogger(suite.T()).Sugar()
-	suite.ipam = NewIPAM(suite.logger, TestIPAMClientAddr)
+	suite.ipam = NewRemoteIPAM(suite.logger, TestIPAMClientAddr)
 	suite.wg = sync.WaitGroup{}
 	suite.wg.Add(1)
 	listener, err := net.Listen("tcp", "[::1]:9091")
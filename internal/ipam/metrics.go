@@ -0,0 +1,35 @@
+package ipam
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	callDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "apiserver",
+		Subsystem: "ipam",
+		Name:      "call_duration_seconds",
+		Help:      "Duration of the calls to the ipam service, by operation.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 4, 8),
+	}, []string{"operation"})
+	callErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "apiserver",
+		Subsystem: "ipam",
+		Name:      "call_errors_total",
+		Help:      "Failed calls to the ipam service, by operation.",
+	}, []string{"operation"})
+)
+
+func init() {
+	prometheus.MustRegister(callDuration, callErrors)
+}
+
+// observeCall records the duration and the outcome of an ipam operation started at the given time.
+func observeCall(operation string, start time.Time, err error) {
+	callDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+	if err != nil {
+		callErrors.WithLabelValues(operation).Inc()
+	}
+}
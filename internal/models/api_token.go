@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIToken is a long-lived token used to access the API on behalf of a user without an interactive login.
+type APIToken struct {
+	Base
+	UserID      uuid.UUID  `json:"user_id" gorm:"type:uuid;index"`                                                      // UserID is the ID of the user the token acts on behalf of.
+	Description string     `json:"description,omitempty"`                                                               // Description of the token.
+	Scopes      []string   `json:"scopes" gorm:"type:JSONB; serializer:json" example:"read:organizations,read:devices"` // Scopes are the API scopes the token grants.
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`                                                                // ExpiresAt is optional, if set the token is only valid until the ExpiresAt time.
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`                                                              // LastUsedAt is the last time the token was used to access the API.
+	BearerToken string     `json:"bearer_token,omitempty" gorm:"-"`                                                     // BearerToken is the token to send in the authorization header, it is only returned when the token is created.
+}
+
+type AddAPIToken struct {
+	Description string     `json:"description,omitempty"`                            // Description of the token.
+	Scopes      []string   `json:"scopes" example:"read:organizations,read:devices"` // Scopes are the API scopes the token grants.
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`                             // ExpiresAt is optional, if set the token is only valid until the ExpiresAt time.
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AuditEvent is an immutable record of a change made to a resource of an organization
+type AuditEvent struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primary_key" example:"aa22666c-0f57-45cb-a449-16efecc04f2e"`
+	CreatedAt      time.Time  `json:"created_at"`
+	OrganizationID uuid.UUID  `json:"organization_id" gorm:"type:uuid;index"`
+	ActorUserID    uuid.UUID  `json:"actor_user_id" gorm:"type:uuid"`
+	ActorDeviceID  *uuid.UUID `json:"actor_device_id,omitempty" gorm:"type:uuid"` // ActorDeviceID is set when the change was made with a device token.
+	TokenScope     string     `json:"token_scope,omitempty"`
+	Action         string     `json:"action" example:"update"`
+	ResourceKind   string     `json:"resource_kind" example:"security-groups"`
+	ResourceID     uuid.UUID  `json:"resource_id" gorm:"type:uuid;index"`
+	// Before and After hold the fields of the resource that were changed, before and after the change.
+	Before   map[string]interface{} `json:"before,omitempty" gorm:"type:JSONB; serializer:json"`
+	After    map[string]interface{} `json:"after,omitempty" gorm:"type:JSONB; serializer:json"`
+	SourceIP string                 `json:"source_ip" example:"192.0.2.10"`
+}
+
+// BeforeCreate populates the ID (if not set)
+func (e *AuditEvent) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
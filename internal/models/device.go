@@ -5,55 +5,116 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
+	"gorm.io/gorm"
 )
 
 // Device is a unique, end-user device.
 // Devices belong to one User and may be onboarded into an organization
 type Device struct {
 	Base
-	OwnerID         uuid.UUID      `json:"owner_id"`
-	VpcID           uuid.UUID      `json:"vpc_id" example:"694aa002-5d19-495e-980b-3d8fd508ea10"`
-	OrganizationID  uuid.UUID      `json:"-"` // Denormalized from the VPC record for performance
-	PublicKey       string         `json:"public_key"`
-	AllowedIPs      pq.StringArray `json:"allowed_ips" gorm:"type:text[]" swaggertype:"array,string"`
-	IPv4TunnelIPs   []TunnelIP     `json:"ipv4_tunnel_ips" gorm:"type:JSONB; serializer:json"`
-	IPv6TunnelIPs   []TunnelIP     `json:"ipv6_tunnel_ips" gorm:"type:JSONB; serializer:json"`
-	AdvertiseCidrs  pq.StringArray `json:"advertise_cidrs" gorm:"type:text[]" swaggertype:"array,string"`
-	Relay           bool           `json:"relay"`
-	SymmetricNat    bool           `json:"symmetric_nat"`
-	Hostname        string         `json:"hostname"`
-	Os              string         `json:"os"`
-	Endpoints       []Endpoint     `json:"endpoints" gorm:"type:JSONB; serializer:json"`
-	Revision        uint64         `json:"revision" gorm:"type:bigserial;index:"`
-	SecurityGroupId uuid.UUID      `json:"security_group_id"`
-	Online          bool           `json:"online"`
-	OnlineAt        *time.Time     `json:"online_at"`
-	RegKeyID        uuid.UUID      `json:"-"`                      // the reg key id that created the device (if it was created with a registration token)
-	BearerToken     string         `json:"bearer_token,omitempty"` // the token nexd should use to reconcile device state.
+	OwnerID         uuid.UUID         `json:"owner_id"`
+	VpcID           uuid.UUID         `json:"vpc_id" example:"694aa002-5d19-495e-980b-3d8fd508ea10"`
+	OrganizationID  uuid.UUID         `json:"-"` // Denormalized from the VPC record for performance
+	PublicKey       string            `json:"public_key"`
+	AllowedIPs      pq.StringArray    `json:"allowed_ips" gorm:"type:text[]" swaggertype:"array,string"`
+	IPv4TunnelIPs   []TunnelIP        `json:"ipv4_tunnel_ips" gorm:"type:JSONB; serializer:json"`
+	IPv6TunnelIPs   []TunnelIP        `json:"ipv6_tunnel_ips" gorm:"type:JSONB; serializer:json"`
+	AdvertiseCidrs  pq.StringArray    `json:"advertise_cidrs" gorm:"type:text[]" swaggertype:"array,string"`
+	PrimaryCidrs    pq.StringArray    `json:"primary_cidrs" gorm:"type:text[]" swaggertype:"array,string"` // PrimaryCidrs are the advertised cidrs the device is the primary router for in its VPC.
+	Relay           bool              `json:"relay"`
+	SymmetricNat    bool              `json:"symmetric_nat"`
+	Hostname        string            `json:"hostname"`
+	Os              string            `json:"os"`
+	Endpoints       []Endpoint        `json:"endpoints" gorm:"type:JSONB; serializer:json"`
+	Revision        uint64            `json:"revision" gorm:"type:bigserial;index:"`
+	SecurityGroupId uuid.UUID         `json:"security_group_id"`
+	Labels          map[string]string `json:"labels,omitempty" gorm:"type:JSONB; serializer:json"`
+	Online          bool              `json:"online"`
+	OnlineAt        *time.Time        `json:"online_at"`
+	RegKeyID        uuid.UUID         `json:"-"`                      // the reg key id that created the device (if it was created with a registration token)
+	BearerToken     string            `json:"bearer_token,omitempty"` // the token nexd should use to reconcile device state.
+	Pending         bool              `json:"pending"`                // pending devices wait for approval before they are served to the other devices of the VPC.
+	Ephemeral       bool              `json:"ephemeral"`              // ephemeral devices are deleted after they have been offline for the ephemeral device grace period.
 }
 
 // AddDevice is the information needed to add a new Device.
 type AddDevice struct {
-	VpcID           uuid.UUID  `json:"vpc_id" example:"694aa002-5d19-495e-980b-3d8fd508ea10"`
-	PublicKey       string     `json:"public_key"`
-	AdvertiseCidrs  []string   `json:"advertise_cidrs" example:"172.16.42.0/24"`
-	IPv4TunnelIPs   []TunnelIP `json:"ipv4_tunnel_ips" gorm:"type:JSONB; serializer:json"`
-	Relay           bool       `json:"relay"`
-	SymmetricNat    bool       `json:"symmetric_nat"`
-	Hostname        string     `json:"hostname" example:"myhost"`
-	Endpoints       []Endpoint `json:"endpoints" gorm:"type:JSONB; serializer:json"`
-	Os              string     `json:"os"`
-	SecurityGroupId uuid.UUID  `json:"security_group_id"`
+	VpcID           uuid.UUID         `json:"vpc_id" example:"694aa002-5d19-495e-980b-3d8fd508ea10"`
+	PublicKey       string            `json:"public_key"`
+	AdvertiseCidrs  []string          `json:"advertise_cidrs" example:"172.16.42.0/24"`
+	IPv4TunnelIPs   []TunnelIP        `json:"ipv4_tunnel_ips" gorm:"type:JSONB; serializer:json"`
+	Relay           bool              `json:"relay"`
+	SymmetricNat    bool              `json:"symmetric_nat"`
+	Hostname        string            `json:"hostname" example:"myhost"`
+	Endpoints       []Endpoint        `json:"endpoints" gorm:"type:JSONB; serializer:json"`
+	Os              string            `json:"os"`
+	SecurityGroupId uuid.UUID         `json:"security_group_id"`
+	Labels          map[string]string `json:"labels,omitempty"`
 }
 
 // UpdateDevice is the information needed to update a Device.
 type UpdateDevice struct {
-	VpcID           *uuid.UUID `json:"vpc_id" example:"694aa002-5d19-495e-980b-3d8fd508ea10"`
-	AdvertiseCidrs  []string   `json:"advertise_cidrs" example:"172.16.42.0/24"`
-	SymmetricNat    *bool      `json:"symmetric_nat"`
-	Hostname        string     `json:"hostname" example:"myhost"`
-	Endpoints       []Endpoint `json:"endpoints" gorm:"type:JSONB; serializer:json"`
-	Revision        *uint64    `json:"revision"`
-	Relay           *bool      `json:"relay"`
-	SecurityGroupId *uuid.UUID `json:"security_group_id"`
+	VpcID           *uuid.UUID        `json:"vpc_id" example:"694aa002-5d19-495e-980b-3d8fd508ea10"`
+	AdvertiseCidrs  []string          `json:"advertise_cidrs" example:"172.16.42.0/24"`
+	SymmetricNat    *bool             `json:"symmetric_nat"`
+	Hostname        string            `json:"hostname" example:"myhost"`
+	Endpoints       []Endpoint        `json:"endpoints" gorm:"type:JSONB; serializer:json"`
+	Revision        *uint64           `json:"revision"`
+	Relay           *bool             `json:"relay"`
+	SecurityGroupId *uuid.UUID        `json:"security_group_id"`
+	Labels          map[string]string `json:"labels,omitempty"`
+	PublicKey       string            `json:"public_key,omitempty"` // PublicKey rotates the wireguard key of the device, the revision of the device must be given with it.
+}
+
+// DevicePresharedKeys are the wireguard pre-shared keys a device uses with its peers.
+type DevicePresharedKeys struct {
+	DeviceID      uuid.UUID         `json:"device_id"`
+	Enabled       bool              `json:"enabled"`                  // Enabled is set when the VPC of the device uses pre-shared keys.
+	PresharedKeys map[string]string `json:"preshared_keys,omitempty"` // PresharedKeys maps the IDs of the peer devices to the pre-shared key of the pair, sealed to the public key of the device.
+}
+
+// DeniedFlow is a packet dropped by the security group of a device.
+type DeniedFlow struct {
+	Time        time.Time `json:"time"`
+	Direction   string    `json:"direction" example:"inbound"`
+	Protocol    string    `json:"protocol" example:"tcp"`
+	Source      string    `json:"source" example:"100.64.0.2"`
+	Destination string    `json:"destination" example:"100.64.0.1"`
+	Port        int       `json:"port,omitempty" example:"22"` // Port is the destination port of the tcp and udp packets.
+}
+
+// ReportDeniedFlows are the flows denied by the security group of a device since its last report.
+type ReportDeniedFlows struct {
+	DeniedFlows []DeniedFlow `json:"denied_flows"`
+}
+
+// DeviceDeniedFlow is a flow reported as denied by the security group of a device. They are
+// kept apart from the audit events, and only for a limited time.
+type DeviceDeniedFlow struct {
+	ID             uuid.UUID `json:"id" gorm:"type:uuid;primary_key" example:"aa22666c-0f57-45cb-a449-16efecc04f2e"`
+	CreatedAt      time.Time `json:"created_at" gorm:"index"`
+	OrganizationID uuid.UUID `json:"organization_id" gorm:"type:uuid;index"`
+	DeviceID       uuid.UUID `json:"device_id" gorm:"type:uuid;index"`
+	Time           time.Time `json:"time"`
+	Direction      string    `json:"direction" example:"inbound"`
+	Protocol       string    `json:"protocol" example:"tcp"`
+	Source         string    `json:"source" example:"100.64.0.2"`
+	Destination    string    `json:"destination" example:"100.64.0.1"`
+	Port           int       `json:"port,omitempty" example:"22"`
+}
+
+// BeforeCreate populates the ID (if not set)
+func (d *DeviceDeniedFlow) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return nil
+}
+
+// AdvertisedRoute is a cidr advertised by the devices of a VPC. The primary device routes the cidr,
+// the standby devices take over when it goes offline.
+type AdvertisedRoute struct {
+	Cidr             string      `json:"cidr" example:"172.16.42.0/24"`
+	PrimaryDeviceID  uuid.UUID   `json:"primary_device_id"`
+	StandbyDeviceIDs []uuid.UUID `json:"standby_device_ids"`
 }
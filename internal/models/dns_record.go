@@ -0,0 +1,29 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// DNSRecord is a custom name published by the mesh DNS resolver of the devices in a VPC
+type DNSRecord struct {
+	Base
+	VpcID          uuid.UUID `json:"vpc_id" gorm:"type:uuid;index"`
+	OrganizationID uuid.UUID `json:"-" gorm:"type:uuid"` // Denormalized from the VPC record for performance
+	Name           string    `json:"name" example:"db.prod"`
+	Addresses      []string  `json:"addresses" gorm:"type:JSONB; serializer:json" example:"100.64.0.1"`
+	Description    string    `json:"description"`
+	Revision       uint64    `json:"revision" gorm:"type:bigserial;index:"`
+}
+
+// AddDNSRecord is the information needed to add a new DNS record to a VPC.
+type AddDNSRecord struct {
+	Name        string   `json:"name" example:"db.prod"`
+	Addresses   []string `json:"addresses" example:"100.64.0.1"`
+	Description string   `json:"description"`
+}
+
+// UpdateDNSRecord is the information needed to update an existing DNS record.
+type UpdateDNSRecord struct {
+	Addresses   []string `json:"addresses,omitempty" example:"100.64.0.1"`
+	Description *string  `json:"description,omitempty"`
+}
@@ -10,17 +10,25 @@ import (
 // RegKey is used to register devices without an interactive login.
 type RegKey struct {
 	Base
-	OwnerID          uuid.UUID              `json:"owner_id,omitempty"`                            // OwnerID is the ID of the user that created the registration key.
-	VpcID            *uuid.UUID             `json:"vpc_id,omitempty"`                              // VpcID is the ID of the VPC the device can join.
-	OrganizationID   *uuid.UUID             `json:"-" gorm:"type:uuid"`                            // OrganizationID is denormalized from the VPC record for performance
-	ServiceNetworkID *uuid.UUID             `json:"service_network_id,omitempty"`                  // ServiceNetworkID is the ID of the Service Network the device can join.
-	SNOrganizationID *uuid.UUID             `json:"-" gorm:"type:uuid; column:sn_organization_id"` // OrganizationID is denormalized from the ServiceNetwork record for performance
-	BearerToken      string                 `json:"bearer_token,omitempty"`                        // BearerToken is the bearer token the client should use to authenticate the device registration request.
-	Description      string                 `json:"description,omitempty"`                         // Description of the registration key.
-	DeviceId         *uuid.UUID             `json:"device_id,omitempty"`                           // DeviceId is set if the RegKey was created for single use
-	ExpiresAt        *time.Time             `json:"expires_at,omitempty"`                          // ExpiresAt is optional, if set the registration key is only valid until the ExpiresAt time.
-	SecurityGroupId  *uuid.UUID             `json:"security_group_id"`                             // SecurityGroupId is the ID of the security group to assign to the device.
-	Settings         map[string]interface{} `json:"settings" gorm:"type:JSONB; serializer:json"`   // Settings contains general settings for the device.
+	OwnerID            uuid.UUID              `json:"owner_id,omitempty"`                                           // OwnerID is the ID of the user that created the registration key.
+	VpcID              *uuid.UUID             `json:"vpc_id,omitempty"`                                             // VpcID is the ID of the VPC the device can join.
+	OrganizationID     *uuid.UUID             `json:"-" gorm:"type:uuid"`                                           // OrganizationID is denormalized from the VPC record for performance
+	ServiceNetworkID   *uuid.UUID             `json:"service_network_id,omitempty"`                                 // ServiceNetworkID is the ID of the Service Network the device can join.
+	SNOrganizationID   *uuid.UUID             `json:"-" gorm:"type:uuid; column:sn_organization_id"`                // OrganizationID is denormalized from the ServiceNetwork record for performance
+	BearerToken        string                 `json:"bearer_token,omitempty"`                                       // BearerToken is the bearer token the client should use to authenticate the device registration request.
+	Description        string                 `json:"description,omitempty"`                                        // Description of the registration key.
+	DeviceId           *uuid.UUID             `json:"device_id,omitempty"`                                          // DeviceId is set if the RegKey was created for single use
+	ExpiresAt          *time.Time             `json:"expires_at,omitempty"`                                         // ExpiresAt is optional, if set the registration key is only valid until the ExpiresAt time.
+	SecurityGroupId    *uuid.UUID             `json:"security_group_id"`                                            // SecurityGroupId is the ID of the security group to assign to the device.
+	Settings           map[string]interface{} `json:"settings" gorm:"type:JSONB; serializer:json"`                  // Settings contains general settings for the device.
+	Ephemeral          bool                   `json:"ephemeral,omitempty"`                                          // Ephemeral devices are deleted after they have been offline for the ephemeral device grace period.
+	MaxUses            int64                  `json:"max_uses,omitempty"`                                           // MaxUses is optional, if set the registration key can only register that many devices.
+	Uses               int64                  `json:"uses"`                                                         // Uses is the number of devices registered with the registration key.
+	RemainingUses      *int64                 `json:"remaining_uses,omitempty" gorm:"-"`                            // RemainingUses is the number of devices that can still be registered when MaxUses is set.
+	AllowedSourceCidrs StringArray            `json:"allowed_source_cidrs,omitempty" swaggertype:"array,string"`    // AllowedSourceCidrs is optional, if set the registration key can only be used from these networks.
+	DeviceLabels       map[string]string      `json:"device_labels,omitempty" gorm:"type:JSONB; serializer:json"`   // DeviceLabels are added to the labels of the devices registered with the registration key.
+	DeviceMetadata     map[string]interface{} `json:"device_metadata,omitempty" gorm:"type:JSONB; serializer:json"` // DeviceMetadata is copied to the metadata of the devices registered with the registration key.
+	HostnameTemplate   string                 `json:"hostname_template,omitempty"`                                  // HostnameTemplate is optional, if set it is used to name the devices registered with the registration key.
 }
 type NexodusClaims struct {
 	jwt.RegisteredClaims
@@ -31,18 +39,29 @@ type NexodusClaims struct {
 }
 
 type AddRegKey struct {
-	VpcID            *uuid.UUID             `json:"vpc_id,omitempty"`             // VpcID is the ID of the VPC the device will join.
-	ServiceNetworkID *uuid.UUID             `json:"service_network_id,omitempty"` // ServiceNetworkID is the ID of the Service Network the device can join.
-	Description      string                 `json:"description,omitempty"`        // Description of the registration key.
-	SingleUse        bool                   `json:"single_use,omitempty"`         // SingleUse only allows the registration key to be used once.
-	ExpiresAt        *time.Time             `json:"expires_at,omitempty"`         // ExpiresAt is optional, if set the registration key is only valid until the ExpiresAt time.
-	SecurityGroupId  *uuid.UUID             `json:"security_group_id"`            // SecurityGroupId is the ID of the security group to assign to the device.
-	Settings         map[string]interface{} `json:"settings"`                     // Settings contains general settings for the device.
+	VpcID              *uuid.UUID             `json:"vpc_id,omitempty"`               // VpcID is the ID of the VPC the device will join.
+	ServiceNetworkID   *uuid.UUID             `json:"service_network_id,omitempty"`   // ServiceNetworkID is the ID of the Service Network the device can join.
+	Description        string                 `json:"description,omitempty"`          // Description of the registration key.
+	SingleUse          bool                   `json:"single_use,omitempty"`           // SingleUse only allows the registration key to be used once.
+	ExpiresAt          *time.Time             `json:"expires_at,omitempty"`           // ExpiresAt is optional, if set the registration key is only valid until the ExpiresAt time.
+	SecurityGroupId    *uuid.UUID             `json:"security_group_id"`              // SecurityGroupId is the ID of the security group to assign to the device.
+	Settings           map[string]interface{} `json:"settings"`                       // Settings contains general settings for the device.
+	Ephemeral          bool                   `json:"ephemeral,omitempty"`            // Ephemeral devices are deleted after they have been offline for the ephemeral device grace period.
+	MaxUses            int64                  `json:"max_uses,omitempty"`             // MaxUses is optional, if set the registration key can only register that many devices.
+	AllowedSourceCidrs []string               `json:"allowed_source_cidrs,omitempty"` // AllowedSourceCidrs is optional, if set the registration key can only be used from these networks.
+	DeviceLabels       map[string]string      `json:"device_labels,omitempty"`        // DeviceLabels are added to the labels of the devices registered with the registration key.
+	DeviceMetadata     map[string]interface{} `json:"device_metadata,omitempty"`      // DeviceMetadata is copied to the metadata of the devices registered with the registration key.
+	HostnameTemplate   string                 `json:"hostname_template,omitempty"`    // HostnameTemplate is optional, if set it is used to name the devices registered with the registration key, it is a Go template with the .Hostname and .Index fields.
 }
 
 type UpdateRegKey struct {
-	Description     *string                `json:"description,omitempty"` // Description of the registration key.
-	ExpiresAt       *time.Time             `json:"expires_at,omitempty"`  // ExpiresAt is optional, if set the registration key is only valid until the ExpiresAt time.
-	SecurityGroupId *uuid.UUID             `json:"security_group_id"`     // SecurityGroupId is the ID of the security group to assign to the device.
-	Settings        map[string]interface{} `json:"settings"`              // Settings contains general settings for the device.
+	Description        *string                `json:"description,omitempty"`          // Description of the registration key.
+	ExpiresAt          *time.Time             `json:"expires_at,omitempty"`           // ExpiresAt is optional, if set the registration key is only valid until the ExpiresAt time.
+	SecurityGroupId    *uuid.UUID             `json:"security_group_id"`              // SecurityGroupId is the ID of the security group to assign to the device.
+	Settings           map[string]interface{} `json:"settings"`                       // Settings contains general settings for the device.
+	MaxUses            *int64                 `json:"max_uses,omitempty"`             // MaxUses is optional, if set the registration key can only register that many devices, 0 removes the limit.
+	AllowedSourceCidrs []string               `json:"allowed_source_cidrs,omitempty"` // AllowedSourceCidrs is optional, if set the registration key can only be used from these networks, an empty list removes the restriction.
+	DeviceLabels       map[string]string      `json:"device_labels,omitempty"`        // DeviceLabels are added to the labels of the devices registered with the registration key.
+	DeviceMetadata     map[string]interface{} `json:"device_metadata,omitempty"`      // DeviceMetadata is copied to the metadata of the devices registered with the registration key.
+	HostnameTemplate   *string                `json:"hostname_template,omitempty"`    // HostnameTemplate is optional, if set it is used to name the devices registered with the registration key, an empty string removes it.
 }
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// Role is a named set of permissions that can be granted to the users of an organization. The built-in
+// roles are shared by all the organizations, the custom roles are defined by an organization.
+type Role struct {
+	Base
+	OrganizationID uuid.UUID `json:"organization_id,omitempty" gorm:"type:uuid;index"`
+	Name           string    `json:"name" example:"auditor"`
+	Description    string    `json:"description"`
+	// Permissions holds the verbs the role allows on each resource type
+	Permissions map[string][]string `json:"permissions" gorm:"type:JSONB; serializer:json"`
+	// Grants holds the permissions as resource:verb pairs, so that they can be matched by a query
+	Grants StringArray `json:"-"`
+}
+
+type AddRole struct {
+	Name        string              `json:"name" example:"auditor"`
+	Description string              `json:"description,omitempty"`
+	Permissions map[string][]string `json:"permissions"`
+}
+
+type UpdateRole struct {
+	Description *string             `json:"description,omitempty"`
+	Permissions map[string][]string `json:"permissions,omitempty"`
+}
+
+// Allows returns true when the role grants the verb on the resource type
+func (r Role) Allows(resource string, verb string) bool {
+	for _, v := range r.Permissions[resource] {
+		if v == verb {
+			return true
+		}
+	}
+	return false
+}
+
+// RoleGrant is the resource:verb pair a permission is stored as in the grants of a role
+func RoleGrant(resource string, verb string) string {
+	return resource + ":" + verb
+}
+
+// PermissionGrants returns the sorted resource:verb pairs of the permissions
+func PermissionGrants(permissions map[string][]string) StringArray {
+	grants := StringArray{}
+	for resource, verbs := range permissions {
+		for _, verb := range verbs {
+			grants = append(grants, RoleGrant(resource, verb))
+		}
+	}
+	sort.Strings(grants)
+	return grants
+}
@@ -36,4 +36,6 @@ type SecurityRule struct {
 	FromPort   int64    `json:"from_port"`
 	ToPort     int64    `json:"to_port"`
 	IpRanges   []string `json:"ip_ranges,omitempty"`
+	// PeerSelector matches the tunnel IPs of the devices in the VPC that carry all the labels, e.g. role=db,env=prod
+	PeerSelector string `json:"peer_selector,omitempty" example:"role=db,env=prod"`
 }
@@ -7,13 +7,19 @@ import (
 // VPC contains Devices
 type VPC struct {
 	Base
-	OrganizationID uuid.UUID     `json:"organization_id"`
-	Description    string        `json:"description"`
-	PrivateCidr    bool          `json:"private_cidr"`
-	Ipv4Cidr       string        `json:"ipv4_cidr"`
-	Ipv6Cidr       string        `json:"ipv6_cidr"`
-	Organization   *Organization `json:"-"`
-	Revision       uint64        `json:"revision" gorm:"type:bigserial;index:"`
+	OrganizationID uuid.UUID `json:"organization_id"`
+	Description    string    `json:"description"`
+	PrivateCidr    bool      `json:"private_cidr"`
+	Ipv4Cidr       string    `json:"ipv4_cidr"`
+	Ipv6Cidr       string    `json:"ipv6_cidr"`
+	// RequireDeviceApproval makes the new devices of the VPC pending until they are approved.
+	RequireDeviceApproval bool `json:"require_device_approval"`
+	// PresharedKeys adds a wireguard pre-shared key to the peering of every pair of devices in the VPC.
+	PresharedKeys bool `json:"preshared_keys"`
+	// PresharedKeySecret is the secret the pre-shared keys of the device pairs are derived from.
+	PresharedKeySecret string        `json:"-"`
+	Organization       *Organization `json:"-"`
+	Revision           uint64        `json:"revision" gorm:"type:bigserial;index:"`
 }
 
 type AddVPC struct {
@@ -22,8 +28,14 @@ type AddVPC struct {
 	PrivateCidr    bool      `json:"private_cidr"`
 	Ipv4Cidr       string    `json:"ipv4_cidr" example:"172.16.42.0/24"`
 	Ipv6Cidr       string    `json:"ipv6_cidr" example:"0200::/8"`
+	// RequireDeviceApproval makes the new devices of the VPC pending until they are approved.
+	RequireDeviceApproval bool `json:"require_device_approval"`
+	// PresharedKeys adds a wireguard pre-shared key to the peering of every pair of devices in the VPC.
+	PresharedKeys bool `json:"preshared_keys"`
 }
 
 type UpdateVPC struct {
-	Description *string `json:"description" example:"The Red Zone"`
+	Description           *string `json:"description" example:"The Red Zone"`
+	RequireDeviceApproval *bool   `json:"require_device_approval"`
+	PresharedKeys         *bool   `json:"preshared_keys"`
 }
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Webhook delivers the change events of the resources of an organization to an HTTP endpoint
+type Webhook struct {
+	Base
+	OrganizationID uuid.UUID `json:"organization_id" gorm:"type:uuid;index"`
+	URL            string    `json:"url" example:"https://example.com/nexodus-events"`
+	Description    string    `json:"description,omitempty"`
+	EventKinds     []string  `json:"event_kinds,omitempty" gorm:"type:JSONB; serializer:json" example:"device,security-group"` // EventKinds filters the kinds of events delivered, all kinds are delivered when empty.
+	Secret         string    `json:"secret,omitempty"`                                                                         // Secret is the HMAC key the payloads are signed with, it is only returned when the webhook is created.
+	// Cursors hold the last revision delivered for each event kind and VPC.
+	Cursors         map[string]uint64 `json:"-" gorm:"type:JSONB; serializer:json"`
+	DispatchVersion uint64            `json:"-"`
+}
+
+type AddWebhook struct {
+	OrganizationID uuid.UUID `json:"organization_id"`
+	URL            string    `json:"url" example:"https://example.com/nexodus-events"`
+	Description    string    `json:"description,omitempty"`
+	EventKinds     []string  `json:"event_kinds,omitempty" example:"device,security-group"` // EventKinds filters the kinds of events delivered, all kinds are delivered when empty.
+	Secret         string    `json:"secret,omitempty"`                                      // Secret is the HMAC key the payloads are signed with, a random secret is generated when not set.
+}
+
+type UpdateWebhook struct {
+	URL         *string  `json:"url,omitempty" example:"https://example.com/nexodus-events"`
+	Description *string  `json:"description,omitempty"`
+	EventKinds  []string `json:"event_kinds,omitempty" example:"device,security-group"`
+	Secret      *string  `json:"secret,omitempty"`
+}
+
+// The states of a webhook delivery
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// WebhookDelivery records the delivery of an event to a webhook
+type WebhookDelivery struct {
+	ID            uuid.UUID  `json:"id" gorm:"type:uuid;primary_key" example:"aa22666c-0f57-45cb-a449-16efecc04f2e"`
+	CreatedAt     time.Time  `json:"created_at"`
+	WebhookID     uuid.UUID  `json:"webhook_id" gorm:"type:uuid;index"`
+	EventKind     string     `json:"event_kind" example:"device"`
+	EventType     string     `json:"event_type" example:"change"`
+	Payload       string     `json:"payload"`
+	Status        string     `json:"status" gorm:"index" example:"succeeded"` // Status is one of pending, succeeded or failed.
+	Attempts      int        `json:"attempts"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty" gorm:"index"` // NextAttemptAt is when a pending delivery is retried.
+	ResponseCode  int        `json:"response_code,omitempty"`
+	Error         string     `json:"error,omitempty"`
+	DeliveredAt   *time.Time `json:"delivered_at,omitempty"`
+}
+
+// BeforeCreate populates the ID (if not set)
+func (d *WebhookDelivery) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return nil
+}
+
+// WebhookEvent is the payload posted to a webhook
+type WebhookEvent struct {
+	WebhookID      uuid.UUID   `json:"webhook_id"`
+	OrganizationID uuid.UUID   `json:"organization_id"`
+	Kind           string      `json:"kind" example:"device"`
+	Type           string      `json:"type" example:"change"`
+	Value          interface{} `json:"value,omitempty"`
+}
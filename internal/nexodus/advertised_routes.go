@@ -0,0 +1,79 @@
+package nexodus
+
+import (
+	"sort"
+
+	"github.com/nexodus-io/nexodus/internal/util"
+	"golang.org/x/exp/slices"
+)
+
+// advertisedCidrRouters returns the device id of the peer that routes each cidr advertised by several
+// peers. The apiserver elects a primary router per cidr, which is used as long as its peering is healthy
+// or no other router of the cidr is. When the peering with the primary is down the first healthy standby
+// takes over until the apiserver elects a new primary. Cidrs advertised by a single peer, and the default
+// routes that the exit node client handles, are left out.
+// assumes deviceCacheLock is held
+func (nx *Nexodus) advertisedCidrRouters() map[string]string {
+	var entries []deviceCacheEntry
+	for _, d := range nx.deviceCache {
+		if d.device.GetPublicKey() != nx.wireguardPubKey && len(d.device.AdvertiseCidrs) > 0 {
+			entries = append(entries, d)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].device.GetId() < entries[j].device.GetId()
+	})
+
+	advertisers := map[string][]deviceCacheEntry{}
+	for _, entry := range entries {
+		for _, cidr := range entry.device.AdvertiseCidrs {
+			if !util.IsDefaultIPRoute(cidr) {
+				advertisers[cidr] = append(advertisers[cidr], entry)
+			}
+		}
+	}
+
+	routers := map[string]string{}
+	for cidr, candidates := range advertisers {
+		if len(candidates) < 2 {
+			continue
+		}
+		var primary, firstHealthy *deviceCacheEntry
+		for i := range candidates {
+			candidate := &candidates[i]
+			if primary == nil && slices.Contains(candidate.device.PrimaryCidrs, cidr) {
+				primary = candidate
+			}
+			if firstHealthy == nil && candidate.peerHealthy {
+				firstHealthy = candidate
+			}
+		}
+
+		router := primary
+		if router == nil || (!router.peerHealthy && firstHealthy != nil) {
+			router = firstHealthy
+		}
+		if router == nil {
+			// an apiserver that does not elect primary routers, or no peering is up yet
+			router = &candidates[0]
+		}
+		routers[cidr] = router.device.GetId()
+	}
+	return routers
+}
+
+// withoutStandbyCidrs returns a copy of the allowed ips without the advertised cidrs that another peer
+// routes, wireguard routes a prefix to a single peer.
+func withoutStandbyCidrs(allowedIPs []string, deviceId string, routers map[string]string) []string {
+	if len(allowedIPs) == 0 {
+		return allowedIPs
+	}
+	result := make([]string, 0, len(allowedIPs))
+	for _, allowedIP := range allowedIPs {
+		if router, ok := routers[allowedIP]; ok && router != deviceId {
+			continue
+		}
+		result = append(result, allowedIP)
+	}
+	return result
+}
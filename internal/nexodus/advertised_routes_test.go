@@ -0,0 +1,60 @@
+package nexodus
+
+import (
+	"testing"
+
+	"github.com/nexodus-io/nexodus/internal/client"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdvertisedCidrRouters(t *testing.T) {
+	require := require.New(t)
+
+	router := func(id string, healthy bool, primaryCidrs ...string) deviceCacheEntry {
+		return deviceCacheEntry{
+			device: client.ModelsDevice{
+				Id:             client.PtrString(id),
+				PublicKey:      client.PtrString(id + "-key"),
+				AllowedIps:     []string{"100.64.0.1/32"},
+				AdvertiseCidrs: []string{"172.16.42.0/24", "0.0.0.0/0"},
+				PrimaryCidrs:   primaryCidrs,
+			},
+			peerHealth: peerHealth{peerHealthy: healthy},
+		}
+	}
+	nx := &Nexodus{
+		wireguardPubKey: "self-key",
+		deviceCache: map[string]deviceCacheEntry{
+			"a-key":    router("a", false),
+			"b-key":    router("b", true, "172.16.42.0/24"),
+			"c-key":    router("c", true),
+			"self-key": router("self", true),
+		},
+	}
+	lonely := router("lonely", true)
+	lonely.device.AdvertiseCidrs = []string{"10.10.0.0/16"}
+	nx.deviceCache["lonely-key"] = lonely
+
+	// the primary routes the cidr, the default route and the cidrs with a single router are left alone
+	routers := nx.advertisedCidrRouters()
+	require.Equal(map[string]string{"172.16.42.0/24": "b"}, routers)
+	require.Equal([]string{"100.64.0.1/32", "0.0.0.0/0"}, withoutStandbyCidrs([]string{"100.64.0.1/32", "172.16.42.0/24", "0.0.0.0/0"}, "a", routers))
+	require.Equal([]string{"100.64.0.1/32", "172.16.42.0/24"}, withoutStandbyCidrs([]string{"100.64.0.1/32", "172.16.42.0/24"}, "b", routers))
+
+	// the first healthy standby takes over while the peering with the primary is down
+	b := nx.deviceCache["b-key"]
+	b.peerHealthy = false
+	nx.deviceCache["b-key"] = b
+	require.Equal(map[string]string{"172.16.42.0/24": "c"}, nx.advertisedCidrRouters())
+
+	// the primary is kept when none of the routers is healthy
+	c := nx.deviceCache["c-key"]
+	c.peerHealthy = false
+	nx.deviceCache["c-key"] = c
+	require.Equal(map[string]string{"172.16.42.0/24": "b"}, nx.advertisedCidrRouters())
+
+	// without a primary, as with an older apiserver, the first router is used
+	b.device.PrimaryCidrs = nil
+	nx.deviceCache["b-key"] = b
+	require.Equal(map[string]string{"172.16.42.0/24": "a"}, nx.advertisedCidrRouters())
+}
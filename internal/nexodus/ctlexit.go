@@ -3,9 +3,22 @@ package nexodus
 import (
 	"encoding/json"
 	"fmt"
+
+	"github.com/nexodus-io/nexodus/internal/util"
 )
 
-func (ac *NexdCtl) EnableExitNodeClient(_ string, result *string) error {
+// EnableExitNodeClient enables the exit node client, via pins it to the exit node origin with the given
+// hostname or device id, or lets it select the origin with auto. An empty via keeps the current selection.
+func (ac *NexdCtl) EnableExitNodeClient(via string, result *string) error {
+	if via != "" {
+		if via == exitNodeViaAuto {
+			via = ""
+		}
+		ac.nx.stateStore.State().ExitNodeVia = via
+		if err := ac.nx.stateStore.Store(); err != nil {
+			return fmt.Errorf("error storing the exit node selection: %w", err)
+		}
+	}
 	err := ac.nx.ExitNodeClientSetup()
 
 	enableExitNodeClientJson, err := json.Marshal(err)
@@ -20,6 +33,9 @@ func (ac *NexdCtl) EnableExitNodeClient(_ string, result *string) error {
 
 func (ac *NexdCtl) DisableExitNodeClient(_ string, result *string) error {
 	err := ac.nx.exitNodeClientTeardown()
+	// stop failing over, and give the default routes back to all the exit node origins
+	ac.nx.exitNode.exitNodeClientEnabled = false
+	ac.nx.exitNode.activeOriginId = ""
 
 	disableExitNodeClientJson, err := json.Marshal(err)
 	if err != nil {
@@ -31,36 +47,49 @@ func (ac *NexdCtl) DisableExitNodeClient(_ string, result *string) error {
 	return nil
 }
 
+// exitNodeOriginStatus is an exit node origin as listed by ListExitNodes
+type exitNodeOriginStatus struct {
+	wgPeerConfig
+	DeviceId string
+	Hostname string
+	// Active is set on the origin the exit node client is routing through
+	Active  bool
+	Healthy bool
+}
+
 // ListExitNodes lists all exit node origins
 func (ac *NexdCtl) ListExitNodes(_ string, result *string) error {
-	var allExitNodeOrigins []wgPeerConfig
-	isExitNode := false
+	var allExitNodeOrigins []exitNodeOriginStatus
+	for _, entry := range ac.nx.exitNodeOriginEntries() {
+		ac.nx.deviceCacheLock.RLock()
+		config := ac.nx.exitNodeOriginConfig(entry)
+		ac.nx.deviceCacheLock.RUnlock()
+		allExitNodeOrigins = append(allExitNodeOrigins, exitNodeOriginStatus{
+			wgPeerConfig: config,
+			DeviceId:     entry.device.GetId(),
+			Hostname:     entry.device.GetHostname(),
+			Active:       entry.device.GetId() == ac.nx.exitNode.activeOriginId,
+			Healthy:      entry.peerHealthy,
+		})
+	}
 
 	// Check if the local node is an exit node
 	for _, prefix := range ac.nx.advertiseCidrs {
-		if prefix == "0.0.0.0/0" {
-			isExitNode = true
+		if util.IsDefaultIPRoute(prefix) {
+			// Append the local node if it is an exit node
+			allExitNodeOrigins = append(allExitNodeOrigins, exitNodeOriginStatus{
+				wgPeerConfig: wgPeerConfig{
+					PublicKey: ac.nx.wireguardPubKey,
+					Endpoint:  ac.nx.nodeReflexiveAddressIPv4.String(),
+				},
+				DeviceId: ac.nx.deviceId,
+				Hostname: ac.nx.hostname,
+				Healthy:  true,
+			})
 			break
 		}
 	}
 
-	// If the local node is an exit node, create a copy and append the new instance
-	if isExitNode {
-		// Make a copy of exitNodeOrigins to a new slice
-		allExitNodeOrigins = make([]wgPeerConfig, len(ac.nx.exitNode.exitNodeOrigins))
-		copy(allExitNodeOrigins, ac.nx.exitNode.exitNodeOrigins)
-
-		// Create a new instance of wgPeerConfig
-		newPeerConfig := wgPeerConfig{
-			PublicKey: ac.nx.wireguardPubKey,
-			Endpoint:  ac.nx.nodeReflexiveAddressIPv4.String(),
-		}
-		// Append the local node if it is an exit node
-		allExitNodeOrigins = append(allExitNodeOrigins, newPeerConfig)
-	} else {
-		allExitNodeOrigins = ac.nx.exitNode.exitNodeOrigins
-	}
-
 	exitNodeOriginsJSON, err := json.Marshal(allExitNodeOrigins)
 	if err != nil {
 		return fmt.Errorf("error marshalling exit node list results: %w", err)
@@ -0,0 +1,48 @@
+package nexodus
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// SecurityGroupStats lists the packets and bytes matched by each rule of the security group of the device
+func (ac *NexdCtl) SecurityGroupStats(_ string, result *string) error {
+	stats, err := ac.nx.securityGroupRuleStats()
+	if err != nil {
+		return fmt.Errorf("error reading the security group counters: %w", err)
+	}
+
+	statsJSON, err := json.Marshal(stats)
+	if err != nil {
+		return fmt.Errorf("error marshalling the security group stats: %w", err)
+	}
+
+	*result = string(statsJSON)
+
+	return nil
+}
+
+// SecurityGroupDenied lists the flows denied by the security group of the device, after is the sequence
+// number of the last flow already read, all the logged flows are listed when it is empty
+func (ac *NexdCtl) SecurityGroupDenied(after string, result *string) error {
+	if ac.nx.deniedFlows == nil {
+		return fmt.Errorf("the denied flows are not logged, start nexd with --security-group-log-denied")
+	}
+	var seq uint64
+	if after != "" {
+		var err error
+		if seq, err = strconv.ParseUint(after, 10, 64); err != nil {
+			return fmt.Errorf("invalid sequence number %q: %w", after, err)
+		}
+	}
+
+	flowsJSON, err := json.Marshal(ac.nx.deniedFlows.since(seq))
+	if err != nil {
+		return fmt.Errorf("error marshalling the denied flows: %w", err)
+	}
+
+	*result = string(flowsJSON)
+
+	return nil
+}
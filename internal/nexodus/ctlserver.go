@@ -2,12 +2,14 @@ package nexodus
 
 import (
 	"fmt"
-
-	"github.com/bytedance/gopkg/util/logger"
+	"time"
 
 	"go.uber.org/zap"
 )
 
+// rotateKeyRequestTimeout is how long RotateKey waits for the agent to pick up the request
+const rotateKeyRequestTimeout = 30 * time.Second
+
 type NexdCtl struct {
 	nx *Nexodus
 }
@@ -21,6 +23,8 @@ func (ac *NexdCtl) Status(_ string, result *string) error {
 		statusStr = "WaitingForAuth"
 	case NexdStatusRunning:
 		statusStr = "Running"
+	case NexdStatusPendingApproval:
+		statusStr = "PendingApproval"
 	default:
 		statusStr = "Unknown"
 	}
@@ -53,8 +57,9 @@ func (ac *NexdCtl) ProxyList(_ string, result *string) error {
 	defer ac.nx.proxyLock.RUnlock()
 	for _, proxy := range ac.nx.proxies {
 		proxy.mu.RLock()
+		checked := proxy.options.healthCheckInterval > 0
 		for _, rule := range proxy.rules {
-			*result += fmt.Sprintf("%s\n", rule.AsFlag())
+			*result += fmt.Sprintf("%s\t%s\n", rule.AsFlag(), proxy.backends[rule.dest].status(checked))
 		}
 		proxy.mu.RUnlock()
 	}
@@ -65,7 +70,7 @@ func (ac *NexdCtl) proxyAdd(proxyType ProxyType, rule string, result *string) er
 
 	proxyRule, err := ParseProxyRule(rule, proxyType)
 	if err != nil {
-		logger.Fatal(fmt.Sprintf("Failed to parse %s proxy rule (%s): %v", proxyType, rule, err))
+		return fmt.Errorf("failed to parse %s proxy rule (%s): %w", proxyType, rule, err)
 	}
 	proxyRule.stored = true
 
@@ -94,7 +99,7 @@ func (ac *NexdCtl) ProxyAddEgress(rule string, result *string) error {
 func (ac *NexdCtl) proxyRemove(proxyType ProxyType, rule string, result *string) error {
 	proxyRule, err := ParseProxyRule(rule, proxyType)
 	if err != nil {
-		logger.Fatal(fmt.Sprintf("Failed to parse %s proxy rule (%s): %v", proxyType, rule, err))
+		return fmt.Errorf("failed to parse %s proxy rule (%s): %w", proxyType, rule, err)
 	}
 	proxyRule.stored = true
 
@@ -138,3 +143,17 @@ func (ac *NexdCtl) GetDebug(_ string, result *string) error {
 	}
 	return nil
 }
+
+func (ac *NexdCtl) RotateKey(_ string, result *string) error {
+	errCh := make(chan error, 1)
+	select {
+	case ac.nx.rotateKeyCh <- errCh:
+	case <-time.After(rotateKeyRequestTimeout):
+		return fmt.Errorf("nexd is not connected to a vpc yet, try again later")
+	}
+	if err := <-errCh; err != nil {
+		return err
+	}
+	*result = fmt.Sprintf("Rotated the wireguard key, the new public key is %s", ac.nx.wireguardPubKey)
+	return nil
+}
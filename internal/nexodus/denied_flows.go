@@ -0,0 +1,163 @@
+package nexodus
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nexodus-io/nexodus/internal/client"
+)
+
+const (
+	// deniedFlowLogGroup is the nflog group the security group sends the denied packets to
+	deniedFlowLogGroup = 51820
+	// deniedFlowLogRate and deniedFlowLogBurst limit the denied packets logged by each direction, a port
+	// scan or a flood must not turn into a flood of logs
+	deniedFlowLogRate  = 10
+	deniedFlowLogBurst = 20
+	// deniedFlowLogSize is the number of denied flows kept for nexctl and the reports
+	deniedFlowLogSize = 1000
+	// deniedFlowReportInterval is how often the denied flows are reported to the api server, at most
+	// deniedFlowReportMax flows per request
+	deniedFlowReportInterval = 30 * time.Second
+	deniedFlowReportMax      = 100
+)
+
+// deniedFlow is a packet dropped by the security group, as listed by SecurityGroupDenied
+type deniedFlow struct {
+	Seq         uint64
+	Time        time.Time
+	Direction   string
+	Protocol    string
+	Source      string
+	Destination string
+	Port        uint16 // the destination port of the tcp and udp packets
+}
+
+// deniedFlowLog keeps the latest denied flows, every flow is numbered so that readers can follow the log
+type deniedFlowLog struct {
+	mu    sync.Mutex
+	seq   uint64
+	flows []deniedFlow
+}
+
+func (l *deniedFlowLog) add(flow deniedFlow) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.seq++
+	flow.Seq = l.seq
+	l.flows = append(l.flows, flow)
+	if len(l.flows) > deniedFlowLogSize {
+		l.flows = l.flows[1:]
+	}
+}
+
+// since returns the flows logged after the flow numbered seq, the oldest first
+func (l *deniedFlowLog) since(seq uint64) []deniedFlow {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for i, flow := range l.flows {
+		if flow.Seq > seq {
+			return append([]deniedFlow(nil), l.flows[i:]...)
+		}
+	}
+	return nil
+}
+
+// parseDeniedFlow reads the addresses, the protocol and the destination port of a denied packet from its
+// network and transport headers
+func parseDeniedFlow(packet []byte) (deniedFlow, error) {
+	var flow deniedFlow
+	if len(packet) == 0 {
+		return flow, fmt.Errorf("empty packet")
+	}
+	var proto byte
+	var transport []byte
+	switch packet[0] >> 4 {
+	case 4:
+		headerLen := int(packet[0]&0x0f) * 4
+		if len(packet) < 20 || headerLen < 20 || len(packet) < headerLen {
+			return flow, fmt.Errorf("truncated ipv4 packet")
+		}
+		proto = packet[9]
+		flow.Source = netip.AddrFrom4([4]byte(packet[12:16])).String()
+		flow.Destination = netip.AddrFrom4([4]byte(packet[16:20])).String()
+		transport = packet[headerLen:]
+	case 6:
+		if len(packet) < 40 {
+			return flow, fmt.Errorf("truncated ipv6 packet")
+		}
+		proto = packet[6]
+		flow.Source = netip.AddrFrom16([16]byte(packet[8:24])).String()
+		flow.Destination = netip.AddrFrom16([16]byte(packet[24:40])).String()
+		transport = packet[40:]
+	default:
+		return flow, fmt.Errorf("unknown ip version %d", packet[0]>>4)
+	}
+
+	switch proto {
+	case 1:
+		flow.Protocol = protoICMP
+	case 6:
+		flow.Protocol = protoTCP
+	case 17:
+		flow.Protocol = protoUDP
+	case 58:
+		flow.Protocol = protoICMPv6
+	default:
+		flow.Protocol = strconv.Itoa(int(proto))
+	}
+	if (proto == 6 || proto == 17) && len(transport) >= 4 {
+		flow.Port = binary.BigEndian.Uint16(transport[2:4])
+	}
+	return flow, nil
+}
+
+// recordDeniedPacket logs a packet sent to the nflog group of the denied flows, the prefix of the packet is
+// the key of the drop rule that denied it
+func (nx *Nexodus) recordDeniedPacket(prefix string, packet []byte) {
+	flow, err := parseDeniedFlow(packet)
+	if err != nil {
+		nx.logger.Debugf("failed to parse a denied packet: %v", err)
+		return
+	}
+	flow.Direction, _, _ = strings.Cut(prefix, ":")
+	flow.Time = time.Now()
+	nx.deniedFlows.add(flow)
+}
+
+// reportDeniedFlows sends the flows denied since the last report to the api server, the flows that fell out
+// of the log before they could be reported are lost
+func (nx *Nexodus) reportDeniedFlows(ctx context.Context) {
+	flows := nx.deniedFlows.since(nx.deniedFlowsReported)
+	for len(flows) > 0 {
+		batch := flows[:min(len(flows), deniedFlowReportMax)]
+		flows = flows[len(batch):]
+
+		report := client.ModelsReportDeniedFlows{}
+		for _, flow := range batch {
+			deniedFlow := client.ModelsDeniedFlow{
+				Time:        client.PtrString(flow.Time.UTC().Format(time.RFC3339Nano)),
+				Direction:   client.PtrString(flow.Direction),
+				Protocol:    client.PtrString(flow.Protocol),
+				Source:      client.PtrString(flow.Source),
+				Destination: client.PtrString(flow.Destination),
+			}
+			if flow.Port != 0 {
+				deniedFlow.Port = client.PtrInt32(int32(flow.Port))
+			}
+			report.DeniedFlows = append(report.DeniedFlows, deniedFlow)
+		}
+		if _, err := nx.client.DevicesApi.ReportDeniedFlows(ctx, nx.deviceId).Flows(report).Execute(); err != nil {
+			nx.metrics.apiError("report_denied_flows")
+			nx.logger.Warnf("failed to report the denied flows: %v", err)
+			return
+		}
+		nx.deniedFlowsReported = batch[len(batch)-1].Seq
+	}
+}
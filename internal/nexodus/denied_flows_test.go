@@ -0,0 +1,97 @@
+package nexodus
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDeniedFlow(t *testing.T) {
+	ipv4 := func(proto byte, src, dst string, transport ...byte) []byte {
+		packet := make([]byte, 20)
+		packet[0] = 0x45
+		packet[9] = proto
+		copy(packet[12:16], netip.MustParseAddr(src).AsSlice())
+		copy(packet[16:20], netip.MustParseAddr(dst).AsSlice())
+		return append(packet, transport...)
+	}
+	ipv6 := func(proto byte, src, dst string, transport ...byte) []byte {
+		packet := make([]byte, 40)
+		packet[0] = 0x60
+		packet[6] = proto
+		copy(packet[8:24], netip.MustParseAddr(src).AsSlice())
+		copy(packet[24:40], netip.MustParseAddr(dst).AsSlice())
+		return append(packet, transport...)
+	}
+
+	tests := []struct {
+		name    string
+		packet  []byte
+		want    deniedFlow
+		wantErr bool
+	}{
+		{
+			name:   "ipv4 tcp",
+			packet: ipv4(6, "100.64.0.2", "100.64.0.1", 0xc0, 0x00, 0x00, 0x16),
+			want:   deniedFlow{Protocol: "tcp", Source: "100.64.0.2", Destination: "100.64.0.1", Port: 22},
+		},
+		{
+			name:   "ipv4 icmp",
+			packet: ipv4(1, "100.64.0.2", "100.64.0.1", 8, 0, 0, 0),
+			want:   deniedFlow{Protocol: "icmp", Source: "100.64.0.2", Destination: "100.64.0.1"},
+		},
+		{
+			name:   "ipv6 udp",
+			packet: ipv6(17, "200::2", "200::1", 0xc0, 0x00, 0x00, 0x35),
+			want:   deniedFlow{Protocol: "udp", Source: "200::2", Destination: "200::1", Port: 53},
+		},
+		{
+			name:   "ipv6 sctp",
+			packet: ipv6(132, "200::2", "200::1"),
+			want:   deniedFlow{Protocol: "132", Source: "200::2", Destination: "200::1"},
+		},
+		{
+			// the port is unknown when the copy of the packet ends before it
+			name:   "truncated tcp",
+			packet: ipv4(6, "100.64.0.2", "100.64.0.1", 0xc0),
+			want:   deniedFlow{Protocol: "tcp", Source: "100.64.0.2", Destination: "100.64.0.1"},
+		},
+		{name: "truncated ipv4", packet: ipv4(6, "100.64.0.2", "100.64.0.1")[:12], wantErr: true},
+		{name: "truncated ipv6", packet: ipv6(6, "200::2", "200::1")[:30], wantErr: true},
+		{name: "empty", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flow, err := parseDeniedFlow(tt.packet)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, flow)
+		})
+	}
+}
+
+func TestDeniedFlowLog(t *testing.T) {
+	require := require.New(t)
+
+	log := &deniedFlowLog{}
+	require.Empty(log.since(0))
+
+	for i := 0; i < deniedFlowLogSize+10; i++ {
+		log.add(deniedFlow{Protocol: "tcp"})
+	}
+
+	// the oldest flows are dropped once the log is full
+	flows := log.since(0)
+	require.Len(flows, deniedFlowLogSize)
+	require.Equal(uint64(11), flows[0].Seq)
+	require.Equal(uint64(deniedFlowLogSize+10), flows[len(flows)-1].Seq)
+
+	flows = log.since(deniedFlowLogSize + 8)
+	require.Len(flows, 2)
+	require.Equal(uint64(deniedFlowLogSize+9), flows[0].Seq)
+	require.Empty(log.since(deniedFlowLogSize + 10))
+}
@@ -2,19 +2,21 @@ package nexodus
 
 import (
 	"fmt"
+	"net/netip"
+
 	"github.com/nexodus-io/nexodus/internal/util"
 	"golang.zx2c4.com/wireguard/wgctrl"
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
 )
 
 const (
-	oobDNS           = 53
-	oobHttps         = 443
-	oobGoogleStun    = 19302
-	wgFwMark         = 51820
-	wgFwMarkStr      = "51820"
-	oobFwMark        = "19302"
-	oobFwdMarkHex    = "0x4B66"
+	oobDNS        = 53
+	oobHttps      = 443
+	oobGoogleStun = 19302
+	// wgFwMark is the firewall mark of the wireguard packets and the routing table of the exit node client
+	wgFwMark = 51820
+	// oobFwMark (0x4B66) is the firewall mark and the routing table of the out of band packets
+	oobFwMark        = 19302
 	nfExitNodeTable  = "nexodus-exit-node"
 	nfOobMangleTable = "nexodus-oob-mangle"
 	nfOobSnatTable   = "nexodus-oob-snat"
@@ -24,39 +26,9 @@ const (
 func (nx *Nexodus) ExitNodeClientSetup() error {
 	nx.exitNode.exitNodeClientEnabled = true
 
-	// Lock deviceCache for read
-	nx.deviceCacheLock.RLock()
-	defer nx.deviceCacheLock.RUnlock()
-
-	exitNodeFound := false
-	for _, deviceEntry := range nx.deviceCache {
-		for _, allowedIp := range deviceEntry.device.AllowedIps {
-			if util.IsDefaultIPv4Route(allowedIp) {
-				// assign the device advertising a default route as the exit node server/origin node
-				localEndpoint := ""
-				for _, endpoint := range deviceEntry.device.Endpoints {
-					if endpoint.GetSource() == "local" {
-						localEndpoint = endpoint.GetAddress()
-						break
-					}
-				}
-				nx.exitNode.exitNodeOrigins[0] = wgPeerConfig{
-					PublicKey:           deviceEntry.device.GetPublicKey(),
-					Endpoint:            localEndpoint,
-					AllowedIPs:          deviceEntry.device.AllowedIps,
-					PersistentKeepAlive: persistentKeepalive,
-				}
-				exitNodeFound = true
-				break
-			}
-		}
-		if exitNodeFound {
-			break
-		}
-	}
-
-	if len(nx.exitNode.exitNodeOrigins) == 0 {
-		return fmt.Errorf("no exit node found in this device's peerings")
+	origin, err := nx.selectExitNodeOrigin()
+	if err != nil {
+		return err
 	}
 
 	// teardown any residual nf tables or routing tables from previous runs
@@ -82,7 +54,7 @@ func (nx *Nexodus) ExitNodeClientSetup() error {
 		return fmt.Errorf("error adding exit node client fwdMark: %w", err)
 	}
 
-	if err := nx.handlePeerTunnel(nx.exitNode.exitNodeOrigins[0]); err != nil {
+	if err := nx.useExitNodeOrigin(origin); err != nil {
 		nx.logger.Debug(err)
 		return err
 	}
@@ -97,34 +69,22 @@ func (nx *Nexodus) ExitNodeClientSetup() error {
 		return err
 	}
 
-	if err := addExitSrcRuleToRPDB(); err != nil {
-		nx.logger.Debug(err)
-		return err
-	}
-
-	if err := addExitSrcRuleIgnorePrefixLength(); err != nil {
-		nx.logger.Debug(err)
-		return err
-	}
-
-	if err := addExitSrcDefaultRouteTable(); err != nil {
-		nx.logger.Debug(err)
-		nx.logger.Debugf("default route already exists in table %s", oobFwMark)
-	}
-
-	if err := nfAddExitSrcMangleTable(nx.logger); err != nil {
-		nx.logger.Debug(err)
-		return err
-	}
+	families := nx.exitNodeIPFamilies()
+	for _, family := range families {
+		if err := addExitSrcRuleToRPDB(family); err != nil {
+			nx.logger.Debug(err)
+			return err
+		}
 
-	if err := nfAddExitSrcMangleOutputChain(nx.logger); err != nil {
-		nx.logger.Debug(err)
-		return err
-	}
+		if err := addExitSrcRuleIgnorePrefixLength(family); err != nil {
+			nx.logger.Debug(err)
+			return err
+		}
 
-	if err := nfAddExitSrcDestPortMangleRule(nx.logger, "udp", oobDNS); err != nil {
-		nx.logger.Debug(err)
-		return err
+		if err := addExitSrcDefaultRouteTable(family); err != nil {
+			nx.logger.Debug(err)
+			nx.logger.Debugf("default route already exists in table %d", wgFwMark)
+		}
 	}
 
 	ips, err := ResolveURLToIP(nx.apiURL.String())
@@ -132,51 +92,47 @@ func (nx *Nexodus) ExitNodeClientSetup() error {
 		nx.logger.Debug(err)
 		return err
 	}
-
+	var apiServerAddrs []netip.Addr
 	for _, ip := range ips {
-		if err := nfAddExitSrcApiServerOOBMangleRule(nx.logger, "tcp", ip.String(), oobHttps); err != nil {
-			fmt.Printf("Error adding rule for IP %s: %v\n", ip, err)
+		if addr, ok := netip.AddrFromSlice(ip); ok {
+			apiServerAddrs = append(apiServerAddrs, addr)
 		}
 	}
 
-	if err := nfAddExitSrcDestPortMangleRule(nx.logger, "udp", oobGoogleStun); err != nil {
-		nx.logger.Debug(err)
-		return err
-	}
-
-	if err := nfAddExitSrcSnatTable(nx.logger); err != nil {
+	// the mangle and snat tables are committed in a single batch
+	if err := nfApplyTables(nx.logger, exitClientMangleTable(apiServerAddrs), exitClientSnatTable(devName)); err != nil {
 		nx.logger.Debug(err)
 		return err
 	}
 
-	if err := nfAddExitSrcSnatOutputChain(nx.logger); err != nil {
-		nx.logger.Debug(err)
-		return err
-	}
+	for _, family := range families {
+		if err := addExitSrcDefaultRouteTableOOB(family, devName); err != nil {
+			nx.logger.Debug(err)
+			nx.logger.Debugf("default route already exists in table %d", oobFwMark)
+		}
 
-	if err := nfAddExitSrcSnatOutputChain(nx.logger); err != nil {
-		nx.logger.Debug(err)
-		return err
+		if err := addExitSrcRuleFwMarkOOB(family); err != nil {
+			nx.logger.Debug(err)
+			return err
+		}
 	}
 
-	if err := nfAddExitSrcSnatRule(nx.logger, devName); err != nil {
-		nx.logger.Debug(err)
-		return err
+	nx.logger.Info("Exit node client configuration has been enabled")
+	nx.logger.Infof("Exit node client is using the exit node origin [ %s ]", origin.device.GetHostname())
+	if nx.ipv6Supported && !isExitNodeOriginIPv6(origin) {
+		nx.logger.Warnf("Exit node origin [ %s ] does not advertise an IPv6 default route, IPv6 traffic leaving the Nexodus mesh is dropped", origin.device.GetHostname())
 	}
 
-	if err := addExitSrcDefaultRouteTableOOB(devName); err != nil {
-		nx.logger.Debugf("default route already exists in table %s", oobFwMark)
-	}
+	return nil
+}
 
-	if err := addExitSrcRuleFwMarkOOB(); err != nil {
-		nx.logger.Debug(err)
-		return err
+// exitNodeIPFamilies returns the address families the exit node client routes through the exit node origin,
+// IPv6 is routed through the origin on dual-stack hosts as well so that it does not leak around it
+func (nx *Nexodus) exitNodeIPFamilies() []int {
+	if nx.ipv6Supported {
+		return []int{ipFamilyV4, ipFamilyV6}
 	}
-
-	nx.logger.Info("Exit node client configuration has been enabled")
-	nx.logger.Debugf("Exit node client enabled and using the exit node server: %+v", nx.exitNode.exitNodeOrigins[0])
-
-	return nil
+	return []int{ipFamilyV4}
 }
 
 // exitNodeOriginSetup sets up the exit node origin where traffic is originated when it exits the wireguard network
@@ -191,27 +147,14 @@ func (nx *Nexodus) exitNodeOriginSetup() error {
 		nx.logger.Debugf("failed to discover the interface with the address [ %s ] %v", nx.endpointLocalAddress, err)
 	}
 
-	if err := addExitDestinationTable(nx.logger); err != nil {
-		return err
-	}
-
-	if err := addExitOriginPreroutingChain(nx.logger); err != nil {
-		return err
-	}
-
-	if err := addExitOriginPostroutingChain(nx.logger); err != nil {
-		return err
-	}
-
-	if err := addExitOriginForwardChain(nx.logger); err != nil {
-		return err
-	}
-
-	if err := addExitOriginPostroutingRule(nx.logger, devName); err != nil {
-		return err
+	// the origin forwards the IPv6 traffic of the exit node clients as well, the masquerade rule of the inet table covers both address families
+	if nx.ipv6Supported {
+		if err := enableForwardingIPv6(); err != nil {
+			return err
+		}
 	}
 
-	if err := addExitOriginForwardRule(nx.logger); err != nil {
+	if err := nfApplyTables(nx.logger, exitOriginTable(wgIface, devName)); err != nil {
 		return err
 	}
 
@@ -226,10 +169,12 @@ func (nx *Nexodus) exitNodeClientTeardown() error {
 	// TODO: this needs to be able to be set by nexctl but not for initial pre-deploy checks
 	// nx.exitNode.exitNodeClientEnabled = false
 
-	exitNodeRouteTables := []string{wgFwMarkStr, oobFwMark}
-	for _, routeTable := range exitNodeRouteTables {
-		if err1 = flushExitSrcRouteTableOOB(routeTable); err1 != nil {
-			nx.logger.Debug(err1)
+	exitNodeRouteTables := []int{wgFwMark, oobFwMark}
+	for _, family := range nx.exitNodeIPFamilies() {
+		for _, routeTable := range exitNodeRouteTables {
+			if err1 = flushExitSrcRouteTableOOB(family, routeTable); err1 != nil {
+				nx.logger.Debug(err1)
+			}
 		}
 	}
 
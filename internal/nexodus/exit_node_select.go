@@ -0,0 +1,258 @@
+package nexodus
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nexodus-io/nexodus/internal/util"
+)
+
+const (
+	// exitNodeViaAuto selects the healthy exit node origin with the lowest latency
+	exitNodeViaAuto = "auto"
+	// exitNodeCheckInterval is how often the exit node client checks that its origin is still healthy
+	exitNodeCheckInterval = 10 * time.Second
+)
+
+// exitNodeCandidate is an exit node origin along with the result of its keepalive probe
+type exitNodeCandidate struct {
+	entry     deviceCacheEntry
+	reachable bool
+	latency   time.Duration
+}
+
+// isExitNodeOrigin returns whether the device advertises a default route
+func isExitNodeOrigin(entry deviceCacheEntry) bool {
+	for _, cidr := range entry.device.AdvertiseCidrs {
+		if util.IsDefaultIPRoute(cidr) {
+			return true
+		}
+	}
+	return false
+}
+
+// isExitNodeOriginIPv6 returns whether the device advertises the IPv6 default route, exit node origins
+// running an older version only forward IPv4
+func isExitNodeOriginIPv6(entry deviceCacheEntry) bool {
+	for _, cidr := range entry.device.AdvertiseCidrs {
+		if util.IsDefaultIPv6Route(cidr) {
+			return true
+		}
+	}
+	return false
+}
+
+// exitNodeVia returns the exit node origin selection that is persisted in the state,
+// a device hostname, a device id or auto
+func (nx *Nexodus) exitNodeVia() string {
+	via := nx.stateStore.State().ExitNodeVia
+	if via == "" {
+		return exitNodeViaAuto
+	}
+	return via
+}
+
+// exitNodeOriginEntries returns the devices in the device cache that can be used as exit node origins,
+// ordered by hostname so that the selection does not depend on the iteration order of the cache
+func (nx *Nexodus) exitNodeOriginEntries() []deviceCacheEntry {
+	var entries []deviceCacheEntry
+	nx.deviceCacheIterRead(func(d deviceCacheEntry) {
+		if d.device.GetPublicKey() != nx.wireguardPubKey && isExitNodeOrigin(d) {
+			entries = append(entries, d)
+		}
+	})
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].device.GetHostname() != entries[j].device.GetHostname() {
+			return entries[i].device.GetHostname() < entries[j].device.GetHostname()
+		}
+		return entries[i].device.GetId() < entries[j].device.GetId()
+	})
+	return entries
+}
+
+// probeExitNodeOrigins probes the tunnel addresses of the exit node origins concurrently
+func (nx *Nexodus) probeExitNodeOrigins(entries []deviceCacheEntry) []exitNodeCandidate {
+	candidates := make([]exitNodeCandidate, len(entries))
+	wg := &sync.WaitGroup{}
+	for i, entry := range entries {
+		i, entry := i, entry
+		candidates[i].entry = entry
+		if len(entry.device.Ipv4TunnelIps) == 0 {
+			continue
+		}
+		util.GoWithWaitGroup(wg, func() {
+			result, err := nx.ping(entry.device.Ipv4TunnelIps[0].GetAddress())
+			if err != nil {
+				nx.logger.Debugf("exit node origin [ %s ] is not reachable: %v", entry.device.GetHostname(), err)
+				return
+			}
+			latency, err := time.ParseDuration(result)
+			if err != nil {
+				return
+			}
+			candidates[i].reachable = true
+			candidates[i].latency = latency
+		})
+	}
+	wg.Wait()
+	return candidates
+}
+
+// bestExitNodeCandidate returns the healthy candidate with the lowest latency, falling back to the
+// reachable candidates when the wireguard sessions are not established yet, and to the first
+// candidate when none of them answers.
+func bestExitNodeCandidate(candidates []exitNodeCandidate) exitNodeCandidate {
+	best := -1
+	better := func(c exitNodeCandidate, than exitNodeCandidate) bool {
+		if c.reachable != than.reachable {
+			return c.reachable
+		}
+		if c.entry.peerHealthy != than.entry.peerHealthy {
+			return c.entry.peerHealthy
+		}
+		return c.reachable && c.latency < than.latency
+	}
+	for i, c := range candidates {
+		if best == -1 || better(c, candidates[best]) {
+			best = i
+		}
+	}
+	return candidates[best]
+}
+
+// selectExitNodeOrigin selects the exit node origin to route through, either the one the exit node
+// client was pinned to or, in auto mode, the best of the available origins.
+func (nx *Nexodus) selectExitNodeOrigin() (deviceCacheEntry, error) {
+	entries := nx.exitNodeOriginEntries()
+	if len(entries) == 0 {
+		return deviceCacheEntry{}, fmt.Errorf("no exit node found in this device's peerings")
+	}
+
+	via := nx.exitNodeVia()
+	if via != exitNodeViaAuto {
+		for _, entry := range entries {
+			if entry.device.GetId() == via || strings.EqualFold(entry.device.GetHostname(), via) {
+				return entry, nil
+			}
+		}
+		return deviceCacheEntry{}, fmt.Errorf("no exit node with the hostname or device id %s found in this device's peerings", via)
+	}
+
+	return bestExitNodeCandidate(nx.probeExitNodeOrigins(entries)).entry, nil
+}
+
+// exitNodeOriginConfig builds the peer configuration that routes the default routes to the exit node origin
+// assumes deviceCacheLock is held
+func (nx *Nexodus) exitNodeOriginConfig(entry deviceCacheEntry) wgPeerConfig {
+	localEndpoint := ""
+	for _, endpoint := range entry.device.Endpoints {
+		if endpoint.GetSource() == "local" {
+			localEndpoint = endpoint.GetAddress()
+			break
+		}
+	}
+	if config, ok := nx.wgConfig.Peers[entry.device.GetPublicKey()]; ok && config.Endpoint != "" {
+		// keep the endpoint that the peering settled on
+		localEndpoint = config.Endpoint
+	}
+	allowedIPs := append([]string{}, entry.device.AllowedIps...)
+	allowedIPs = append(allowedIPs, entry.device.AdvertiseCidrs...)
+	return wgPeerConfig{
+		PublicKey:           entry.device.GetPublicKey(),
+		Endpoint:            localEndpoint,
+		AllowedIPs:          allowedIPs,
+		PersistentKeepAlive: persistentKeepalive,
+		PresharedKey:        nx.presharedKeys[entry.device.GetId()],
+	}
+}
+
+// useExitNodeOrigin routes the default routes through the exit node origin, wireguard routes a prefix
+// to a single peer so the routes move away from the previous origin.
+func (nx *Nexodus) useExitNodeOrigin(entry deviceCacheEntry) error {
+	nx.deviceCacheLock.Lock()
+	defer nx.deviceCacheLock.Unlock()
+
+	config := nx.exitNodeOriginConfig(entry)
+	if err := nx.handlePeerTunnel(config); err != nil {
+		return err
+	}
+	nx.exitNode.activeOriginId = entry.device.GetId()
+	return nil
+}
+
+// checkExitNodeOrigin fails over to another exit node origin when the active origin is gone or
+// stopped answering. The client only fails over in auto mode, a pinned origin is kept until it
+// comes back.
+func (nx *Nexodus) checkExitNodeOrigin() {
+	if !nx.exitNode.exitNodeClientEnabled || nx.exitNode.activeOriginId == "" {
+		return
+	}
+
+	entries := nx.exitNodeOriginEntries()
+	var active *deviceCacheEntry
+	for i := range entries {
+		if entries[i].device.GetId() == nx.exitNode.activeOriginId {
+			active = &entries[i]
+			break
+		}
+	}
+
+	if nx.exitNodeVia() != exitNodeViaAuto {
+		// re-select in case the pinned origin re-registered, otherwise there is nothing to fail over to
+		if active == nil {
+			if selected, err := nx.selectExitNodeOrigin(); err == nil {
+				nx.failoverExitNodeOrigin(selected)
+			}
+		}
+		return
+	}
+
+	if active != nil && active.peerHealthy {
+		return
+	}
+	if active != nil {
+		// the wireguard session looks idle, make sure the origin really stopped answering
+		if probe := nx.probeExitNodeOrigins([]deviceCacheEntry{*active}); probe[0].reachable {
+			return
+		}
+	}
+
+	var others []deviceCacheEntry
+	for _, entry := range entries {
+		if entry.device.GetId() != nx.exitNode.activeOriginId {
+			others = append(others, entry)
+		}
+	}
+	if len(others) == 0 {
+		return
+	}
+	best := bestExitNodeCandidate(nx.probeExitNodeOrigins(others))
+	if !best.reachable {
+		nx.logger.Debug("The exit node origin is unhealthy and no other exit node origin is reachable")
+		return
+	}
+	nx.failoverExitNodeOrigin(best.entry)
+}
+
+func (nx *Nexodus) failoverExitNodeOrigin(entry deviceCacheEntry) {
+	previous := nx.exitNode.activeOriginId
+	if err := nx.useExitNodeOrigin(entry); err != nil {
+		nx.logger.Errorf("failed to fail over to the exit node origin [ %s ]: %v", entry.device.GetHostname(), err)
+		return
+	}
+	nx.logger.Infof("Exit node origin [ %s ] is unavailable, failed over to the exit node origin [ %s ]", previous, entry.device.GetHostname())
+}
+
+// withoutDefaultRoutes returns a copy of the allowed ips without the default routes
+func withoutDefaultRoutes(allowedIPs []string) []string {
+	result := make([]string, 0, len(allowedIPs))
+	for _, allowedIP := range allowedIPs {
+		if !util.IsDefaultIPRoute(allowedIP) {
+			result = append(result, allowedIP)
+		}
+	}
+	return result
+}
@@ -0,0 +1,111 @@
+package nexodus
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nexodus-io/nexodus/internal/client"
+	"github.com/nexodus-io/nexodus/internal/state/fstore"
+	"github.com/stretchr/testify/require"
+)
+
+func exitNodeTestEntry(id, hostname string, healthy bool) deviceCacheEntry {
+	return deviceCacheEntry{
+		device: client.ModelsDevice{
+			Id:             client.PtrString(id),
+			Hostname:       client.PtrString(hostname),
+			PublicKey:      client.PtrString(id + "-key"),
+			AllowedIps:     []string{"100.64.0.1/32"},
+			AdvertiseCidrs: []string{"0.0.0.0/0"},
+		},
+		peerHealth: peerHealth{peerHealthy: healthy},
+	}
+}
+
+func TestBestExitNodeCandidate(t *testing.T) {
+	require := require.New(t)
+
+	slow := exitNodeCandidate{entry: exitNodeTestEntry("a", "slow", true), reachable: true, latency: 80 * time.Millisecond}
+	fast := exitNodeCandidate{entry: exitNodeTestEntry("b", "fast", true), reachable: true, latency: 5 * time.Millisecond}
+	idle := exitNodeCandidate{entry: exitNodeTestEntry("c", "idle", false), reachable: true, latency: time.Millisecond}
+	down := exitNodeCandidate{entry: exitNodeTestEntry("d", "down", false)}
+
+	best := func(candidates ...exitNodeCandidate) string {
+		entry := bestExitNodeCandidate(candidates).entry
+		return entry.device.GetHostname()
+	}
+	require.Equal("fast", best(slow, fast, idle, down))
+	// a healthy session wins over a lower latency on an idle one
+	require.Equal("slow", best(idle, slow))
+	require.Equal("idle", best(down, idle))
+	// the first candidate is used when none of them answers
+	require.Equal("down", best(down))
+}
+
+func TestSelectPinnedExitNodeOrigin(t *testing.T) {
+	require := require.New(t)
+
+	stateStore := fstore.New(filepath.Join(t.TempDir(), "state.json"))
+	require.NoError(stateStore.Load())
+	nx := &Nexodus{
+		stateStore:      stateStore,
+		wireguardPubKey: "self-key",
+		deviceCache: map[string]deviceCacheEntry{
+			"a-key":    exitNodeTestEntry("a", "exit-a", true),
+			"b-key":    exitNodeTestEntry("b", "Exit-B", false),
+			"self-key": exitNodeTestEntry("self", "self", true),
+			"peer-key": {
+				device: client.ModelsDevice{
+					Id:             client.PtrString("peer"),
+					Hostname:       client.PtrString("peer"),
+					PublicKey:      client.PtrString("peer-key"),
+					AllowedIps:     []string{"100.64.0.9/32"},
+					AdvertiseCidrs: []string{"10.10.0.0/16"},
+				},
+			},
+		},
+	}
+
+	entries := nx.exitNodeOriginEntries()
+	require.Len(entries, 2)
+	require.Equal("Exit-B", entries[0].device.GetHostname())
+	require.Equal("exit-a", entries[1].device.GetHostname())
+
+	stateStore.State().ExitNodeVia = "exit-b"
+	selected, err := nx.selectExitNodeOrigin()
+	require.NoError(err)
+	require.Equal("b", selected.device.GetId())
+
+	stateStore.State().ExitNodeVia = "a"
+	selected, err = nx.selectExitNodeOrigin()
+	require.NoError(err)
+	require.Equal("a", selected.device.GetId())
+	require.Equal([]string{"100.64.0.1/32", "0.0.0.0/0"}, nx.exitNodeOriginConfig(selected).AllowedIPs)
+
+	stateStore.State().ExitNodeVia = "peer"
+	_, err = nx.selectExitNodeOrigin()
+	require.Error(err)
+}
+
+func TestWithoutDefaultRoutes(t *testing.T) {
+	allowedIPs := []string{"100.64.0.1/32", "0.0.0.0/0", "200::1/128", "::/0"}
+	require.Equal(t, []string{"100.64.0.1/32", "200::1/128"}, withoutDefaultRoutes(allowedIPs))
+	require.Len(t, allowedIPs, 4)
+}
+
+func TestIsExitNodeOriginIPv6(t *testing.T) {
+	require := require.New(t)
+
+	dualStack := exitNodeTestEntry("a", "dual-stack", true)
+	dualStack.device.AdvertiseCidrs = []string{"0.0.0.0/0", "::/0"}
+	require.True(isExitNodeOrigin(dualStack))
+	require.True(isExitNodeOriginIPv6(dualStack))
+
+	ipv4Only := exitNodeTestEntry("b", "ipv4-only", true)
+	require.True(isExitNodeOrigin(ipv4Only))
+	require.False(isExitNodeOriginIPv6(ipv4Only))
+
+	require.Equal("0.0.0.0/0", defaultRouteForFamily(ipFamilyV4))
+	require.Equal("::/0", defaultRouteForFamily(ipFamilyV6))
+}
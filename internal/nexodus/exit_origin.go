@@ -1,65 +1,34 @@
 package nexodus
 
-import (
-	"fmt"
-	"go.uber.org/zap"
-)
-
 // Origin netfilter and forwarding configuration
 // sysctl -w net.ipv4.ip_forward=1
-// nft add table inet nexodus-exit-node
-// nft add chain inet nexodus-exit-node prerouting '{ type nat hook prerouting priority dstnat; }'
-// nft add chain inet nexodus-exit-node postrouting '{ type nat hook postrouting priority srcnat; }'
-// nft add chain inet nexodus-exit-node forward '{ type filter hook forward priority filter; }'
-// nft add rule inet nexodus-exit-node postrouting oifname "<PHYSICAL_IFACE>" counter masquerade
-// nft add rule inet nexodus-exit-node forward iifname "wg0" counter accept
-
-func addExitDestinationTable(logger *zap.SugaredLogger) error {
-	if _, err := policyCmd(logger, []string{"add", "table", "inet", nfExitNodeTable}); err != nil {
-		return fmt.Errorf("failed to add nftables table %s: %w", nfExitNodeTable, err)
-	}
-
-	return nil
-}
-
-func addExitOriginPreroutingChain(logger *zap.SugaredLogger) error {
-	if _, err := policyCmd(logger, []string{"add", "chain", "inet", nfExitNodeTable, "prerouting", "{", "type", "nat", "hook", "prerouting", "priority", "dstnat", ";", "}"}); err != nil {
-		return fmt.Errorf("failed to add nftables chain nexodus-exit-node: %w", err)
-	}
-
-	return nil
-}
-
-func addExitOriginPostroutingChain(logger *zap.SugaredLogger) error {
-	if _, err := policyCmd(logger, []string{"add", "chain", "inet", nfExitNodeTable, "postrouting", "{", "type", "nat", "hook", "postrouting", "priority", "srcnat", ";", "}"}); err != nil {
-		return fmt.Errorf("failed to add nftables chain nexodus-exit-node: %w", err)
-	}
-
-	return nil
-}
-
-func addExitOriginForwardChain(logger *zap.SugaredLogger) error {
-	if _, err := policyCmd(logger, []string{"add", "chain", "inet", nfExitNodeTable, "forward", "{", "type", "filter", "hook", "forward", "priority", "filter", ";", "}"}); err != nil {
-		return fmt.Errorf("failed to add nftables chain nexodus-exit-node: %w", err)
-	}
-
-	return nil
-}
-
-func addExitOriginPostroutingRule(logger *zap.SugaredLogger, phyIface string) error {
-	if _, err := policyCmd(logger, []string{"add", "rule", "inet", nfExitNodeTable, "postrouting", "oifname", phyIface, "masquerade"}); err != nil {
-		return fmt.Errorf("failed to add nftables rule nexodus-exit-node: %w", err)
-	}
-
-	return nil
-}
-
-func addExitOriginForwardRule(logger *zap.SugaredLogger) error {
-	if _, err := policyCmd(logger, []string{"add", "rule", "inet", nfExitNodeTable, "forward", "iifname", wgIface, "accept"}); err != nil {
-		return fmt.Errorf("failed to add nftables rule nexodus-exit-node: %w", err)
-	}
-
-	return nil
+// sysctl -w net.ipv6.conf.all.forwarding=1
+//
+//	table inet nexodus-exit-node {
+//		chain prerouting {
+//			type nat hook prerouting priority dstnat;
+//		}
+//		chain postrouting {
+//			type nat hook postrouting priority srcnat;
+//			oifname "<PHYSICAL_IFACE>" counter masquerade
+//		}
+//		chain forward {
+//			type filter hook forward priority filter;
+//			iifname "wg0" counter accept
+//		}
+//	}
+
+// exitOriginTable builds the nftables table of the exit node origin, which forwards the traffic the exit node
+// clients send through the tunnel and masquerades it out of the physical interface
+func exitOriginTable(tunnelIface, phyIface string) *nfTable {
+	table := &nfTable{name: nfExitNodeTable}
+	table.addChain(chainPrerouting, chainTypeNAT, "", priorityDstNAT)
+	postrouting := table.addChain(chainPostrouting, chainTypeNAT, "", prioritySrcNAT)
+	forward := table.addChain(chainForward, chainTypeFilter, "", priorityFilter)
+	postrouting.add(nfMasquerade, nfIfname{output: true, name: phyIface})
+	forward.add(nfAccept, nfIfname{name: tunnelIface})
+
+	return table
 }
 
 func (nx *Nexodus) updateExitNodeOrigins(newPeer wgPeerConfig) {
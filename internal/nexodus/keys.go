@@ -1,8 +1,15 @@
 package nexodus
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"runtime"
+	"time"
 
+	"github.com/nexodus-io/nexodus/internal/client"
+	"github.com/nexodus-io/nexodus/internal/wgcrypto"
 	"go4.org/mem"
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
 	"tailscale.com/types/key"
@@ -20,6 +27,13 @@ func (nx *Nexodus) handleKeys() error {
 
 	if state.PublicKey != "" && state.PrivateKey != "" {
 		nx.logger.Debugf("Existing key pair found in [ %s ]", nx.stateStore)
+		if state.KeyCreatedAt.IsZero() {
+			// keys created before key rotation existed start their rotation interval now
+			state.KeyCreatedAt = time.Now()
+			if err := nx.stateStore.Store(); err != nil {
+				return fmt.Errorf("failed store the keys: %w", err)
+			}
+		}
 	} else {
 		nx.logger.Debugf("No existing public/private key pair found, generating a new pair")
 		wgKey, err := wgtypes.GeneratePrivateKey()
@@ -34,6 +48,7 @@ func (nx *Nexodus) handleKeys() error {
 
 		nx.logger.Debugf("Public key for relay is set to [ %s]", nx.nexRelay.privateKey.Public().WireGuardGoString())
 		state.PrivateKey = wgKey.String()
+		state.KeyCreatedAt = time.Now()
 
 		err = nx.stateStore.Store()
 		if err != nil {
@@ -46,3 +61,174 @@ func (nx *Nexodus) handleKeys() error {
 	nx.wireguardPvtKey = state.PrivateKey
 	return nil
 }
+
+// keyRotationDue returns whether the wireguard key is older than the key rotation interval
+func (nx *Nexodus) keyRotationDue() bool {
+	if nx.keyRotationInterval <= 0 {
+		return false
+	}
+	return time.Since(nx.stateStore.State().KeyCreatedAt) >= nx.keyRotationInterval
+}
+
+// reconcilePendingKey resolves a key rotation that was interrupted after its key was persisted as
+// pending. The public key the apiserver has for the device tells whether the rotation went through, in
+// which case the device switches to the pending key, or not, in which case the pending key is dropped.
+func (nx *Nexodus) reconcilePendingKey(ctx context.Context) error {
+	state := nx.stateStore.State()
+	if state.PendingPrivateKey == "" {
+		return nil
+	}
+	wgKey, err := wgtypes.ParseKey(state.PendingPrivateKey)
+	if err != nil {
+		return fmt.Errorf("failed to parse the pending key: %w", err)
+	}
+	publicKey := wgKey.PublicKey().String()
+
+	device, resp, err := nx.client.DevicesApi.GetDevice(ctx, state.PendingDeviceId).Execute()
+	if err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+		return fmt.Errorf("failed to get the device: %w", err)
+	}
+	if err == nil && device.GetPublicKey() == publicKey {
+		nx.logger.Infof("Completing the interrupted rotation of the wireguard key to [ %s ]", publicKey)
+		state.PublicKey = publicKey
+		state.PrivateKey = wgKey.String()
+		state.KeyCreatedAt = time.Now()
+	} else {
+		nx.logger.Infof("Dropping the key [ %s ] of an interrupted rotation, the apiserver did not register it", publicKey)
+	}
+	state.PendingPrivateKey = ""
+	state.PendingDeviceId = ""
+	if err := nx.stateStore.Store(); err != nil {
+		return fmt.Errorf("failed store the keys: %w", err)
+	}
+
+	nx.wireguardPubKey = state.PublicKey
+	nx.wireguardPvtKey = state.PrivateKey
+	return nil
+}
+
+// rotateKey replaces the wireguard key pair of the device. The new key is persisted as pending before
+// its public key is registered with the apiserver, so that a rotation interrupted in between is resolved
+// by reconcilePendingKey on the next start, and a failed rotation is retried with the same key.
+//
+// The local interface switches to the new private key as soon as the apiserver has the new public key.
+// Peers pick it up through the devices informer and re-key their peering to it, until then their
+// handshakes with the device fail: the tunnel to a peer with an open watch stream is down for the time
+// the device update takes to reach it plus a handshake retry (5s), the tunnel to a peer that is not
+// connected to the apiserver stays down until it reconnects.
+func (nx *Nexodus) rotateKey(ctx context.Context) error {
+	if runtime.GOOS == Windows.String() && !nx.userspaceMode {
+		return fmt.Errorf("key rotation is not supported on windows")
+	}
+	if nx.deviceId == "" {
+		return fmt.Errorf("the device has not joined a vpc yet")
+	}
+
+	state := nx.stateStore.State()
+	var wgKey wgtypes.Key
+	var err error
+	if state.PendingPrivateKey != "" && state.PendingDeviceId == nx.deviceId {
+		// the apiserver may already have the key of the previous attempt, so it is registered again
+		wgKey, err = wgtypes.ParseKey(state.PendingPrivateKey)
+		if err != nil {
+			return fmt.Errorf("failed to parse the pending key: %w", err)
+		}
+	} else {
+		wgKey, err = wgtypes.GeneratePrivateKey()
+		if err != nil {
+			return fmt.Errorf("failed to generate private key: %w", err)
+		}
+		state.PendingPrivateKey = wgKey.String()
+		state.PendingDeviceId = nx.deviceId
+		if err := nx.stateStore.Store(); err != nil {
+			return fmt.Errorf("failed store the pending key: %w", err)
+		}
+	}
+	publicKey := wgKey.PublicKey().String()
+
+	device, err := nx.registerPublicKey(ctx, publicKey)
+	if err != nil {
+		// the update may have been applied without its response making it back
+		current, _, getErr := nx.client.DevicesApi.GetDevice(ctx, nx.deviceId).Execute()
+		if getErr != nil || current.GetPublicKey() != publicKey {
+			return err
+		}
+		device = current
+	}
+
+	// the device token is now sealed to the new key, opening it proves the apiserver has the right key
+	if device.GetBearerToken() != "" {
+		sealed, err := wgcrypto.ParseSealed(device.GetBearerToken())
+		if err == nil {
+			_, err = sealed.Open(wgKey[:])
+		}
+		if err != nil {
+			nx.logger.Warnf("failed to open the device token with the new key: %v", err)
+		}
+	}
+
+	oldPublicKey := nx.wireguardPubKey
+
+	state.PublicKey = publicKey
+	state.PrivateKey = wgKey.String()
+	state.KeyCreatedAt = time.Now()
+	state.PendingPrivateKey = ""
+	state.PendingDeviceId = ""
+	if err := nx.stateStore.Store(); err != nil {
+		// the key is still pending in the stored state, the next start completes the rotation
+		nx.logger.Errorf("failed store the keys: %v", err)
+	}
+
+	nx.deviceCacheLock.Lock()
+	nx.wireguardPubKey = publicKey
+	nx.wireguardPvtKey = wgKey.String()
+	nx.wgConfig.Interface.PrivateKey = nx.wireguardPvtKey
+	if entry, ok := nx.deviceCache[oldPublicKey]; ok {
+		delete(nx.deviceCache, oldPublicKey)
+		entry.device.PublicKey = client.PtrString(publicKey)
+		nx.deviceCache[publicKey] = entry
+	}
+	err = nx.setPrivateKey(wgKey)
+	nx.deviceCacheLock.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to set the new private key on the wireguard interface: %w", err)
+	}
+
+	nr := &nx.nexRelay
+	nr.mu.Lock()
+	if !nr.privateKey.IsZero() {
+		// reconnect to the derp servers with the new key
+		nr.privateKey = key.NodePrivateFromRaw32(mem.B(wgKey[:])) //nolint:staticcheck
+		nr.closeAllDerpLocked("key-rotation")
+	}
+	nr.mu.Unlock()
+
+	nx.logger.Infof("Rotated the wireguard key of the device from [ %s ] to [ %s ]", oldPublicKey, publicKey)
+	return nil
+}
+
+// registerPublicKey updates the public key of the device on the apiserver. The revision makes the
+// apiserver refuse the new key if the device was changed by someone else in the meantime, in which
+// case the device is read again and the update retried.
+func (nx *Nexodus) registerPublicKey(ctx context.Context, publicKey string) (*client.ModelsDevice, error) {
+	for attempt := 0; ; attempt++ {
+		current, _, err := nx.client.DevicesApi.GetDevice(ctx, nx.deviceId).Execute()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the device: %w", err)
+		}
+		device, resp, err := nx.client.DevicesApi.UpdateDevice(ctx, nx.deviceId).Update(client.ModelsUpdateDevice{
+			PublicKey: client.PtrString(publicKey),
+			Revision:  client.PtrInt32(current.GetRevision()),
+		}).Execute()
+		if err == nil {
+			return device, nil
+		}
+		var apiError *client.GenericOpenAPIError
+		if attempt < maxRetries && resp != nil && resp.StatusCode == http.StatusConflict && errors.As(err, &apiError) {
+			if conflict, ok := apiError.Model().(client.ModelsConflictsError); ok && conflict.GetId() == nx.deviceId {
+				continue
+			}
+		}
+		return nil, fmt.Errorf("failed to register the new public key: %w", err)
+	}
+}
@@ -0,0 +1,342 @@
+package nexodus
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nexodus-io/nexodus/internal/client"
+	"github.com/nexodus-io/nexodus/internal/state/fstore"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.zx2c4.com/wireguard/conn"
+	"golang.zx2c4.com/wireguard/device"
+	"golang.zx2c4.com/wireguard/tun/netstack"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+const keyTestDeviceId = "2c3f6a6e-3d8b-4b1a-9f4e-0d8c2a6b7e11"
+
+// keyTestApiServer serves the device of a nexd under test, failPatch lets the updates of the device fail
+type keyTestApiServer struct {
+	mu        sync.Mutex
+	publicKey string
+	revision  int32
+	found     bool
+	// failPatch is called for each update, it applies the update first when it returns true for applied
+	failPatch func(w http.ResponseWriter) (failed bool, applied bool)
+}
+
+func (s *keyTestApiServer) device() client.ModelsDevice {
+	return client.ModelsDevice{
+		Id:        client.PtrString(keyTestDeviceId),
+		PublicKey: client.PtrString(s.publicKey),
+		Revision:  client.PtrInt32(s.revision),
+	}
+}
+
+func (s *keyTestApiServer) start(t *testing.T) string {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/devices/{id}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if !s.found || r.PathValue("id") != keyTestDeviceId {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"not found"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(s.device())
+	})
+	mux.HandleFunc("PATCH /api/devices/{id}", func(w http.ResponseWriter, r *http.Request) {
+		var update client.ModelsUpdateDevice
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		apply := func() {
+			s.publicKey = update.GetPublicKey()
+			s.revision++
+		}
+		if s.failPatch != nil {
+			if failed, applied := s.failPatch(w); failed {
+				if applied {
+					apply()
+				}
+				return
+			}
+		}
+		apply()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(s.device())
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+// newKeyTestNexodus returns a nexd in userspace mode with a wireguard device and a key pair, joined
+// to the vpc as keyTestDeviceId
+func newKeyTestNexodus(t *testing.T, apiURL string) *Nexodus {
+	require := require.New(t)
+
+	stateStore := fstore.New(filepath.Join(t.TempDir(), "state.json"))
+	require.NoError(stateStore.Load())
+	wgKey, err := wgtypes.GeneratePrivateKey()
+	require.NoError(err)
+	stateStore.State().PublicKey = wgKey.PublicKey().String()
+	stateStore.State().PrivateKey = wgKey.String()
+	require.NoError(stateStore.Store())
+
+	apiClient, err := client.NewClient(context.Background(), apiURL, nil, client.WithBearerToken("device-token"))
+	require.NoError(err)
+
+	tun, _, err := netstack.CreateNetTUN([]netip.Addr{netip.MustParseAddr("100.64.0.1")}, nil, 1420)
+	require.NoError(err)
+	dev := device.NewDevice(tun, conn.NewDefaultBind(), device.NewLogger(device.LogLevelSilent, ""))
+	t.Cleanup(dev.Close)
+	require.NoError(dev.IpcSet("private_key=" + hex.EncodeToString(wgKey[:]) + "\n"))
+
+	nx := &Nexodus{
+		logger:          zap.NewNop().Sugar(),
+		stateStore:      stateStore,
+		client:          apiClient,
+		deviceId:        keyTestDeviceId,
+		wireguardPubKey: wgKey.PublicKey().String(),
+		wireguardPvtKey: wgKey.String(),
+		wgConfig: wgConfig{
+			Interface: wgLocalConfig{PrivateKey: wgKey.String()},
+			Peers:     map[string]wgPeerConfig{},
+		},
+		deviceCache: map[string]deviceCacheEntry{
+			wgKey.PublicKey().String(): {
+				device: client.ModelsDevice{
+					Id:        client.PtrString(keyTestDeviceId),
+					PublicKey: client.PtrString(wgKey.PublicKey().String()),
+				},
+			},
+		},
+	}
+	nx.userspaceMode = true
+	nx.userspaceDev = dev
+	return nx
+}
+
+// interfacePublicKey returns the public key of the private key set on the wireguard device
+func interfacePublicKey(t *testing.T, nx *Nexodus) string {
+	config, err := nx.userspaceDev.IpcGet()
+	require.NoError(t, err)
+	for _, line := range strings.Split(config, "\n") {
+		if value, found := strings.CutPrefix(line, "private_key="); found {
+			raw, err := hex.DecodeString(value)
+			require.NoError(t, err)
+			key, err := wgtypes.NewKey(raw)
+			require.NoError(t, err)
+			return key.PublicKey().String()
+		}
+	}
+	t.Fatal("the wireguard device has no private key")
+	return ""
+}
+
+func TestRotateKey(t *testing.T) {
+	require := require.New(t)
+	api := &keyTestApiServer{found: true}
+	nx := newKeyTestNexodus(t, api.start(t))
+	oldPublicKey := nx.wireguardPubKey
+	api.publicKey = oldPublicKey
+
+	require.NoError(nx.rotateKey(context.Background()))
+
+	newPublicKey := nx.wireguardPubKey
+	require.NotEqual(oldPublicKey, newPublicKey)
+	require.Equal(newPublicKey, api.publicKey)
+	require.Equal(newPublicKey, interfacePublicKey(t, nx))
+	require.NotContains(nx.deviceCache, oldPublicKey)
+	entry := nx.deviceCache[newPublicKey]
+	require.Equal(newPublicKey, entry.device.GetPublicKey())
+
+	// the new key survives a restart
+	require.NoError(nx.stateStore.Load())
+	state := nx.stateStore.State()
+	require.Equal(newPublicKey, state.PublicKey)
+	require.Equal(nx.wireguardPvtKey, state.PrivateKey)
+	require.Empty(state.PendingPrivateKey)
+	require.False(state.KeyCreatedAt.IsZero())
+}
+
+func TestRotateKeyResponseLost(t *testing.T) {
+	require := require.New(t)
+	api := &keyTestApiServer{found: true}
+	api.failPatch = func(w http.ResponseWriter) (bool, bool) {
+		// the update is applied but the connection drops before the response is sent
+		conn, _, err := w.(http.Hijacker).Hijack()
+		require.NoError(err)
+		_ = conn.Close()
+		return true, true
+	}
+	nx := newKeyTestNexodus(t, api.start(t))
+	oldPublicKey := nx.wireguardPubKey
+	api.publicKey = oldPublicKey
+
+	// the device is read back to find out the apiserver has the new key
+	require.NoError(nx.rotateKey(context.Background()))
+	require.NotEqual(oldPublicKey, nx.wireguardPubKey)
+	require.Equal(nx.wireguardPubKey, api.publicKey)
+	require.Equal(nx.wireguardPubKey, interfacePublicKey(t, nx))
+	require.Empty(nx.stateStore.State().PendingPrivateKey)
+}
+
+func TestRotateKeyFailureKeepsPendingKey(t *testing.T) {
+	require := require.New(t)
+	api := &keyTestApiServer{found: true}
+	api.failPatch = func(w http.ResponseWriter) (bool, bool) {
+		w.WriteHeader(http.StatusInternalServerError)
+		return true, false
+	}
+	nx := newKeyTestNexodus(t, api.start(t))
+	oldPublicKey := nx.wireguardPubKey
+	api.publicKey = oldPublicKey
+
+	require.Error(nx.rotateKey(context.Background()))
+
+	// nothing changed but the pending key
+	require.Equal(oldPublicKey, nx.wireguardPubKey)
+	require.Equal(oldPublicKey, api.publicKey)
+	require.Equal(oldPublicKey, interfacePublicKey(t, nx))
+	state := nx.stateStore.State()
+	require.Equal(oldPublicKey, state.PublicKey)
+	require.NotEmpty(state.PendingPrivateKey)
+	require.Equal(keyTestDeviceId, state.PendingDeviceId)
+	pendingKey, err := wgtypes.ParseKey(state.PendingPrivateKey)
+	require.NoError(err)
+
+	// the next rotation registers the pending key
+	api.failPatch = nil
+	require.NoError(nx.rotateKey(context.Background()))
+	require.Equal(pendingKey.PublicKey().String(), nx.wireguardPubKey)
+	require.Equal(pendingKey.PublicKey().String(), api.publicKey)
+	require.Empty(nx.stateStore.State().PendingPrivateKey)
+}
+
+func TestReconcilePendingKey(t *testing.T) {
+	pendingKey, err := wgtypes.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		// registered tells whether the apiserver has the pending key, found whether it has the device
+		registered bool
+		found      bool
+	}{
+		{name: "registered", registered: true, found: true},
+		{name: "not registered", found: true},
+		{name: "device deleted"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			api := &keyTestApiServer{found: tt.found}
+			nx := newKeyTestNexodus(t, api.start(t))
+			oldPublicKey := nx.wireguardPubKey
+			api.publicKey = oldPublicKey
+			if tt.registered {
+				api.publicKey = pendingKey.PublicKey().String()
+			}
+			state := nx.stateStore.State()
+			state.PendingPrivateKey = pendingKey.String()
+			state.PendingDeviceId = keyTestDeviceId
+			require.NoError(nx.stateStore.Store())
+
+			require.NoError(nx.reconcilePendingKey(context.Background()))
+
+			want := oldPublicKey
+			if tt.registered {
+				want = pendingKey.PublicKey().String()
+			}
+			require.Equal(want, nx.wireguardPubKey)
+			require.Equal(want, state.PublicKey)
+			require.Empty(state.PendingPrivateKey)
+			require.Empty(state.PendingDeviceId)
+		})
+	}
+}
+
+func TestReconcilePendingKeyApiserverDown(t *testing.T) {
+	require := require.New(t)
+	server := httptest.NewServer(http.NotFoundHandler())
+	apiURL := server.URL
+	server.Close()
+
+	nx := newKeyTestNexodus(t, apiURL)
+	pendingKey, err := wgtypes.GeneratePrivateKey()
+	require.NoError(err)
+	nx.stateStore.State().PendingPrivateKey = pendingKey.String()
+	nx.stateStore.State().PendingDeviceId = keyTestDeviceId
+
+	// the pending key is kept until the apiserver tells whether it has it
+	require.Error(nx.reconcilePendingKey(context.Background()))
+	require.Equal(pendingKey.String(), nx.stateStore.State().PendingPrivateKey)
+}
+
+func TestHandlePeerKeyRotation(t *testing.T) {
+	require := require.New(t)
+	api := &keyTestApiServer{found: true}
+	nx := newKeyTestNexodus(t, api.start(t))
+
+	keys := map[string]string{}
+	for _, name := range []string{"rotated-old", "rotated-new", "stable", "gone"} {
+		key, err := wgtypes.GeneratePrivateKey()
+		require.NoError(err)
+		keys[name] = key.PublicKey().String()
+	}
+	peer := func(id, publicKey string) client.ModelsDevice {
+		return client.ModelsDevice{
+			Id:        client.PtrString(id),
+			Hostname:  client.PtrString(id),
+			PublicKey: client.PtrString(publicKey),
+		}
+	}
+	for id, publicKey := range map[string]string{"rotated": keys["rotated-old"], "stable": keys["stable"], "gone": keys["gone"]} {
+		nx.deviceCache[publicKey] = deviceCacheEntry{device: peer(id, publicKey)}
+		nx.wgConfig.Peers[publicKey] = wgPeerConfig{PublicKey: publicKey}
+		require.NoError(nx.userspaceDev.IpcSet(fmt.Sprintf("public_key=%s\n", hexKey(t, publicKey))))
+	}
+
+	peerMap := map[string]client.ModelsDevice{
+		"rotated": peer("rotated", keys["rotated-new"]),
+		"stable":  peer("stable", keys["stable"]),
+		// the local device is re-keyed by rotateKey, not from the peer listing
+		keyTestDeviceId: peer(keyTestDeviceId, keys["rotated-new"]),
+	}
+	nx.handlePeerKeyRotation(peerMap)
+
+	require.NotContains(nx.deviceCache, keys["rotated-old"])
+	require.NotContains(nx.wgConfig.Peers, keys["rotated-old"])
+	require.Contains(nx.deviceCache, keys["stable"])
+	require.Contains(nx.deviceCache, nx.wireguardPubKey)
+	// devices that left the vpc are cleaned up with the rest of the peers that are gone
+	require.Contains(nx.deviceCache, keys["gone"])
+
+	config, err := nx.userspaceDev.IpcGet()
+	require.NoError(err)
+	require.NotContains(config, hexKey(t, keys["rotated-old"]))
+	require.Contains(config, hexKey(t, keys["stable"]))
+}
+
+func hexKey(t *testing.T, publicKey string) string {
+	key, err := wgtypes.ParseKey(publicKey)
+	require.NoError(t, err)
+	return hex.EncodeToString(key[:])
+}
@@ -0,0 +1,325 @@
+package nexodus
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/miekg/dns"
+	"github.com/nexodus-io/nexodus/internal/dnsserver"
+)
+
+const (
+	// meshDNSDomain is the parent domain of all the VPC zones served by the mesh resolver
+	meshDNSDomain = "nexodus.internal"
+	// meshDNSPort is the port the mesh resolver listens on at the tunnel addresses
+	meshDNSPort = 53
+	// meshDNSTTL is the TTL in seconds of the records served by the mesh resolver
+	meshDNSTTL = 60
+	// resolvConfPath is the host resolver configuration used to discover the upstream resolvers
+	resolvConfPath = "/etc/resolv.conf"
+)
+
+// meshDNS is the state of the embedded resolver that serves the names of the devices in the VPC
+type meshDNS struct {
+	enabled bool
+	port    int
+	server  *dnsserver.Server
+	// the Corefile the server is currently running with
+	corefile string
+	// the resolvers that queries outside the mesh zone are forwarded to
+	upstreams []string
+	// the resolver addresses the host has been configured to send the mesh zone to
+	splitDNSServers []string
+	// the resolver running inside the netstack when in userspace mode
+	usServer *usDNSServer
+
+	// the custom records of the VPC managed through the API, keyed by the fully qualified name
+	customRecords map[string][]string
+
+	mu      sync.RWMutex
+	records map[string][]string
+}
+
+// meshDNSZone returns the zone that the devices of the VPC are published in, <vpc>.nexodus.internal
+func (nx *Nexodus) meshDNSZone() string {
+	return fmt.Sprintf("%s.%s", strings.ToLower(nx.vpc.GetId()), meshDNSDomain)
+}
+
+// meshDNSLabel converts a device hostname into a valid DNS label. Only the
+// first label of a fully qualified hostname is used.
+func meshDNSLabel(hostname string) string {
+	hostname, _, _ = strings.Cut(strings.ToLower(hostname), ".")
+	label := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' {
+			return r
+		}
+		return '-'
+	}, hostname)
+	label = strings.Trim(label, "-")
+	if len(label) > 63 {
+		label = strings.TrimRight(label[:63], "-")
+	}
+	return label
+}
+
+// meshDNSRecords returns the A and AAAA records of all devices in the device cache and the
+// custom records of the VPC keyed by the fully qualified name. A custom record replaces the
+// device record of the same name.
+// assumes deviceCacheLock is held
+func (nx *Nexodus) meshDNSRecords() map[string][]string {
+	zone := nx.meshDNSZone()
+	records := map[string][]string{}
+	for _, d := range nx.deviceCache {
+		label := meshDNSLabel(d.device.GetHostname())
+		if label == "" {
+			continue
+		}
+		name := fmt.Sprintf("%s.%s", label, zone)
+		for _, ip := range d.device.Ipv4TunnelIps {
+			if ip.GetAddress() != "" {
+				records[name] = append(records[name], ip.GetAddress())
+			}
+		}
+		for _, ip := range d.device.Ipv6TunnelIps {
+			if ip.GetAddress() != "" {
+				records[name] = append(records[name], ip.GetAddress())
+			}
+		}
+	}
+	for name, addresses := range nx.meshDNS.customRecords {
+		records[name] = addresses
+	}
+	return records
+}
+
+// lookupPeerHost resolves the name of a device in the VPC, or of a custom record of the VPC, to its first
+// tunnel address. The name may be the hostname of the device, a name relative to the mesh zone or a fully
+// qualified name in the mesh zone. Names that are not in the mesh are resolved with the system resolver.
+func (nx *Nexodus) lookupPeerHost(ctx context.Context, host string) (string, error) {
+	name := strings.ToLower(strings.TrimSuffix(host, "."))
+	zone := nx.meshDNSZone()
+
+	nx.deviceCacheLock.RLock()
+	records := nx.meshDNSRecords()
+	nx.deviceCacheLock.RUnlock()
+
+	for _, candidate := range []string{name, fmt.Sprintf("%s.%s", name, zone), fmt.Sprintf("%s.%s", meshDNSLabel(name), zone)} {
+		if addresses := records[candidate]; len(addresses) > 0 {
+			return addresses[0], nil
+		}
+	}
+	return lookupLocalHost(ctx, host)
+}
+
+// lookupLocalHost resolves a name to its first address with the system resolver
+func lookupLocalHost(ctx context.Context, host string) (string, error) {
+	addresses, err := net.DefaultResolver.LookupHost(ctx, host)
+	if err != nil {
+		return "", err
+	}
+	if len(addresses) == 0 {
+		return "", fmt.Errorf("no addresses found for %s", host)
+	}
+	return addresses[0], nil
+}
+
+// dnsRecordsChanged returns the channel that signals changes to the custom records of the VPC,
+// or nil when they are not being watched.
+func (nx *Nexodus) dnsRecordsChanged() <-chan struct{} {
+	if nx.dnsRecordsInformer == nil {
+		return nil
+	}
+	return nx.dnsRecordsInformer.Changed()
+}
+
+// reconcileDNSRecords fetches the custom records of the VPC and publishes them on the mesh resolver
+func (nx *Nexodus) reconcileDNSRecords() {
+	if nx.dnsRecordsInformer == nil {
+		return
+	}
+	defer nx.metrics.reconcileTimer("dns_records")()
+
+	dnsRecords, _, err := nx.dnsRecordsInformer.Execute()
+	if err != nil {
+		nx.metrics.apiError("list_dns_records")
+		nx.logger.Errorf("Error retrieving the DNS records: %v", err)
+		return
+	}
+
+	zone := nx.meshDNSZone()
+	customRecords := map[string][]string{}
+	for _, r := range dnsRecords {
+		name := fmt.Sprintf("%s.%s", strings.ToLower(r.GetName()), zone)
+		customRecords[name] = append(customRecords[name], r.GetAddresses()...)
+	}
+
+	nx.deviceCacheLock.Lock()
+	defer nx.deviceCacheLock.Unlock()
+	nx.meshDNS.customRecords = customRecords
+	if err := nx.reconcileMeshDNS(); err != nil {
+		nx.logger.Error(err)
+	}
+}
+
+// buildMeshDNSCorefile renders the CoreDNS configuration of the mesh resolver. Names in the
+// zone are answered from records, everything else is forwarded to the upstream resolvers.
+// The zone is served with templates rather than the hosts plugin, which answers unknown
+// names with SERVFAIL: records get a template per address family, known names queried
+// for other types get an empty answer, and any other name in the zone gets NXDOMAIN.
+func buildMeshDNSCorefile(bind []string, port int, zone string, records map[string][]string, upstreams []string) string {
+	names := make([]string, 0, len(records))
+	for name := range records {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, ".:%d {\n", port)
+	fmt.Fprintf(sb, "    bind %s\n", strings.Join(bind, " "))
+	var matches []string
+	for _, name := range names {
+		match := "^" + regexp.QuoteMeta(name+".") + "$"
+		matches = append(matches, match)
+		ips := append([]string{}, records[name]...)
+		sort.Strings(ips)
+		for _, qtype := range []string{"A", "AAAA"} {
+			var answers []string
+			for _, ip := range ips {
+				if isIPv4 := net.ParseIP(ip).To4() != nil; isIPv4 == (qtype == "A") {
+					answers = append(answers, fmt.Sprintf("%s. %d IN %s %s", name, meshDNSTTL, qtype, ip))
+				}
+			}
+			if len(answers) == 0 {
+				continue
+			}
+			fmt.Fprintf(sb, "    template IN %s %s {\n", qtype, zone)
+			fmt.Fprintf(sb, "        match %s\n", match)
+			for _, answer := range answers {
+				fmt.Fprintf(sb, "        answer \"%s\"\n", answer)
+			}
+			fmt.Fprintf(sb, "        fallthrough\n")
+			fmt.Fprintf(sb, "    }\n")
+		}
+	}
+	if len(matches) > 0 {
+		fmt.Fprintf(sb, "    template ANY ANY %s {\n", zone)
+		fmt.Fprintf(sb, "        match %s\n", strings.Join(matches, " "))
+		fmt.Fprintf(sb, "        fallthrough\n")
+		fmt.Fprintf(sb, "    }\n")
+	}
+	fmt.Fprintf(sb, "    template ANY ANY %s {\n", zone)
+	fmt.Fprintf(sb, "        rcode NXDOMAIN\n")
+	fmt.Fprintf(sb, "    }\n")
+	if len(upstreams) > 0 {
+		fmt.Fprintf(sb, "    forward . %s\n", strings.Join(upstreams, " "))
+	}
+	fmt.Fprintf(sb, "}\n")
+	return sb.String()
+}
+
+// hostUpstreamResolvers returns the nameservers from the host resolver configuration,
+// skipping any of the addresses the mesh resolver listens on.
+func hostUpstreamResolvers(exclude ...string) ([]string, error) {
+	config, err := dns.ClientConfigFromFile(resolvConfPath)
+	if err != nil {
+		return nil, err
+	}
+	var upstreams []string
+	for _, server := range config.Servers {
+		skip := false
+		for _, e := range exclude {
+			if server == e {
+				skip = true
+				break
+			}
+		}
+		if !skip {
+			upstreams = append(upstreams, net.JoinHostPort(server, config.Port))
+		}
+	}
+	return upstreams, nil
+}
+
+// meshDNSServers returns the tunnel addresses the mesh resolver listens on
+func (nx *Nexodus) meshDNSServers() []string {
+	servers := []string{nx.TunnelIP}
+	if nx.ipv6Supported && nx.TunnelIpV6 != "" {
+		servers = append(servers, nx.TunnelIpV6)
+	}
+	return servers
+}
+
+// reconcileMeshDNS regenerates the mesh resolver configuration from the device cache and
+// starts or restarts the resolver when it changed.
+// assumes deviceCacheLock is held
+func (nx *Nexodus) reconcileMeshDNS() error {
+	if !nx.meshDNS.enabled || nx.TunnelIP == "" {
+		return nil
+	}
+
+	servers := nx.meshDNSServers()
+	if nx.meshDNS.upstreams == nil {
+		upstreams, err := hostUpstreamResolvers(servers...)
+		if err != nil {
+			nx.logger.Warnf("unable to determine the upstream DNS resolvers, only mesh names will be resolved: %v", err)
+		}
+		nx.meshDNS.upstreams = upstreams
+	}
+
+	records := nx.meshDNSRecords()
+	nx.meshDNS.mu.Lock()
+	nx.meshDNS.records = records
+	nx.meshDNS.mu.Unlock()
+
+	if nx.userspaceMode {
+		// the tunnel addresses only exist inside of the netstack
+		return nx.reconcileMeshDNSUS()
+	}
+
+	corefile := buildMeshDNSCorefile(servers, nx.meshDNS.port, nx.meshDNSZone(), records, nx.meshDNS.upstreams)
+	if corefile != nx.meshDNS.corefile {
+		if nx.meshDNS.server == nil {
+			server, err := dnsserver.Start(nx.nexCtx, nx.nexWg, corefile)
+			if err != nil {
+				return fmt.Errorf("failed to start the mesh DNS resolver: %w", err)
+			}
+			nx.meshDNS.server = server
+			nx.logger.Infof("Mesh DNS resolver serving zone %s on %s", nx.meshDNSZone(), strings.Join(servers, ", "))
+		} else {
+			if err := nx.meshDNS.server.Restart(corefile); err != nil {
+				return fmt.Errorf("failed to restart the mesh DNS resolver: %w", err)
+			}
+			nx.logger.Debugf("Mesh DNS resolver configuration updated")
+		}
+		nx.meshDNS.corefile = corefile
+	}
+
+	if strings.Join(servers, ",") != strings.Join(nx.meshDNS.splitDNSServers, ",") {
+		if err := nx.setupSplitDNS(nx.meshDNSZone(), servers); err != nil {
+			return fmt.Errorf("failed to configure split DNS for %s: %w", nx.meshDNSZone(), err)
+		}
+		nx.meshDNS.splitDNSServers = servers
+		nx.logger.Infof("Host resolver configured to send %s queries to the mesh DNS resolver", nx.meshDNSZone())
+	}
+	return nil
+}
+
+// meshDNSStop removes the split DNS configuration of the host resolver
+func (nx *Nexodus) meshDNSStop() {
+	if nx.meshDNS.usServer != nil {
+		nx.meshDNS.usServer.Stop()
+		nx.meshDNS.usServer = nil
+	}
+	if len(nx.meshDNS.splitDNSServers) == 0 {
+		return
+	}
+	if err := nx.teardownSplitDNS(nx.meshDNSZone()); err != nil {
+		nx.logger.Errorf("failed to remove the split DNS configuration: %v", err)
+	}
+	nx.meshDNS.splitDNSServers = nil
+}
@@ -0,0 +1,52 @@
+//go:build darwin
+
+package nexodus
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// darwinResolverDir holds the per domain resolver configuration on macOS, see resolver(5)
+const darwinResolverDir = "/etc/resolver"
+
+// setupSplitDNS configures the host resolver to send queries for the zone to the mesh resolver
+func (nx *Nexodus) setupSplitDNS(zone string, servers []string) error {
+	if err := os.MkdirAll(darwinResolverDir, 0755); err != nil {
+		return err
+	}
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "# Generated by nexd\n")
+	for _, server := range servers {
+		fmt.Fprintf(sb, "nameserver %s\n", server)
+	}
+	fmt.Fprintf(sb, "port %d\n", meshDNSPort)
+	return os.WriteFile(filepath.Join(darwinResolverDir, zone), []byte(sb.String()), 0644)
+}
+
+// teardownSplitDNS reverts the changes made by setupSplitDNS
+func (nx *Nexodus) teardownSplitDNS(zone string) error {
+	err := os.Remove(filepath.Join(darwinResolverDir, zone))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// recoverSplitDNS removes the resolver configuration of mesh zones left behind by a nexd that did
+// not shut down cleanly.
+func (nx *Nexodus) recoverSplitDNS() error {
+	files, err := filepath.Glob(filepath.Join(darwinResolverDir, "*."+meshDNSDomain))
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		nx.logger.Infof("Removing %s left behind by a previous run", file)
+		if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
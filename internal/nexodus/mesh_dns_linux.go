@@ -0,0 +1,108 @@
+//go:build linux
+
+package nexodus
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// resolvConfBackupPath holds the original resolv.conf while nexd has modified it
+const resolvConfBackupPath = "/etc/resolv.conf.nexodus"
+
+// setupSplitDNS configures the host resolver to send queries for the zone to the mesh resolver.
+// systemd-resolved is configured per link through resolvectl, otherwise the mesh resolver is
+// added as the first nameserver in resolv.conf and forwards everything else upstream. resolv.conf
+// can not scope a nameserver to a domain, so in that case the mesh resolver becomes the global
+// resolver of the host: all lookups wait for the glibc timeout (5s by default) whenever it is not
+// reachable, and a third nameserver of the original configuration is no longer used.
+func (nx *Nexodus) setupSplitDNS(zone string, servers []string) error {
+	if systemdResolvedActive() {
+		args := append([]string{"resolvectl", "dns", nx.tunnelIface}, servers...)
+		if _, err := RunCommand(args...); err != nil {
+			return err
+		}
+		if _, err := RunCommand("resolvectl", "domain", nx.tunnelIface, "~"+zone); err != nil {
+			return err
+		}
+		return nil
+	}
+	nx.logger.Warnf("systemd-resolved is not active, %s is added as the first nameserver in %s for all lookups, "+
+		"name resolution on this host is degraded while the mesh DNS resolver is unavailable", servers[0], resolvConfPath)
+	return setupResolvConf(zone, servers)
+}
+
+// teardownSplitDNS reverts the changes made by setupSplitDNS
+func (nx *Nexodus) teardownSplitDNS(zone string) error {
+	if systemdResolvedActive() {
+		if !linkExists(nx.tunnelIface) {
+			return nil
+		}
+		_, err := RunCommand("resolvectl", "revert", nx.tunnelIface)
+		return err
+	}
+	return restoreResolvConf()
+}
+
+// recoverSplitDNS restores the resolv.conf backup left behind by a nexd that did not shut down
+// cleanly, so that the host does not keep using a mesh resolver that is no longer running.
+func (nx *Nexodus) recoverSplitDNS() error {
+	if _, err := os.Stat(resolvConfBackupPath); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	nx.logger.Infof("Restoring %s from %s left behind by a previous run", resolvConfPath, resolvConfBackupPath)
+	return restoreResolvConf()
+}
+
+func systemdResolvedActive() bool {
+	if !IsCommandAvailable("resolvectl") {
+		return false
+	}
+	if _, err := os.Stat("/run/systemd/resolve"); err != nil {
+		return false
+	}
+	_, err := RunCommand("resolvectl", "status")
+	return err == nil
+}
+
+func setupResolvConf(zone string, servers []string) error {
+	original, err := os.ReadFile(resolvConfBackupPath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return err
+		}
+		original, err = os.ReadFile(resolvConfPath)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(resolvConfBackupPath, original, 0644); err != nil {
+			return fmt.Errorf("failed to back up %s: %w", resolvConfPath, err)
+		}
+	}
+
+	// glibc only uses the first three nameservers, so just the IPv4 mesh resolver is added and
+	// a third nameserver of the original configuration is ignored
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "# Generated by nexd for the %s zone, the original configuration is in %s\n", zone, resolvConfBackupPath)
+	fmt.Fprintf(sb, "nameserver %s\n", servers[0])
+	sb.Write(original)
+	return os.WriteFile(resolvConfPath, []byte(sb.String()), 0644)
+}
+
+func restoreResolvConf() error {
+	original, err := os.ReadFile(resolvConfBackupPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if err := os.WriteFile(resolvConfPath, original, 0644); err != nil {
+		return err
+	}
+	return os.Remove(resolvConfBackupPath)
+}
@@ -0,0 +1,226 @@
+package nexodus
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/nexodus-io/nexodus/internal/client"
+	"github.com/nexodus-io/nexodus/internal/dnsserver"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestMeshDNSLabel(t *testing.T) {
+	testCases := map[string]string{
+		"myhost":                "myhost",
+		"MyHost.example.com":    "myhost",
+		"my_host":               "my-host",
+		"-edge-":                "edge",
+		"":                      "",
+		"host with spaces.corp": "host-with-spaces",
+	}
+	for hostname, expected := range testCases {
+		require.Equal(t, expected, meshDNSLabel(hostname), hostname)
+	}
+}
+
+func TestMeshDNSResolver(t *testing.T) {
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	nx := &Nexodus{
+		vpc: &client.ModelsVPC{
+			Id: client.PtrString("694aa002-5d19-495e-980b-3d8fd508ea10"),
+		},
+		deviceCache: map[string]deviceCacheEntry{
+			"key1": {
+				device: client.ModelsDevice{
+					Hostname:      client.PtrString("Alpha.example.com"),
+					Ipv4TunnelIps: []client.ModelsTunnelIP{{Address: client.PtrString("100.64.0.1")}},
+					Ipv6TunnelIps: []client.ModelsTunnelIP{{Address: client.PtrString("200::1")}},
+				},
+			},
+			"key2": {
+				device: client.ModelsDevice{
+					Hostname:      client.PtrString("beta"),
+					Ipv4TunnelIps: []client.ModelsTunnelIP{{Address: client.PtrString("100.64.0.2")}},
+				},
+			},
+		},
+	}
+
+	zone := nx.meshDNSZone()
+	require.Equal("694aa002-5d19-495e-980b-3d8fd508ea10.nexodus.internal", zone)
+	nx.meshDNS.customRecords = map[string][]string{
+		"db.prod." + zone: {"100.64.0.2", "100.64.0.3"},
+	}
+
+	records := nx.meshDNSRecords()
+	require.Equal(map[string][]string{
+		"alpha." + zone:   {"100.64.0.1", "200::1"},
+		"beta." + zone:    {"100.64.0.2"},
+		"db.prod." + zone: {"100.64.0.2", "100.64.0.3"},
+	}, records)
+
+	corefile := buildMeshDNSCorefile([]string{"127.0.0.1"}, 0, zone, records, nil)
+	require.Contains(corefile, "template IN A "+zone+" {")
+	require.NotContains(corefile, "forward")
+	require.Contains(buildMeshDNSCorefile([]string{"127.0.0.1"}, 0, zone, records, []string{"8.8.8.8:53"}), "forward . 8.8.8.8:53")
+
+	server, err := dnsserver.Start(ctx, nil, corefile)
+	require.NoError(err)
+	listenAddr, _, err := server.Ports()
+	require.NoError(err)
+
+	d := &dns.Client{
+		Timeout: 5 * time.Second,
+	}
+
+	m := &dns.Msg{}
+	m.SetQuestion("alpha."+zone+".", dns.TypeA)
+	resp, _, err := d.Exchange(m, listenAddr.String())
+	require.NoError(err)
+	require.Equal(dns.RcodeSuccess, resp.Rcode)
+	require.Equal(1, len(resp.Answer))
+	require.Equal("alpha."+zone+".\t60\tIN\tA\t100.64.0.1", resp.Answer[0].String())
+
+	m = &dns.Msg{}
+	m.SetQuestion("alpha."+zone+".", dns.TypeAAAA)
+	resp, _, err = d.Exchange(m, listenAddr.String())
+	require.NoError(err)
+	require.Equal(dns.RcodeSuccess, resp.Rcode)
+	require.Equal(1, len(resp.Answer))
+	require.Equal("alpha."+zone+".\t60\tIN\tAAAA\t200::1", resp.Answer[0].String())
+
+	m = &dns.Msg{}
+	m.SetQuestion("db.prod."+zone+".", dns.TypeA)
+	resp, _, err = d.Exchange(m, listenAddr.String())
+	require.NoError(err)
+	require.Equal(dns.RcodeSuccess, resp.Rcode)
+	require.Equal(2, len(resp.Answer))
+
+	// known names without records of the queried type get an empty answer
+	m = &dns.Msg{}
+	m.SetQuestion("beta."+zone+".", dns.TypeAAAA)
+	resp, _, err = d.Exchange(m, listenAddr.String())
+	require.NoError(err)
+	require.Equal(dns.RcodeSuccess, resp.Rcode)
+	require.Equal(0, len(resp.Answer))
+
+	// unknown names in the mesh zone are not forwarded upstream, they do not exist
+	m = &dns.Msg{}
+	m.SetQuestion("gamma."+zone+".", dns.TypeA)
+	resp, _, err = d.Exchange(m, listenAddr.String())
+	require.NoError(err)
+	require.Equal(dns.RcodeNameError, resp.Rcode)
+}
+
+func TestMeshDNSUserspaceResolver(t *testing.T) {
+	require := require.New(t)
+	zLogger, _ := zap.NewDevelopment()
+
+	zone := "694aa002-5d19-495e-980b-3d8fd508ea10.nexodus.internal"
+	md := &meshDNS{
+		records: map[string][]string{
+			"alpha." + zone: {"100.64.0.1", "200::1"},
+		},
+	}
+
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(err)
+	s := &usDNSServer{
+		logger:  zLogger.Sugar(),
+		meshDNS: md,
+		zone:    dns.Fqdn(zone),
+	}
+	s.server = &dns.Server{PacketConn: pc, Handler: s}
+	go func() {
+		_ = s.server.ActivateAndServe()
+	}()
+	defer s.Stop()
+
+	d := &dns.Client{
+		Timeout: 5 * time.Second,
+	}
+
+	m := &dns.Msg{}
+	m.SetQuestion("Alpha."+zone+".", dns.TypeA)
+	resp, _, err := d.Exchange(m, pc.LocalAddr().String())
+	require.NoError(err)
+	require.Equal(dns.RcodeSuccess, resp.Rcode)
+	require.Equal(1, len(resp.Answer))
+	require.Equal("Alpha."+zone+".\t60\tIN\tA\t100.64.0.1", resp.Answer[0].String())
+
+	m = &dns.Msg{}
+	m.SetQuestion("alpha."+zone+".", dns.TypeAAAA)
+	resp, _, err = d.Exchange(m, pc.LocalAddr().String())
+	require.NoError(err)
+	require.Equal(1, len(resp.Answer))
+	require.Equal("alpha."+zone+".\t60\tIN\tAAAA\t200::1", resp.Answer[0].String())
+
+	m = &dns.Msg{}
+	m.SetQuestion("gamma."+zone+".", dns.TypeA)
+	resp, _, err = d.Exchange(m, pc.LocalAddr().String())
+	require.NoError(err)
+	require.Equal(dns.RcodeNameError, resp.Rcode)
+
+	// names outside the zone fail when there are no upstream resolvers
+	m = &dns.Msg{}
+	m.SetQuestion("example.com.", dns.TypeA)
+	resp, _, err = d.Exchange(m, pc.LocalAddr().String())
+	require.NoError(err)
+	require.Equal(dns.RcodeServerFailure, resp.Rcode)
+}
+
+func TestLookupPeerHost(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	nx := &Nexodus{
+		vpc: &client.ModelsVPC{
+			Id: client.PtrString("694aa002-5d19-495e-980b-3d8fd508ea10"),
+		},
+		deviceCache: map[string]deviceCacheEntry{
+			"key1": {
+				device: client.ModelsDevice{
+					Hostname:      client.PtrString("Alpha.example.com"),
+					Ipv4TunnelIps: []client.ModelsTunnelIP{{Address: client.PtrString("100.64.0.1")}},
+				},
+			},
+			"key2": {
+				device: client.ModelsDevice{
+					Hostname:      client.PtrString("my_host"),
+					Ipv6TunnelIps: []client.ModelsTunnelIP{{Address: client.PtrString("200::2")}},
+				},
+			},
+		},
+	}
+	zone := nx.meshDNSZone()
+	nx.meshDNS.customRecords = map[string][]string{
+		"db.prod." + zone: {"100.64.0.3"},
+	}
+
+	testCases := map[string]string{
+		"alpha":               "100.64.0.1",
+		"Alpha.example.com":   "100.64.0.1",
+		"alpha." + zone + ".": "100.64.0.1",
+		"my_host":             "200::2",
+		"db.prod":             "100.64.0.3",
+	}
+	for host, expected := range testCases {
+		address, err := nx.lookupPeerHost(ctx, host)
+		require.NoError(err, host)
+		require.Equal(expected, address, host)
+	}
+
+	// names outside the mesh fall back to the system resolver
+	address, err := nx.lookupPeerHost(ctx, "localhost")
+	require.NoError(err)
+	require.Contains([]string{"127.0.0.1", "::1"}, address)
+	_, err = nx.lookupPeerHost(ctx, "gamma.invalid")
+	require.Error(err)
+}
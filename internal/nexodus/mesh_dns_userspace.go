@@ -0,0 +1,138 @@
+package nexodus
+
+import (
+	"net"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/nexodus-io/nexodus/internal/util"
+	"go.uber.org/zap"
+)
+
+// fallback resolver used in userspace mode when the host resolvers are unknown
+const usDNSFallbackUpstream = "8.8.8.8:53"
+
+// usDNSServer is the mesh resolver used in userspace mode. It listens on the tunnel address
+// inside the netstack so that peers and the userspace proxies can resolve mesh names.
+type usDNSServer struct {
+	logger    *zap.SugaredLogger
+	meshDNS   *meshDNS
+	address   string
+	zone      string
+	upstreams []string
+	server    *dns.Server
+}
+
+// reconcileMeshDNSUS starts the netstack resolver, or moves it when the tunnel address changed.
+func (nx *Nexodus) reconcileMeshDNSUS() error {
+	if nx.userspaceNet == nil {
+		return nil
+	}
+	if nx.meshDNS.usServer != nil {
+		if nx.meshDNS.usServer.address == nx.TunnelIP {
+			return nil
+		}
+		nx.meshDNS.usServer.Stop()
+		nx.meshDNS.usServer = nil
+	}
+
+	addr, err := netip.ParseAddr(nx.TunnelIP)
+	if err != nil {
+		return err
+	}
+	pc, err := nx.userspaceNet.ListenUDPAddrPort(netip.AddrPortFrom(addr, uint16(nx.meshDNS.port)))
+	if err != nil {
+		return err
+	}
+
+	upstreams := nx.meshDNS.upstreams
+	if len(upstreams) == 0 {
+		upstreams = []string{usDNSFallbackUpstream}
+	}
+	s := &usDNSServer{
+		logger:    nx.logger,
+		meshDNS:   &nx.meshDNS,
+		address:   nx.TunnelIP,
+		zone:      dns.Fqdn(nx.meshDNSZone()),
+		upstreams: upstreams,
+	}
+	s.server = &dns.Server{
+		PacketConn: pc,
+		Handler:    s,
+	}
+	util.GoWithWaitGroup(nx.nexWg, func() {
+		if err := s.server.ActivateAndServe(); err != nil {
+			s.logger.Debugf("userspace mesh DNS resolver stopped: %v", err)
+		}
+	})
+	nx.meshDNS.usServer = s
+	nx.logger.Infof("Mesh DNS resolver serving zone %s on %s inside the userspace network", nx.meshDNSZone(), nx.TunnelIP)
+	return nil
+}
+
+func (s *usDNSServer) Stop() {
+	if err := s.server.Shutdown(); err != nil {
+		s.logger.Debugf("failed to stop the userspace mesh DNS resolver: %v", err)
+	}
+}
+
+// ServeDNS answers queries for the mesh zone from the device records and forwards everything else.
+func (s *usDNSServer) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
+	if len(r.Question) != 1 {
+		m := &dns.Msg{}
+		m.SetRcode(r, dns.RcodeFormatError)
+		_ = w.WriteMsg(m)
+		return
+	}
+
+	q := r.Question[0]
+	if !dns.IsSubDomain(s.zone, strings.ToLower(q.Name)) {
+		_ = w.WriteMsg(s.forward(r))
+		return
+	}
+
+	name := strings.TrimSuffix(strings.ToLower(q.Name), ".")
+	s.meshDNS.mu.RLock()
+	ips, found := s.meshDNS.records[name]
+	s.meshDNS.mu.RUnlock()
+
+	m := &dns.Msg{}
+	m.SetReply(r)
+	m.Authoritative = true
+	if !found {
+		m.Rcode = dns.RcodeNameError
+		_ = w.WriteMsg(m)
+		return
+	}
+	hdr := dns.RR_Header{Name: q.Name, Class: dns.ClassINET, Ttl: meshDNSTTL}
+	for _, ip := range ips {
+		parsed := net.ParseIP(ip)
+		switch {
+		case q.Qtype == dns.TypeA && parsed.To4() != nil:
+			hdr.Rrtype = dns.TypeA
+			m.Answer = append(m.Answer, &dns.A{Hdr: hdr, A: parsed.To4()})
+		case q.Qtype == dns.TypeAAAA && parsed.To4() == nil:
+			hdr.Rrtype = dns.TypeAAAA
+			m.Answer = append(m.Answer, &dns.AAAA{Hdr: hdr, AAAA: parsed})
+		}
+	}
+	_ = w.WriteMsg(m)
+}
+
+// forward sends the query to the upstream resolvers in order, returning the first answer
+func (s *usDNSServer) forward(r *dns.Msg) *dns.Msg {
+	c := &dns.Client{Timeout: 5 * time.Second}
+	for _, upstream := range s.upstreams {
+		resp, _, err := c.Exchange(r, upstream)
+		if err != nil {
+			s.logger.Debugf("mesh DNS forward to %s failed: %v", upstream, err)
+			continue
+		}
+		return resp
+	}
+	m := &dns.Msg{}
+	m.SetRcode(r, dns.RcodeServerFailure)
+	return m
+}
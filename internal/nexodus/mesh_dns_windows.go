@@ -0,0 +1,19 @@
+//go:build windows
+
+package nexodus
+
+// setupSplitDNS for windows build purposes, split DNS is currently unsupported on windows
+func (nx *Nexodus) setupSplitDNS(zone string, servers []string) error {
+	nx.logger.Infof("Split DNS is currently not supported on Windows, use %v to resolve names in %s", servers, zone)
+	return nil
+}
+
+// teardownSplitDNS for windows build purposes
+func (nx *Nexodus) teardownSplitDNS(zone string) error {
+	return nil
+}
+
+// recoverSplitDNS for windows build purposes
+func (nx *Nexodus) recoverSplitDNS() error {
+	return nil
+}
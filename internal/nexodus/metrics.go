@@ -0,0 +1,236 @@
+package nexodus
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/nexodus-io/nexodus/internal/util"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const metricsNamespace = "nexd"
+
+var (
+	peerLabels = []string{"public_key", "hostname"}
+
+	peerReceiveBytesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "peer", "receive_bytes_total"),
+		"Bytes received from the peer over the wireguard tunnel.",
+		peerLabels, nil)
+	peerTransmitBytesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "peer", "transmit_bytes_total"),
+		"Bytes sent to the peer over the wireguard tunnel.",
+		peerLabels, nil)
+	peerLastHandshakeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "peer", "last_handshake_age_seconds"),
+		"Seconds since the last wireguard handshake with the peer.",
+		peerLabels, nil)
+	peerHealthyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "peer", "healthy"),
+		"Whether the connection to the peer is healthy (1) or not (0).",
+		peerLabels, nil)
+	peerPeeringMethodDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "peer", "peering_method"),
+		"The method used to connect to the peer, always 1.",
+		append(peerLabels, "method"), nil)
+	derpActiveConnectionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "derp", "active_connections"),
+		"Connections open to DERP relay regions.",
+		nil, nil)
+
+	proxyLabels = []string{"type", "protocol", "port"}
+
+	proxyActiveConnectionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "proxy", "active_connections"),
+		"Connections currently handled by the userspace proxy.",
+		proxyLabels, nil)
+	proxyConnectionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "proxy", "connections_total"),
+		"Connections handled by the userspace proxy.",
+		proxyLabels, nil)
+	proxyConnectionErrorsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "proxy", "connection_errors_total"),
+		"Connections the userspace proxy failed to open to a destination.",
+		proxyLabels, nil)
+
+	securityRuleLabels = []string{"direction", "rule"}
+
+	securityRulePacketsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "security_group", "rule_packets_total"),
+		"Packets matched by the security group rule, by the index of the rule or established and drop for the implicit rules.",
+		securityRuleLabels, nil)
+	securityRuleBytesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "security_group", "rule_bytes_total"),
+		"Bytes matched by the security group rule, by the index of the rule or established and drop for the implicit rules.",
+		securityRuleLabels, nil)
+)
+
+// nexdMetrics holds the metrics that nexd records as events happen. The state it already tracks,
+// like the peer health, is read when the metrics are scraped, see Nexodus.Collect. The methods
+// are safe to call on a nil *nexdMetrics.
+type nexdMetrics struct {
+	registry             *prometheus.Registry
+	peeringMethodChanges *prometheus.CounterVec
+	reconcileDuration    *prometheus.HistogramVec
+	apiErrors            *prometheus.CounterVec
+}
+
+func newNexdMetrics() *nexdMetrics {
+	m := &nexdMetrics{
+		registry: prometheus.NewRegistry(),
+		peeringMethodChanges: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "peering_method_changes_total",
+			Help:      "Changes of the method used to connect to a peer, by the new method.",
+		}, []string{"method"}),
+		reconcileDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "reconcile_duration_seconds",
+			Help:      "Duration of the reconcile loops.",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 4, 8),
+		}, []string{"loop"}),
+		apiErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "api_errors_total",
+			Help:      "Failed requests to the nexodus api server, by operation.",
+		}, []string{"operation"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.peeringMethodChanges,
+		m.reconcileDuration,
+		m.apiErrors,
+	)
+	return m
+}
+
+func (m *nexdMetrics) peeringMethodChanged(method string) {
+	if m == nil {
+		return
+	}
+	m.peeringMethodChanges.WithLabelValues(method).Inc()
+}
+
+// reconcileTimer starts timing a reconcile loop, call the returned function when it is done.
+func (m *nexdMetrics) reconcileTimer(loop string) func() {
+	start := time.Now()
+	return func() {
+		if m == nil {
+			return
+		}
+		m.reconcileDuration.WithLabelValues(loop).Observe(time.Since(start).Seconds())
+	}
+}
+
+func (m *nexdMetrics) apiError(operation string) {
+	if m == nil {
+		return
+	}
+	m.apiErrors.WithLabelValues(operation).Inc()
+}
+
+// Describe implements prometheus.Collector
+func (nx *Nexodus) Describe(ch chan<- *prometheus.Desc) {
+	ch <- peerReceiveBytesDesc
+	ch <- peerTransmitBytesDesc
+	ch <- peerLastHandshakeDesc
+	ch <- peerHealthyDesc
+	ch <- peerPeeringMethodDesc
+	ch <- derpActiveConnectionsDesc
+	ch <- proxyActiveConnectionsDesc
+	ch <- proxyConnectionsDesc
+	ch <- proxyConnectionErrorsDesc
+	ch <- securityRulePacketsDesc
+	ch <- securityRuleBytesDesc
+}
+
+// Collect implements prometheus.Collector, it reports the state of the peers, DERP relays and proxies, and the
+// counters of the security group rules.
+func (nx *Nexodus) Collect(ch chan<- prometheus.Metric) {
+	now := time.Now()
+
+	nx.deviceCacheLock.RLock()
+	for publicKey, d := range nx.deviceCache {
+		if publicKey == nx.wireguardPubKey {
+			continue
+		}
+		labels := []string{publicKey, d.device.GetHostname()}
+		ch <- prometheus.MustNewConstMetric(peerReceiveBytesDesc, prometheus.CounterValue, float64(d.lastRxBytes), labels...)
+		ch <- prometheus.MustNewConstMetric(peerTransmitBytesDesc, prometheus.CounterValue, float64(d.lastTxBytes), labels...)
+		if !d.lastHandshakeTime.IsZero() {
+			ch <- prometheus.MustNewConstMetric(peerLastHandshakeDesc, prometheus.GaugeValue, now.Sub(d.lastHandshakeTime).Seconds(), labels...)
+		}
+		ch <- prometheus.MustNewConstMetric(peerHealthyDesc, prometheus.GaugeValue, boolToFloat(d.peerHealthy), labels...)
+		if d.peeringMethod != "" {
+			ch <- prometheus.MustNewConstMetric(peerPeeringMethodDesc, prometheus.GaugeValue, 1, append(labels, d.peeringMethod)...)
+		}
+	}
+	nx.deviceCacheLock.RUnlock()
+
+	nx.nexRelay.mu.Lock()
+	activeDerp := len(nx.nexRelay.activeDerp)
+	nx.nexRelay.mu.Unlock()
+	ch <- prometheus.MustNewConstMetric(derpActiveConnectionsDesc, prometheus.GaugeValue, float64(activeDerp))
+
+	nx.proxyLock.RLock()
+	for key, proxy := range nx.proxies {
+		labels := []string{key.ruleType.String(), string(key.protocol), strconv.Itoa(key.listenPort)}
+		ch <- prometheus.MustNewConstMetric(proxyActiveConnectionsDesc, prometheus.GaugeValue, float64(proxy.activeConnections.Load()), labels...)
+		ch <- prometheus.MustNewConstMetric(proxyConnectionsDesc, prometheus.CounterValue, float64(proxy.totalConnections.Load()), labels...)
+		ch <- prometheus.MustNewConstMetric(proxyConnectionErrorsDesc, prometheus.CounterValue, float64(proxy.connectionErrors.Load()), labels...)
+	}
+	nx.proxyLock.RUnlock()
+
+	stats, err := nx.securityGroupRuleStats()
+	if err != nil {
+		nx.logger.Debugf("Failed to read the security group counters: %v", err)
+	}
+	for _, stat := range stats {
+		labels := []string{stat.Direction, stat.Rule}
+		ch <- prometheus.MustNewConstMetric(securityRulePacketsDesc, prometheus.CounterValue, float64(stat.Packets), labels...)
+		ch <- prometheus.MustNewConstMetric(securityRuleBytesDesc, prometheus.CounterValue, float64(stat.Bytes), labels...)
+	}
+}
+
+// startMetricsServer serves the prometheus metrics on /metrics of the address until the context is done.
+func (nx *Nexodus) startMetricsServer(ctx context.Context, wg *sync.WaitGroup, address string) error {
+	if err := nx.metrics.registry.Register(nx); err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(nx.metrics.registry, promhttp.HandlerOpts{}))
+	server := &http.Server{
+		Addr:              address,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	util.GoWithWaitGroup(wg, func() {
+		nx.logger.Infof("Serving metrics on http://%s/metrics", address)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			nx.logger.Errorf("Metrics server failed: %v", err)
+		}
+	})
+	util.GoWithWaitGroup(wg, func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	})
+	return nil
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,95 @@
+package nexodus
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nexodus-io/nexodus/internal/client"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsCollect(t *testing.T) {
+	require := require.New(t)
+
+	proxy := &UsProxy{}
+	proxy.totalConnections.Add(3)
+	proxy.activeConnections.Add(1)
+	nx := &Nexodus{
+		wireguardPubKey: "local",
+		deviceCache: map[string]deviceCacheEntry{
+			"local": {
+				device: client.ModelsDevice{Hostname: client.PtrString("self")},
+			},
+			"peer1": {
+				device: client.ModelsDevice{Hostname: client.PtrString("one")},
+				peerHealth: peerHealth{
+					lastRxBytes:       100,
+					lastTxBytes:       200,
+					lastHandshakeTime: time.Now(),
+					peerHealthy:       true,
+				},
+				peeringMethod: peeringMethodReflexive,
+			},
+		},
+		userspaceWG: userspaceWG{
+			proxies: map[ProxyKey]*UsProxy{
+				{ruleType: ProxyTypeIngress, protocol: proxyProtocolTCP, listenPort: 8080}: proxy,
+			},
+		},
+	}
+
+	registry := prometheus.NewPedanticRegistry()
+	require.NoError(registry.Register(nx))
+
+	expected := `
+# HELP nexd_peer_healthy Whether the connection to the peer is healthy (1) or not (0).
+# TYPE nexd_peer_healthy gauge
+nexd_peer_healthy{hostname="one",public_key="peer1"} 1
+# HELP nexd_peer_peering_method The method used to connect to the peer, always 1.
+# TYPE nexd_peer_peering_method gauge
+nexd_peer_peering_method{hostname="one",method="reflexive",public_key="peer1"} 1
+# HELP nexd_peer_receive_bytes_total Bytes received from the peer over the wireguard tunnel.
+# TYPE nexd_peer_receive_bytes_total counter
+nexd_peer_receive_bytes_total{hostname="one",public_key="peer1"} 100
+# HELP nexd_peer_transmit_bytes_total Bytes sent to the peer over the wireguard tunnel.
+# TYPE nexd_peer_transmit_bytes_total counter
+nexd_peer_transmit_bytes_total{hostname="one",public_key="peer1"} 200
+# HELP nexd_proxy_active_connections Connections currently handled by the userspace proxy.
+# TYPE nexd_proxy_active_connections gauge
+nexd_proxy_active_connections{port="8080",protocol="tcp",type="ingress"} 1
+# HELP nexd_proxy_connections_total Connections handled by the userspace proxy.
+# TYPE nexd_proxy_connections_total counter
+nexd_proxy_connections_total{port="8080",protocol="tcp",type="ingress"} 3
+# HELP nexd_derp_active_connections Connections open to DERP relay regions.
+# TYPE nexd_derp_active_connections gauge
+nexd_derp_active_connections 0
+`
+	require.NoError(testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"nexd_peer_healthy",
+		"nexd_peer_peering_method",
+		"nexd_peer_receive_bytes_total",
+		"nexd_peer_transmit_bytes_total",
+		"nexd_proxy_active_connections",
+		"nexd_proxy_connections_total",
+		"nexd_derp_active_connections",
+	))
+
+	// the local device is not a peer
+	count, err := testutil.GatherAndCount(registry, "nexd_peer_last_handshake_age_seconds")
+	require.NoError(err)
+	require.Equal(1, count)
+}
+
+func TestMetricsNil(t *testing.T) {
+	var m *nexdMetrics
+	m.peeringMethodChanged(peeringMethodDirectLocal)
+	m.apiError("list_devices")
+	m.reconcileTimer("devices")()
+
+	m = newNexdMetrics()
+	m.peeringMethodChanged(peeringMethodDirectLocal)
+	require.Equal(t, 1.0, testutil.ToFloat64(m.peeringMethodChanges.WithLabelValues(peeringMethodDirectLocal)))
+}
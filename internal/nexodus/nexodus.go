@@ -61,6 +61,8 @@ const (
 	NexdStatusAuth
 	// nexd is up and running normally
 	NexdStatusRunning
+	// nexd joined a vpc that requires device approval and waits for the device to be approved
+	NexdStatusPendingApproval
 )
 
 const (
@@ -68,6 +70,8 @@ const (
 	retryInterval = 15 * time.Second
 	// max retries for api server retries
 	maxRetries = 3
+	// how often the age of the wireguard key is checked against the key rotation interval
+	keyRotationCheckInterval = time.Hour
 )
 
 var (
@@ -181,6 +185,8 @@ type exitNode struct {
 	exitNodeClientEnabled bool
 	exitNodeOriginEnabled bool
 	exitNodeOrigins       []wgPeerConfig
+	// the device id of the exit node origin the client is routing through
+	activeOriginId string
 }
 
 type Options struct {
@@ -194,6 +200,7 @@ type Options struct {
 	ListenPort              int
 	LogLevel                *zap.AtomicLevel
 	Logger                  *zap.SugaredLogger
+	MeshDNS                 bool
 	NetworkRouter           bool
 	NetworkRouterDisableNAT bool
 	Password                string
@@ -210,6 +217,14 @@ type Options struct {
 	Version                 string
 	VpcId                   string
 	SecurityGroupId         string
+	// MetricsAddress is the address prometheus metrics are served on, they are not served when empty
+	MetricsAddress string
+	// KeyRotationInterval is the age after which the wireguard key is rotated, keys are not rotated when zero
+	KeyRotationInterval time.Duration
+	// SecurityGroupLogDenied logs the packets dropped by the security group, SecurityGroupReportDenied also
+	// reports them to the api server
+	SecurityGroupLogDenied    bool
+	SecurityGroupReportDenied bool
 }
 type Nexodus struct {
 	advertiseCidrs          []string
@@ -245,11 +260,13 @@ type Nexodus struct {
 	deviceCacheLock          sync.RWMutex
 	deviceReconciled         bool
 	devicesInformer          *client.ListInformer[client.ModelsDevice]
+	dnsRecordsInformer       *client.ListInformer[client.ModelsDNSRecord]
 	endpointLocalAddress     string
 	exitNode                 exitNode
 	hostname                 string
 	informerStop             context.CancelFunc
 	ipv6Supported            bool
+	meshDNS                  meshDNS
 	needSecGroupReconcile    bool
 	netRouterInterfaceMap    map[string]*net.Interface
 	nexCtx                   context.Context
@@ -259,6 +276,7 @@ type Nexodus struct {
 	reflexiveAddrStunSrc     string
 	relayWgIP                string
 	securityGroup            *client.ModelsSecurityGroup
+	securityGroupPeers       map[string][]string // the tunnel IPs the peer selectors of the security group were last applied with
 	securityGroupsInformer   *client.ListInformer[client.ModelsSecurityGroup]
 	status                   int // See the NexdStatus* constants
 	statusMsg                string
@@ -271,6 +289,16 @@ type Nexodus struct {
 	wireguardPvtKey          string
 	relayMetadataInformer    *client.ListInformer[client.ModelsDeviceMetadata]
 	deviceId                 string
+	metrics                  *nexdMetrics
+	metricsAddress           string
+	keyRotationInterval      time.Duration
+	rotateKeyCh              chan chan error
+	presharedKeys            map[string]string // the pre-shared keys of the peers by device id
+	presharedKeysEnabled     bool
+	presharedKeysFetchedAt   time.Time
+	deniedFlows              *deniedFlowLog // nil when the denied flows are not logged
+	deniedFlowsReport        bool
+	deniedFlowsReported      uint64 // the sequence number of the last denied flow reported to the api server
 }
 
 type wgConfig struct {
@@ -284,6 +312,7 @@ type wgPeerConfig struct {
 	AllowedIPs          []string
 	PersistentKeepAlive string
 	AllowedIPsForRelay  []string
+	PresharedKey        string
 }
 
 type wgLocalConfig struct {
@@ -324,6 +353,11 @@ func New(o Options) (*Nexodus, error) {
 		stateDir:                o.StateDir,
 		vpcId:                   o.VpcId,
 		securityGroupId:         o.SecurityGroupId,
+		metricsAddress:          o.MetricsAddress,
+		keyRotationInterval:     o.KeyRotationInterval,
+		deniedFlowsReport:       o.SecurityGroupReportDenied,
+		rotateKeyCh:             make(chan chan error),
+		metrics:                 newNexdMetrics(),
 
 		hostname:    hostname,
 		deviceCache: make(map[string]deviceCacheEntry),
@@ -345,6 +379,10 @@ func New(o Options) (*Nexodus, error) {
 			exitNodeClientEnabled: o.ExitNodeClientEnabled,
 			exitNodeOriginEnabled: o.ExitNodeOriginEnabled,
 		},
+		meshDNS: meshDNS{
+			enabled: o.MeshDNS,
+			port:    meshDNSPort,
+		},
 	}
 
 	err = nx.setListenPort(o.ListenPort)
@@ -358,6 +396,11 @@ func New(o Options) (*Nexodus, error) {
 
 	nx.userspaceMode = o.UserspaceMode
 
+	// reporting the denied flows requires logging them
+	if o.SecurityGroupLogDenied || o.SecurityGroupReportDenied {
+		nx.deniedFlows = &deniedFlowLog{}
+	}
+
 	if !nx.userspaceMode {
 		isOk, err := isElevated()
 		if !isOk {
@@ -521,12 +564,28 @@ func (nx *Nexodus) Start(ctx context.Context, wg *sync.WaitGroup) error {
 		return fmt.Errorf("CtlServerStart(): %w", err)
 	}
 
+	if nx.metricsAddress != "" {
+		if err := nx.startMetricsServer(ctx, wg, nx.metricsAddress); err != nil {
+			return fmt.Errorf("failed to start the metrics server: %w", err)
+		}
+	}
+
+	if err := nx.recoverSplitDNS(); err != nil {
+		nx.logger.Warnf("failed to restore the host resolver configuration of a previous run: %v", err)
+	}
+
 	if runtime.GOOS != Linux.String() && runtime.GOOS != Darwin.String() {
 		nx.logger.Info("Security Groups are currently only supported on Linux and macOS")
 	} else if nx.userspaceMode {
 		nx.logger.Info("Security Groups are not supported in userspace proxy mode")
 	}
 
+	if nx.deniedFlows != nil && !nx.userspaceMode {
+		if err := nx.listenDeniedFlows(ctx, wg); err != nil {
+			nx.logger.Errorf("failed to log the flows denied by the security group: %v", err)
+		}
+	}
+
 	options := []client.Option{
 		client.WithUserAgent(fmt.Sprintf("nexd/%s (%s; %s)", nx.version, runtime.GOOS, runtime.GOARCH)),
 	}
@@ -573,6 +632,12 @@ func (nx *Nexodus) Start(ctx context.Context, wg *sync.WaitGroup) error {
 	if err := nx.handleKeys(); err != nil {
 		return fmt.Errorf("handleKeys: %w", err)
 	}
+	err = util.RetryOperation(ctx, retryInterval, maxRetries, func() error {
+		return nx.reconcilePendingKey(ctx)
+	})
+	if err != nil {
+		return fmt.Errorf("reconcilePendingKey: %w", err)
+	}
 	userId, vpc, err := nx.fetchUserIdAndVpc(ctx)
 	if err != nil {
 		return err
@@ -636,6 +701,11 @@ func (nx *Nexodus) Start(ctx context.Context, wg *sync.WaitGroup) error {
 	nx.logger.Debugf("Device: %s", util.JsonStringer(modelsDevice))
 	nx.logger.Infof("%s with UUID: [ %+v ] into vpc: [ %s (%s) ]",
 		deviceOperationLogMsg, nx.deviceId, nx.vpc.GetId(), nx.vpc.GetDescription())
+	if modelsDevice.GetPending() {
+		// the device is not served to the vpc, our own device included, until it is approved.
+		nx.logger.Infof("Device [ %s ] is waiting to be approved by an administrator of the vpc", nx.deviceId)
+		nx.SetStatus(NexdStatusPendingApproval, fmt.Sprintf("Device %s is waiting to be approved by an administrator of the vpc\n", nx.deviceId))
+	}
 
 	// Use the device token to auth with the apiserver...
 	if modelsDevice.GetBearerToken() != "" {
@@ -676,6 +746,9 @@ func (nx *Nexodus) Start(ctx context.Context, wg *sync.WaitGroup) error {
 	nx.securityGroupsInformer = nx.client.VPCApi.ListSecurityGroupsInVPC(informerCtx, nx.vpc.GetId()).Informer()
 	nx.devicesInformer = nx.client.VPCApi.ListDevicesInVPC(informerCtx, nx.vpc.GetId()).Informer()
 	nx.relayMetadataInformer = nx.client.VPCApi.ListMetadataInVPC(informerCtx, nx.vpc.GetId()).Key("relay").Informer()
+	if nx.meshDNS.enabled {
+		nx.dnsRecordsInformer = nx.client.VPCApi.ListDNSRecordsInVPC(informerCtx, nx.vpc.GetId()).Informer()
+	}
 
 	if nx.relay {
 		peerMap, _, err := nx.devicesInformer.Execute()
@@ -710,6 +783,7 @@ func (nx *Nexodus) Start(ctx context.Context, wg *sync.WaitGroup) error {
 		// kick it off with an immediate reconcile
 		nx.reconcileDevices(ctx, options)
 		nx.reconcileSecurityGroups(ctx)
+		nx.reconcileDNSRecords()
 		for _, proxy := range nx.proxies {
 			proxy.Start(ctx, wg, nx.userspaceNet)
 		}
@@ -723,6 +797,22 @@ func (nx *Nexodus) Start(ctx context.Context, wg *sync.WaitGroup) error {
 		defer stunTicker.Stop()
 		pollTicker := time.NewTicker(pollInterval)
 		defer pollTicker.Stop()
+		exitNodeTicker := time.NewTicker(exitNodeCheckInterval)
+		defer exitNodeTicker.Stop()
+		// keyRotationC stays nil, and never fires, when key rotation is disabled
+		var keyRotationC <-chan time.Time
+		if nx.keyRotationInterval > 0 {
+			keyRotationTicker := time.NewTicker(min(nx.keyRotationInterval, keyRotationCheckInterval))
+			defer keyRotationTicker.Stop()
+			keyRotationC = keyRotationTicker.C
+		}
+		// deniedFlowsC stays nil, and never fires, when the denied flows are not reported
+		var deniedFlowsC <-chan time.Time
+		if nx.deniedFlows != nil && nx.deniedFlowsReport {
+			deniedFlowsTicker := time.NewTicker(deniedFlowReportInterval)
+			defer deniedFlowsTicker.Stop()
+			deniedFlowsC = deniedFlowsTicker.C
+		}
 		for {
 			select {
 			case <-ctx.Done():
@@ -739,6 +829,8 @@ func (nx *Nexodus) Start(ctx context.Context, wg *sync.WaitGroup) error {
 				nx.reconcileDevices(ctx, options)
 			case <-nx.securityGroupsInformer.Changed():
 				nx.reconcileSecurityGroups(ctx)
+			case <-nx.dnsRecordsChanged():
+				nx.reconcileDNSRecords()
 			case <-pollTicker.C:
 				// This does not actually poll the API for changes. Peer configuration changes will only
 				// be processed when they come in on the informer. This periodic check is needed to
@@ -746,9 +838,22 @@ func (nx *Nexodus) Start(ctx context.Context, wg *sync.WaitGroup) error {
 				nx.reconcileDevices(ctx, options)
 			case <-secGroupTicker.C:
 				nx.reconcileSecurityGroups(ctx)
+			case <-exitNodeTicker.C:
+				nx.checkExitNodeOrigin()
+			case <-keyRotationC:
+				if nx.keyRotationDue() {
+					if err := nx.rotateKey(ctx); err != nil {
+						nx.logger.Errorf("failed to rotate the wireguard key: %v", err)
+					}
+				}
+			case <-deniedFlowsC:
+				nx.reportDeniedFlows(ctx)
+			case result := <-nx.rotateKeyCh:
+				// rotations requested through the ctl server run here so they do not race the reconcilers
+				result <- nx.rotateKey(ctx)
 			}
 			if nx.needSecGroupReconcile {
-				// device reconcile noticed that the security group Id changed
+				// device reconcile noticed that the security group Id or the devices matching its peer selectors changed
 				nx.reconcileSecurityGroups(ctx)
 				nx.needSecGroupReconcile = false
 			}
@@ -858,6 +963,11 @@ func (nx *Nexodus) Stop() {
 		proxy.Stop()
 	}
 
+	if nx.meshDNS.enabled {
+		nx.logger.Debugf("Stopping Mesh DNS")
+		nx.meshDNSStop()
+	}
+
 	if nx.exitNode.exitNodeClientEnabled {
 		nx.logger.Debugf("Stopping Exit Node Client")
 		if err := nx.exitNodeClientTeardown(); err != nil {
@@ -884,6 +994,8 @@ func (nx *Nexodus) Stop() {
 
 // reconcileSecurityGroups will check the security group and update it if necessary.
 func (nx *Nexodus) reconcileSecurityGroups(ctx context.Context) {
+	defer nx.metrics.reconcileTimer("security_groups")()
+
 	if runtime.GOOS != Linux.String() && runtime.GOOS != Darwin.String() || nx.userspaceMode {
 		return
 	}
@@ -919,6 +1031,7 @@ func (nx *Nexodus) reconcileSecurityGroups(ctx context.Context) {
 			}
 			return
 		}
+		nx.metrics.apiError("list_security_groups")
 		nx.logger.Errorf("Error retrieving the security groups: %v", err)
 		return
 	}
@@ -933,7 +1046,11 @@ func (nx *Nexodus) reconcileSecurityGroups(ctx context.Context) {
 		return
 	}
 
-	if nx.securityGroup != nil && reflect.DeepEqual(responseSecGroup, *nx.securityGroup) {
+	nx.deviceCacheLock.RLock()
+	peersChanged := nx.securityGroupPeersChanged()
+	nx.deviceCacheLock.RUnlock()
+
+	if nx.securityGroup != nil && reflect.DeepEqual(responseSecGroup, *nx.securityGroup) && !peersChanged {
 		// no changes to previously applied security group
 		return
 	}
@@ -944,7 +1061,7 @@ func (nx *Nexodus) reconcileSecurityGroups(ctx context.Context) {
 
 	if oldSecGroup != nil && responseSecGroup.GetId() == oldSecGroup.GetId() &&
 		reflect.DeepEqual(responseSecGroup.InboundRules, oldSecGroup.InboundRules) &&
-		reflect.DeepEqual(responseSecGroup.OutboundRules, oldSecGroup.OutboundRules) {
+		reflect.DeepEqual(responseSecGroup.OutboundRules, oldSecGroup.OutboundRules) && !peersChanged {
 		// the group changed, but not in a way that matters for applying the rules locally
 		return
 	}
@@ -956,6 +1073,8 @@ func (nx *Nexodus) reconcileSecurityGroups(ctx context.Context) {
 }
 
 func (nx *Nexodus) reconcileDevices(ctx context.Context, options []client.Option) {
+	defer nx.metrics.reconcileTimer("devices")()
+
 	var err error
 	if err = nx.reconcileDeviceCache(); err == nil {
 		if !nx.deviceReconciled {
@@ -1010,6 +1129,9 @@ func (nx *Nexodus) reconcileDevices(ctx context.Context, options []client.Option
 	nx.securityGroupsInformer = nx.client.VPCApi.ListSecurityGroupsInVPC(informerCtx, nx.vpc.GetId()).Informer()
 	nx.devicesInformer = nx.client.VPCApi.ListDevicesInVPC(informerCtx, nx.vpc.GetId()).Informer()
 	nx.relayMetadataInformer = nx.client.VPCApi.ListMetadataInVPC(informerCtx, nx.vpc.GetId()).Key("relay").Informer()
+	if nx.meshDNS.enabled {
+		nx.dnsRecordsInformer = nx.client.VPCApi.ListDNSRecordsInVPC(informerCtx, nx.vpc.GetId()).Informer()
+	}
 
 	nx.SetStatus(NexdStatusRunning, "")
 	nx.logger.Infoln("Nexodus agent has re-established a connection to the api-server")
@@ -1019,6 +1141,7 @@ func (nx *Nexodus) reconcileStun(deviceID string) error {
 	if nx.symmetricNat {
 		return nil
 	}
+	defer nx.metrics.reconcileTimer("stun")()
 
 	nx.logger.Debug("sending stun request")
 	stunServer1 := stun.NextServer()
@@ -1137,12 +1260,17 @@ func (nx *Nexodus) addToDeviceCache(p client.ModelsDevice) {
 func (nx *Nexodus) reconcileDeviceCache() error {
 	peerMap, resp, err := nx.devicesInformer.Execute()
 	if err != nil {
+		nx.metrics.apiError("list_devices")
 		if resp != nil {
 			return fmt.Errorf("error: %w header: %v", err, resp.Header)
 		}
 		return fmt.Errorf("error: %w", err)
 	}
 
+	if err := nx.refreshPresharedKeys(nx.nexCtx, peerMap); err != nil {
+		nx.logger.Warn(err)
+	}
+
 	// Get the current peer configuration data from the wireguard interface
 	peerStats, err := nx.DumpPeersDefault()
 	if err != nil {
@@ -1158,14 +1286,25 @@ func (nx *Nexodus) reconcileDeviceCache() error {
 	nx.deviceCacheLock.Lock()
 	defer nx.deviceCacheLock.Unlock()
 
+	// drop the peering of devices that rotated their wireguard key, the peering is rebuilt for the new key below
+	nx.handlePeerKeyRotation(peerMap)
+
 	// Get our device cache up to date
 	newLocalConfig := false
 	for _, p := range peerMap {
+		if p.GetId() == nx.deviceId && p.GetPublicKey() != nx.wireguardPubKey {
+			// the informer has not caught up with a rotation of our own key yet
+			continue
+		}
 		// Update the cache if the device is new or has changed
 		existing, ok := nx.deviceCache[p.GetPublicKey()]
 		if !ok || deviceUpdated(existing.device, p) {
 			if p.GetPublicKey() == nx.wireguardPubKey {
 				newLocalConfig = true
+				if nx.status == NexdStatusPendingApproval {
+					nx.logger.Infof("Device [ %s ] has been approved", nx.deviceId)
+					nx.SetStatus(NexdStatusRunning, "")
+				}
 				if nx.securityGroup == nil || !reflect.DeepEqual(p.SecurityGroupId, nx.securityGroup.Id) {
 					nx.needSecGroupReconcile = true
 				}
@@ -1173,12 +1312,19 @@ func (nx *Nexodus) reconcileDeviceCache() error {
 			nx.addToDeviceCache(p)
 			existing = nx.deviceCache[p.GetPublicKey()]
 			delete(peerStats, p.GetPublicKey())
+		} else if !reflect.DeepEqual(existing.device.Labels, p.Labels) || !reflect.DeepEqual(existing.device.PrimaryCidrs, p.PrimaryCidrs) {
+			// labels only matter to the security group peer selectors and the primary cidrs to the allowed ips
+			// built below, the peering is left alone
+			existing.device.Labels = p.Labels
+			existing.device.PrimaryCidrs = p.PrimaryCidrs
+			nx.deviceCache[p.GetPublicKey()] = existing
 		}
 
 		// Store the relay IP for easy reference later
 		if p.GetRelay() {
 			metadata, _, err := nx.getDeviceRelayMetadata(p.GetId())
 			if err != nil {
+				nx.metrics.apiError("get_relay_metadata")
 				nx.logger.Warnf("failed to get relay metadata for peer (hostname:%s pubkey:%s): %v",
 					p.GetHostname(), p.GetPublicKey(), err)
 			} else {
@@ -1257,6 +1403,16 @@ func (nx *Nexodus) reconcileDeviceCache() error {
 		nx.logger.Error(err)
 	}
 
+	// re-apply the security group when devices matching its peer selectors came or went
+	if nx.securityGroupPeersChanged() {
+		nx.needSecGroupReconcile = true
+	}
+
+	// publish any device name changes to the mesh resolver
+	if err := nx.reconcileMeshDNS(); err != nil {
+		nx.logger.Error(err)
+	}
+
 	return nil
 }
 
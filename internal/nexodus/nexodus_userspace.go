@@ -16,15 +16,18 @@ import (
 const defaultDeviceName = "go"
 
 func (nx *Nexodus) setupInterfaceUS() error {
+	// When mesh DNS is enabled, the netstack resolves names through the mesh resolver
+	// listening on our own tunnel address, so that proxy rules can target mesh hostnames.
+	dnsServer := netip.MustParseAddr("8.8.8.8")
+	if nx.meshDNS.enabled {
+		dnsServer = netip.MustParseAddr(nx.TunnelIP)
+	}
 	tun, tnet, err := netstack.CreateNetTUN(
 		[]netip.Addr{
 			netip.MustParseAddr(nx.TunnelIP),
 			netip.MustParseAddr(nx.TunnelIpV6),
 		},
-		// TODO - Is there something else that makes more sense as a DNS server?
-		// So far I don't think DNS will ever be used. If Nexodus has its own
-		// built-in DNS, that would make sense here.
-		[]netip.Addr{netip.MustParseAddr("8.8.8.8")},
+		[]netip.Addr{dnsServer},
 		// Assume a standard 1500 minus our tunneling overhead
 		// TODO - make this configurable or dynamic. If there are
 		// multiple layers of tunneling involved, it may need to be
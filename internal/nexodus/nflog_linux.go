@@ -0,0 +1,116 @@
+//go:build linux
+
+package nexodus
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/mdlayher/netlink"
+	"github.com/nexodus-io/nexodus/internal/util"
+	"golang.org/x/sys/unix"
+)
+
+// The nfnetlink_log messages and attributes, from linux/netfilter/nfnetlink_log.h
+const (
+	nfulnlMsgPacket = 0
+	nfulnlMsgConfig = 1
+
+	nfulaPayload = 9
+	nfulaPrefix  = 10
+
+	nfulaCfgCmd  = 1
+	nfulaCfgMode = 2
+
+	nfulnlCfgCmdBind = 1
+	nfulnlCopyPacket = 2
+
+	// nflogCopyRange is the length of the packets copied to the log, enough for the network and transport headers
+	nflogCopyRange = 128
+)
+
+// listenDeniedFlows binds to the nflog group the security group logs the denied packets to, and records the
+// packets it receives until ctx is done
+func (nx *Nexodus) listenDeniedFlows(ctx context.Context, wg *sync.WaitGroup) error {
+	conn, err := netlink.Dial(unix.NETLINK_NETFILTER, nil)
+	if err != nil {
+		return fmt.Errorf("failed to open the netfilter netlink socket: %w", err)
+	}
+	if err := nflogConfig(conn, deniedFlowLogGroup, nfulaCfgCmd, []byte{nfulnlCfgCmdBind}); err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("failed to bind to nflog group %d: %w", deniedFlowLogGroup, err)
+	}
+	// struct nfulnl_msg_config_mode is the copy range and the copy mode followed by a padding byte
+	mode := make([]byte, 6)
+	binary.BigEndian.PutUint32(mode, nflogCopyRange)
+	mode[4] = nfulnlCopyPacket
+	if err := nflogConfig(conn, deniedFlowLogGroup, nfulaCfgMode, mode); err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("failed to set the copy mode of nflog group %d: %w", deniedFlowLogGroup, err)
+	}
+
+	util.GoWithWaitGroup(wg, func() {
+		<-ctx.Done()
+		_ = conn.Close()
+	})
+	util.GoWithWaitGroup(wg, func() {
+		packetType := netlink.HeaderType(unix.NFNL_SUBSYS_ULOG<<8 | nfulnlMsgPacket)
+		for {
+			msgs, err := conn.Receive()
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				// the socket buffer overflows when packets are denied faster than they are read
+				nx.logger.Debugf("failed to receive denied packets: %v", err)
+				continue
+			}
+			for _, msg := range msgs {
+				// the attributes follow the nfgenmsg header
+				if msg.Header.Type != packetType || len(msg.Data) < 4 {
+					continue
+				}
+				ad, err := netlink.NewAttributeDecoder(msg.Data[4:])
+				if err != nil {
+					continue
+				}
+				var prefix string
+				var payload []byte
+				for ad.Next() {
+					switch ad.Type() {
+					case nfulaPrefix:
+						prefix = strings.TrimRight(ad.String(), "\x00")
+					case nfulaPayload:
+						payload = ad.Bytes()
+					}
+				}
+				if ad.Err() == nil && payload != nil {
+					nx.recordDeniedPacket(prefix, payload)
+				}
+			}
+		}
+	})
+	return nil
+}
+
+// nflogConfig sends a config attribute for the nflog group
+func nflogConfig(conn *netlink.Conn, group uint16, attrType uint16, data []byte) error {
+	attrs, err := netlink.MarshalAttributes([]netlink.Attribute{{Type: attrType, Data: data}})
+	if err != nil {
+		return err
+	}
+	// struct nfgenmsg is the family, the version and the resource id, which is the group, in network byte order
+	header := []byte{unix.AF_UNSPEC, unix.NFNETLINK_V0, 0, 0}
+	binary.BigEndian.PutUint16(header[2:], group)
+	_, err = conn.Execute(netlink.Message{
+		Header: netlink.Header{
+			Type:  netlink.HeaderType(unix.NFNL_SUBSYS_ULOG<<8 | nfulnlMsgConfig),
+			Flags: netlink.Request | netlink.Acknowledge,
+		},
+		Data: append(header, attrs...),
+	})
+	return err
+}
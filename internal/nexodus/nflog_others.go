@@ -0,0 +1,14 @@
+//go:build !linux
+
+package nexodus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// listenDeniedFlows for build purposes, the denied flows are logged through nflog which is only available on Linux
+func (nx *Nexodus) listenDeniedFlows(ctx context.Context, wg *sync.WaitGroup) error {
+	return fmt.Errorf("logging the denied flows is only supported on Linux")
+}
@@ -0,0 +1,337 @@
+package nexodus
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// The nftables tables of nexd are built by the pure functions of this file and the ones that use it, then
+// committed to the kernel in a single netlink batch on Linux. A table is replaced as a whole, so there is no
+// window where only part of its rules are in place. The String methods render the tables the way
+// `nft list table` prints them, which is what the tests check and what is logged at debug level.
+
+const (
+	// nftables keywords
+	tableFamily      = "inet"
+	chainPrerouting  = "prerouting"
+	chainPostrouting = "postrouting"
+	chainForward     = "forward"
+	chainInput       = "input"
+	chainOutput      = "output"
+	chainTypeNAT     = "nat"
+	chainTypeFilter  = "filter"
+	chainTypeRoute   = "route"
+	priorityDstNAT   = "dstnat"
+	prioritySrcNAT   = "srcnat"
+	priorityFilter   = "filter"
+	priorityMangle   = "mangle"
+	actionAccept     = "accept"
+	actionDrop       = "drop"
+	srcAddr          = "saddr"
+	destAddr         = "daddr"
+	// Protocols
+	protoIPv4   = "ipv4"
+	protoIPv6   = "ipv6"
+	protoICMPv4 = "icmpv4"
+	protoICMP   = "icmp"
+	protoICMPv6 = "icmpv6"
+	protoTCP    = "tcp"
+	protoUDP    = "udp"
+	// protoTH matches the ports of any transport header
+	protoTH = "th"
+)
+
+// nfTable is a table of the inet family along with its base chains
+type nfTable struct {
+	name   string
+	chains []*nfChain
+}
+
+// nfChain is a base chain, hooked into the netfilter hook of the same name unless hook is set
+type nfChain struct {
+	name      string
+	chainType string
+	hook      string
+	priority  string
+	// policy is the verdict of the packets that no rule matched, empty for the default of accept
+	policy string
+	rules  []nfRule
+}
+
+// nfRule is a list of matches, an optional counter and the statement run on the matching packets
+type nfRule struct {
+	matches []nfMatch
+	counter bool
+	verdict nfVerdict
+	// comment is kept in the user data of the rule, it identifies the rule when its counter is read back
+	comment string
+}
+
+// nfCounter is the value of the counters of one or more rules
+type nfCounter struct {
+	packets uint64
+	bytes   uint64
+}
+
+// nfMatch is a match of a rule, the Linux implementation turns every match type into nftables expressions
+type nfMatch interface {
+	String() string
+}
+
+// nfVerdict is the statement of a rule
+type nfVerdict struct {
+	// kind is accept, drop, masquerade, mark or log
+	kind string
+	// mark is the packet mark set by the mark statement
+	mark uint32
+	// prefix and group are the prefix and the nflog group of the packets sent by the log statement
+	prefix string
+	group  uint16
+}
+
+var (
+	nfAccept     = nfVerdict{kind: actionAccept}
+	nfDrop       = nfVerdict{kind: actionDrop}
+	nfMasquerade = nfVerdict{kind: "masquerade"}
+)
+
+// nfSetMark returns the statement that sets the packet mark
+func nfSetMark(mark uint32) nfVerdict {
+	return nfVerdict{kind: "mark", mark: mark}
+}
+
+// nfLog returns the statement that sends the packets to the nflog group, the rule does not end the
+// evaluation of the chain
+func nfLog(prefix string, group uint16) nfVerdict {
+	return nfVerdict{kind: "log", prefix: prefix, group: group}
+}
+
+// nfMetaNfproto matches the address family of the packets of an inet table
+type nfMetaNfproto struct {
+	ipv6 bool
+}
+
+// nfMetaL4proto matches the transport protocol
+type nfMetaL4proto struct {
+	proto string
+}
+
+// nfIfname matches the name of the input or output interface
+type nfIfname struct {
+	output bool
+	name   string
+}
+
+// nfICMP matches the icmp protocol of the ipv4 header or the ipv6-icmp next header of the ipv6 header
+type nfICMP struct {
+	ipv6 bool
+}
+
+// nfAddr matches the source or destination address against a prefix, or against an address range
+// when to is valid
+type nfAddr struct {
+	ipv6   bool
+	dir    string
+	prefix netip.Prefix
+	from   netip.Addr
+	to     netip.Addr
+}
+
+// nfPort matches the destination port against a port or a port range
+type nfPort struct {
+	proto string
+	from  uint16
+	to    uint16
+}
+
+// nfCtEstablished matches the packets of the connections that are established or related to one
+type nfCtEstablished struct{}
+
+// nfLimit matches the packets while their rate stays under rate per second, allowing bursts of burst packets
+type nfLimit struct {
+	rate  uint64
+	burst uint32
+}
+
+func (m nfMetaNfproto) String() string {
+	if m.ipv6 {
+		return "meta nfproto " + protoIPv6
+	}
+	return "meta nfproto " + protoIPv4
+}
+
+func (m nfMetaL4proto) String() string {
+	return "meta l4proto " + m.proto
+}
+
+func (m nfIfname) String() string {
+	if m.output {
+		return fmt.Sprintf("oifname %q", m.name)
+	}
+	return fmt.Sprintf("iifname %q", m.name)
+}
+
+func (m nfICMP) String() string {
+	if m.ipv6 {
+		return "ip6 nexthdr ipv6-icmp"
+	}
+	return "ip protocol icmp"
+}
+
+func (m nfAddr) String() string {
+	family := "ip"
+	if m.ipv6 {
+		family = "ip6"
+	}
+	if m.to.IsValid() {
+		return fmt.Sprintf("%s %s %s-%s", family, m.dir, m.from, m.to)
+	}
+	if m.prefix.IsSingleIP() {
+		return fmt.Sprintf("%s %s %s", family, m.dir, m.prefix.Addr())
+	}
+	return fmt.Sprintf("%s %s %s", family, m.dir, m.prefix)
+}
+
+func (m nfPort) String() string {
+	if m.from == m.to {
+		return fmt.Sprintf("%s dport %d", m.proto, m.from)
+	}
+	return fmt.Sprintf("%s dport %d-%d", m.proto, m.from, m.to)
+}
+
+func (m nfCtEstablished) String() string {
+	return "ct state established,related"
+}
+
+func (m nfLimit) String() string {
+	return fmt.Sprintf("limit rate %d/second burst %d packets", m.rate, m.burst)
+}
+
+func (v nfVerdict) String() string {
+	switch v.kind {
+	case "mark":
+		return fmt.Sprintf("meta mark set 0x%08x", v.mark)
+	case "log":
+		return fmt.Sprintf("log prefix %q group %d", v.prefix, v.group)
+	}
+	return v.kind
+}
+
+func (r nfRule) String() string {
+	var fields []string
+	for _, match := range r.matches {
+		fields = append(fields, match.String())
+	}
+	if r.counter {
+		fields = append(fields, "counter")
+	}
+	fields = append(fields, r.verdict.String())
+	if r.comment != "" {
+		fields = append(fields, fmt.Sprintf("comment %q", r.comment))
+	}
+	return strings.Join(fields, " ")
+}
+
+func (c *nfChain) String() string {
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "\tchain %s {\n", c.name)
+	fmt.Fprintf(sb, "\t\ttype %s hook %s priority %s;", c.chainType, c.hookName(), c.priority)
+	if c.policy != "" {
+		fmt.Fprintf(sb, " policy %s;", c.policy)
+	}
+	sb.WriteString("\n")
+	for _, rule := range c.rules {
+		fmt.Fprintf(sb, "\t\t%s\n", rule)
+	}
+	sb.WriteString("\t}\n")
+	return sb.String()
+}
+
+func (t *nfTable) String() string {
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "table %s %s {\n", tableFamily, t.name)
+	for _, chain := range t.chains {
+		sb.WriteString(chain.String())
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+func (c *nfChain) hookName() string {
+	if c.hook != "" {
+		return c.hook
+	}
+	return c.name
+}
+
+// addChain adds a base chain to the table
+func (t *nfTable) addChain(name, chainType, hook, priority string) *nfChain {
+	chain := &nfChain{
+		name:      name,
+		chainType: chainType,
+		hook:      hook,
+		priority:  priority,
+	}
+	t.chains = append(t.chains, chain)
+	return chain
+}
+
+// add appends a counted rule to the chain
+func (c *nfChain) add(verdict nfVerdict, matches ...nfMatch) {
+	c.addRule(nfRule{matches: matches, counter: true, verdict: verdict})
+}
+
+// insert prepends a counted rule to the chain
+func (c *nfChain) insert(verdict nfVerdict, matches ...nfMatch) {
+	c.insertRule(nfRule{matches: matches, counter: true, verdict: verdict})
+}
+
+func (c *nfChain) addRule(rule nfRule) {
+	c.rules = append(c.rules, rule)
+}
+
+func (c *nfChain) insertRule(rule nfRule) {
+	c.rules = append([]nfRule{rule}, c.rules...)
+}
+
+// parseNfAddr parses a prefix, an address or an address range in the form of from-to into an address
+// match of the family
+func parseNfAddr(ipv6 bool, dir, ipRange string) (nfAddr, error) {
+	match := nfAddr{ipv6: ipv6, dir: dir}
+	isFamily := func(addr netip.Addr) bool {
+		return addr.Is6() == ipv6
+	}
+
+	if from, to, ok := strings.Cut(ipRange, "-"); ok {
+		var err error
+		if match.from, err = netip.ParseAddr(strings.TrimSpace(from)); err != nil {
+			return match, fmt.Errorf("invalid address range %s: %w", ipRange, err)
+		}
+		if match.to, err = netip.ParseAddr(strings.TrimSpace(to)); err != nil {
+			return match, fmt.Errorf("invalid address range %s: %w", ipRange, err)
+		}
+		if !isFamily(match.from) || !isFamily(match.to) || match.to.Less(match.from) {
+			return match, fmt.Errorf("invalid address range %s", ipRange)
+		}
+		return match, nil
+	}
+
+	if strings.Contains(ipRange, "/") {
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(ipRange))
+		if err != nil {
+			return match, fmt.Errorf("invalid prefix %s: %w", ipRange, err)
+		}
+		match.prefix = prefix.Masked()
+	} else {
+		addr, err := netip.ParseAddr(strings.TrimSpace(ipRange))
+		if err != nil {
+			return match, fmt.Errorf("invalid address %s: %w", ipRange, err)
+		}
+		match.prefix = netip.PrefixFrom(addr, addr.BitLen())
+	}
+	if !isFamily(match.prefix.Addr()) {
+		return match, fmt.Errorf("%s is not an address of the rule family", ipRange)
+	}
+	return match, nil
+}
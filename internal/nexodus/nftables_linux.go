@@ -0,0 +1,367 @@
+//go:build linux
+
+package nexodus
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/google/nftables"
+	"github.com/google/nftables/binaryutil"
+	"github.com/google/nftables/expr"
+	"go.uber.org/zap"
+	"golang.org/x/sys/unix"
+)
+
+var nfChainPriorities = map[string]*nftables.ChainPriority{
+	priorityFilter: nftables.ChainPriorityFilter,
+	priorityMangle: nftables.ChainPriorityMangle,
+	priorityDstNAT: nftables.ChainPriorityNATDest,
+	prioritySrcNAT: nftables.ChainPriorityNATSource,
+}
+
+var nfChainHooks = map[string]*nftables.ChainHook{
+	chainPrerouting:  nftables.ChainHookPrerouting,
+	chainInput:       nftables.ChainHookInput,
+	chainForward:     nftables.ChainHookForward,
+	chainOutput:      nftables.ChainHookOutput,
+	chainPostrouting: nftables.ChainHookPostrouting,
+}
+
+var nfChainTypes = map[string]nftables.ChainType{
+	chainTypeFilter: nftables.ChainTypeFilter,
+	chainTypeNAT:    nftables.ChainTypeNAT,
+	chainTypeRoute:  nftables.ChainTypeRoute,
+}
+
+var nfL4Protos = map[string]byte{
+	protoTCP: unix.IPPROTO_TCP,
+	protoUDP: unix.IPPROTO_UDP,
+}
+
+// nfApplyTables replaces the tables, along with all of their chains and rules, in a single batch
+func nfApplyTables(logger *zap.SugaredLogger, tables ...*nfTable) error {
+	conn, err := nftables.New()
+	if err != nil {
+		return fmt.Errorf("failed to open a netlink connection to nftables: %w", err)
+	}
+
+	for _, table := range tables {
+		logger.Debugf("nftables ruleset:\n%s", table)
+		t := nfDeleteTableOp(conn, table.name)
+		t = conn.AddTable(t)
+		for _, chain := range table.chains {
+			c, err := nfChainOf(t, chain)
+			if err != nil {
+				return fmt.Errorf("invalid nftables chain %s of table %s: %w", chain.name, table.name, err)
+			}
+			c = conn.AddChain(c)
+			for _, rule := range chain.rules {
+				exprs, err := nfRuleExprs(rule)
+				if err != nil {
+					return fmt.Errorf("invalid nftables rule [ %s ] of table %s: %w", rule, table.name, err)
+				}
+				conn.AddRule(&nftables.Rule{Table: t, Chain: c, Exprs: exprs, UserData: nfCommentUserData(rule.comment)})
+			}
+		}
+	}
+
+	if err := conn.Flush(); err != nil {
+		return fmt.Errorf("failed to commit the nftables tables: %w", err)
+	}
+	return nil
+}
+
+// nfDeleteTable deletes the table if it exists
+func nfDeleteTable(name string) error {
+	conn, err := nftables.New()
+	if err != nil {
+		return fmt.Errorf("failed to open a netlink connection to nftables: %w", err)
+	}
+	nfDeleteTableOp(conn, name)
+	if err := conn.Flush(); err != nil {
+		return fmt.Errorf("failed to delete the nftables table %s: %w", name, err)
+	}
+	return nil
+}
+
+// nfRuleCounters reads the counters of the rules of the table, summed by the comment of the rules. The rules
+// without a comment are left out, as is everything when the table does not exist.
+func nfRuleCounters(name string) (map[string]nfCounter, error) {
+	conn, err := nftables.New()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open a netlink connection to nftables: %w", err)
+	}
+	chains, err := conn.ListChainsOfTableFamily(nftables.TableFamilyINet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the nftables chains: %w", err)
+	}
+
+	counters := map[string]nfCounter{}
+	for _, chain := range chains {
+		if chain.Table.Name != name {
+			continue
+		}
+		rules, err := conn.GetRules(chain.Table, chain)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the rules of the nftables chain %s: %w", chain.Name, err)
+		}
+		for _, rule := range rules {
+			comment := nfUserDataComment(rule.UserData)
+			if comment == "" {
+				continue
+			}
+			for _, e := range rule.Exprs {
+				if c, ok := e.(*expr.Counter); ok {
+					counter := counters[comment]
+					counter.packets += c.Packets
+					counter.bytes += c.Bytes
+					counters[comment] = counter
+				}
+			}
+		}
+	}
+	return counters, nil
+}
+
+// nfUserDataTypeComment is the type of the comment in the user data of a rule, the user data is a list of
+// type, length and value attributes, the way libnftnl and the nft command write it
+const nfUserDataTypeComment = 0
+
+// nfCommentUserData returns the user data that holds the comment of a rule
+func nfCommentUserData(comment string) []byte {
+	if comment == "" {
+		return nil
+	}
+	value := append([]byte(comment), 0)
+	return append([]byte{nfUserDataTypeComment, byte(len(value))}, value...)
+}
+
+// nfUserDataComment returns the comment held by the user data of a rule
+func nfUserDataComment(data []byte) string {
+	for len(data) >= 2 {
+		attrType, length := data[0], int(data[1])
+		if len(data) < 2+length {
+			break
+		}
+		if attrType == nfUserDataTypeComment {
+			return strings.TrimRight(string(data[2:2+length]), "\x00")
+		}
+		data = data[2+length:]
+	}
+	return ""
+}
+
+// nfDeleteTableOp queues the deletion of the table, adding it first makes the deletion succeed whether
+// the table exists or not
+func nfDeleteTableOp(conn *nftables.Conn, name string) *nftables.Table {
+	t := &nftables.Table{Family: nftables.TableFamilyINet, Name: name}
+	conn.AddTable(t)
+	conn.DelTable(t)
+	return t
+}
+
+func nfChainOf(table *nftables.Table, chain *nfChain) (*nftables.Chain, error) {
+	chainType, ok := nfChainTypes[chain.chainType]
+	if !ok {
+		return nil, fmt.Errorf("unknown chain type %s", chain.chainType)
+	}
+	hook, ok := nfChainHooks[chain.hookName()]
+	if !ok {
+		return nil, fmt.Errorf("unknown hook %s", chain.hookName())
+	}
+	priority, ok := nfChainPriorities[chain.priority]
+	if !ok {
+		return nil, fmt.Errorf("unknown priority %s", chain.priority)
+	}
+	c := &nftables.Chain{
+		Name:     chain.name,
+		Table:    table,
+		Type:     chainType,
+		Hooknum:  hook,
+		Priority: priority,
+	}
+	switch chain.policy {
+	case "":
+	case actionAccept:
+		policy := nftables.ChainPolicyAccept
+		c.Policy = &policy
+	case actionDrop:
+		policy := nftables.ChainPolicyDrop
+		c.Policy = &policy
+	default:
+		return nil, fmt.Errorf("unknown policy %s", chain.policy)
+	}
+	return c, nil
+}
+
+// nfRuleExprs returns the nftables expressions of the matches and the statement of the rule
+func nfRuleExprs(rule nfRule) ([]expr.Any, error) {
+	var exprs []expr.Any
+	for _, match := range rule.matches {
+		matchExprs, err := nfMatchExprs(match)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, matchExprs...)
+	}
+	if rule.counter {
+		exprs = append(exprs, &expr.Counter{})
+	}
+
+	switch rule.verdict.kind {
+	case actionAccept:
+		exprs = append(exprs, &expr.Verdict{Kind: expr.VerdictAccept})
+	case actionDrop:
+		exprs = append(exprs, &expr.Verdict{Kind: expr.VerdictDrop})
+	case nfMasquerade.kind:
+		exprs = append(exprs, &expr.Masq{})
+	case "mark":
+		exprs = append(exprs,
+			&expr.Immediate{Register: 1, Data: binaryutil.NativeEndian.PutUint32(rule.verdict.mark)},
+			&expr.Meta{Key: expr.MetaKeyMARK, SourceRegister: true, Register: 1},
+		)
+	case "log":
+		exprs = append(exprs, &expr.Log{
+			Key:   1<<unix.NFTA_LOG_GROUP | 1<<unix.NFTA_LOG_PREFIX,
+			Group: rule.verdict.group,
+			Data:  []byte(rule.verdict.prefix),
+		})
+	default:
+		return nil, fmt.Errorf("unknown statement %s", rule.verdict.kind)
+	}
+	return exprs, nil
+}
+
+func nfMatchExprs(match nfMatch) ([]expr.Any, error) {
+	switch m := match.(type) {
+	case nfMetaNfproto:
+		proto := byte(unix.NFPROTO_IPV4)
+		if m.ipv6 {
+			proto = unix.NFPROTO_IPV6
+		}
+		return nfCmpExprs(&expr.Meta{Key: expr.MetaKeyNFPROTO, Register: 1}, []byte{proto}), nil
+
+	case nfMetaL4proto:
+		proto, ok := nfL4Protos[m.proto]
+		if !ok {
+			return nil, fmt.Errorf("unknown transport protocol %s", m.proto)
+		}
+		return nfCmpExprs(&expr.Meta{Key: expr.MetaKeyL4PROTO, Register: 1}, []byte{proto}), nil
+
+	case nfIfname:
+		key := expr.MetaKeyIIFNAME
+		if m.output {
+			key = expr.MetaKeyOIFNAME
+		}
+		return nfCmpExprs(&expr.Meta{Key: key, Register: 1}, nfIfnameData(m.name)), nil
+
+	case nfICMP:
+		if m.ipv6 {
+			return nfCmpExprs(nfNetworkHeader(6, 1), []byte{unix.IPPROTO_ICMPV6}), nil
+		}
+		return nfCmpExprs(nfNetworkHeader(9, 1), []byte{unix.IPPROTO_ICMP}), nil
+
+	case nfAddr:
+		return nfAddrExprs(m), nil
+
+	case nfPort:
+		var exprs []expr.Any
+		if m.proto != protoTH {
+			proto, ok := nfL4Protos[m.proto]
+			if !ok {
+				return nil, fmt.Errorf("unknown transport protocol %s", m.proto)
+			}
+			exprs = nfCmpExprs(&expr.Meta{Key: expr.MetaKeyL4PROTO, Register: 1}, []byte{proto})
+		}
+		dport := &expr.Payload{DestRegister: 1, Base: expr.PayloadBaseTransportHeader, Offset: 2, Len: 2}
+		if m.from == m.to {
+			return append(exprs, nfCmpExprs(dport, binaryutil.BigEndian.PutUint16(m.from))...), nil
+		}
+		return append(exprs, dport, &expr.Range{
+			Op:       expr.CmpOpEq,
+			Register: 1,
+			FromData: binaryutil.BigEndian.PutUint16(m.from),
+			ToData:   binaryutil.BigEndian.PutUint16(m.to),
+		}), nil
+
+	case nfCtEstablished:
+		return []expr.Any{
+			&expr.Ct{Register: 1, Key: expr.CtKeySTATE},
+			&expr.Bitwise{
+				SourceRegister: 1,
+				DestRegister:   1,
+				Len:            4,
+				Mask:           binaryutil.NativeEndian.PutUint32(expr.CtStateBitESTABLISHED | expr.CtStateBitRELATED),
+				Xor:            binaryutil.NativeEndian.PutUint32(0),
+			},
+			&expr.Cmp{Op: expr.CmpOpNeq, Register: 1, Data: binaryutil.NativeEndian.PutUint32(0)},
+		}, nil
+
+	case nfLimit:
+		return []expr.Any{&expr.Limit{
+			Type:  expr.LimitTypePkts,
+			Rate:  m.rate,
+			Unit:  expr.LimitTimeSecond,
+			Burst: m.burst,
+		}}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown match %s", match)
+	}
+}
+
+// nfAddrExprs loads the address from the network header and compares it against the prefix or the range
+func nfAddrExprs(m nfAddr) []expr.Any {
+	offset, length := uint32(12), uint32(net.IPv4len)
+	if m.dir == destAddr {
+		offset = 16
+	}
+	if m.ipv6 {
+		offset, length = 8, net.IPv6len
+		if m.dir == destAddr {
+			offset = 24
+		}
+	}
+	load := nfNetworkHeader(offset, length)
+
+	if m.to.IsValid() {
+		return []expr.Any{load, &expr.Range{
+			Op:       expr.CmpOpEq,
+			Register: 1,
+			FromData: m.from.AsSlice(),
+			ToData:   m.to.AsSlice(),
+		}}
+	}
+	if m.prefix.IsSingleIP() {
+		return nfCmpExprs(load, m.prefix.Addr().AsSlice())
+	}
+	mask := net.CIDRMask(m.prefix.Bits(), int(length*8))
+	return []expr.Any{
+		load,
+		&expr.Bitwise{
+			SourceRegister: 1,
+			DestRegister:   1,
+			Len:            length,
+			Mask:           mask,
+			Xor:            make([]byte, length),
+		},
+		&expr.Cmp{Op: expr.CmpOpEq, Register: 1, Data: m.prefix.Addr().AsSlice()},
+	}
+}
+
+func nfNetworkHeader(offset, length uint32) *expr.Payload {
+	return &expr.Payload{DestRegister: 1, Base: expr.PayloadBaseNetworkHeader, Offset: offset, Len: length}
+}
+
+func nfCmpExprs(load expr.Any, data []byte) []expr.Any {
+	return []expr.Any{load, &expr.Cmp{Op: expr.CmpOpEq, Register: 1, Data: data}}
+}
+
+// nfIfnameData returns the interface name the way the kernel compares it, padded to IFNAMSIZ
+func nfIfnameData(name string) []byte {
+	data := make([]byte, unix.IFNAMSIZ)
+	copy(data, name+"\x00")
+	return data
+}
@@ -0,0 +1,19 @@
+//go:build !linux
+
+package nexodus
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// nfApplyTables for build purposes, nftables is only available on Linux
+func nfApplyTables(logger *zap.SugaredLogger, tables ...*nfTable) error {
+	return fmt.Errorf("nftables is only supported on Linux")
+}
+
+// nfRuleCounters for build purposes, nftables is only available on Linux
+func nfRuleCounters(name string) (map[string]nfCounter, error) {
+	return nil, fmt.Errorf("nftables is only supported on Linux")
+}
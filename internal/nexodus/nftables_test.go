@@ -0,0 +1,82 @@
+package nexodus
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExitNodeTables(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal(`table inet nexodus-exit-node {
+	chain prerouting {
+		type nat hook prerouting priority dstnat;
+	}
+	chain postrouting {
+		type nat hook postrouting priority srcnat;
+		oifname "eth0" counter masquerade
+	}
+	chain forward {
+		type filter hook forward priority filter;
+		iifname "wg0" counter accept
+	}
+}
+`, exitOriginTable("wg0", "eth0").String())
+
+	apiServerAddrs := []netip.Addr{
+		netip.MustParseAddr("::ffff:192.0.2.10"),
+		netip.MustParseAddr("2001:db8::10"),
+	}
+	require.Equal(`table inet nexodus-oob-mangle {
+	chain OUTPUT {
+		type route hook output priority mangle; policy accept;
+		meta l4proto udp udp dport 53 counter meta mark set 0x00004b66
+		ip daddr 192.0.2.10 tcp dport 443 counter meta mark set 0x00004b66
+		ip6 daddr 2001:db8::10 tcp dport 443 counter meta mark set 0x00004b66
+		meta l4proto udp udp dport 19302 counter meta mark set 0x00004b66
+	}
+}
+`, exitClientMangleTable(apiServerAddrs).String())
+
+	require.Equal(`table inet nexodus-oob-snat {
+	chain POSTROUTING {
+		type nat hook postrouting priority srcnat; policy accept;
+		oifname "eth0" counter masquerade
+	}
+}
+`, exitClientSnatTable("eth0").String())
+}
+
+func TestParseNfAddr(t *testing.T) {
+	tests := []struct {
+		ipv6    bool
+		ipRange string
+		want    string
+		wantErr bool
+	}{
+		{ipRange: "10.0.0.1/24", want: "ip saddr 10.0.0.0/24"},
+		{ipRange: "10.0.0.1", want: "ip saddr 10.0.0.1"},
+		{ipRange: "10.0.0.1/32", want: "ip saddr 10.0.0.1"},
+		{ipRange: "10.0.0.1 - 10.0.0.9", want: "ip saddr 10.0.0.1-10.0.0.9"},
+		{ipv6: true, ipRange: "2001:db8::1/64", want: "ip6 saddr 2001:db8::/64"},
+		{ipv6: true, ipRange: "2001:db8::1-2001:db8::9", want: "ip6 saddr 2001:db8::1-2001:db8::9"},
+		{ipRange: "10.0.0.9-10.0.0.1", wantErr: true},
+		{ipRange: "10.0.0.1-2001:db8::1", wantErr: true},
+		{ipRange: "2001:db8::1", wantErr: true},
+		{ipv6: true, ipRange: "10.0.0.0/8", wantErr: true},
+		{ipRange: "10.0.0.300", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ipRange, func(t *testing.T) {
+			addr, err := parseNfAddr(tt.ipv6, srcAddr, tt.ipRange)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, addr.String())
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package nexodus
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/nexodus-io/nexodus/internal/client"
+	"github.com/nexodus-io/nexodus/internal/util"
+)
+
+// securityGroupPeerAddresses returns the tunnel IPs of the devices in the device cache matched by
+// each peer selector of the security group, keyed by the selector.
+// assumes deviceCacheLock is held
+func (nx *Nexodus) securityGroupPeerAddresses() map[string][]string {
+	if nx.securityGroup == nil {
+		return nil
+	}
+	var peers map[string][]string
+	for _, rule := range append(append([]client.ModelsSecurityRule{}, nx.securityGroup.InboundRules...), nx.securityGroup.OutboundRules...) {
+		if rule.GetPeerSelector() == "" {
+			continue
+		}
+		if peers == nil {
+			peers = map[string][]string{}
+		}
+		if _, ok := peers[rule.GetPeerSelector()]; ok {
+			continue
+		}
+		selector, err := util.ParseLabelSelector(rule.GetPeerSelector())
+		if err != nil {
+			nx.logger.Warnf("ignoring invalid security group peer selector %q: %v", rule.GetPeerSelector(), err)
+			peers[rule.GetPeerSelector()] = nil
+			continue
+		}
+		var addresses []string
+		for _, d := range nx.deviceCache {
+			if !util.LabelsMatch(selector, d.device.Labels) {
+				continue
+			}
+			for _, ip := range append(append([]client.ModelsTunnelIP{}, d.device.Ipv4TunnelIps...), d.device.Ipv6TunnelIps...) {
+				if ip.GetAddress() != "" {
+					addresses = append(addresses, ip.GetAddress())
+				}
+			}
+		}
+		sort.Strings(addresses)
+		peers[rule.GetPeerSelector()] = addresses
+	}
+	return peers
+}
+
+// securityGroupPeersChanged returns true when the devices matched by the peer selectors of the
+// security group differ from the ones the rules were last applied with.
+// assumes deviceCacheLock is held
+func (nx *Nexodus) securityGroupPeersChanged() bool {
+	if nx.securityGroup == nil {
+		return false
+	}
+	return !reflect.DeepEqual(nx.securityGroupPeerAddresses(), nx.securityGroupPeers)
+}
+
+// securityGroupRules returns the inbound and outbound rules of the security group with the peer
+// selectors expanded into the tunnel IPs of the matching devices.
+func (nx *Nexodus) securityGroupRules() ([]client.ModelsSecurityRule, []client.ModelsSecurityRule) {
+	nx.deviceCacheLock.RLock()
+	peers := nx.securityGroupPeerAddresses()
+	nx.deviceCacheLock.RUnlock()
+
+	nx.securityGroupPeers = peers
+	return expandPeerSelectors(nx.securityGroup.InboundRules, peers), expandPeerSelectors(nx.securityGroup.OutboundRules, peers)
+}
+
+// expandPeerSelectors adds the addresses matched by the peer selector of each rule to its ip ranges.
+// The addresses are split into one rule per address family, since the rule processing of each
+// platform expects the ranges of a rule to be of a single family. A rule with a selector that
+// does not match any address is dropped, an empty ip range would otherwise permit any address.
+func expandPeerSelectors(rules []client.ModelsSecurityRule, peers map[string][]string) []client.ModelsSecurityRule {
+	var result []client.ModelsSecurityRule
+	for _, rule := range rules {
+		if rule.GetPeerSelector() == "" {
+			result = append(result, rule)
+			continue
+		}
+
+		var v4Ranges, v6Ranges []string
+		for _, ipRange := range append(append([]string{}, rule.IpRanges...), peers[rule.GetPeerSelector()]...) {
+			switch {
+			case ipRange == "":
+				continue
+			case util.ContainsValidCustomIPv4Ranges([]string{ipRange}):
+				v4Ranges = append(v4Ranges, ipRange)
+			case util.ContainsValidCustomIPv6Ranges([]string{ipRange}):
+				v6Ranges = append(v6Ranges, ipRange)
+			}
+		}
+
+		switch rule.GetIpProtocol() {
+		case "ipv4", "icmpv4", "icmp4":
+			v6Ranges = nil
+		case "ipv6", "icmpv6", "icmp6":
+			v4Ranges = nil
+		}
+
+		for _, ranges := range [][]string{v4Ranges, v6Ranges} {
+			if len(ranges) == 0 {
+				continue
+			}
+			expanded := rule
+			expanded.IpRanges = ranges
+			expanded.PeerSelector = nil
+			result = append(result, expanded)
+		}
+	}
+	return result
+}
@@ -0,0 +1,67 @@
+package nexodus
+
+import (
+	"testing"
+
+	"github.com/nexodus-io/nexodus/internal/client"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSecurityGroupPeerSelectors(t *testing.T) {
+	require := require.New(t)
+
+	nx := &Nexodus{
+		logger: zap.NewNop().Sugar(),
+		securityGroup: &client.ModelsSecurityGroup{
+			InboundRules: []client.ModelsSecurityRule{
+				{IpProtocol: client.PtrString("tcp"), FromPort: client.PtrInt32(5432), ToPort: client.PtrInt32(5432), PeerSelector: client.PtrString("role=web")},
+				{IpProtocol: client.PtrString("icmpv4"), PeerSelector: client.PtrString("role=web")},
+				{IpProtocol: client.PtrString("tcp"), FromPort: client.PtrInt32(22), ToPort: client.PtrInt32(22), PeerSelector: client.PtrString("role=bastion")},
+				{IpProtocol: client.PtrString("tcp"), FromPort: client.PtrInt32(443), ToPort: client.PtrInt32(443), IpRanges: []string{"10.0.0.0/8"}},
+			},
+		},
+		deviceCache: map[string]deviceCacheEntry{
+			"key1": {
+				device: client.ModelsDevice{
+					Labels:        map[string]string{"role": "web", "env": "prod"},
+					Ipv4TunnelIps: []client.ModelsTunnelIP{{Address: client.PtrString("100.64.0.1")}},
+					Ipv6TunnelIps: []client.ModelsTunnelIP{{Address: client.PtrString("200::1")}},
+				},
+			},
+			"key2": {
+				device: client.ModelsDevice{
+					Labels:        map[string]string{"role": "db"},
+					Ipv4TunnelIps: []client.ModelsTunnelIP{{Address: client.PtrString("100.64.0.2")}},
+				},
+			},
+		},
+	}
+
+	require.True(nx.securityGroupPeersChanged())
+	inbound, outbound := nx.securityGroupRules()
+	require.False(nx.securityGroupPeersChanged())
+	require.Empty(outbound)
+	require.Equal([]client.ModelsSecurityRule{
+		{IpProtocol: client.PtrString("tcp"), FromPort: client.PtrInt32(5432), ToPort: client.PtrInt32(5432), IpRanges: []string{"100.64.0.1"}},
+		{IpProtocol: client.PtrString("tcp"), FromPort: client.PtrInt32(5432), ToPort: client.PtrInt32(5432), IpRanges: []string{"200::1"}},
+		{IpProtocol: client.PtrString("icmpv4"), IpRanges: []string{"100.64.0.1"}},
+		// the bastion selector does not match any device so its rule is dropped
+		{IpProtocol: client.PtrString("tcp"), FromPort: client.PtrInt32(443), ToPort: client.PtrInt32(443), IpRanges: []string{"10.0.0.0/8"}},
+	}, inbound)
+
+	// a new device matching a selector changes the rules
+	nx.deviceCache["key3"] = deviceCacheEntry{
+		device: client.ModelsDevice{
+			Labels:        map[string]string{"role": "bastion"},
+			Ipv4TunnelIps: []client.ModelsTunnelIP{{Address: client.PtrString("100.64.0.3")}},
+		},
+	}
+	require.True(nx.securityGroupPeersChanged())
+	inbound, _ = nx.securityGroupRules()
+	require.Contains(inbound, client.ModelsSecurityRule{IpProtocol: client.PtrString("tcp"), FromPort: client.PtrInt32(22), ToPort: client.PtrInt32(22), IpRanges: []string{"100.64.0.3"}})
+
+	// and so does one that goes away
+	delete(nx.deviceCache, "key1")
+	require.True(nx.securityGroupPeersChanged())
+}
@@ -77,13 +77,16 @@ func (nx *Nexodus) processSecurityGroupRules() error {
 		return fmt.Errorf("failed to append io.nexodus anchor: %w", err)
 	}
 
+	// Peer selectors are expanded into the tunnel IPs of the matching devices
+	inboundRules, outboundRules := nx.securityGroupRules()
+
 	// Explicit drop if rules are defined
 	if len(nx.securityGroup.InboundRules) > 0 {
 		prb.pfBlockAll("in")
 	}
 
 	// Process inbound rules
-	for _, rule := range nx.securityGroup.InboundRules {
+	for _, rule := range inboundRules {
 		if len(rule.IpRanges) == 0 || containsEmptyRange(rule.IpRanges) {
 			if err := prb.pfPermitProtoPortAnyAddr(rule, "inbound"); err != nil {
 				nx.logger.Errorf("pfctl setup error, failed to process inbound rule with 'any': %v", err)
@@ -108,7 +111,7 @@ func (nx *Nexodus) processSecurityGroupRules() error {
 	}
 
 	// Process outbound rules
-	for _, rule := range nx.securityGroup.OutboundRules {
+	for _, rule := range outboundRules {
 		if len(rule.IpRanges) == 0 || containsEmptyRange(rule.IpRanges) {
 			if err := prb.pfPermitProtoPortAnyAddr(rule, "outbound"); err != nil {
 				nx.logger.Errorf("pfctl setup error, failed to process outbound rule with 'any': %v", err)
@@ -187,7 +190,7 @@ func (prb *pfRuleBuilder) pfPermitProtoPortAddr(rule client.ModelsSecurityRule,
 
 	protocol := rule.GetIpProtocol()
 	inetType := "inet"
-	if protocol == "ipv6" || protocol == "icmp6" || protocol == "icmpv6" {
+	if protocol == "ipv6" || protocol == "icmp6" || protocol == "icmpv6" || (util.ContainsValidCustomIPv6Ranges(rule.IpRanges) && !util.ContainsValidCustomIPv4Ranges(rule.IpRanges)) {
 		inetType = "inet6"
 	}
 
@@ -407,3 +410,8 @@ func (nx *Nexodus) networkRouterSetup() error {
 func (nx *Nexodus) policyTableDrop(table string) error {
 	return nil
 }
+
+// securityGroupCounters for Darwin build purposes, the pf rules are not counted per security rule
+func (nx *Nexodus) securityGroupCounters() (map[string]nfCounter, error) {
+	return nil, fmt.Errorf("security group counters are only supported on Linux")
+}
@@ -0,0 +1,259 @@
+//go:build linux
+
+package nexodus
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/nftables/expr"
+	"github.com/nexodus-io/nexodus/internal/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runTestNftablesRuleBuilder(t *testing.T, securityGroupJSON string, peers map[string][]string, expectedRules []string) {
+	var secGroup client.ModelsSecurityGroup
+	err := json.Unmarshal([]byte(securityGroupJSON), &secGroup)
+	if err != nil {
+		t.Fatalf("Error unmarshaling JSON: %v", err)
+	}
+
+	table, err := securityGroupTable("wg0", secGroup, peers, false)
+	require.NoError(t, err)
+
+	// Assert and output the generated rules for debugging
+	t.Logf("Generated nftables rules:\n%s\n", table)
+
+	for _, rule := range expectedRules {
+		assert.Contains(t, table.String(), rule, "Generated rules should include: "+rule)
+	}
+
+	// every rule must translate into netlink expressions
+	for _, chain := range table.chains {
+		_, err := nfChainOf(nil, chain)
+		require.NoError(t, err)
+		for _, rule := range chain.rules {
+			_, err := nfRuleExprs(rule)
+			require.NoError(t, err, rule.String())
+		}
+	}
+}
+
+func TestLinuxRuleBuilder(t *testing.T) {
+	mockSecurityGroup1 := `
+{
+	"group_name": "Test",
+	"inbound_rules": [
+		{"ip_protocol": "ipv4", "ip_ranges": ["10.0.0.1/24", "192.168.1.1/32"]},
+		{"ip_protocol": "tcp"},
+		{"ip_protocol": "ipv6", "ip_ranges": ["::1/128", "2001:db8::/64"]},
+		{"from_port": 22, "to_port": 22, "ip_protocol": "tcp", "ip_ranges": ["10.0.0.1", "192.168.0.1"]},
+		{"ip_protocol": "icmpv4", "ip_ranges": ["10.0.0.1"]},
+		{"ip_protocol": "icmpv4"},
+		{"ip_protocol": "tcp", "from_port": 9001, "to_port": 9005, "ip_ranges": ["100.64.0.1 - 100.64.0.45"]},
+		{"ip_protocol": "icmpv6", "ip_ranges": ["::1"]},
+		{"ip_protocol": "ipv4", "from_port": 9000, "to_port": 9001},
+		{"ip_protocol": "ipv6", "from_port": 0, "to_port": 0}
+	],
+	"outbound_rules": [
+		{"ip_protocol": "udp", "from_port": 53, "to_port": 53, "ip_ranges": ["8.8.8.8"]},
+		{"ip_protocol": "icmpv6"},
+		{"ip_protocol": "ipv4", "ip_ranges": ["", "10.0.0.3"]},
+		{"ip_protocol": "ipv6", "from_port": 78, "to_port": 89, "ip_ranges": ["2001:db9::2/64", "3001:da9::2-3001:da9::6"]}
+	]
+}
+`
+	expectedRules1 := []string{
+		"chain nexodus-inbound {\n\t\ttype filter hook input priority filter; policy accept;\n\t\tct state established,related iifname \"wg0\" counter accept comment \"inbound:established\"\n",
+		`meta nfproto ipv4 ip saddr 10.0.0.0/24 iifname "wg0" counter accept comment "inbound:0"`,
+		`meta nfproto ipv4 ip saddr 192.168.1.1 iifname "wg0" counter accept comment "inbound:0"`,
+		`meta nfproto ipv4 tcp dport 0-65535 iifname "wg0" counter accept comment "inbound:1"`,
+		`meta nfproto ipv6 tcp dport 0-65535 iifname "wg0" counter accept comment "inbound:1"`,
+		`meta nfproto ipv6 ip6 saddr ::1 iifname "wg0" counter accept comment "inbound:2"`,
+		`meta nfproto ipv6 ip6 saddr 2001:db8::/64 iifname "wg0" counter accept`,
+		`meta nfproto ipv4 ip saddr 10.0.0.1 tcp dport 22 iifname "wg0" counter accept comment "inbound:3"`,
+		`meta nfproto ipv4 ip saddr 192.168.0.1 tcp dport 22 iifname "wg0" counter accept`,
+		`meta nfproto ipv4 ip protocol icmp ip saddr 10.0.0.1 iifname "wg0" counter accept`,
+		`meta nfproto ipv4 ip protocol icmp iifname "wg0" counter accept comment "inbound:5"`,
+		`meta nfproto ipv4 ip saddr 100.64.0.1-100.64.0.45 tcp dport 9001-9005 iifname "wg0" counter accept`,
+		`meta nfproto ipv6 ip6 nexthdr ipv6-icmp ip6 saddr ::1 iifname "wg0" counter accept`,
+		`meta nfproto ipv4 tcp dport 9000-9001 iifname "wg0" counter accept`,
+		`meta nfproto ipv4 udp dport 9000-9001 iifname "wg0" counter accept`,
+		`meta nfproto ipv6 iifname "wg0" counter accept comment "inbound:9"`,
+		"iifname \"wg0\" counter drop comment \"inbound:drop\"\n\t}\n\tchain nexodus-outbound {",
+		`meta nfproto ipv4 ip daddr 8.8.8.8 udp dport 53 iifname "wg0" counter accept comment "outbound:0"`,
+		`meta nfproto ipv6 ip6 nexthdr ipv6-icmp iifname "wg0" counter accept`,
+		`meta nfproto ipv4 ip daddr 10.0.0.3 iifname "wg0" counter accept`,
+		`meta nfproto ipv6 ip6 daddr 2001:db9::/64 th dport 78-89 iifname "wg0" counter accept`,
+		`meta nfproto ipv6 ip6 daddr 3001:da9::2-3001:da9::6 th dport 78-89 iifname "wg0" counter accept`,
+		"iifname \"wg0\" counter drop comment \"outbound:drop\"\n\t}\n}\n",
+	}
+	runTestNftablesRuleBuilder(t, mockSecurityGroup1, nil, expectedRules1)
+
+	mockSecurityGroup2 := `
+{
+	"group_name": "Test",
+	"inbound_rules": [
+		{"ip_protocol": "udp", "from_port": 5000, "to_port": 6000, "ip_ranges": ["2001:db8::1-2001:db8::ff"]}
+	],
+	"outbound_rules": []
+}
+`
+	expectedRules2 := []string{
+		`meta nfproto ipv6 ip6 saddr 2001:db8::1-2001:db8::ff udp dport 5000-6000 iifname "wg0" counter accept`,
+		"iifname \"wg0\" counter drop comment \"inbound:drop\"\n\t}\n\tchain nexodus-outbound {\n\t\ttype filter hook input priority filter; policy accept;\n\t}\n",
+	}
+	runTestNftablesRuleBuilder(t, mockSecurityGroup2, nil, expectedRules2)
+}
+
+func TestLinuxRuleBuilderPeerSelector(t *testing.T) {
+	mockSecurityGroup := `
+{
+	"group_name": "Test",
+	"inbound_rules": [
+		{"ip_protocol": "tcp", "from_port": 22, "to_port": 22, "peer_selector": "role=admin"},
+		{"ip_protocol": "udp", "peer_selector": "role=none"}
+	],
+	"outbound_rules": []
+}
+`
+	peers := map[string][]string{
+		"role=admin": {"100.64.0.1", "200::1"},
+	}
+	// a rule split by address family keeps the key of the security rule
+	expectedRules := []string{
+		`meta nfproto ipv4 ip saddr 100.64.0.1 tcp dport 22 iifname "wg0" counter accept comment "inbound:0"`,
+		`meta nfproto ipv6 ip6 saddr 200::1 tcp dport 22 iifname "wg0" counter accept comment "inbound:0"`,
+		"counter accept comment \"inbound:0\"\n\t\tiifname \"wg0\" counter drop comment \"inbound:drop\"\n",
+	}
+	runTestNftablesRuleBuilder(t, mockSecurityGroup, peers, expectedRules)
+}
+
+func TestLinuxRuleBuilderLogDenied(t *testing.T) {
+	var secGroup client.ModelsSecurityGroup
+	err := json.Unmarshal([]byte(`{
+		"group_name": "Test",
+		"inbound_rules": [{"ip_protocol": "tcp", "from_port": 22, "to_port": 22}],
+		"outbound_rules": [{"ip_protocol": "udp", "from_port": 53, "to_port": 53}]
+	}`), &secGroup)
+	require.NoError(t, err)
+
+	table, err := securityGroupTable("wg0", secGroup, nil, true)
+	require.NoError(t, err)
+
+	// the denied packets are logged right before the default drops
+	require.Contains(t, table.String(), `iifname "wg0" limit rate 10/second burst 20 packets log prefix "inbound:drop" group 51820
+		iifname "wg0" counter drop comment "inbound:drop"
+`)
+	require.Contains(t, table.String(), `iifname "wg0" limit rate 10/second burst 20 packets log prefix "outbound:drop" group 51820
+		iifname "wg0" counter drop comment "outbound:drop"
+`)
+	for _, chain := range table.chains {
+		for _, rule := range chain.rules {
+			_, err := nfRuleExprs(rule)
+			require.NoError(t, err, rule.String())
+		}
+	}
+}
+
+func TestLinuxRuleBuilderInvalidRange(t *testing.T) {
+	var secGroup client.ModelsSecurityGroup
+	err := json.Unmarshal([]byte(`{"group_name": "Test", "inbound_rules": [{"ip_protocol": "ipv4", "ip_ranges": ["10.0.0.9-10.0.0.1"]}]}`), &secGroup)
+	require.NoError(t, err)
+
+	_, err = securityGroupTable("wg0", secGroup, nil, false)
+	require.Error(t, err)
+}
+
+func TestNetworkRouterTable(t *testing.T) {
+	require := require.New(t)
+
+	prefixInterfaces := map[string]string{
+		"192.168.100.0/24": "eth1",
+		"10.0.0.0/8":       "eth0",
+		"172.16.0.0/12":    "eth0",
+	}
+	table, err := networkRouterTable(prefixInterfaces, false)
+	require.NoError(err)
+	require.Equal(`table inet nexodus-net-router {
+	chain prerouting {
+		type nat hook prerouting priority dstnat;
+	}
+	chain postrouting {
+		type nat hook postrouting priority srcnat;
+		oifname "eth0" counter masquerade
+		oifname "eth1" counter masquerade
+	}
+	chain forward {
+		type filter hook forward priority filter;
+		oifname "eth0" ip daddr 10.0.0.0/8 counter accept
+		oifname "eth0" ip daddr 172.16.0.0/12 counter accept
+		oifname "eth1" ip daddr 192.168.100.0/24 counter accept
+	}
+}
+`, table.String())
+
+	// no masquerade when NAT is disabled
+	table, err = networkRouterTable(prefixInterfaces, true)
+	require.NoError(err)
+	require.NotContains(table.String(), "masquerade")
+
+	_, err = networkRouterTable(map[string]string{"2001:db8::/64": "eth0"}, false)
+	require.Error(err)
+}
+
+func TestNfRuleExprs(t *testing.T) {
+	require := require.New(t)
+
+	addr, err := parseNfAddr(false, srcAddr, "100.64.0.0/10")
+	require.NoError(err)
+	exprs, err := nfRuleExprs(nfRule{
+		matches: []nfMatch{addr, nfPort{proto: protoTCP, from: 22, to: 22}},
+		counter: true,
+		verdict: nfAccept,
+	})
+	require.NoError(err)
+	require.Equal([]expr.Any{
+		// ip saddr 100.64.0.0/10
+		&expr.Payload{DestRegister: 1, Base: expr.PayloadBaseNetworkHeader, Offset: 12, Len: 4},
+		&expr.Bitwise{SourceRegister: 1, DestRegister: 1, Len: 4, Mask: []byte{0xff, 0xc0, 0, 0}, Xor: []byte{0, 0, 0, 0}},
+		&expr.Cmp{Op: expr.CmpOpEq, Register: 1, Data: []byte{100, 64, 0, 0}},
+		// tcp dport 22
+		&expr.Meta{Key: expr.MetaKeyL4PROTO, Register: 1},
+		&expr.Cmp{Op: expr.CmpOpEq, Register: 1, Data: []byte{6}},
+		&expr.Payload{DestRegister: 1, Base: expr.PayloadBaseTransportHeader, Offset: 2, Len: 2},
+		&expr.Cmp{Op: expr.CmpOpEq, Register: 1, Data: []byte{0, 22}},
+		&expr.Counter{},
+		&expr.Verdict{Kind: expr.VerdictAccept},
+	}, exprs)
+
+	exprs, err = nfRuleExprs(nfRule{
+		matches: []nfMatch{nfIfname{output: true, name: "eth0"}},
+		verdict: nfMasquerade,
+	})
+	require.NoError(err)
+	require.Equal([]expr.Any{
+		&expr.Meta{Key: expr.MetaKeyOIFNAME, Register: 1},
+		&expr.Cmp{Op: expr.CmpOpEq, Register: 1, Data: []byte("eth0\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00")},
+		&expr.Masq{},
+	}, exprs)
+
+	_, err = nfRuleExprs(nfRule{matches: []nfMatch{nfMetaL4proto{proto: "sctp"}}, verdict: nfAccept})
+	require.Error(err)
+}
+
+func TestNfCommentUserData(t *testing.T) {
+	require := require.New(t)
+
+	// the user data nft writes for: comment "inbound:0"
+	data := []byte{0x00, 0x0a, 'i', 'n', 'b', 'o', 'u', 'n', 'd', ':', '0', 0x00}
+	require.Equal(data, nfCommentUserData("inbound:0"))
+	require.Equal("inbound:0", nfUserDataComment(data))
+
+	// attributes of other types are skipped
+	require.Equal("inbound:0", nfUserDataComment(append([]byte{0x01, 0x02, 'x', 0x00}, data...)))
+	require.Nil(nfCommentUserData(""))
+	require.Equal("", nfUserDataComment(nil))
+	require.Equal("", nfUserDataComment([]byte{0x00, 0x0a, 'i'}))
+}
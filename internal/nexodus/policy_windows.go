@@ -2,9 +2,7 @@
 
 package nexodus
 
-import (
-	"go.uber.org/zap"
-)
+import "fmt"
 
 // processSecurityGroupRules for windows build purposes, policy currently unsupported on windows
 func (nx *Nexodus) processSecurityGroupRules() error {
@@ -16,12 +14,12 @@ func (nx *Nexodus) networkRouterSetup() error {
 	return nil
 }
 
-// policyCmd for windows build purposes
-func policyCmd(logger *zap.SugaredLogger, cmd []string) (string, error) {
-	return "", nil
-}
-
 // policyTableDrop for windows build purposes
 func (nx *Nexodus) policyTableDrop(table string) error {
 	return nil
 }
+
+// securityGroupCounters for windows build purposes, policy currently unsupported on windows
+func (nx *Nexodus) securityGroupCounters() (map[string]nfCounter, error) {
+	return nil, fmt.Errorf("security group counters are only supported on Linux")
+}
@@ -0,0 +1,86 @@
+package nexodus
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nexodus-io/nexodus/internal/client"
+	"github.com/nexodus-io/nexodus/internal/wgcrypto"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+// presharedKeysRefreshInterval is how often the pre-shared keys are fetched to pick up changes of the VPC setting
+const presharedKeysRefreshInterval = time.Minute
+
+// refreshPresharedKeys fetches the pre-shared keys of the device pairs when they are due for a refresh or
+// when a peer showed up that there is no key for yet.
+func (nx *Nexodus) refreshPresharedKeys(ctx context.Context, peerMap map[string]client.ModelsDevice) error {
+	if nx.deviceId == "" {
+		return nil
+	}
+	sinceFetch := time.Since(nx.presharedKeysFetchedAt)
+	if sinceFetch < presharedKeysRefreshInterval && !(nx.presharedKeysEnabled && nx.presharedKeysMissing(peerMap) && sinceFetch >= pollInterval) {
+		return nil
+	}
+	nx.presharedKeysFetchedAt = time.Now()
+
+	res, _, err := nx.client.DevicesApi.GetDevicePresharedKeys(ctx, nx.deviceId).Execute()
+	if err != nil {
+		nx.metrics.apiError("get_preshared_keys")
+		return fmt.Errorf("failed to get the pre-shared keys: %w", err)
+	}
+
+	privateKey, err := wgtypes.ParseKey(nx.wireguardPvtKey)
+	if err != nil {
+		return err
+	}
+	presharedKeys := make(map[string]string, len(res.PresharedKeys))
+	for peerId, value := range res.PresharedKeys {
+		sealed, err := wgcrypto.ParseSealed(value)
+		if err != nil {
+			return fmt.Errorf("invalid pre-shared key for peer %s: %w", peerId, err)
+		}
+		data, err := sealed.Open(privateKey[:])
+		if err != nil {
+			return fmt.Errorf("failed to open the pre-shared key for peer %s: %w", peerId, err)
+		}
+		psk, err := wgtypes.NewKey(data)
+		if err != nil {
+			return fmt.Errorf("invalid pre-shared key for peer %s: %w", peerId, err)
+		}
+		presharedKeys[peerId] = psk.String()
+	}
+
+	if res.GetEnabled() != nx.presharedKeysEnabled {
+		if res.GetEnabled() {
+			nx.logger.Info("The vpc uses pre-shared keys, adding them to the peers")
+		} else {
+			nx.logger.Info("The vpc no longer uses pre-shared keys, removing them from the peers")
+		}
+	}
+	nx.presharedKeysEnabled = res.GetEnabled()
+	nx.presharedKeys = presharedKeys
+	return nil
+}
+
+// presharedKeysMissing returns whether there is a peer without a pre-shared key
+func (nx *Nexodus) presharedKeysMissing(peerMap map[string]client.ModelsDevice) bool {
+	for id := range peerMap {
+		if id == nx.deviceId {
+			continue
+		}
+		if _, ok := nx.presharedKeys[id]; !ok {
+			return true
+		}
+	}
+	return false
+}
+
+// parsePresharedKey returns the pre-shared key to configure on a peer, the zero key removes the pre-shared key
+func parsePresharedKey(presharedKey string) (wgtypes.Key, error) {
+	if presharedKey == "" {
+		return wgtypes.Key{}, nil
+	}
+	return wgtypes.ParseKey(presharedKey)
+}
@@ -0,0 +1,192 @@
+package nexodus
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nexodus-io/nexodus/internal/util"
+)
+
+// proxyHealthCheckTimeout is the longest a health check waits for a destination to accept a connection
+const proxyHealthCheckTimeout = 2 * time.Second
+
+// proxyBackend is a destination of a proxy along with its health and load
+type proxyBackend struct {
+	dest HostPort
+	// connections currently proxied to the destination
+	activeConnections atomic.Int64
+
+	// the health check state, guarded by the mutex of the proxy
+	healthy   bool
+	successes int
+	failures  int
+	lastError string
+}
+
+func newProxyBackend(dest HostPort) *proxyBackend {
+	// destinations are healthy until the health checks find otherwise
+	return &proxyBackend{dest: dest, healthy: true}
+}
+
+// status describes the health and load of the destination for the proxy rule listing
+func (backend *proxyBackend) status(checked bool) string {
+	health := "unchecked"
+	if checked {
+		health = "healthy"
+		if !backend.healthy {
+			health = fmt.Sprintf("unhealthy (%s)", backend.lastError)
+		}
+	}
+	return fmt.Sprintf("%s, active connections: %d", health, backend.activeConnections.Load())
+}
+
+// addBackend adds the backend of a destination if the proxy does not have one yet
+// assumes proxy.mu is held
+func (proxy *UsProxy) addBackend(dest HostPort) {
+	if proxy.backends == nil {
+		proxy.backends = map[HostPort]*proxyBackend{}
+	}
+	if _, found := proxy.backends[dest]; !found {
+		proxy.backends[dest] = newProxyBackend(dest)
+	}
+}
+
+// removeBackend removes the backend of a destination once no rule of the proxy uses it
+// assumes proxy.mu is held
+func (proxy *UsProxy) removeBackend(dest HostPort) {
+	for _, rule := range proxy.rules {
+		if rule.dest == dest {
+			return
+		}
+	}
+	delete(proxy.backends, dest)
+}
+
+// NextBackend selects the destination of a new connection from the client with the policy of
+// the proxy. Unhealthy destinations are skipped, unless all of them are unhealthy.
+func (proxy *UsProxy) NextBackend(clientAddr net.Addr) *proxyBackend {
+	proxy.mu.RLock()
+	defer proxy.mu.RUnlock()
+
+	var candidates []*proxyBackend
+	for _, rule := range proxy.rules {
+		if backend := proxy.backends[rule.dest]; backend.healthy {
+			candidates = append(candidates, backend)
+		}
+	}
+	if len(candidates) == 0 {
+		for _, rule := range proxy.rules {
+			candidates = append(candidates, proxy.backends[rule.dest])
+		}
+	}
+
+	counter := atomic.AddUint64(&proxy.connectionCounter, 1)
+	switch proxy.options.policy {
+	case proxyPolicyLeastConnections:
+		// start at the next destination in turn so that ties are spread over the destinations
+		var selected *proxyBackend
+		for i := range candidates {
+			backend := candidates[(counter+uint64(i))%uint64(len(candidates))]
+			if selected == nil || backend.activeConnections.Load() < selected.activeConnections.Load() {
+				selected = backend
+			}
+		}
+		return selected
+	case proxyPolicySourceHash:
+		host := ""
+		if clientAddr != nil {
+			host, _, _ = net.SplitHostPort(clientAddr.String())
+		}
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(host))
+		return candidates[h.Sum32()%uint32(len(candidates))]
+	default:
+		return candidates[counter%uint64(len(candidates))]
+	}
+}
+
+// runHealthChecks checks the destinations of the proxy every health check interval until the context is done
+func (proxy *UsProxy) runHealthChecks(ctx context.Context) {
+	ticker := time.NewTicker(proxy.options.healthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			proxy.checkBackends(ctx)
+		}
+	}
+}
+
+// checkBackends opens a TCP connection to every destination of the proxy and ejects the destinations
+// that failed the unhealthy threshold of checks in a row, or brings them back once they passed the
+// healthy threshold of checks in a row.
+func (proxy *UsProxy) checkBackends(ctx context.Context) {
+	proxy.mu.RLock()
+	backends := make([]*proxyBackend, 0, len(proxy.backends))
+	for _, backend := range proxy.backends {
+		backends = append(backends, backend)
+	}
+	proxy.mu.RUnlock()
+
+	timeout := min(proxy.options.healthCheckInterval, proxyHealthCheckTimeout)
+	results := make([]error, len(backends))
+	wg := &sync.WaitGroup{}
+	for i, backend := range backends {
+		i, backend := i, backend
+		util.GoWithWaitGroup(wg, func() {
+			checkCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			results[i] = proxy.checkBackend(checkCtx, backend.dest)
+		})
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return
+	}
+
+	proxy.mu.Lock()
+	defer proxy.mu.Unlock()
+	for i, backend := range backends {
+		if err := results[i]; err != nil {
+			backend.successes = 0
+			backend.failures++
+			backend.lastError = err.Error()
+			if backend.healthy && backend.failures >= proxy.options.unhealthyThreshold {
+				backend.healthy = false
+				proxy.logger.Warnf("Proxy destination %s is unhealthy, ejecting it: %v", backend.dest, err)
+			}
+		} else {
+			backend.failures = 0
+			backend.successes++
+			if !backend.healthy && backend.successes >= proxy.options.healthyThreshold {
+				backend.healthy = true
+				backend.lastError = ""
+				proxy.logger.Infof("Proxy destination %s is healthy again", backend.dest)
+			}
+		}
+	}
+}
+
+func (proxy *UsProxy) checkBackend(ctx context.Context, dest HostPort) error {
+	resolved, err := proxy.resolveDest(ctx, dest)
+	if err != nil {
+		return err
+	}
+	var conn net.Conn
+	if proxy.key.ruleType == ProxyTypeEgress {
+		conn, err = proxy.userspaceNet.DialContext(ctx, "tcp", resolved.String())
+	} else {
+		conn, err = (&net.Dialer{}).DialContext(ctx, "tcp", resolved.String())
+	}
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
@@ -0,0 +1,149 @@
+package nexodus
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestParseProxyRuleOptions(t *testing.T) {
+	require := require.New(t)
+
+	rule, err := ParseProxyRule("tcp:443:web:8443,policy=least-connections,health-check-interval=5s,unhealthy-threshold=1", ProxyTypeEgress)
+	require.NoError(err)
+	require.Equal(HostPort{host: "web", port: 8443}, rule.dest)
+	require.Equal(ProxyOptions{
+		policy:              proxyPolicyLeastConnections,
+		healthCheckInterval: 5 * time.Second,
+		healthyThreshold:    defaultProxyHealthyThreshold,
+		unhealthyThreshold:  1,
+	}, rule.options)
+	require.Equal("tcp:443:web:8443,policy=least-connections,health-check-interval=5s,unhealthy-threshold=1", rule.String())
+
+	rule, err = ParseProxyRule("udp:53:[fd00::1]:53,policy=source-hash", ProxyTypeIngress)
+	require.NoError(err)
+	require.Equal(HostPort{host: "fd00::1", port: 53}, rule.dest)
+	require.Equal(proxyPolicySourceHash, rule.options.policy)
+
+	rule, err = ParseProxyRule("tcp:443:10.0.0.1:8443", ProxyTypeIngress)
+	require.NoError(err)
+	require.Equal(defaultProxyOptions(), rule.options)
+	require.Equal("tcp:443:10.0.0.1:8443", rule.String())
+
+	for _, invalid := range []string{
+		"tcp:443:web:8443,policy=random",
+		"tcp:443:web:8443,health-check-interval=0s",
+		"tcp:443:web:8443,healthy-threshold=0",
+		"tcp:443:web:8443,timeout=5s",
+		"tcp:443:web:8443,policy",
+		"udp:53:10.0.0.1:53,health-check-interval=5s",
+	} {
+		_, err := ParseProxyRule(invalid, ProxyTypeEgress)
+		require.Error(err, invalid)
+	}
+}
+
+func TestProxyNextBackend(t *testing.T) {
+	require := require.New(t)
+
+	newProxy := func(options ...string) *UsProxy {
+		proxy := &UsProxy{logger: zap.NewNop().Sugar()}
+		for _, rule := range []string{"tcp:80:10.0.0.1:8080", "tcp:80:10.0.0.2:8080", "tcp:80:10.0.0.3:8080"} {
+			for _, option := range options {
+				rule += "," + option
+			}
+			r, err := ParseProxyRule(rule, ProxyTypeIngress)
+			require.NoError(err)
+			proxy.options = r.options
+			proxy.rules = append(proxy.rules, r)
+			proxy.addBackend(r.dest)
+		}
+		return proxy
+	}
+	client := func(ip string) net.Addr {
+		return &net.TCPAddr{IP: net.ParseIP(ip), Port: 50000}
+	}
+
+	// round-robin spreads the connections over the destinations, and skips the ejected ones
+	proxy := newProxy()
+	seen := map[string]int{}
+	for i := 0; i < 6; i++ {
+		seen[proxy.NextBackend(nil).dest.host]++
+	}
+	require.Equal(map[string]int{"10.0.0.1": 2, "10.0.0.2": 2, "10.0.0.3": 2}, seen)
+	proxy.backends[HostPort{host: "10.0.0.2", port: 8080}].healthy = false
+	for i := 0; i < 6; i++ {
+		require.NotEqual("10.0.0.2", proxy.NextBackend(nil).dest.host)
+	}
+
+	// all the destinations are used when none of them is healthy
+	for _, backend := range proxy.backends {
+		backend.healthy = false
+	}
+	require.NotNil(proxy.NextBackend(nil))
+
+	// least-connections picks the destination with the fewest active connections
+	proxy = newProxy("policy=least-connections")
+	proxy.backends[HostPort{host: "10.0.0.1", port: 8080}].activeConnections.Store(3)
+	proxy.backends[HostPort{host: "10.0.0.3", port: 8080}].activeConnections.Store(1)
+	for i := 0; i < 3; i++ {
+		require.Equal("10.0.0.2", proxy.NextBackend(nil).dest.host)
+	}
+
+	// source-hash keeps a client on the same destination, regardless of its source port
+	proxy = newProxy("policy=source-hash")
+	first := proxy.NextBackend(client("192.0.2.10"))
+	for i := 0; i < 5; i++ {
+		require.Equal(first, proxy.NextBackend(&net.TCPAddr{IP: net.ParseIP("192.0.2.10"), Port: 50001 + i}))
+	}
+}
+
+func TestProxyCheckBackends(t *testing.T) {
+	require := require.New(t)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	defer l.Close()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+	up := HostPort{host: "127.0.0.1", port: l.Addr().(*net.TCPAddr).Port}
+
+	// a port that nothing listens on
+	l2, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	down := HostPort{host: "127.0.0.1", port: l2.Addr().(*net.TCPAddr).Port}
+	require.NoError(l2.Close())
+
+	proxy := &UsProxy{
+		key:        ProxyKey{ruleType: ProxyTypeIngress, protocol: proxyProtocolTCP, listenPort: 80},
+		logger:     zap.NewNop().Sugar(),
+		lookupHost: lookupLocalHost,
+		options: ProxyOptions{
+			policy:              proxyPolicyRoundRobin,
+			healthCheckInterval: time.Second,
+			healthyThreshold:    2,
+			unhealthyThreshold:  2,
+		},
+	}
+	proxy.addBackend(up)
+	proxy.addBackend(down)
+
+	proxy.checkBackends(context.Background())
+	require.True(proxy.backends[down].healthy)
+	proxy.checkBackends(context.Background())
+	require.True(proxy.backends[up].healthy)
+	require.False(proxy.backends[down].healthy)
+	require.NotEmpty(proxy.backends[down].lastError)
+	require.Contains(proxy.backends[down].status(true), "unhealthy")
+}
@@ -5,6 +5,7 @@ import (
 	"net"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type ProxyType int
@@ -43,6 +44,119 @@ func parseProxyProtocol(protocol string) (ProxyProtocol, error) {
 	}
 }
 
+// ProxyPolicy selects the destination of a new connection among the destinations of a proxy
+type ProxyPolicy string
+
+const (
+	proxyPolicyRoundRobin       ProxyPolicy = "round-robin"
+	proxyPolicyLeastConnections ProxyPolicy = "least-connections"
+	proxyPolicySourceHash       ProxyPolicy = "source-hash"
+)
+
+func parseProxyPolicy(policy string) (ProxyPolicy, error) {
+	switch ProxyPolicy(strings.ToLower(policy)) {
+	case proxyPolicyRoundRobin:
+		return proxyPolicyRoundRobin, nil
+	case proxyPolicyLeastConnections:
+		return proxyPolicyLeastConnections, nil
+	case proxyPolicySourceHash:
+		return proxyPolicySourceHash, nil
+	default:
+		return "", fmt.Errorf("invalid policy (%s)", policy)
+	}
+}
+
+const (
+	defaultProxyHealthyThreshold   = 2
+	defaultProxyUnhealthyThreshold = 3
+)
+
+// ProxyOptions are the load-balancing and health check options of a proxy, all the rules
+// of a proxy must use the same options.
+type ProxyOptions struct {
+	policy ProxyPolicy
+	// healthCheckInterval enables the TCP health checks of the destinations when set
+	healthCheckInterval time.Duration
+	// healthyThreshold is the number of passed checks that bring an ejected destination back
+	healthyThreshold int
+	// unhealthyThreshold is the number of failed checks that eject a destination
+	unhealthyThreshold int
+}
+
+func defaultProxyOptions() ProxyOptions {
+	return ProxyOptions{
+		policy:             proxyPolicyRoundRobin,
+		healthyThreshold:   defaultProxyHealthyThreshold,
+		unhealthyThreshold: defaultProxyUnhealthyThreshold,
+	}
+}
+
+func (options ProxyOptions) String() string {
+	// option=value,option=value with only the options that differ from the defaults
+	var parts []string
+	if options.policy != proxyPolicyRoundRobin {
+		parts = append(parts, fmt.Sprintf("policy=%s", options.policy))
+	}
+	if options.healthCheckInterval != 0 {
+		parts = append(parts, fmt.Sprintf("health-check-interval=%s", options.healthCheckInterval))
+	}
+	if options.healthyThreshold != defaultProxyHealthyThreshold {
+		parts = append(parts, fmt.Sprintf("healthy-threshold=%d", options.healthyThreshold))
+	}
+	if options.unhealthyThreshold != defaultProxyUnhealthyThreshold {
+		parts = append(parts, fmt.Sprintf("unhealthy-threshold=%d", options.unhealthyThreshold))
+	}
+	return strings.Join(parts, ",")
+}
+
+func parseThreshold(name, value string) (int, error) {
+	threshold, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s (%s): %w", name, value, err)
+	}
+	if threshold < 1 {
+		return 0, fmt.Errorf("invalid %s (%d): must be at least 1", name, threshold)
+	}
+	return threshold, nil
+}
+
+func parseProxyOptions(optionsStr string, protocol ProxyProtocol) (ProxyOptions, error) {
+	// option=value,option=value
+	options := defaultProxyOptions()
+	if optionsStr == "" {
+		return options, nil
+	}
+	for _, option := range strings.Split(optionsStr, ",") {
+		name, value, found := strings.Cut(option, "=")
+		if !found || value == "" {
+			return options, fmt.Errorf("invalid proxy rule option, must be in the form option=value (%s)", option)
+		}
+		var err error
+		switch name {
+		case "policy":
+			options.policy, err = parseProxyPolicy(value)
+		case "health-check-interval":
+			options.healthCheckInterval, err = time.ParseDuration(value)
+			if err == nil && options.healthCheckInterval <= 0 {
+				err = fmt.Errorf("invalid health-check-interval (%s): must be positive", value)
+			}
+		case "healthy-threshold":
+			options.healthyThreshold, err = parseThreshold(name, value)
+		case "unhealthy-threshold":
+			options.unhealthyThreshold, err = parseThreshold(name, value)
+		default:
+			err = fmt.Errorf("unknown proxy rule option (%s)", name)
+		}
+		if err != nil {
+			return options, err
+		}
+	}
+	if options.healthCheckInterval != 0 && protocol != proxyProtocolTCP {
+		return options, fmt.Errorf("health checks are only supported by tcp proxy rules")
+	}
+	return options, nil
+}
+
 type ProxyKey struct {
 	ruleType   ProxyType
 	protocol   ProxyProtocol
@@ -55,8 +169,9 @@ func (rule ProxyKey) String() string {
 
 type ProxyRule struct {
 	ProxyKey
-	dest   HostPort
-	stored bool
+	dest    HostPort
+	options ProxyOptions
+	stored  bool
 }
 
 type HostPort struct {
@@ -65,12 +180,15 @@ type HostPort struct {
 }
 
 func (hp HostPort) String() string {
-	// destination_ip:destination_port
+	// destination:destination_port
 	return net.JoinHostPort(hp.host, fmt.Sprintf("%d", hp.port))
 }
 
 func (rule ProxyRule) String() string {
-	// protocol:port:destination_ip:destination_port
+	// protocol:port:destination:destination_port[,option=value...]
+	if options := rule.options.String(); options != "" {
+		return fmt.Sprintf("%s:%d:%s,%s", rule.protocol, rule.listenPort, rule.dest, options)
+	}
 	return fmt.Sprintf("%s:%d:%s", rule.protocol, rule.listenPort, rule.dest)
 }
 
@@ -90,7 +208,8 @@ func parsePort(portStr string) (int, error) {
 }
 
 func ParseProxyRule(rule string, ruleType ProxyType) (emptyRule ProxyRule, err error) {
-	// protocol:port:destination_ip:destination_port
+	// protocol:port:destination:destination_port[,option=value...]
+	rule, optionsStr, _ := strings.Cut(rule, ",")
 	parts := strings.Split(rule, ":")
 	if len(parts) < 4 {
 		return emptyRule, fmt.Errorf("invalid proxy rule format, must specify 4 colon-separated values (%s)", rule)
@@ -122,6 +241,11 @@ func ParseProxyRule(rule string, ruleType ProxyType) (emptyRule ProxyRule, err e
 		return emptyRule, err
 	}
 
+	options, err := parseProxyOptions(optionsStr, protocol)
+	if err != nil {
+		return emptyRule, err
+	}
+
 	return ProxyRule{
 		ProxyKey: ProxyKey{
 			ruleType:   ruleType,
@@ -132,5 +256,6 @@ func ParseProxyRule(rule string, ruleType ProxyType) (emptyRule ProxyRule, err e
 			host: destHost,
 			port: destPort,
 		},
+		options: options,
 	}, nil
 }
@@ -3,12 +3,14 @@
 package nexodus
 
 import (
+	"errors"
 	"fmt"
 	"net"
 
 	"github.com/nexodus-io/nexodus/internal/client"
 	"github.com/nexodus-io/nexodus/internal/util"
 	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
 )
 
 // handlePeerRoute when a new configuration is deployed, delete/add the peer allowedIPs
@@ -89,3 +91,117 @@ func findInterfaceForIPRoute(ip string) (*net.Interface, error) {
 
 	return nil, fmt.Errorf("no matching interface found")
 }
+
+// addExitSrcRuleToRPDB adds a rule to the routing policy database (RPDB) of the address family that says, If a packet does
+// not have the firewall mark 51820, look up the routing table 51820.
+func addExitSrcRuleToRPDB(family int) error {
+	rule := netlink.NewRule()
+	rule.Family = family
+	rule.Mark = wgFwMark
+	rule.Invert = true
+	rule.Table = wgFwMark
+	if err := ruleAdd(rule); err != nil {
+		return fmt.Errorf("failed to add fwmark rule to RPDB: %w", err)
+	}
+
+	return nil
+}
+
+// addExitSrcRuleIgnorePrefixLength adds a rule to the RPDB that says, "When looking up the main routing table, ignore
+// the source address prefix length. This is useful for avoiding unnecessary routing cache updates when using policy-based routing.
+func addExitSrcRuleIgnorePrefixLength(family int) error {
+	rule := netlink.NewRule()
+	rule.Family = family
+	rule.Table = unix.RT_TABLE_MAIN
+	rule.SuppressPrefixlen = 0
+	if err := ruleAdd(rule); err != nil {
+		return fmt.Errorf("failed to add suppress_prefixlength rule to RPDB: %w", err)
+	}
+
+	return nil
+}
+
+// addExitSrcRuleFwMarkOOB This command adds a rule to the RPDB that says, If a packet has the firewall mark 19302, look up the routing
+// table 19302. This is used to route marked packets with destination port 19302 using the custom routing table
+func addExitSrcRuleFwMarkOOB(family int) error {
+	rule := netlink.NewRule()
+	rule.Family = family
+	rule.Mark = oobFwMark
+	rule.Table = oobFwMark
+	if err := ruleAdd(rule); err != nil {
+		return fmt.Errorf("failed to add OOB fwmark rule to RPDB: %w", err)
+	}
+
+	return nil
+}
+
+// ruleAdd adds the rule to the RPDB, a rule left by a previous run is kept as is
+func ruleAdd(rule *netlink.Rule) error {
+	if err := netlink.RuleAdd(rule); err != nil && !errors.Is(err, unix.EEXIST) {
+		return err
+	}
+
+	return nil
+}
+
+// addExitSrcDefaultRouteTable adds a default route to the routing table 51820, which says that all traffic should be sent through wg0.
+func addExitSrcDefaultRouteTable(family int) error {
+	link, err := netlink.LinkByName(wgIface)
+	if err != nil {
+		return fmt.Errorf("failed to lookup netlink device %s: %w", wgIface, err)
+	}
+	_, dst, _ := net.ParseCIDR(defaultRouteForFamily(family))
+	if err := netlink.RouteAdd(&netlink.Route{
+		LinkIndex: link.Attrs().Index,
+		Dst:       dst,
+		Table:     wgFwMark,
+	}); err != nil {
+		return fmt.Errorf("failed to add default route to routing table: %w", err)
+	}
+
+	return nil
+}
+
+// addExitSrcDefaultRouteTableOOB adds a default route to the OOB routing table, which sources traffic through the physical interface with a gateway
+func addExitSrcDefaultRouteTableOOB(family int, phyIface string) error {
+	getDefaultGateway, familyName := getDefaultGatewayIPv4, "IPv4"
+	if family == ipFamilyV6 {
+		getDefaultGateway, familyName = getDefaultGatewayIPv6, "IPv6"
+	}
+	gwIP, err := getDefaultGateway()
+	if err != nil {
+		return fmt.Errorf("failed to find an %s default gateway: %w", familyName, err)
+	}
+
+	link, err := netlink.LinkByName(phyIface)
+	if err != nil {
+		return fmt.Errorf("failed to lookup netlink device %s: %w", phyIface, err)
+	}
+	_, dst, _ := net.ParseCIDR(defaultRouteForFamily(family))
+	if err := netlink.RouteAdd(&netlink.Route{
+		LinkIndex: link.Attrs().Index,
+		Dst:       dst,
+		Gw:        net.ParseIP(gwIP),
+		Table:     oobFwMark,
+	}); err != nil {
+		return fmt.Errorf("failed to add default route to routing table %d: %w", oobFwMark, err)
+	}
+
+	return nil
+}
+
+// flushExitSrcRouteTableOOB flushes the specified routing table of the address family
+func flushExitSrcRouteTableOOB(family, routeTable int) error {
+	routes, err := netlink.RouteListFiltered(family, &netlink.Route{Table: routeTable}, netlink.RT_FILTER_TABLE)
+	if err != nil {
+		return fmt.Errorf("failed to list routing table %d: %w", routeTable, err)
+	}
+	for _, route := range routes {
+		route := route
+		if err := netlink.RouteDel(&route); err != nil {
+			return fmt.Errorf("failed to flush routing table %d: %w", routeTable, err)
+		}
+	}
+
+	return nil
+}
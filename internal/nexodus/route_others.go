@@ -0,0 +1,33 @@
+//go:build !linux
+
+package nexodus
+
+import "fmt"
+
+// The exit node client is only supported on Linux, these are for build purposes
+
+var errExitNodeUnsupported = fmt.Errorf("exit node support is currently only supported for Linux operating systems")
+
+func addExitSrcRuleToRPDB(family int) error {
+	return errExitNodeUnsupported
+}
+
+func addExitSrcRuleIgnorePrefixLength(family int) error {
+	return errExitNodeUnsupported
+}
+
+func addExitSrcRuleFwMarkOOB(family int) error {
+	return errExitNodeUnsupported
+}
+
+func addExitSrcDefaultRouteTable(family int) error {
+	return errExitNodeUnsupported
+}
+
+func addExitSrcDefaultRouteTableOOB(family int, phyIface string) error {
+	return errExitNodeUnsupported
+}
+
+func flushExitSrcRouteTableOOB(family, routeTable int) error {
+	return errExitNodeUnsupported
+}
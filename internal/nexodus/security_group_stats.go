@@ -0,0 +1,96 @@
+package nexodus
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/nexodus-io/nexodus/internal/client"
+)
+
+const (
+	sgInbound  = "inbound"
+	sgOutbound = "outbound"
+	// sgRuleEstablished is the rule that permits the packets of the established inbound connections
+	sgRuleEstablished = "established"
+	// sgRuleDrop is the default drop that follows the rules of a direction
+	sgRuleDrop = "drop"
+)
+
+// securityRuleKey returns the key of a rule of the security group, rule is the index of the rule in the
+// rules of the direction, or one of the implicit sgRuleEstablished and sgRuleDrop rules
+func securityRuleKey(direction, rule string) string {
+	return direction + ":" + rule
+}
+
+// securityRuleStats are the hits of a rule of the security group as listed by SecurityGroupStats
+type securityRuleStats struct {
+	Direction    string
+	Rule         string
+	IpProtocol   string
+	Ports        string
+	IpRanges     []string
+	PeerSelector string
+	Packets      uint64
+	Bytes        uint64
+}
+
+// securityGroupStats lists the counters of every rule of the security group, including the implicit rules,
+// in the order they are evaluated. The counters are by the key of the rules, see securityRuleKey.
+func securityGroupStats(securityGroup client.ModelsSecurityGroup, counters map[string]nfCounter) []securityRuleStats {
+	var stats []securityRuleStats
+	add := func(stat securityRuleStats) {
+		counter := counters[securityRuleKey(stat.Direction, stat.Rule)]
+		stat.Packets, stat.Bytes = counter.packets, counter.bytes
+		stats = append(stats, stat)
+	}
+
+	add(securityRuleStats{Direction: sgInbound, Rule: sgRuleEstablished})
+	for _, direction := range []struct {
+		name  string
+		rules []client.ModelsSecurityRule
+	}{
+		{sgInbound, securityGroup.InboundRules},
+		{sgOutbound, securityGroup.OutboundRules},
+	} {
+		for i, rule := range direction.rules {
+			add(securityRuleStats{
+				Direction:    direction.name,
+				Rule:         strconv.Itoa(i),
+				IpProtocol:   rule.GetIpProtocol(),
+				Ports:        securityRulePorts(rule),
+				IpRanges:     rule.IpRanges,
+				PeerSelector: rule.GetPeerSelector(),
+			})
+		}
+		if len(direction.rules) != 0 {
+			add(securityRuleStats{Direction: direction.name, Rule: sgRuleDrop})
+		}
+	}
+	return stats
+}
+
+func securityRulePorts(rule client.ModelsSecurityRule) string {
+	from, to := rule.GetFromPort(), rule.GetToPort()
+	switch {
+	case from == 0 && to == 0:
+		return "any"
+	case from == to:
+		return strconv.Itoa(int(from))
+	default:
+		return fmt.Sprintf("%d-%d", from, to)
+	}
+}
+
+// securityGroupRuleStats lists the counters of the rules of the security group applied to the device, it
+// returns no stats when the device has no security group
+func (nx *Nexodus) securityGroupRuleStats() ([]securityRuleStats, error) {
+	securityGroup := nx.securityGroup
+	if securityGroup == nil {
+		return nil, nil
+	}
+	counters, err := nx.securityGroupCounters()
+	if err != nil {
+		return nil, err
+	}
+	return securityGroupStats(*securityGroup, counters), nil
+}
@@ -0,0 +1,42 @@
+package nexodus
+
+import (
+	"testing"
+
+	"github.com/nexodus-io/nexodus/internal/client"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecurityGroupStats(t *testing.T) {
+	require := require.New(t)
+
+	securityGroup := client.ModelsSecurityGroup{
+		InboundRules: []client.ModelsSecurityRule{
+			{IpProtocol: client.PtrString("tcp"), FromPort: client.PtrInt32(22), ToPort: client.PtrInt32(22), IpRanges: []string{"100.64.0.0/10"}},
+			{IpProtocol: client.PtrString("icmpv4"), PeerSelector: client.PtrString("role=admin")},
+		},
+		OutboundRules: []client.ModelsSecurityRule{
+			{IpProtocol: client.PtrString("udp"), FromPort: client.PtrInt32(5000), ToPort: client.PtrInt32(6000)},
+		},
+	}
+	counters := map[string]nfCounter{
+		"inbound:established": {packets: 10, bytes: 1000},
+		"inbound:0":           {packets: 3, bytes: 180},
+		"inbound:drop":        {packets: 7, bytes: 420},
+		"outbound:0":          {packets: 1, bytes: 60},
+	}
+
+	require.Equal([]securityRuleStats{
+		{Direction: "inbound", Rule: "established", Packets: 10, Bytes: 1000},
+		{Direction: "inbound", Rule: "0", IpProtocol: "tcp", Ports: "22", IpRanges: []string{"100.64.0.0/10"}, Packets: 3, Bytes: 180},
+		{Direction: "inbound", Rule: "1", IpProtocol: "icmpv4", Ports: "any", PeerSelector: "role=admin"},
+		{Direction: "inbound", Rule: "drop", Packets: 7, Bytes: 420},
+		{Direction: "outbound", Rule: "0", IpProtocol: "udp", Ports: "5000-6000", Packets: 1, Bytes: 60},
+		{Direction: "outbound", Rule: "drop"},
+	}, securityGroupStats(securityGroup, counters))
+
+	// there is no default drop without rules
+	require.Equal([]securityRuleStats{
+		{Direction: "inbound", Rule: "established"},
+	}, securityGroupStats(client.ModelsSecurityGroup{}, nil))
+}
@@ -27,11 +27,19 @@ type UsProxy struct {
 	debugTraffic      bool
 	mu                sync.RWMutex
 	rules             []ProxyRule
+	options           ProxyOptions
+	backends          map[HostPort]*proxyBackend
 	connectionCounter uint64
 	userspaceNet      *netstack.Net
 	proxyCtx          context.Context
 	proxyCancel       context.CancelFunc
 	wg                sync.WaitGroup
+	// lookupHost resolves the destinations that name a host when a connection is made
+	lookupHost func(ctx context.Context, host string) (string, error)
+	// connection statistics reported by the metrics
+	activeConnections atomic.Int64
+	totalConnections  atomic.Uint64
+	connectionErrors  atomic.Uint64
 }
 
 const (
@@ -54,6 +62,12 @@ func (nx *Nexodus) UserspaceProxyAdd(newRule ProxyRule) (*UsProxy, error) {
 			key:    newRule.ProxyKey,
 			logger: nx.logger.With("proxy", newRule.ruleType, "key", newRule.ProxyKey),
 		}
+		if newRule.ruleType == ProxyTypeEgress {
+			// egress destinations are reached through the Nexodus network, so names of peers are looked up first
+			proxy.lookupHost = nx.lookupPeerHost
+		} else {
+			proxy.lookupHost = lookupLocalHost
+		}
 		proxy.debugTraffic, _ = strconv.ParseBool(os.Getenv("NEXD_PROXY_DEBUG_TRAFFIC"))
 		nx.proxies[newRule.ProxyKey] = proxy
 	}
@@ -67,7 +81,12 @@ func (nx *Nexodus) UserspaceProxyAdd(newRule ProxyRule) (*UsProxy, error) {
 	proxy.mu.Lock()
 	defer proxy.mu.Unlock()
 
+	if len(proxy.rules) > 0 && proxy.options != newRule.options {
+		return nil, fmt.Errorf("%s proxy rules must use the same options as the other rules of the proxy (%s)", newRule.ruleType, proxy.options)
+	}
+	proxy.options = newRule.options
 	proxy.rules = append(proxy.rules, newRule)
+	proxy.addBackend(newRule.dest)
 	return proxy, nil
 }
 
@@ -89,6 +108,7 @@ func (nx *Nexodus) UserspaceProxyRemove(cmpProxy ProxyRule) (*UsProxy, error) {
 	for i, rule := range proxy.rules {
 		if rule == cmpProxy {
 			proxy.rules = append(proxy.rules[:i], proxy.rules[i+1:]...)
+			proxy.removeBackend(rule.dest)
 			if len(proxy.rules) == 0 {
 				proxy.Stop()
 				delete(nx.proxies, cmpProxy.ProxyKey)
@@ -183,6 +203,13 @@ func (proxy *UsProxy) Start(ctx context.Context, wg *sync.WaitGroup, net *netsta
 			time.Sleep(time.Second)
 		}
 	})
+	if proxy.options.healthCheckInterval > 0 {
+		proxy.wg.Add(1)
+		util.GoWithWaitGroup(wg, func() {
+			defer proxy.wg.Done()
+			proxy.runHealthChecks(proxy.proxyCtx)
+		})
+	}
 
 }
 
@@ -224,6 +251,8 @@ type udpProxyConn struct {
 	goProxyConn *gonet.UDPConn
 	// The connection with the destination for an ingress proxy
 	proxyConn *net.UDPConn
+	// The destination the flow was assigned to
+	backend *proxyBackend
 	// Notify when the connection is to be closed
 	closeChan chan string
 	// track the last time inbound traffic was received
@@ -317,6 +346,8 @@ func (proxy *UsProxy) runUDP(ctx context.Context, proxyWg *sync.WaitGroup) error
 				_ = proxyConn.proxyConn.Close()
 			}
 			delete(proxyConns, clientAddrStr)
+			proxy.activeConnections.Add(-1)
+			proxyConn.backend.activeConnections.Add(-1)
 		default:
 			// read a packet from the originator sent to the proxy
 			if err = udpProxy.setReadDeadline(); err != nil {
@@ -344,10 +375,14 @@ func (proxy *UsProxy) runUDP(ctx context.Context, proxyWg *sync.WaitGroup) error
 				proxyConn = &udpProxyConn{udpProxy: udpProxy, clientAddr: clientAddr, closeChan: closeChan}
 				err = proxy.createUDPProxyConn(ctx, proxyWg, proxyConn)
 				if err != nil {
+					proxy.connectionErrors.Add(1)
 					proxy.logger.Warn("Error creating UDP proxy connection:", err)
 					continue
 				}
 				proxyConns[clientAddr.String()] = proxyConn
+				proxy.totalConnections.Add(1)
+				proxy.activeConnections.Add(1)
+				proxyConn.backend.activeConnections.Add(1)
 			}
 
 			// forward the original packet to the destination
@@ -413,21 +448,30 @@ func (proxyConn *udpProxyConn) writeBackToOriginator(buffer []byte, n int) error
 	return err
 }
 
-func (proxy *UsProxy) NextDest() HostPort {
-	proxy.mu.RLock()
-	defer proxy.mu.RUnlock()
-
-	counter := atomic.AddUint64(&proxy.connectionCounter, 1)
-
-	index := counter % uint64(len(proxy.rules))
-	return proxy.rules[index].dest
+// resolveDest resolves the host of a destination to an address at connection time, so that rules naming
+// a host keep working when the address of the host changes.
+func (proxy *UsProxy) resolveDest(ctx context.Context, dest HostPort) (HostPort, error) {
+	if net.ParseIP(dest.host) != nil {
+		return dest, nil
+	}
+	address, err := proxy.lookupHost(ctx, dest.host)
+	if err != nil {
+		return dest, fmt.Errorf("failed to resolve proxy destination %s: %w", dest.host, err)
+	}
+	return HostPort{host: address, port: dest.port}, nil
 }
 
 func (proxy *UsProxy) createUDPProxyConn(ctx context.Context, proxyWg *sync.WaitGroup, proxyConn *udpProxyConn) error {
 	var err error
-	dest := proxy.NextDest()
+	proxyConn.backend = proxy.NextBackend(proxyConn.clientAddr)
+	dest := proxyConn.backend.dest
 	logger := proxy.logger.With("dest", dest)
 
+	dest, err = proxy.resolveDest(ctx, dest)
+	if err != nil {
+		return err
+	}
+
 	if proxy.key.ruleType == ProxyTypeEgress {
 		newConn, err := proxy.userspaceNet.DialUDP(nil, &net.UDPAddr{Port: dest.port, IP: net.ParseIP(dest.host)})
 		if err != nil {
@@ -573,14 +617,19 @@ func (proxy *UsProxy) runTCP(ctx context.Context, proxyWg *sync.WaitGroup) error
 func (proxy *UsProxy) handleTCPConnection(ctx context.Context, proxyWg *sync.WaitGroup, inConn net.Conn) error {
 	defer util.IgnoreError(inConn.Close)
 
-	dest := proxy.NextDest()
+	backend := proxy.NextBackend(inConn.RemoteAddr())
+	dest := backend.dest
 	logger := proxy.logger.With("dest", dest)
 
-	proxyDest := net.JoinHostPort(dest.host, fmt.Sprintf("%d", dest.port))
+	resolved, err := proxy.resolveDest(ctx, dest)
+	if err != nil {
+		proxy.connectionErrors.Add(1)
+		return err
+	}
+	proxyDest := resolved.String()
 	logger.Debugf("Handling connection from %s, proxying to %s", inConn.RemoteAddr().String(), proxyDest)
 
 	var outConn net.Conn
-	var err error
 	protocolStr := fmt.Sprintf("%v", proxy.key.protocol)
 	if proxy.key.ruleType == ProxyTypeEgress {
 		outConn, err = proxy.userspaceNet.DialContext(ctx, protocolStr, proxyDest)
@@ -588,10 +637,17 @@ func (proxy *UsProxy) handleTCPConnection(ctx context.Context, proxyWg *sync.Wai
 		outConn, err = net.Dial(protocolStr, proxyDest)
 	}
 	if err != nil {
+		proxy.connectionErrors.Add(1)
 		return err
 	}
 	defer util.IgnoreError(outConn.Close)
 
+	proxy.totalConnections.Add(1)
+	proxy.activeConnections.Add(1)
+	defer proxy.activeConnections.Add(-1)
+	backend.activeConnections.Add(1)
+	defer backend.activeConnections.Add(-1)
+
 	util.GoWithWaitGroup(proxyWg, func() {
 		_, err := io.Copy(inConn, outConn)
 		if err != nil {
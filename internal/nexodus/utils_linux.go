@@ -231,6 +231,29 @@ func getDefaultGatewayIPv4() (string, error) {
 	return "", fmt.Errorf("unable to determine default route")
 }
 
+// getDefaultGatewayIPv6 return the IPv6 default gateway
+func getDefaultGatewayIPv6() (string, error) {
+	routes, err := netlink.RouteList(nil, syscall.AF_INET6)
+	if err != nil {
+		return "", err
+	}
+
+	for _, route := range routes {
+		if route.Dst == nil || route.Dst.String() == "::/0" {
+			// router advertisements from several routers install a multipath default route
+			if route.Gw == nil && len(route.MultiPath) > 0 && route.MultiPath[0].Gw != nil {
+				return route.MultiPath[0].Gw.String(), nil
+			}
+			if route.Gw == nil {
+				return "", fmt.Errorf("default route present, but gateway was not found")
+			}
+			return route.Gw.String(), nil
+		}
+	}
+
+	return "", fmt.Errorf("unable to determine default route")
+}
+
 // isElevatedUnix checks that nexd was started with appropriate permissions for Unix-based OS mode (Linux/macOS)
 func isElevated() (bool, error) {
 	if os.Geteuid() != 0 {
@@ -97,6 +97,11 @@ func getDefaultGatewayIPv4() (string, error) {
 	return "", fmt.Errorf("method currently unsupported for windows")
 }
 
+// getDefaultGatewayIPv6 not currently implemented for windows
+func getDefaultGatewayIPv6() (string, error) {
+	return "", fmt.Errorf("method currently unsupported for windows")
+}
+
 // isElevatedWindows checks that nexd was started with appropriate permissions for Windows OS mode
 func isElevated() (bool, error) {
 	_, err := os.Open("\\\\.\\PHYSICALDRIVE0")
@@ -51,6 +51,11 @@ func (nx *Nexodus) addPeerUS(wgPeerConfig wgPeerConfig) error {
 		return err
 	}
 
+	presharedKey, err := parsePresharedKey(wgPeerConfig.PresharedKey)
+	if err != nil {
+		return err
+	}
+
 	// Note: The default behavior is "replace_peers=false". If you try to send
 	// this, it returns an error. The code only handles "replace_peers=true".
 	//config := "replace_peers=false\n"
@@ -60,6 +65,8 @@ func (nx *Nexodus) addPeerUS(wgPeerConfig wgPeerConfig) error {
 	}
 	config += fmt.Sprintf("endpoint=%s\n", wgPeerConfig.Endpoint)
 	config += fmt.Sprintf("persistent_keepalive_interval=%d\n", keepaliveInterval/time.Second)
+	// the zero key removes a pre-shared key the peer had before
+	config += fmt.Sprintf("preshared_key=%s\n", hex.EncodeToString(presharedKey[:]))
 
 	nx.logger.Debugf("Adding wireguard peer using: %s", config)
 	err = nx.userspaceDev.IpcSet(config)
@@ -89,6 +96,12 @@ func (nx *Nexodus) addPeerOS(wgPeerConfig wgPeerConfig) error {
 		return err
 	}
 
+	// the zero key removes a pre-shared key the peer had before
+	presharedKey, err := parsePresharedKey(wgPeerConfig.PresharedKey)
+	if err != nil {
+		return err
+	}
+
 	allowedIP := make([]net.IPNet, len(wgPeerConfig.AllowedIPs))
 	for i := range wgPeerConfig.AllowedIPs {
 		_, ipNet, err := net.ParseCIDR(wgPeerConfig.AllowedIPs[i])
@@ -124,6 +137,7 @@ func (nx *Nexodus) addPeerOS(wgPeerConfig wgPeerConfig) error {
 			Peers: []wgtypes.PeerConfig{
 				{
 					PublicKey:                   pubKey,
+					PresharedKey:                &presharedKey,
 					Remove:                      false,
 					AllowedIPs:                  allowedIP,
 					PersistentKeepaliveInterval: &keepalive,
@@ -138,6 +152,7 @@ func (nx *Nexodus) addPeerOS(wgPeerConfig wgPeerConfig) error {
 			Peers: []wgtypes.PeerConfig{
 				{
 					PublicKey:                   pubKey,
+					PresharedKey:                &presharedKey,
 					Remove:                      false,
 					Endpoint:                    udpAddr,
 					AllowedIPs:                  allowedIP,
@@ -168,6 +183,25 @@ func (nx *Nexodus) handlePeerDelete(peerMap map[string]client.ModelsDevice) erro
 	return nil
 }
 
+// handlePeerKeyRotation removes the cache entries and wireguard peers of devices that are still in the
+// canonical peer listing but with a different public key, assumes a write lock is held on deviceCacheLock
+func (nx *Nexodus) handlePeerKeyRotation(peerMap map[string]client.ModelsDevice) {
+	for publicKey, d := range nx.deviceCache {
+		p, ok := peerMap[d.device.GetId()]
+		if !ok || p.GetPublicKey() == publicKey || p.GetId() == nx.deviceId {
+			// rotateKey re-keys the cache entry of the local device itself
+			continue
+		}
+		nx.logger.Debugf("Peer [ %s ] rotated its key from [ %s ] to [ %s ]", p.GetHostname(), publicKey, p.GetPublicKey())
+		// the routes stay in place since the peer keeps its allowed ips
+		if err := nx.deletePeer(publicKey, nx.tunnelIface); err != nil {
+			nx.logger.Warnf("failed to delete the peer with the rotated key %s: %v", publicKey, err)
+		}
+		delete(nx.deviceCache, publicKey)
+		delete(nx.wgConfig.Peers, publicKey)
+	}
+}
+
 func (nx *Nexodus) peerCleanup(peer client.ModelsDevice) error {
 	nx.logger.Debugf("Deleting peering config for key: %s\n", peer.GetPublicKey())
 	if err := nx.deletePeer(peer.GetPublicKey(), nx.tunnelIface); err != nil {
@@ -245,6 +279,38 @@ func (nx *Nexodus) deletePeerOS(publicKey, dev string) error {
 	return nil
 }
 
+// setPrivateKey replaces the private key of the wireguard interface, the peers are left in place
+func (nx *Nexodus) setPrivateKey(privateKey wgtypes.Key) error {
+	if nx.userspaceMode {
+		return nx.setPrivateKeyUS(privateKey)
+	}
+	return nx.setPrivateKeyOS(privateKey)
+}
+
+// setPrivateKeyUS replaces the private key of a userspace wireguard device
+func (nx *Nexodus) setPrivateKeyUS(privateKey wgtypes.Key) error {
+	// https://www.wireguard.com/xplatform/#configuration-protocol
+	config := fmt.Sprintf("private_key=%s\n", hex.EncodeToString(privateKey[:]))
+	if err := nx.userspaceDev.IpcSet(config); err != nil {
+		nx.logger.Errorf("Failed to set the wireguard private key: %w", err)
+		return err
+	}
+	return nil
+}
+
+// setPrivateKeyOS replaces the private key of an OS tun networking device
+func (nx *Nexodus) setPrivateKeyOS(privateKey wgtypes.Key) error {
+	wgClient, err := wgctrl.New()
+	if err != nil {
+		return err
+	}
+	defer wgClient.Close()
+
+	return wgClient.ConfigureDevice(nx.tunnelIface, wgtypes.Config{
+		PrivateKey: &privateKey,
+	})
+}
+
 func testWgListenPort(port int) error {
 	l, err := net.ListenUDP("udp", &net.UDPAddr{Port: port})
 	if err != nil {
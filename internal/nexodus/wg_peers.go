@@ -310,6 +310,7 @@ func (nx *Nexodus) buildPeersConfig() map[string]client.ModelsDevice {
 
 	now := time.Now()
 	wgRelayAvailable := relayAvailable && !isDerpRelay
+	routers := nx.advertisedCidrRouters()
 	for _, dIter := range nx.deviceCache {
 		d := dIter
 		// skip ourselves
@@ -318,6 +319,13 @@ func (nx *Nexodus) buildPeersConfig() map[string]client.ModelsDevice {
 		}
 
 		peerConfig, chosenMethod, chosenMethodIndex := nx.rebuildPeerConfig(&d, healthyRelay, wgRelayAvailable)
+		peerConfig.PresharedKey = nx.presharedKeys[d.device.GetId()]
+		if nx.exitNode.activeOriginId != "" && d.device.GetId() != nx.exitNode.activeOriginId {
+			// the exit node client routes the default routes through a single exit node origin
+			peerConfig.AllowedIPs = withoutDefaultRoutes(peerConfig.AllowedIPs)
+		}
+		peerConfig.AllowedIPs = withoutStandbyCidrs(peerConfig.AllowedIPs, d.device.GetId(), routers)
+		peerConfig.AllowedIPsForRelay = withoutStandbyCidrs(peerConfig.AllowedIPsForRelay, d.device.GetId(), routers)
 		if len(peerConfig.AllowedIPsForRelay) > 0 {
 			allowedIPsForRelay = append(allowedIPsForRelay, peerConfig.AllowedIPsForRelay...)
 		}
@@ -337,6 +345,9 @@ func (nx *Nexodus) buildPeersConfig() map[string]client.ModelsDevice {
 		} else {
 			nx.wgConfig.Peers[d.device.GetPublicKey()] = peerConfig
 		}
+		if d.peeringMethod != chosenMethod {
+			nx.metrics.peeringMethodChanged(chosenMethod)
+		}
 		d.peeringMethodIndex = chosenMethodIndex
 		d.peeringMethod = chosenMethod
 		d.peeringTime = now
@@ -395,6 +406,10 @@ func (nx *Nexodus) peerConfigUpdated(device client.ModelsDevice, peer wgPeerConf
 		return true
 	}
 
+	if nx.wgConfig.Peers[device.GetPublicKey()].PresharedKey != peer.PresharedKey {
+		return true
+	}
+
 	return false
 }
 
@@ -0,0 +1,42 @@
+package routers
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const metricsPath = "/private/metrics"
+
+var routeRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "apiserver",
+	Subsystem: "http",
+	Name:      "request_duration_seconds",
+	Help:      "Duration of the HTTP requests, by route and status.",
+	Buckets:   prometheus.DefBuckets,
+}, []string{"method", "route", "status"})
+
+func init() {
+	prometheus.MustRegister(routeRequestDuration)
+}
+
+// RouteMetricsMiddleware records the latency and count of the requests by the route template
+// they matched, so that the cardinality of the metric does not grow with the resource ids. It
+// replaces the go-gin-prometheus middleware, which labels the requests with their URL path.
+func RouteMetricsMiddleware(c *gin.Context) {
+	if c.Request.URL.Path == metricsPath {
+		c.Next()
+		return
+	}
+	start := time.Now()
+	c.Next()
+
+	route := c.FullPath()
+	if route == "" {
+		route = "unmatched"
+	}
+	routeRequestDuration.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).
+		Observe(time.Since(start).Seconds())
+}
@@ -180,6 +180,22 @@ func ValidateJWT(ctx context.Context, o APIRouterOptions, jwksURI string, nexodu
 		prefixId := fmt.Sprintf("%s:%s", handlers.CachePrefix, idpUserID)
 		cachedUserId := ""
 
+		// api tokens are issued by us, so the user already exists, but the token may have been revoked.
+		if scope, _ := claims["scope"].(string); strings.Contains(scope, handlers.APITokenScope) {
+			tokenId, _ := claims["jti"].(string)
+			userId, err := o.Api.CheckAPIToken(c.Request.Context(), tokenId)
+			if errors.Is(err, handlers.ErrAPITokenNotValid) {
+				logger.Debug("api token has been revoked or has expired")
+				c.AbortWithStatus(http.StatusUnauthorized)
+				return
+			} else if err != nil {
+				o.Api.SendInternalServerError(c, err)
+				c.Abort()
+				return
+			}
+			cachedUserId = userId.String()
+		}
+
 		// for now just use the concurrency limiter to serialize the cache lookup and create user if not exists
 		// in the future we can use the concurrency limiter to limit the number of concurrent requests to  other
 		//  requests in the apiserver.  We may need different concurrency levels for things like device requests.
@@ -188,6 +204,9 @@ func ValidateJWT(ctx context.Context, o APIRouterOptions, jwksURI string, nexodu
 		// still occur. The change here will still limit the number of db connections from here at a time, but they
 		// will not necessarily be serialized.
 		canceled := limiters.Single.Do(c, func() {
+			if cachedUserId != "" {
+				return
+			}
 			cachedUserId, err = o.Api.Redis.Get(c.Request.Context(), prefixId).Result()
 			if err != nil {
 				if errors.Is(err, redis.Nil) {
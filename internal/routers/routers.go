@@ -13,7 +13,6 @@ import (
 	"go.uber.org/zap/zapcore"
 	"net/http"
 	"net/url"
-	"strings"
 	"time"
 
 	"github.com/coreos/go-oidc/v3/oidc"
@@ -23,7 +22,7 @@ import (
 	"github.com/nexodus-io/nexodus/internal/handlers"
 	agent "github.com/nexodus-io/nexodus/pkg/oidcagent"
 	"github.com/open-policy-agent/opa/storage"
-	ginprometheus "github.com/zsais/go-gin-prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 	"go.uber.org/zap"
 )
@@ -65,7 +64,7 @@ func NewAPIRouter(ctx context.Context, o APIRouterOptions) (*gin.Engine, error)
 	)))
 	r.Use(ginzap.RecoveryWithZap(o.Logger.Desugar(), true))
 
-	newPrometheus().Use(r)
+	r.Use(RouteMetricsMiddleware)
 
 	u, err := url.Parse(o.Api.URL)
 	if err != nil {
@@ -119,6 +118,10 @@ func NewAPIRouter(ctx context.Context, o APIRouterOptions) (*gin.Engine, error)
 		apiGroup.GET("/users/:id", api.GetUser)
 		apiGroup.DELETE("/users/:id", api.DeleteUser)
 		apiGroup.DELETE("/users/:id/organizations/:organization", api.DeleteUserFromOrganization)
+		apiGroup.GET("/users/:id/tokens", api.ListAPITokens)
+		apiGroup.GET("/users/:id/tokens/:token_id", api.GetAPIToken)
+		apiGroup.POST("/users/:id/tokens", api.CreateAPIToken)
+		apiGroup.DELETE("/users/:id/tokens/:token_id", api.DeleteAPIToken)
 
 		// Organizations
 		apiGroup.GET("/organizations", api.ListOrganizations)
@@ -129,6 +132,12 @@ func NewAPIRouter(ctx context.Context, o APIRouterOptions) (*gin.Engine, error)
 		apiGroup.GET("/organizations/:id/users", api.ListOrganizationUsers)
 		apiGroup.GET("/organizations/:id/users/:uid", api.GetOrganizationUser)
 		apiGroup.DELETE("/organizations/:id/users/:uid", api.DeleteOrganizationUser)
+		apiGroup.GET("/organizations/:id/audit-events", api.ListAuditEventsInOrganization)
+		apiGroup.GET("/organizations/:id/roles", api.ListRolesInOrganization)
+		apiGroup.POST("/organizations/:id/roles", api.CreateRoleInOrganization)
+		apiGroup.GET("/organizations/:id/roles/:rid", api.GetRoleInOrganization)
+		apiGroup.PATCH("/organizations/:id/roles/:rid", api.UpdateRoleInOrganization)
+		apiGroup.DELETE("/organizations/:id/roles/:rid", api.DeleteRoleInOrganization)
 
 		// Invitations
 		apiGroup.GET("/invitations", api.ListInvitations)
@@ -140,6 +149,7 @@ func NewAPIRouter(ctx context.Context, o APIRouterOptions) (*gin.Engine, error)
 		// Registration Tokens
 		apiGroup.GET("/reg-keys", api.ListRegKeys)
 		apiGroup.GET("/reg-keys/:id", api.GetRegKey)
+		apiGroup.GET("/reg-keys/:id/devices", api.ListDevicesForRegKey)
 		apiGroup.POST("/reg-keys", api.CreateRegKey)
 		apiGroup.PATCH("/reg-keys/:id", api.UpdateRegKey)
 		apiGroup.DELETE("/reg-keys/:id", api.DeleteRegKey)
@@ -157,7 +167,13 @@ func NewAPIRouter(ctx context.Context, o APIRouterOptions) (*gin.Engine, error)
 		apiGroup.POST("/vpcs/:id/events", api.WatchEventsInVPC)
 		apiGroup.GET("/vpcs/:id/devices", api.ListDevicesInVPC)
 		apiGroup.GET("/vpcs/:id/metadata", api.ListMetadataInVPC)
+		apiGroup.GET("/vpcs/:id/routes", api.ListRoutesInVPC)
 		apiGroup.GET("/vpcs/:id/security-groups", api.ListSecurityGroupsInVPC)
+		apiGroup.GET("/vpcs/:id/dns-records", api.ListDNSRecordsInVPC)
+		apiGroup.POST("/vpcs/:id/dns-records", api.CreateDNSRecordInVPC)
+		apiGroup.GET("/vpcs/:id/dns-records/:record_id", api.GetDNSRecordInVPC)
+		apiGroup.PATCH("/vpcs/:id/dns-records/:record_id", api.UpdateDNSRecordInVPC)
+		apiGroup.DELETE("/vpcs/:id/dns-records/:record_id", api.DeleteDNSRecordInVPC)
 
 		// Devices
 		apiGroup.GET("/devices", api.ListDevices)
@@ -165,6 +181,11 @@ func NewAPIRouter(ctx context.Context, o APIRouterOptions) (*gin.Engine, error)
 		apiGroup.PATCH("/devices/:id", api.UpdateDevice)
 		apiGroup.POST("/devices", api.CreateDevice)
 		apiGroup.DELETE("/devices/:id", api.DeleteDevice)
+		apiGroup.POST("/devices/:id/approve", api.ApproveDevice)
+		apiGroup.POST("/devices/:id/reject", api.RejectDevice)
+		apiGroup.GET("/devices/:id/preshared-keys", api.GetDevicePresharedKeys)
+		apiGroup.GET("/devices/:id/denied-flows", api.ListDeniedFlows)
+		apiGroup.POST("/devices/:id/denied-flows", api.ReportDeniedFlows)
 
 		// Device Metadata
 		apiGroup.GET("/devices/:id/metadata", api.ListDeviceMetadata)
@@ -180,6 +201,15 @@ func NewAPIRouter(ctx context.Context, o APIRouterOptions) (*gin.Engine, error)
 		apiGroup.PATCH("/security-groups/:id", api.UpdateSecurityGroup)
 		apiGroup.DELETE("/security-groups/:id", api.DeleteSecurityGroup)
 
+		// Webhooks
+		apiGroup.GET("/webhooks", api.ListWebhooks)
+		apiGroup.GET("/webhooks/:id", api.GetWebhook)
+		apiGroup.POST("/webhooks", api.CreateWebhook)
+		apiGroup.PATCH("/webhooks/:id", api.UpdateWebhook)
+		apiGroup.DELETE("/webhooks/:id", api.DeleteWebhook)
+		apiGroup.GET("/webhooks/:id/deliveries", api.ListWebhookDeliveries)
+		apiGroup.POST("/webhooks/:id/test", api.TestWebhook)
+
 		// Service Networks
 		apiGroup.GET("/service-networks", api.ListServiceNetworks)
 		apiGroup.GET("/service-networks/:id", api.GetServiceNetwork)
@@ -203,6 +233,7 @@ func NewAPIRouter(ctx context.Context, o APIRouterOptions) (*gin.Engine, error)
 		privateGroup.GET("/gc", o.Api.GarbageCollect, loggerMiddleware)
 		privateGroup.GET("/ready", o.Api.Ready)
 		privateGroup.GET("/live", o.Api.Live)
+		privateGroup.GET("/metrics", gin.WrapH(promhttp.Handler()))
 	}
 
 	return r, nil
@@ -238,19 +269,3 @@ func newValidateJWT(ctx context.Context, o APIRouterOptions, nexodusJWKS string)
 
 	return ValidateJWT(ctx, o, claims.JWKSUri, nexodusJWKS)
 }
-
-func newPrometheus() *ginprometheus.Prometheus {
-	p := ginprometheus.NewPrometheus("apiserver")
-	p.ReqCntURLLabelMappingFn = func(c *gin.Context) string {
-		url := c.Request.URL.Path
-		for _, p := range c.Params {
-			if p.Key == "id" {
-				url = strings.Replace(url, p.Value, ":id", 1)
-				break
-			}
-			// If zone cardinality is too big we'll replace here too
-		}
-		return url
-	}
-	return p
-}
@@ -32,6 +32,21 @@ valid_device_token if {
 	contains(token_payload.scope, "device-token")
 }
 
+valid_api_token if {
+	valid_nexodus_token
+	contains(token_payload.scope, "api-token")
+}
+
+# user tokens are issued by keycloak, or are api tokens issued by nexodus on behalf of the user,
+# both are limited by the scopes they carry.
+valid_user_token if {
+	valid_keycloak_token
+}
+
+valid_user_token if {
+	valid_api_token
+}
+
 default allow := false
 
 allow if {
@@ -42,9 +57,10 @@ allow if {
 		"vpcs",
 		"service-networks",
 		"security-groups",
+		"webhooks",
 	]
 	action_is_read
-	valid_keycloak_token
+	valid_user_token
 	contains(token_payload.scope, "read:organizations")
 }
 
@@ -58,9 +74,10 @@ allow if {
 		"vpcs",
 		"service-networks",
 		"security-groups",
+		"webhooks",
 	]
 	action_is_write
-	valid_keycloak_token
+	valid_user_token
 	contains(token_payload.scope, "write:organizations")
 }
 
@@ -70,7 +87,7 @@ allow if {
 		"sites",
 	]
 	action_is_read
-	valid_keycloak_token
+	valid_user_token
 	contains(token_payload.scope, "read:devices")
 }
 
@@ -80,27 +97,27 @@ allow if {
 		"sites",
 	]
 	action_is_write
-	valid_keycloak_token
+	valid_user_token
 	contains(token_payload.scope, "write:devices")
 }
 
 allow if {
 	input.path[1] in ["users"]
 	action_is_read
-	valid_keycloak_token
+	valid_user_token
 	contains(token_payload.scope, "read:users")
 }
 
 allow if {
 	input.path[1] in ["users"]
 	action_is_write
-	valid_keycloak_token
+	valid_user_token
 	contains(token_payload.scope, "write:users")
 }
 
 allow if {
 	input.path[1] in ["fflags"]
-	valid_keycloak_token
+	valid_user_token
 }
 
 # reg token can get its own token
@@ -165,6 +182,16 @@ allow if {
 	valid_device_token
 }
 
+# device tokens can report the flows denied by the security group of their own device
+allow if {
+	count(input.path) == 4
+	"devices" = input.path[1]
+	input.path[2] == token_payload.jti
+	"denied-flows" = input.path[3]
+	input.method == "POST"
+	valid_device_token
+}
+
 allow if {
 	input.path[1] in [
 		"organizations",
@@ -191,7 +218,7 @@ allow if {
 allow if {
 	input.path == ["api", "events"]
 	input.method == "POST"
-	valid_keycloak_token
+	valid_user_token
 }
 
 allow if {
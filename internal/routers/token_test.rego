@@ -39,6 +39,19 @@ mock_decode("user-read-jwt") := [{}, valid_user("openid profile email read:users
 
 mock_decode_verify("bad-jwt", _) := [false, {}, {}]
 
+# api tokens are only signed by the nexodus key
+mock_decode_verify("api-org-read-jwt", opts) := [opts.cert == "nexodus-cert", {}, {}]
+
+mock_decode("api-org-read-jwt") := [{}, valid_user("api-token read:organizations"), {}]
+
+mock_decode_verify("reg-jwt", opts) := [opts.cert == "nexodus-cert", {}, {}]
+
+mock_decode("reg-jwt") := [{}, valid_user("reg-token"), {}]
+
+mock_decode_verify("device-token-jwt", opts) := [opts.cert == "nexodus-cert", {}, {}]
+
+mock_decode("device-token-jwt") := [{}, object.union(valid_user("device-token"), {"jti": "a0c2cd39-5e9e-4c4d-a3c7-9d1b2b6d4d2e"}), {}]
+
 test_org_get_allowed if {
 	token.allow with input.path as ["api", "organizations"]
 		with input.method as "GET"
@@ -200,3 +213,91 @@ test_get_fflags_bad_jwt_denied if {
 		with io.jwt.decode_verify as mock_decode_verify
 		with io.jwt.decode as mock_decode
 }
+
+test_api_token_org_get_allowed if {
+	token.allow with input.path as ["api", "organizations"]
+		with input.method as "GET"
+		with input.jwks as "my-cert"
+		with input.nexodus_jwks as "nexodus-cert"
+		with input.access_token as "api-org-read-jwt"
+		with io.jwt.decode_verify as mock_decode_verify
+		with io.jwt.decode as mock_decode
+}
+
+test_api_token_org_post_with_read_scope_denied if {
+	not token.allow with input.path as ["api", "organizations"]
+		with input.method as "POST"
+		with input.jwks as "my-cert"
+		with input.nexodus_jwks as "nexodus-cert"
+		with input.access_token as "api-org-read-jwt"
+		with io.jwt.decode_verify as mock_decode_verify
+		with io.jwt.decode as mock_decode
+}
+
+test_api_token_device_get_without_scope_denied if {
+	not token.allow with input.path as ["api", "devices"]
+		with input.method as "GET"
+		with input.jwks as "my-cert"
+		with input.nexodus_jwks as "nexodus-cert"
+		with input.access_token as "api-org-read-jwt"
+		with io.jwt.decode_verify as mock_decode_verify
+		with io.jwt.decode as mock_decode
+}
+
+test_reg_token_is_not_a_user_token if {
+	not token.allow with input.path as ["api", "users", "me"]
+		with input.method as "GET"
+		with input.jwks as "my-cert"
+		with input.nexodus_jwks as "nexodus-cert"
+		with input.access_token as "reg-jwt"
+		with io.jwt.decode_verify as mock_decode_verify
+		with io.jwt.decode as mock_decode
+}
+
+test_webhook_test_post_allowed if {
+	token.allow with input.path as ["api", "webhooks", "foo", "test"]
+		with input.method as "POST"
+		with input.jwks as "my-cert"
+		with input.access_token as "org-write-jwt"
+		with io.jwt.decode_verify as mock_decode_verify
+		with io.jwt.decode as mock_decode
+}
+
+test_webhook_post_with_read_scope_denied if {
+	not token.allow with input.path as ["api", "webhooks"]
+		with input.method as "POST"
+		with input.jwks as "my-cert"
+		with input.access_token as "org-read-jwt"
+		with io.jwt.decode_verify as mock_decode_verify
+		with io.jwt.decode as mock_decode
+}
+
+test_device_token_report_denied_flows_allowed if {
+	token.allow with input.path as ["api", "devices", "a0c2cd39-5e9e-4c4d-a3c7-9d1b2b6d4d2e", "denied-flows"]
+		with input.method as "POST"
+		with input.jwks as "my-cert"
+		with input.nexodus_jwks as "nexodus-cert"
+		with input.access_token as "device-token-jwt"
+		with io.jwt.decode_verify as mock_decode_verify
+		with io.jwt.decode as mock_decode
+}
+
+test_device_token_report_denied_flows_of_other_device_denied if {
+	not token.allow with input.path as ["api", "devices", "5a1b0a1c-2f1e-4b3e-8d52-1f0c4f1e9b6a", "denied-flows"]
+		with input.method as "POST"
+		with input.jwks as "my-cert"
+		with input.nexodus_jwks as "nexodus-cert"
+		with input.access_token as "device-token-jwt"
+		with io.jwt.decode_verify as mock_decode_verify
+		with io.jwt.decode as mock_decode
+}
+
+test_device_token_post_device_metadata_denied if {
+	not token.allow with input.path as ["api", "devices", "a0c2cd39-5e9e-4c4d-a3c7-9d1b2b6d4d2e", "metadata"]
+		with input.method as "POST"
+		with input.jwks as "my-cert"
+		with input.nexodus_jwks as "nexodus-cert"
+		with input.access_token as "device-token-jwt"
+		with io.jwt.decode_verify as mock_decode_verify
+		with io.jwt.decode as mock_decode
+}
@@ -0,0 +1,47 @@
+package signalbus
+
+import (
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	notifications = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "apiserver",
+		Subsystem: "signalbus",
+		Name:      "notifications_total",
+		Help:      "Signals notified on the in memory signal bus, by kind of signal.",
+	}, []string{"kind"})
+	subscriptions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "apiserver",
+		Subsystem: "signalbus",
+		Name:      "subscriptions_total",
+		Help:      "Subscriptions created on the in memory signal bus, by kind of signal.",
+	}, []string{"kind"})
+	activeSubscriptions = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "apiserver",
+		Subsystem: "signalbus",
+		Name:      "active_subscriptions",
+		Help:      "Subscriptions currently open on the in memory signal bus.",
+	})
+	notifyErrors = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "apiserver",
+		Subsystem: "signalbus",
+		Name:      "notify_errors_total",
+		Help:      "Signals that could not be published to the postgresql signal bus.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(notifications, subscriptions, activeSubscriptions, notifyErrors)
+}
+
+// signalKind strips the resource id from a signal name so that it can be used as a metric label,
+// for example "/devices/vpc=<id>" becomes "/devices/vpc".
+func signalKind(name string) string {
+	if i := strings.Index(name, "="); i >= 0 {
+		return name[:i]
+	}
+	return name
+}
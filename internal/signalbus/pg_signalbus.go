@@ -39,12 +39,14 @@ func (pgsb *PgSignalBus) Notify(name string) {
 	// with the pg_notify function.  The DB will send it back to us and all other processes
 	// that are listening for those events.
 	if err := pgsb.db.Exec("SELECT pg_notify('signalbus', ?)", name).Error; err != nil {
+		notifyErrors.Inc()
 		pgsb.logger.Info("notify failed:", err.Error())
 	}
 }
 
 func (pgsb *PgSignalBus) NotifyAll() {
 	if err := pgsb.db.Exec("SELECT pg_notify('signalbus', ?)", "*").Error; err != nil {
+		notifyErrors.Inc()
 		pgsb.logger.Info("notify failed:", err.Error())
 	}
 }
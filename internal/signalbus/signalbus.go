@@ -34,6 +34,7 @@ func (sb *signalBus) Notify(name string) {
 	sb.RLock()
 	result = sb.signals[name]
 	sb.RUnlock()
+	notifications.WithLabelValues(signalKind(name)).Inc()
 
 	for _, sub := range result {
 		select {
@@ -49,6 +50,7 @@ func (sb *signalBus) NotifyAll() {
 		result = append(result, s...)
 	}
 	sb.RUnlock()
+	notifications.WithLabelValues("*").Inc()
 
 	for _, sub := range result {
 		select {
@@ -70,6 +72,8 @@ func (sb *signalBus) Subscribe(name string) *Subscription {
 	subs := sb.signals[name]
 	sb.signals[name] = append(subs, sub)
 	sb.Unlock()
+	subscriptions.WithLabelValues(signalKind(name)).Inc()
+	activeSubscriptions.Inc()
 	return sub
 }
 
@@ -133,5 +137,6 @@ func (sub *Subscription) IsSignaled() bool {
 func (sub *Subscription) Close() {
 	sub.closeOnce.Do(func() {
 		sub.sb.close(sub)
+		activeSubscriptions.Dec()
 	})
 }
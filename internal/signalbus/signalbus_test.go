@@ -46,3 +46,10 @@ func TestNewSignalBus(t *testing.T) {
 	aSub2.Close()
 	require.Equal(0, len(bus.signals))
 }
+
+func TestSignalKind(t *testing.T) {
+	require := require.New(t)
+	require.Equal("/devices/vpc", signalKind("/devices/vpc=694aa002-5d19-495e-980b-3d8fd508ea10"))
+	require.Equal("/service-network", signalKind("/service-network=694aa002-5d19-495e-980b-3d8fd508ea10"))
+	require.Equal("unknown", signalKind("unknown"))
+}
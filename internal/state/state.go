@@ -3,6 +3,7 @@ package state
 import (
 	"fmt"
 	"io"
+	"time"
 
 	"golang.org/x/oauth2"
 )
@@ -13,6 +14,12 @@ type State struct {
 	PrivateKey       string           `json:"private-key"`
 	ProxyRulesConfig ProxyRulesConfig `json:"proxy-rules-config"`
 	Port             int              `json:"port"`
+	KeyCreatedAt     time.Time        `json:"key-created-at,omitempty"`
+	ExitNodeVia      string           `json:"exit-node-via,omitempty"`
+	// PendingPrivateKey is the key of a rotation that may have been registered with the apiserver
+	// for the PendingDeviceId device but that the device has not switched to yet.
+	PendingPrivateKey string `json:"pending-private-key,omitempty"`
+	PendingDeviceId   string `json:"pending-device-id,omitempty"`
 }
 
 type ProxyRulesConfig struct {
@@ -121,6 +121,24 @@ func IsValidPrefix(prefix string) bool {
 	return IsIPv4Prefix(prefix) || IsIPv6Prefix(prefix)
 }
 
+// PrefixesContainIP checks if the given IP address is in one of the given cidrs
+func PrefixesContainIP(prefixes []string, addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, prefix := range prefixes {
+		_, ipNet, err := net.ParseCIDR(prefix)
+		if err != nil {
+			continue
+		}
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
 // ContainsValidCustomIPv4Ranges matches the following custom IPv4 patterns usable by netfilter userspace utils:
 // Cidr notation 100.100.0.0/16
 // Individual address 10.100.0.2
@@ -191,3 +191,13 @@ func TestContainsValidCustomIPv6Ranges(t *testing.T) {
 	assert.False(ContainsValidCustomIPv6Ranges([]string{"making_biscuits"}))
 	assert.False(ContainsValidCustomIPv6Ranges([]string{"2001:db8::zzz"}))
 }
+
+func TestPrefixesContainIP(t *testing.T) {
+	assert := assert.New(t)
+	prefixes := []string{"10.0.0.0/8", "2001:db8::/32"}
+	assert.True(PrefixesContainIP(prefixes, "10.1.2.3"))
+	assert.True(PrefixesContainIP(prefixes, "2001:db8::1"))
+	assert.False(PrefixesContainIP(prefixes, "192.168.1.1"))
+	assert.False(PrefixesContainIP(prefixes, "not-an-ip"))
+	assert.False(PrefixesContainIP(nil, "10.1.2.3"))
+}
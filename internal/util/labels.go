@@ -0,0 +1,83 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+)
+
+const maxLabelLength = 63
+
+// ValidateLabels checks that the keys and values of device labels are usable in a label selector
+func ValidateLabels(labels map[string]string) error {
+	for key, value := range labels {
+		if err := validateLabelToken(key); err != nil {
+			return fmt.Errorf("invalid label key %q: %w", key, err)
+		}
+		if value == "" {
+			continue
+		}
+		if err := validateLabelToken(value); err != nil {
+			return fmt.Errorf("invalid label value %q: %w", value, err)
+		}
+	}
+	return nil
+}
+
+// ParseLabelSelector parses a selector of comma separated key=value pairs, e.g. role=db,env=prod
+func ParseLabelSelector(selector string) (map[string]string, error) {
+	result := map[string]string{}
+	for _, term := range strings.Split(selector, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		key, value, found := strings.Cut(term, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid selector term %q: expected key=value", term)
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if err := validateLabelToken(key); err != nil {
+			return nil, fmt.Errorf("invalid selector key %q: %w", key, err)
+		}
+		if value != "" {
+			if err := validateLabelToken(value); err != nil {
+				return nil, fmt.Errorf("invalid selector value %q: %w", value, err)
+			}
+		}
+		result[key] = value
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("invalid selector %q: no key=value terms", selector)
+	}
+	return result, nil
+}
+
+// LabelsMatch returns true when labels contain every key=value pair of the selector
+func LabelsMatch(selector map[string]string, labels map[string]string) bool {
+	if len(selector) == 0 {
+		return false
+	}
+	for key, value := range selector {
+		actual, ok := labels[key]
+		if !ok || actual != value {
+			return false
+		}
+	}
+	return true
+}
+
+func validateLabelToken(token string) error {
+	if token == "" {
+		return fmt.Errorf("must not be empty")
+	}
+	if len(token) > maxLabelLength {
+		return fmt.Errorf("must be at most %d characters", maxLabelLength)
+	}
+	for _, r := range token {
+		if !((r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '_' || r == '.' || r == '/') {
+			return fmt.Errorf("must only contain alphanumeric characters, '-', '_', '.' or '/'")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLabelSelector(t *testing.T) {
+	selector, err := ParseLabelSelector("role=db, env=prod")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"role": "db", "env": "prod"}, selector)
+
+	for _, invalid := range []string{"", ",", "role", "=db", "role=d b", "ro le=db"} {
+		_, err := ParseLabelSelector(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestLabelsMatch(t *testing.T) {
+	selector := map[string]string{"role": "db", "env": "prod"}
+	assert.True(t, LabelsMatch(selector, map[string]string{"role": "db", "env": "prod", "zone": "a"}))
+	assert.False(t, LabelsMatch(selector, map[string]string{"role": "db"}))
+	assert.False(t, LabelsMatch(selector, map[string]string{"role": "db", "env": "dev"}))
+	assert.False(t, LabelsMatch(selector, nil))
+	assert.False(t, LabelsMatch(nil, map[string]string{"role": "db"}))
+}
+
+func TestValidateLabels(t *testing.T) {
+	assert.NoError(t, ValidateLabels(map[string]string{"role": "db", "example.com/tier": "1", "canary": ""}))
+	assert.Error(t, ValidateLabels(map[string]string{"": "db"}))
+	assert.Error(t, ValidateLabels(map[string]string{"role": "d,b"}))
+	assert.Error(t, ValidateLabels(map[string]string{"role": "a=b"}))
+}